@@ -115,7 +115,7 @@ func init() {
 
 	indexCommand.PersistentFlags().String(flag.KeyConfig, "./deploy/config.yaml", "config file path")
 	schedulerCommand.PersistentFlags().String(flag.KeyConfig, "./deploy/config.yaml", "config file path")
-	schedulerCommand.PersistentFlags().String(flag.KeyServer, "detector", "server name")
+	schedulerCommand.PersistentFlags().String(flag.KeyServer, "detector", "server name, a comma separated list of server names, or all")
 	settlerCommand.PersistentFlags().String(flag.KeyConfig, "./deploy/config.yaml", "config file path")
 }
 
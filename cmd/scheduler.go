@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rss3-network/global-indexer/internal/provider"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+const keySchedulerJob = "job"
+
+var schedulerRunOnceCommand = &cobra.Command{
+	Use:   "run-once",
+	Short: "Run a cron job of the scheduler once outside its schedule, the run is recorded as a manual one",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		configFile, err := provider.ProvideConfig()
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+
+		databaseClient, err := provider.ProvideDatabaseClient(configFile)
+		if err != nil {
+			return err
+		}
+
+		cacheClient, err := provider.ProvideCacheClient(configFile)
+		if err != nil {
+			return err
+		}
+
+		ethereumMultiChainClient, err := provider.ProvideEthereumMultiChainClient(configFile)
+		if err != nil {
+			return fmt.Errorf("dial ethereum: %w", err)
+		}
+
+		httpClient, err := provider.ProvideHTTPClient()
+		if err != nil {
+			return err
+		}
+
+		job, err := scheduler.NewJob(viper.GetString(keySchedulerJob), databaseClient, cacheClient, ethereumMultiChainClient, httpClient, configFile)
+		if err != nil {
+			return err
+		}
+
+		if err := job.RunOnce(cmd.Context()); err != nil {
+			return fmt.Errorf("run %s: %w", job.Name(), err)
+		}
+
+		zap.L().Info("run cron job completed", zap.String("job", job.Name()))
+
+		return nil
+	},
+}
+
+func init() {
+	schedulerRunOnceCommand.Flags().String(keySchedulerJob, "", fmt.Sprintf("name of the cron job to run, one of %s", strings.Join(scheduler.Jobs(), ", ")))
+
+	_ = schedulerRunOnceCommand.MarkFlagRequired(keySchedulerJob)
+
+	schedulerCommand.AddCommand(schedulerRunOnceCommand)
+}
@@ -0,0 +1,321 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rss3-network/global-indexer/common/txmgr"
+	"github.com/rss3-network/global-indexer/internal/config/flag"
+	"github.com/rss3-network/global-indexer/internal/provider"
+	"github.com/rss3-network/global-indexer/internal/service/settler"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	keyTransactionState = "state"
+	keyTransactionLimit = "limit"
+)
+
+var settlerTxCommand = &cobra.Command{
+	Use:   "tx",
+	Short: "Operate the transactions of the settler wallet",
+}
+
+var settlerTxListCommand = &cobra.Command{
+	Use:   "list",
+	Short: "List the journaled transactions against the on-chain nonces",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		txManager, err := newSettlerTxManager()
+		if err != nil {
+			return err
+		}
+
+		states := make([]schema.ManagedTransactionState, 0)
+
+		for _, value := range viper.GetStringSlice(keyTransactionState) {
+			state, err := schema.ManagedTransactionStateString(value)
+			if err != nil {
+				return fmt.Errorf("invalid transaction state %s: %w", value, err)
+			}
+
+			states = append(states, state)
+		}
+
+		nonces, err := txManager.Nonces(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		transactions, err := txManager.Transactions(cmd.Context(), states, viper.GetInt(keyTransactionLimit))
+		if err != nil {
+			return fmt.Errorf("find transactions: %w", err)
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+		_, _ = fmt.Fprintf(writer, "wallet %s, latest nonce %d, pending nonce %d\n\n", txManager.From(), nonces.Latest, nonces.Pending)
+		_, _ = fmt.Fprintln(writer, "NONCE\tOWNER\tSTATE\tON-CHAIN\tFEE BUMPS\tGAS TIP CAP\tGAS FEE CAP\tHASH\tUPDATED AT")
+
+		for _, transaction := range transactions {
+			_, _ = fmt.Fprintf(writer, "%d\t%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\n",
+				transaction.Nonce,
+				transaction.Owner,
+				transaction.State,
+				nonceStatus(transaction.Nonce, nonces),
+				transaction.FeeBumps,
+				transaction.GasTipCap,
+				transaction.GasFeeCap,
+				transaction.TransactionHash,
+				transaction.UpdatedAt.Format("2006-01-02 15:04:05"),
+			)
+		}
+
+		return writer.Flush()
+	},
+}
+
+var settlerTxInspectCommand = &cobra.Command{
+	Use:   "inspect <nonce>",
+	Short: "Inspect the journaled transaction of a nonce and the on-chain status of all its signed transactions",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		nonce, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid nonce %s: %w", args[0], err)
+		}
+
+		txManager, err := newSettlerTxManager()
+		if err != nil {
+			return err
+		}
+
+		nonces, err := txManager.Nonces(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		transaction, statuses, err := txManager.Transaction(cmd.Context(), nonce)
+		if err != nil {
+			return err
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(struct {
+			Nonces       *txmgr.Nonces              `json:"nonces"`
+			Transaction  *schema.ManagedTransaction `json:"transaction"`
+			Transactions []*txmgr.TransactionStatus `json:"transactions"`
+		}{
+			Nonces:       nonces,
+			Transaction:  transaction,
+			Transactions: statuses,
+		})
+	},
+}
+
+var settlerTxSpeedUpCommand = &cobra.Command{
+	Use:   "speedup <nonce>",
+	Short: "Resend the pending transaction of a nonce with bumped fees",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		nonce, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid nonce %s: %w", args[0], err)
+		}
+
+		txManager, err := newSettlerTxManager()
+		if err != nil {
+			return err
+		}
+
+		tx, err := txManager.SpeedUp(cmd.Context(), nonce)
+		if err != nil {
+			return fmt.Errorf("speed up transaction of nonce %d: %w", nonce, err)
+		}
+
+		printReplacement(tx)
+
+		return nil
+	},
+}
+
+var settlerTxCancelCommand = &cobra.Command{
+	Use:   "cancel <nonce>",
+	Short: "Replace the transaction of a nonce with a zero-value self-transfer",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		nonce, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid nonce %s: %w", args[0], err)
+		}
+
+		txManager, err := newSettlerTxManager()
+		if err != nil {
+			return err
+		}
+
+		tx, err := txManager.Cancel(cmd.Context(), nonce)
+		if err != nil {
+			return fmt.Errorf("cancel transaction of nonce %d: %w", nonce, err)
+		}
+
+		printReplacement(tx)
+
+		return nil
+	},
+}
+
+var settlerTxResolveCommand = &cobra.Command{
+	Use:   "resolve <nonce>",
+	Short: "Mark the halted transaction of a nonce as resolved, so that its owner can send transactions again",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		nonce, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid nonce %s: %w", args[0], err)
+		}
+
+		txManager, err := newSettlerTxManager()
+		if err != nil {
+			return err
+		}
+
+		transaction, err := txManager.Resolve(cmd.Context(), nonce)
+		if err != nil {
+			return fmt.Errorf("resolve transaction of nonce %d: %w", nonce, err)
+		}
+
+		fmt.Printf("resolved transaction %s of nonce %d owned by %s\n", transaction.TransactionHash, transaction.Nonce, transaction.Owner)
+
+		return nil
+	},
+}
+
+var settlerTxImportCommand = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import a transaction signed offline, as a raw hex or JSON transaction, and broadcast it",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tx, err := readSignedTransaction(args[0])
+		if err != nil {
+			return err
+		}
+
+		txManager, err := newSettlerTxManager()
+		if err != nil {
+			return err
+		}
+
+		if err := txManager.Import(cmd.Context(), tx); err != nil {
+			return fmt.Errorf("import transaction of nonce %d: %w", tx.Nonce(), err)
+		}
+
+		printReplacement(tx)
+
+		return nil
+	},
+}
+
+// readSignedTransaction reads a signed transaction from a file, encoded either as raw hex or as JSON.
+func readSignedTransaction(path string) (*types.Transaction, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read signed transaction: %w", err)
+	}
+
+	var (
+		tx      types.Transaction
+		content = strings.TrimSpace(string(data))
+	)
+
+	if strings.HasPrefix(content, "{") {
+		if err := tx.UnmarshalJSON([]byte(content)); err != nil {
+			return nil, fmt.Errorf("unmarshal signed transaction: %w", err)
+		}
+
+		return &tx, nil
+	}
+
+	raw, err := hexutil.Decode("0x" + strings.TrimPrefix(content, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("decode signed transaction: %w", err)
+	}
+
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("unmarshal signed transaction: %w", err)
+	}
+
+	return &tx, nil
+}
+
+// newSettlerTxManager creates the transaction manager of the settler wallet from the config file.
+func newSettlerTxManager() (*txmgr.SimpleTxManager, error) {
+	configFile, err := provider.ProvideConfig()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	databaseClient, err := provider.ProvideDatabaseClient(configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheClient, err := provider.ProvideCacheClient(configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	ethereumMultiChainClient, err := provider.ProvideEthereumMultiChainClient(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("dial ethereum: %w", err)
+	}
+
+	chainID := new(big.Int).SetUint64(viper.GetUint64(flag.KeyChainIDL2))
+
+	ethereumClient, err := ethereumMultiChainClient.Get(chainID.Uint64())
+	if err != nil {
+		return nil, fmt.Errorf("load l2 ethereum client: %w", err)
+	}
+
+	return settler.NewTxManager(settler.Name, databaseClient, cacheClient, ethereumClient, chainID, configFile)
+}
+
+// nonceStatus describes a nonce against the on-chain nonces of the wallet.
+func nonceStatus(nonce uint64, nonces *txmgr.Nonces) string {
+	switch {
+	case nonce < nonces.Latest:
+		return "mined"
+	case nonce < nonces.Pending:
+		return "mempool"
+	default:
+		return "missing"
+	}
+}
+
+func printReplacement(tx *types.Transaction) {
+	fmt.Printf("published transaction %s of nonce %d, gas tip cap %s, gas fee cap %s\n", tx.Hash(), tx.Nonce(), tx.GasTipCap(), tx.GasFeeCap())
+}
+
+func init() {
+	settlerTxListCommand.Flags().StringSlice(keyTransactionState, nil, "transaction states to list, e.g. pending,halted")
+	settlerTxListCommand.Flags().Int(keyTransactionLimit, 20, "maximum number of transactions to list")
+
+	settlerTxCommand.AddCommand(settlerTxListCommand)
+	settlerTxCommand.AddCommand(settlerTxInspectCommand)
+	settlerTxCommand.AddCommand(settlerTxSpeedUpCommand)
+	settlerTxCommand.AddCommand(settlerTxCancelCommand)
+	settlerTxCommand.AddCommand(settlerTxResolveCommand)
+	settlerTxCommand.AddCommand(settlerTxImportCommand)
+
+	settlerCommand.AddCommand(settlerTxCommand)
+}
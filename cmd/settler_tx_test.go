@@ -0,0 +1,116 @@
+package main
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rss3-network/global-indexer/common/txmgr"
+)
+
+func TestReadSignedTransaction(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chainID := big.NewInt(2331)
+
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     7,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       21000,
+		To:        &common.Address{},
+		Value:     big.NewInt(0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := tx.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name:    "raw hex",
+			content: hexutil.Encode(raw) + "\n",
+		},
+		{
+			name:    "raw hex without prefix",
+			content: common.Bytes2Hex(raw),
+		},
+		{
+			name:    "json",
+			content: string(encoded),
+		},
+		{
+			name:    "invalid hex",
+			content: "0xzz",
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			content: `{"nonce": "0x7"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "signed.txt")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := readSignedTransaction(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && got.Hash() != tx.Hash() {
+				t.Errorf("hash = %s, want %s", got.Hash(), tx.Hash())
+			}
+		})
+	}
+}
+
+func TestNonceStatus(t *testing.T) {
+	t.Parallel()
+
+	nonces := &txmgr.Nonces{Latest: 5, Pending: 7}
+
+	for nonce, want := range map[uint64]string{
+		4: "mined",
+		5: "mempool",
+		6: "mempool",
+		7: "missing",
+	} {
+		if got := nonceStatus(nonce, nonces); got != want {
+			t.Errorf("status of nonce %d = %s, want %s", nonce, got, want)
+		}
+	}
+}
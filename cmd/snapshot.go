@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rss3-network/global-indexer/internal/config/flag"
+	"github.com/rss3-network/global-indexer/internal/provider"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	keySnapshotKind      = "kind"
+	keySnapshotFromEpoch = "from-epoch"
+	keySnapshotToEpoch   = "to-epoch"
+)
+
+var snapshotCommand = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Operate the snapshots computed by the scheduler",
+}
+
+var snapshotBackfillCommand = &cobra.Command{
+	Use:   "backfill",
+	Short: "Recompute the snapshots of a range of Epochs at their historical block heights, which requires an archive node",
+	Long: `Recompute the snapshots of a range of Epochs at their historical block heights, which requires an archive node.
+
+The staker counts are derived from the stake transactions up to each height. The Chip transfers are not indexed
+with their heights, so a transferred Chip is counted for the staker who minted it and the counts are approximate.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		configFile, err := provider.ProvideConfig()
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+
+		databaseClient, err := provider.ProvideDatabaseClient(configFile)
+		if err != nil {
+			return err
+		}
+
+		cacheClient, err := provider.ProvideCacheClient(configFile)
+		if err != nil {
+			return err
+		}
+
+		ethereumMultiChainClient, err := provider.ProvideEthereumMultiChainClient(configFile)
+		if err != nil {
+			return fmt.Errorf("dial ethereum: %w", err)
+		}
+
+		ethereumClient, err := ethereumMultiChainClient.Get(viper.GetUint64(flag.KeyChainIDL2))
+		if err != nil {
+			return fmt.Errorf("load l2 ethereum client: %w", err)
+		}
+
+		backfiller, err := snapshot.NewBackfiller(viper.GetString(keySnapshotKind), databaseClient, cacheClient, ethereumClient)
+		if err != nil {
+			return err
+		}
+
+		return snapshot.Backfill(cmd.Context(), databaseClient, backfiller, viper.GetUint64(keySnapshotFromEpoch), viper.GetUint64(keySnapshotToEpoch))
+	},
+}
+
+func init() {
+	snapshotBackfillCommand.Flags().String(keySnapshotKind, "", fmt.Sprintf("kind of the snapshots to backfill, one of %s", strings.Join(snapshot.Kinds(), ", ")))
+	snapshotBackfillCommand.Flags().Uint64(keySnapshotFromEpoch, 0, "first epoch to backfill")
+	snapshotBackfillCommand.Flags().Uint64(keySnapshotToEpoch, 0, "last epoch to backfill, defaults to the latest indexed epoch")
+
+	_ = snapshotBackfillCommand.MarkFlagRequired(keySnapshotKind)
+	_ = snapshotBackfillCommand.MarkFlagRequired(keySnapshotFromEpoch)
+
+	snapshotCommand.PersistentFlags().String(flag.KeyConfig, "./deploy/config.yaml", "config file path")
+	snapshotCommand.AddCommand(snapshotBackfillCommand)
+
+	command.AddCommand(snapshotCommand)
+}
@@ -0,0 +1,59 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// NewKeystoreSignerFactory creates a SignerFactory from a go-ethereum JSON keystore file.
+func NewKeystoreSignerFactory(path, passphrase string) (SignerFactory, common.Address, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("failed to read the keystore file: %w", err)
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("failed to decrypt the keystore file: %w", err)
+	}
+
+	signer := func(chainID *big.Int) SignerFn {
+		s := PrivateKeySignerFn(key.PrivateKey, chainID)
+
+		return func(_ context.Context, addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return s(addr, tx)
+		}
+	}
+
+	return signer, key.Address, nil
+}
+
+// ReadPassphrase reads a keystore passphrase from a file, or from an environment variable if no file is specified.
+func ReadPassphrase(file, env string) (string, error) {
+	if file != "" {
+		passphrase, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the passphrase file: %w", err)
+		}
+
+		return strings.TrimRight(string(passphrase), "\r\n"), nil
+	}
+
+	if env != "" {
+		passphrase, ok := os.LookupEnv(env)
+		if !ok {
+			return "", fmt.Errorf("passphrase environment variable %s is not set", env)
+		}
+
+		return passphrase, nil
+	}
+
+	return "", fmt.Errorf("at least specify a passphrase file or environment variable")
+}
@@ -0,0 +1,106 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeystoreSignerFactory(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+
+	account, err := keystore.StoreKey(directory, "passphrase", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+
+	passphraseFile := filepath.Join(directory, "passphrase")
+	require.NoError(t, os.WriteFile(passphraseFile, []byte("passphrase\n"), 0o600))
+
+	passphrase, err := ReadPassphrase(passphraseFile, "")
+	require.NoError(t, err)
+
+	signerFactory, from, err := NewKeystoreSignerFactory(account.URL.Path, passphrase)
+	require.NoError(t, err)
+	require.Equal(t, account.Address, from)
+
+	chainID := big.NewInt(12553)
+
+	tx, err := signerFactory(chainID)(context.Background(), from, types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 1, Gas: 21000}))
+	require.NoError(t, err)
+
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	require.NoError(t, err)
+	require.Equal(t, from, sender)
+
+	_, _, err = NewKeystoreSignerFactory(account.URL.Path, "wrong passphrase")
+	require.Error(t, err)
+}
+
+func TestOfflineSignerFactory(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	var (
+		directory = t.TempDir()
+		address   = crypto.PubkeyToAddress(key.PublicKey)
+		chainID   = big.NewInt(12553)
+		to        = common.HexToAddress("0x0000000000000000000000000000000000000001")
+	)
+
+	signerFactory, from, err := NewOfflineSignerFactory(directory, address.String())
+	require.NoError(t, err)
+	require.Equal(t, address, from)
+
+	unsigned := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 7, Gas: 21000, To: &to, Value: big.NewInt(0)})
+
+	_, err = signerFactory(chainID)(context.Background(), from, unsigned)
+	require.True(t, errors.Is(err, ErrOfflineSigning))
+
+	// The exported transaction can be signed on another machine.
+	data, err := os.ReadFile(filepath.Join(directory, "unsigned-12553-"+address.String()+"-7.json"))
+	require.NoError(t, err)
+
+	var exported types.Transaction
+	require.NoError(t, exported.UnmarshalJSON(data))
+	require.Equal(t, unsigned.Hash(), exported.Hash())
+
+	signed, err := PrivateKeySignerFn(key, chainID)(address, &exported)
+	require.NoError(t, err)
+
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	require.NoError(t, err)
+	require.Equal(t, address, sender)
+
+	// A fee bump of the same nonce overwrites the previous export.
+	bumped := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 7, GasTipCap: big.NewInt(1), Gas: 21000, To: &to, Value: big.NewInt(0)})
+
+	_, err = signerFactory(chainID)(context.Background(), from, bumped)
+	require.True(t, errors.Is(err, ErrOfflineSigning))
+
+	data, err = os.ReadFile(filepath.Join(directory, "unsigned-12553-"+address.String()+"-7.json"))
+	require.NoError(t, err)
+
+	var exportedBump types.Transaction
+	require.NoError(t, exportedBump.UnmarshalJSON(data))
+	require.Equal(t, bumped.Hash(), exportedBump.Hash())
+
+	// Transactions of other wallets are never exported.
+	_, err = signerFactory(chainID)(context.Background(), to, unsigned)
+	require.Error(t, err)
+	require.False(t, errors.Is(err, ErrOfflineSigning))
+
+	_, _, err = NewOfflineSignerFactory(directory, "invalid")
+	require.Error(t, err)
+}
@@ -0,0 +1,72 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrOfflineSigning is returned by the SignerFn of the offline mode, after the unsigned transaction has been exported.
+var ErrOfflineSigning = errors.New("transaction is exported for offline signing")
+
+// NewOfflineSignerFactory creates a SignerFactory that never signs,
+// it exports the unsigned transactions to the directory to be signed on an air-gapped machine instead.
+// The signed transactions are imported back into the transaction manager for broadcast.
+func NewOfflineSignerFactory(directory, address string) (SignerFactory, common.Address, error) {
+	if !common.IsHexAddress(address) {
+		return nil, common.Address{}, fmt.Errorf("offline signing requires a valid wallet address: %s", address)
+	}
+
+	if err := os.MkdirAll(directory, 0o700); err != nil {
+		return nil, common.Address{}, fmt.Errorf("failed to create the offline signing directory: %w", err)
+	}
+
+	fromAddress := common.HexToAddress(address)
+
+	signer := func(chainID *big.Int) SignerFn {
+		return func(_ context.Context, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != fromAddress {
+				return nil, fmt.Errorf("attempting to sign for %s, expected %s", address, fromAddress)
+			}
+
+			path, err := ExportUnsignedTransaction(directory, chainID, address, tx)
+			if err != nil {
+				return nil, err
+			}
+
+			return nil, fmt.Errorf("%w: %s", ErrOfflineSigning, path)
+		}
+	}
+
+	return signer, fromAddress, nil
+}
+
+// ExportUnsignedTransaction writes the unsigned transaction as JSON to the directory and returns the file path.
+// A transaction of the same nonce, e.g. a fee bump, overwrites the previous export.
+func ExportUnsignedTransaction(directory string, chainID *big.Int, from common.Address, tx *types.Transaction) (string, error) {
+	data, err := tx.MarshalJSON()
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the unsigned transaction: %w", err)
+	}
+
+	path := filepath.Join(directory, fmt.Sprintf("unsigned-%d-%s-%d.json", chainID, from, tx.Nonce()))
+
+	// Write to a temporary file first, so that the air-gapped signer never reads a partial export.
+	temporary := path + ".tmp"
+
+	if err := os.WriteFile(temporary, data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write the unsigned transaction: %w", err)
+	}
+
+	if err := os.Rename(temporary, path); err != nil {
+		return "", fmt.Errorf("failed to write the unsigned transaction: %w", err)
+	}
+
+	return path, nil
+}
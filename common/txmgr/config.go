@@ -2,11 +2,18 @@ package txmgr
 
 import (
 	"fmt"
+	"math/big"
 	"time"
+
+	"github.com/ethereum/go-ethereum/params"
 )
 
 // Config houses parameters for altering the behavior of a SimpleTxManager.
 type Config struct {
+	// Owner identifies the service sending the transactions, e.g. settler or taxer.
+	// Pending and halted transactions in the Journal are scoped to the owner.
+	Owner string
+
 	// ResubmissionTimeout is the interval at which, if no previously
 	// published transaction has been mined, the new tx with a bumped gas
 	// price will be published. Only one publication at MaxGasPrice will be
@@ -41,6 +48,33 @@ type Config struct {
 	// are required to give up on a tx at a particular nonce without receiving
 	// confirmation.
 	SafeAbortNonceTooLowCount uint64
+
+	// MaxFeePerTransaction caps the projected cost (gas limit * gas fee cap) of a single tx in wei,
+	// including fee bumps. Nil means unlimited.
+	MaxFeePerTransaction *big.Int
+
+	// FeeOracle configures how the gas tip cap is suggested.
+	FeeOracle FeeOracleConfig
+}
+
+// NewConfig creates a Config of the owner with the default timeouts of the transaction manager.
+func NewConfig(owner string, resubmissionTimeout time.Duration, feeLimitMultiplier, numConfirmations uint64) Config {
+	return Config{
+		Owner:                     owner,
+		ResubmissionTimeout:       resubmissionTimeout,
+		FeeLimitMultiplier:        feeLimitMultiplier,
+		TxSendTimeout:             5 * time.Minute,
+		TxNotInMempoolTimeout:     1 * time.Hour,
+		NetworkTimeout:            5 * time.Minute,
+		ReceiptQueryInterval:      500 * time.Millisecond,
+		NumConfirmations:          numConfirmations,
+		SafeAbortNonceTooLowCount: 3,
+	}
+}
+
+// GweiToWei converts an amount in gwei to wei.
+func GweiToWei(gwei uint64) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(gwei), big.NewInt(params.GWei))
 }
 
 func (m Config) Check() error {
@@ -0,0 +1,144 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ErrFeeBudgetExceeded is returned when the projected cost of a transaction exceeds its fee budget.
+var ErrFeeBudgetExceeded = errors.New("fee budget exceeded")
+
+type FeeOracleMode string
+
+const (
+	// FeeOracleModeNode uses the gas tip cap suggested by the node.
+	FeeOracleModeNode FeeOracleMode = "node"
+	// FeeOracleModePercentile uses a percentile of the priority fees paid in recent blocks.
+	FeeOracleModePercentile FeeOracleMode = "percentile"
+	// FeeOracleModeFixed uses a fixed gas tip cap.
+	FeeOracleModeFixed FeeOracleMode = "fixed"
+)
+
+// FeeOracleConfig configures the FeeOracle of a SimpleTxManager.
+type FeeOracleConfig struct {
+	Mode FeeOracleMode
+	// Percentile of the priority fees paid in each block, used by FeeOracleModePercentile.
+	Percentile float64
+	// Blocks is the number of recent blocks, used by FeeOracleModePercentile.
+	Blocks uint64
+	// GasTipCap is the fixed gas tip cap, used by FeeOracleModeFixed.
+	GasTipCap *big.Int
+}
+
+// FeeOracle suggests the gas tip cap of a transaction.
+type FeeOracle interface {
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+}
+
+type nodeFeeOracle struct {
+	ethereumClient *ethclient.Client
+}
+
+func (o *nodeFeeOracle) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return o.ethereumClient.SuggestGasTipCap(ctx)
+}
+
+type percentileFeeOracle struct {
+	ethereumClient *ethclient.Client
+	percentile     float64
+	blocks         uint64
+}
+
+// SuggestGasTipCap returns the average of the percentile priority fees of the recent blocks.
+func (o *percentileFeeOracle) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	feeHistory, err := o.ethereumClient.FeeHistory(ctx, o.blocks, nil, []float64{o.percentile})
+	if err != nil {
+		return nil, fmt.Errorf("fetch fee history: %w", err)
+	}
+
+	var (
+		sum   = new(big.Int)
+		count int64
+	)
+
+	for _, rewards := range feeHistory.Reward {
+		if len(rewards) == 0 || rewards[0] == nil {
+			continue
+		}
+
+		sum.Add(sum, rewards[0])
+		count++
+	}
+
+	if count == 0 {
+		return nil, fmt.Errorf("no priority fees in the last %d blocks", o.blocks)
+	}
+
+	return sum.Div(sum, big.NewInt(count)), nil
+}
+
+type fixedFeeOracle struct {
+	gasTipCap *big.Int
+}
+
+func (o *fixedFeeOracle) SuggestGasTipCap(_ context.Context) (*big.Int, error) {
+	return new(big.Int).Set(o.gasTipCap), nil
+}
+
+// NewFeeOracle creates a FeeOracle, the node suggestion is used if the mode is empty.
+func NewFeeOracle(conf FeeOracleConfig, ethereumClient *ethclient.Client) (FeeOracle, error) {
+	switch conf.Mode {
+	case "", FeeOracleModeNode:
+		return &nodeFeeOracle{ethereumClient: ethereumClient}, nil
+	case FeeOracleModePercentile:
+		if conf.Percentile <= 0 || conf.Percentile > 100 {
+			return nil, fmt.Errorf("invalid fee oracle percentile: %v", conf.Percentile)
+		}
+
+		if conf.Blocks == 0 {
+			return nil, fmt.Errorf("fee oracle blocks must not be 0")
+		}
+
+		return &percentileFeeOracle{ethereumClient: ethereumClient, percentile: conf.Percentile, blocks: conf.Blocks}, nil
+	case FeeOracleModeFixed:
+		if conf.GasTipCap == nil || conf.GasTipCap.Sign() <= 0 {
+			return nil, fmt.Errorf("fee oracle gas tip cap must be positive")
+		}
+
+		return &fixedFeeOracle{gasTipCap: conf.GasTipCap}, nil
+	default:
+		return nil, fmt.Errorf("unsupported fee oracle mode: %s", conf.Mode)
+	}
+}
+
+// checkFeeBudget checks the projected cost of a transaction against the fee budget, a nil budget is unlimited.
+func checkFeeBudget(gas uint64, gasFeeCap, budget *big.Int) error {
+	if budget == nil {
+		return nil
+	}
+
+	cost := new(big.Int).Mul(new(big.Int).SetUint64(gas), gasFeeCap)
+	if cost.Cmp(budget) > 0 {
+		return fmt.Errorf("%w: projected cost %s exceeds budget %s", ErrFeeBudgetExceeded, cost, budget)
+	}
+
+	return nil
+}
+
+// minFeeBudget returns the smaller budget, a nil budget is unlimited.
+func minFeeBudget(a, b *big.Int) *big.Int {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.Cmp(b) <= 0:
+		return a
+	default:
+		return b
+	}
+}
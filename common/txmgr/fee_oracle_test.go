@@ -0,0 +1,68 @@
+package txmgr
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestCheckFeeBudget(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		gas       uint64
+		gasFeeCap *big.Int
+		budget    *big.Int
+		exceeded  bool
+	}{
+		{
+			name:      "unlimited",
+			gas:       3000000,
+			gasFeeCap: big.NewInt(1e12),
+			budget:    nil,
+		},
+		{
+			name:      "within budget",
+			gas:       100,
+			gasFeeCap: big.NewInt(10),
+			budget:    big.NewInt(1000),
+		},
+		{
+			name:      "exceeds budget",
+			gas:       100,
+			gasFeeCap: big.NewInt(11),
+			budget:    big.NewInt(1000),
+			exceeded:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkFeeBudget(tt.gas, tt.gasFeeCap, tt.budget)
+			if exceeded := errors.Is(err, ErrFeeBudgetExceeded); exceeded != tt.exceeded {
+				t.Errorf("got = %v, want exceeded %v", err, tt.exceeded)
+			}
+		})
+	}
+}
+
+func TestMinFeeBudget(t *testing.T) {
+	t.Parallel()
+
+	if minFeeBudget(nil, nil) != nil {
+		t.Errorf("two unlimited budgets must be unlimited")
+	}
+
+	if got := minFeeBudget(big.NewInt(2), nil); got.Int64() != 2 {
+		t.Errorf("got = %v, want 2", got)
+	}
+
+	if got := minFeeBudget(big.NewInt(2), big.NewInt(1)); got.Int64() != 1 {
+		t.Errorf("got = %v, want 1", got)
+	}
+}
@@ -0,0 +1,460 @@
+package txmgr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// NonceLockKey is the key of the NonceLocker of a wallet, formatted with the chain ID and the address.
+var NonceLockKey = "txmgr:nonce:%d:%s"
+
+// ErrHalted is returned by Send when a previous transaction of the owner received a failed receipt.
+// The halted transaction is recorded in the Journal and must be resolved by an operator.
+var ErrHalted = errors.New("transaction manager is halted")
+
+// ErrAwaitingSignature is returned by Send when a transaction of the owner has been exported for offline signing,
+// no further transactions are sent until the signed transaction is imported.
+var ErrAwaitingSignature = errors.New("transaction is awaiting offline signature")
+
+// ErrJournalConflict is returned by the Journal when an entry is saved with a stale version,
+// i.e. it has been saved by another process since it was read, e.g. an operator cancelled the transaction.
+var ErrJournalConflict = errors.New("journal entry has been changed by another process")
+
+// ErrCancelled is returned by Send when the transaction has been cancelled by an operator while it was monitored.
+var ErrCancelled = errors.New("transaction has been cancelled")
+
+// maxJournalConflicts is the number of times an update of the owner is applied again to a reloaded journal entry.
+const maxJournalConflicts = 3
+
+// Journal persists the transactions sent by a SimpleTxManager,
+// so that in-flight transactions survive restarts and can be monitored again.
+// SaveManagedTransaction inserts an entry of version 0, and updates an entry only if its version is unchanged,
+// otherwise it returns ErrJournalConflict. The version of the saved entry is incremented.
+type Journal interface {
+	SaveManagedTransaction(ctx context.Context, transaction *schema.ManagedTransaction) error
+	FindManagedTransactions(ctx context.Context, query schema.ManagedTransactionQuery) ([]*schema.ManagedTransaction, error)
+}
+
+// NonceLocker serializes the nonce allocation of a wallet,
+// it must be shared by all processes sending transactions from the same wallet.
+type NonceLocker interface {
+	Lock() error
+	Unlock() (bool, error)
+}
+
+// Halted returns the halted transaction of the owner, or nil if the transaction manager is not halted.
+func (m *SimpleTxManager) Halted(ctx context.Context) (*schema.ManagedTransaction, error) {
+	if m.journal == nil {
+		return nil, nil
+	}
+
+	transactions, err := m.journal.FindManagedTransactions(ctx, schema.ManagedTransactionQuery{
+		ChainID: lo.ToPtr(m.chainID.Uint64()),
+		From:    lo.ToPtr(m.from),
+		Owner:   lo.ToPtr(m.cfg.Owner),
+		States:  []schema.ManagedTransactionState{schema.ManagedTransactionStateHalted},
+		Limit:   lo.ToPtr(1),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find halted transactions: %w", err)
+	}
+
+	if len(transactions) == 0 {
+		return nil, nil
+	}
+
+	return transactions[0], nil
+}
+
+// AwaitingSignature returns the transaction of the owner exported for offline signing, or nil if there is none.
+func (m *SimpleTxManager) AwaitingSignature(ctx context.Context) (*schema.ManagedTransaction, error) {
+	if m.journal == nil {
+		return nil, nil
+	}
+
+	transactions, err := m.journal.FindManagedTransactions(ctx, schema.ManagedTransactionQuery{
+		ChainID: lo.ToPtr(m.chainID.Uint64()),
+		From:    lo.ToPtr(m.from),
+		Owner:   lo.ToPtr(m.cfg.Owner),
+		States:  []schema.ManagedTransactionState{schema.ManagedTransactionStateUnsigned},
+		Limit:   lo.ToPtr(1),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find unsigned transactions: %w", err)
+	}
+
+	if len(transactions) == 0 {
+		return nil, nil
+	}
+
+	return transactions[0], nil
+}
+
+// Recover monitors the pending transactions of the owner recorded in the Journal until they are settled.
+// It is called by Send, and should be called on startup to resume the transactions in flight before a restart.
+func (m *SimpleTxManager) Recover(ctx context.Context) error {
+	_, err := m.recover(ctx, nil)
+
+	return err
+}
+
+// recover settles the pending transactions of the owner,
+// and returns the successful receipt of the transaction sent for the same candidate, if any.
+// A candidate may already be in flight if the caller restarted, or if it was signed offline.
+func (m *SimpleTxManager) recover(ctx context.Context, candidate *TxCandidate) (*types.Receipt, error) {
+	if m.journal == nil {
+		return nil, nil
+	}
+
+	transactions, err := m.journal.FindManagedTransactions(ctx, schema.ManagedTransactionQuery{
+		ChainID: lo.ToPtr(m.chainID.Uint64()),
+		From:    lo.ToPtr(m.from),
+		Owner:   lo.ToPtr(m.cfg.Owner),
+		States:  []schema.ManagedTransactionState{schema.ManagedTransactionStatePending},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find pending transactions: %w", err)
+	}
+
+	var candidateReceipt *types.Receipt
+
+	// Resume from the lowest nonce, as a transaction cannot be mined before its predecessors.
+	for i := len(transactions) - 1; i >= 0; i-- {
+		transaction := transactions[i]
+
+		zap.L().Info("recover pending transaction", zap.Uint64("nonce", transaction.Nonce), zap.String("hash", transaction.TransactionHash.String()))
+
+		receipt, err := m.recoverTransaction(ctx, transaction)
+		if err != nil {
+			return nil, fmt.Errorf("recover transaction of nonce %d: %w", transaction.Nonce, err)
+		}
+
+		if receipt != nil {
+			zap.L().Info("recovered pending transaction", zap.Uint64("nonce", transaction.Nonce), zap.String("hash", receipt.TxHash.String()), zap.Uint64("status", receipt.Status))
+
+			if receipt.Status == types.ReceiptStatusSuccessful && matchCandidate(transaction, candidate) {
+				candidateReceipt = receipt
+			}
+		}
+	}
+
+	return candidateReceipt, nil
+}
+
+// matchCandidate returns true if the journal entry was sent for the candidate.
+func matchCandidate(transaction *schema.ManagedTransaction, candidate *TxCandidate) bool {
+	if candidate == nil {
+		return false
+	}
+
+	if (transaction.To == nil) != (candidate.To == nil) || (transaction.To != nil && *transaction.To != *candidate.To) {
+		return false
+	}
+
+	value := candidate.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	return bytes.Equal(transaction.Data, candidate.TxData) && transaction.Value.Cmp(value) == 0
+}
+
+func (m *SimpleTxManager) recoverTransaction(ctx context.Context, transaction *schema.ManagedTransaction) (*types.Receipt, error) {
+	if m.cfg.TxSendTimeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.cfg.TxSendTimeout)
+
+		defer cancel()
+	}
+
+	sendState := NewSendState(m.cfg.SafeAbortNonceTooLowCount, m.cfg.TxNotInMempoolTimeout)
+
+	// Any of the fee bumped transactions of the nonce may have been mined before the restart.
+	for _, transactionHash := range transaction.TransactionHashes {
+		cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+		_, err := m.ethereumClient.TransactionReceipt(cCtx, transactionHash)
+
+		cancel()
+
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("get transaction receipt: %w", err)
+		}
+
+		receipt, err := m.waitMined(ctx, transactionHash, sendState)
+		if err != nil {
+			return nil, err
+		}
+
+		return receipt, m.settleTransaction(ctx, transaction, receipt)
+	}
+
+	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+
+	nonce, err := m.ethereumClient.NonceAt(cCtx, m.from, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+
+	// The nonce has been consumed by a transaction that is not in the journal.
+	if nonce > transaction.Nonce {
+		return nil, m.dropTransaction(ctx, transaction, errors.New("nonce consumed by an unknown transaction"))
+	}
+
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(transaction.RawTransaction); err != nil {
+		return nil, fmt.Errorf("unmarshal raw transaction: %w", err)
+	}
+
+	return m.sendTx(ctx, &tx, transaction, m.cfg.MaxFeePerTransaction)
+}
+
+// nextJournalNonce returns the next nonce of the wallet,
+// taking the pending, cancelled and unsigned transactions of all owners in the Journal into account.
+func (m *SimpleTxManager) nextJournalNonce(ctx context.Context) (uint64, error) {
+	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+
+	nonce, err := m.ethereumClient.NonceAt(cCtx, m.from, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get nonce: %w", err)
+	}
+
+	transactions, err := m.journal.FindManagedTransactions(ctx, schema.ManagedTransactionQuery{
+		ChainID: lo.ToPtr(m.chainID.Uint64()),
+		From:    lo.ToPtr(m.from),
+		States: []schema.ManagedTransactionState{
+			schema.ManagedTransactionStatePending,
+			schema.ManagedTransactionStateCancelled,
+			schema.ManagedTransactionStateUnsigned,
+		},
+		Limit: lo.ToPtr(1),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("find pending transactions: %w", err)
+	}
+
+	if len(transactions) > 0 && transactions[0].Nonce >= nonce {
+		nonce = transactions[0].Nonce + 1
+	}
+
+	return nonce, nil
+}
+
+// newTransaction creates a pending journal entry of a signed transaction.
+func (m *SimpleTxManager) newTransaction(tx *types.Transaction) (*schema.ManagedTransaction, error) {
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("marshal transaction: %w", err)
+	}
+
+	return &schema.ManagedTransaction{
+		ChainID:           m.chainID.Uint64(),
+		From:              m.from,
+		Nonce:             tx.Nonce(),
+		Owner:             m.cfg.Owner,
+		To:                tx.To(),
+		Data:              tx.Data(),
+		Value:             tx.Value(),
+		GasLimit:          tx.Gas(),
+		GasTipCap:         tx.GasTipCap(),
+		GasFeeCap:         tx.GasFeeCap(),
+		RawTransaction:    raw,
+		TransactionHash:   tx.Hash(),
+		TransactionHashes: []common.Hash{tx.Hash()},
+		State:             schema.ManagedTransactionStatePending,
+	}, nil
+}
+
+// awaitSignature journals a transaction exported for offline signing,
+// which keeps its nonce allocated until the signed transaction is imported.
+func (m *SimpleTxManager) awaitSignature(ctx context.Context, tx *types.Transaction, cause error) error {
+	transaction, err := m.newTransaction(tx)
+	if err != nil {
+		return err
+	}
+
+	// There is no signed transaction yet.
+	transaction.TransactionHash = common.Hash{}
+	transaction.TransactionHashes = []common.Hash{}
+	transaction.State = schema.ManagedTransactionStateUnsigned
+
+	if err := m.saveTransaction(ctx, transaction); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w: transaction of nonce %d: %w", ErrAwaitingSignature, tx.Nonce(), cause)
+}
+
+// replaceTransaction records a fee bumped transaction of the same nonce sent by the owner.
+// It returns ErrCancelled if the transaction has been cancelled by an operator.
+func (m *SimpleTxManager) replaceTransaction(ctx context.Context, transaction *schema.ManagedTransaction, tx *types.Transaction) error {
+	if transaction == nil || transaction.TransactionHash == tx.Hash() {
+		return nil
+	}
+
+	return m.updateTransaction(ctx, transaction, func(transaction *schema.ManagedTransaction) error {
+		if transaction.State == schema.ManagedTransactionStateCancelled {
+			return fmt.Errorf("%w: transaction of nonce %d", ErrCancelled, transaction.Nonce)
+		}
+
+		return applyTransaction(transaction, tx)
+	})
+}
+
+// applyTransaction sets a signed transaction of the nonce as the latest one of the journal entry.
+func applyTransaction(transaction *schema.ManagedTransaction, tx *types.Transaction) error {
+	if lo.Contains(transaction.TransactionHashes, tx.Hash()) {
+		return nil
+	}
+
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("marshal transaction: %w", err)
+	}
+
+	transaction.GasTipCap = tx.GasTipCap()
+	transaction.GasFeeCap = tx.GasFeeCap()
+	transaction.RawTransaction = raw
+	transaction.TransactionHash = tx.Hash()
+	transaction.TransactionHashes = append(transaction.TransactionHashes, tx.Hash())
+	transaction.FeeBumps++
+
+	return nil
+}
+
+// settleTransaction records the receipt of a transaction, a failed receipt halts the owner.
+// The receipt is recorded even if the transaction has been cancelled, as the cancellation lost the race.
+func (m *SimpleTxManager) settleTransaction(ctx context.Context, transaction *schema.ManagedTransaction, receipt *types.Receipt) error {
+	if transaction == nil {
+		return nil
+	}
+
+	return m.updateTransaction(ctx, transaction, func(transaction *schema.ManagedTransaction) error {
+		transaction.Receipt = receipt
+
+		if receipt.Status == types.ReceiptStatusSuccessful {
+			transaction.State = schema.ManagedTransactionStateConfirmed
+		} else {
+			transaction.State = schema.ManagedTransactionStateHalted
+			transaction.Error = fmt.Sprintf("transaction %s received a failed receipt", receipt.TxHash)
+
+			zap.L().Error("transaction manager halted", zap.String("owner", m.cfg.Owner), zap.Uint64("nonce", transaction.Nonce), zap.String("hash", receipt.TxHash.String()))
+		}
+
+		return nil
+	})
+}
+
+// dropTransaction records a transaction that was given up without a receipt.
+// A cancelled transaction is kept as is, as its nonce stays allocated until the cancellation is mined.
+func (m *SimpleTxManager) dropTransaction(ctx context.Context, transaction *schema.ManagedTransaction, cause error) error {
+	if transaction == nil {
+		return nil
+	}
+
+	err := m.updateTransaction(ctx, transaction, func(transaction *schema.ManagedTransaction) error {
+		if transaction.State == schema.ManagedTransactionStateCancelled {
+			return ErrCancelled
+		}
+
+		transaction.State = schema.ManagedTransactionStateDropped
+		transaction.Error = cause.Error()
+
+		return nil
+	})
+	if errors.Is(err, ErrCancelled) {
+		return nil
+	}
+
+	return err
+}
+
+// updateTransaction applies an update of the owner to the journal entry and saves it.
+// If the entry has been changed by another process, e.g. sped up by an operator, it is reloaded and the update is applied again.
+func (m *SimpleTxManager) updateTransaction(ctx context.Context, transaction *schema.ManagedTransaction, update func(transaction *schema.ManagedTransaction) error) error {
+	for attempt := 1; ; attempt++ {
+		if err := update(transaction); err != nil {
+			return err
+		}
+
+		err := m.saveTransaction(ctx, transaction)
+		if !errors.Is(err, ErrJournalConflict) || attempt == maxJournalConflicts {
+			return err
+		}
+
+		zap.L().Info("journal entry changed by another process, reload it", zap.Uint64("nonce", transaction.Nonce))
+
+		if err := m.reloadTransaction(ctx, transaction); err != nil {
+			return err
+		}
+	}
+}
+
+// reloadTransaction replaces the journal entry with the saved one.
+func (m *SimpleTxManager) reloadTransaction(ctx context.Context, transaction *schema.ManagedTransaction) error {
+	saved, err := m.findTransaction(ctx, transaction.Nonce)
+	if err != nil {
+		return err
+	}
+
+	*transaction = *saved
+
+	return nil
+}
+
+// syncTransaction picks up the changes of the journal entry made by an operator while the owner is monitoring it.
+// It returns the latest signed transaction if it has been replaced, e.g. sped up or signed offline,
+// and ErrCancelled if the transaction has been cancelled, in which case the owner stops monitoring it.
+func (m *SimpleTxManager) syncTransaction(ctx context.Context, transaction *schema.ManagedTransaction) (*types.Transaction, error) {
+	latest := transaction.TransactionHash
+
+	if err := m.reloadTransaction(ctx, transaction); err != nil {
+		return nil, err
+	}
+
+	if transaction.State == schema.ManagedTransactionStateCancelled {
+		return nil, fmt.Errorf("%w: %s", ErrCancelled, transaction.Error)
+	}
+
+	if transaction.TransactionHash == latest {
+		return nil, nil
+	}
+
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(transaction.RawTransaction); err != nil {
+		return nil, fmt.Errorf("unmarshal raw transaction: %w", err)
+	}
+
+	zap.L().Info("transaction replaced by another process", zap.Uint64("nonce", transaction.Nonce), zap.String("hash", tx.Hash().String()))
+
+	return &tx, nil
+}
+
+func (m *SimpleTxManager) saveTransaction(ctx context.Context, transaction *schema.ManagedTransaction) error {
+	if m.journal == nil || transaction == nil {
+		return nil
+	}
+
+	// The journal must be written even if the sending context has been cancelled.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), m.cfg.NetworkTimeout)
+	defer cancel()
+
+	if err := m.journal.SaveManagedTransaction(ctx, transaction); err != nil {
+		return fmt.Errorf("save managed transaction: %w", err)
+	}
+
+	return nil
+}
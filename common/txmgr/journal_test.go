@@ -0,0 +1,427 @@
+package txmgr
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	gicrypto "github.com/rss3-network/global-indexer/common/crypto"
+	"github.com/rss3-network/global-indexer/schema"
+)
+
+var testChainID = big.NewInt(2331)
+
+// testJournal is an in-memory Journal of a single wallet, keyed by the nonce, with a compare-and-set on the version.
+type testJournal struct {
+	mutex        sync.Mutex
+	transactions map[uint64]*schema.ManagedTransaction
+}
+
+func newTestJournal(transactions ...*schema.ManagedTransaction) *testJournal {
+	journal := testJournal{transactions: make(map[uint64]*schema.ManagedTransaction)}
+
+	for _, transaction := range transactions {
+		transaction.Version = 1
+		journal.transactions[transaction.Nonce] = transaction
+	}
+
+	return &journal
+}
+
+func (j *testJournal) SaveManagedTransaction(_ context.Context, transaction *schema.ManagedTransaction) error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	version := uint64(0)
+	if saved, exists := j.transactions[transaction.Nonce]; exists {
+		version = saved.Version
+	}
+
+	if transaction.Version != version {
+		return ErrJournalConflict
+	}
+
+	saved := *transaction
+	saved.Version++
+	j.transactions[transaction.Nonce] = &saved
+	transaction.Version = saved.Version
+
+	return nil
+}
+
+func (j *testJournal) FindManagedTransactions(_ context.Context, query schema.ManagedTransactionQuery) ([]*schema.ManagedTransaction, error) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	transactions := make([]*schema.ManagedTransaction, 0, len(j.transactions))
+
+	for _, transaction := range j.transactions {
+		if query.Owner != nil && transaction.Owner != *query.Owner ||
+			query.Nonce != nil && transaction.Nonce != *query.Nonce ||
+			len(query.States) > 0 && !containsState(query.States, transaction.State) {
+			continue
+		}
+
+		found := *transaction
+		transactions = append(transactions, &found)
+	}
+
+	sort.Slice(transactions, func(i, k int) bool {
+		return transactions[i].Nonce > transactions[k].Nonce
+	})
+
+	if query.Limit != nil && len(transactions) > *query.Limit {
+		transactions = transactions[:*query.Limit]
+	}
+
+	return transactions, nil
+}
+
+func (j *testJournal) transaction(nonce uint64) schema.ManagedTransaction {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	return *j.transactions[nonce]
+}
+
+func containsState(states []schema.ManagedTransactionState, state schema.ManagedTransactionState) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+
+	return false
+}
+
+// testChain serves the eth namespace of a chain that mines every published transaction immediately.
+type testChain struct {
+	mutex    sync.Mutex
+	nonce    uint64
+	head     uint64
+	receipts map[common.Hash]*types.Receipt
+}
+
+func (c *testChain) GetTransactionCount(_ common.Address, _ string) hexutil.Uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return hexutil.Uint64(c.nonce)
+}
+
+func (c *testChain) GetTransactionReceipt(hash common.Hash) *types.Receipt {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.receipts[hash]
+}
+
+func (c *testChain) BlockNumber() hexutil.Uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return hexutil.Uint64(c.head)
+}
+
+func (c *testChain) GetBlockByNumber(_ string, _ bool) *types.Header {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return &types.Header{
+		Number:     new(big.Int).SetUint64(c.head),
+		Difficulty: big.NewInt(0),
+		BaseFee:    big.NewInt(params.GWei),
+	}
+}
+
+func (c *testChain) SendRawTransaction(data hexutil.Bytes) (common.Hash, error) {
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return common.Hash{}, err
+	}
+
+	c.mine(tx.Hash(), tx.Nonce())
+
+	return tx.Hash(), nil
+}
+
+func (c *testChain) mine(hash common.Hash, nonce uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.head++
+	c.nonce = nonce + 1
+	c.receipts[hash] = &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		TxHash:      hash,
+		BlockNumber: new(big.Int).SetUint64(c.head),
+		Logs:        []*types.Log{},
+	}
+}
+
+func newTestTxManager(t *testing.T, chain *testChain, journal Journal, key *ecdsa.PrivateKey) *SimpleTxManager {
+	t.Helper()
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", chain); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(server.Stop)
+
+	signerFn := gicrypto.PrivateKeySignerFn(key, testChainID)
+
+	return &SimpleTxManager{
+		cfg: Config{
+			Owner:                     "settler",
+			ResubmissionTimeout:       time.Hour,
+			FeeLimitMultiplier:        5,
+			TxNotInMempoolTimeout:     time.Hour,
+			NetworkTimeout:            time.Minute,
+			ReceiptQueryInterval:      10 * time.Millisecond,
+			NumConfirmations:          1,
+			SafeAbortNonceTooLowCount: 3,
+		},
+		chainID:        testChainID,
+		ethereumClient: ethclient.NewClient(rpc.DialInProc(server)),
+		from:           crypto.PubkeyToAddress(key.PublicKey),
+		journal:        journal,
+		feeOracle:      &fixedFeeOracle{gasTipCap: big.NewInt(params.GWei)},
+		signer: func(_ context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return signerFn(from, tx)
+		},
+	}
+}
+
+func newTestTransaction(t *testing.T, key *ecdsa.PrivateKey, owner string, nonce uint64, state schema.ManagedTransactionState) *schema.ManagedTransaction {
+	t.Helper()
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(testChainID), &types.DynamicFeeTx{
+		ChainID:   testChainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       21000,
+		To:        &from,
+		Value:     big.NewInt(0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &schema.ManagedTransaction{
+		ChainID:           testChainID.Uint64(),
+		From:              from,
+		Nonce:             nonce,
+		Owner:             owner,
+		To:                tx.To(),
+		Value:             tx.Value(),
+		GasLimit:          tx.Gas(),
+		GasTipCap:         tx.GasTipCap(),
+		GasFeeCap:         tx.GasFeeCap(),
+		RawTransaction:    raw,
+		TransactionHash:   tx.Hash(),
+		TransactionHashes: []common.Hash{tx.Hash()},
+		State:             state,
+	}
+}
+
+func TestRecover(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		// Mined before the restart.
+		mined = newTestTransaction(t, key, "settler", 4, schema.ManagedTransactionStatePending)
+		// Consumed by a transaction that is not in the journal.
+		consumed = newTestTransaction(t, key, "settler", 5, schema.ManagedTransactionStatePending)
+		// Journaled but never published before the restart.
+		unpublished = newTestTransaction(t, key, "settler", 6, schema.ManagedTransactionStatePending)
+		// Cancelled by an operator.
+		cancelled = newTestTransaction(t, key, "taxer", 7, schema.ManagedTransactionStateCancelled)
+	)
+
+	chain := &testChain{receipts: make(map[common.Hash]*types.Receipt)}
+	chain.mine(mined.TransactionHash, mined.Nonce)
+	chain.mine(common.HexToHash("0x1"), consumed.Nonce)
+
+	journal := newTestJournal(mined, consumed, unpublished, cancelled)
+	manager := newTestTxManager(t, chain, journal, key)
+
+	if err := manager.Recover(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	for nonce, want := range map[uint64]schema.ManagedTransactionState{
+		4: schema.ManagedTransactionStateConfirmed,
+		5: schema.ManagedTransactionStateDropped,
+		6: schema.ManagedTransactionStateConfirmed,
+		7: schema.ManagedTransactionStateCancelled,
+	} {
+		if got := journal.transaction(nonce).State; got != want {
+			t.Errorf("state of nonce %d = %s, want %s", nonce, got, want)
+		}
+	}
+
+	if chain.receipts[unpublished.TransactionHash] == nil {
+		t.Errorf("unpublished transaction of nonce %d was not resent", unpublished.Nonce)
+	}
+
+	// The cancelled nonce is still reserved until it is consumed on chain.
+	nonce, err := manager.nextJournalNonce(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if nonce != 8 {
+		t.Errorf("next nonce = %d, want 8", nonce)
+	}
+}
+
+func TestNextJournalNonce(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		chainNonce   uint64
+		transactions []*schema.ManagedTransaction
+		want         uint64
+	}{
+		{
+			name:       "empty journal",
+			chainNonce: 5,
+			want:       5,
+		},
+		{
+			name:       "pending and cancelled entries of all owners",
+			chainNonce: 5,
+			transactions: []*schema.ManagedTransaction{
+				newTestTransaction(t, key, "settler", 5, schema.ManagedTransactionStatePending),
+				newTestTransaction(t, key, "taxer", 6, schema.ManagedTransactionStateCancelled),
+			},
+			want: 7,
+		},
+		{
+			name:       "unsigned entry",
+			chainNonce: 5,
+			transactions: []*schema.ManagedTransaction{
+				newTestTransaction(t, key, "settler", 5, schema.ManagedTransactionStateUnsigned),
+			},
+			want: 6,
+		},
+		{
+			name:       "settled entries",
+			chainNonce: 5,
+			transactions: []*schema.ManagedTransaction{
+				newTestTransaction(t, key, "settler", 5, schema.ManagedTransactionStateDropped),
+				newTestTransaction(t, key, "settler", 6, schema.ManagedTransactionStateHalted),
+			},
+			want: 5,
+		},
+		{
+			name:       "entries behind the chain",
+			chainNonce: 5,
+			transactions: []*schema.ManagedTransaction{
+				newTestTransaction(t, key, "settler", 3, schema.ManagedTransactionStatePending),
+			},
+			want: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			chain := &testChain{nonce: tt.chainNonce, receipts: make(map[common.Hash]*types.Receipt)}
+			manager := newTestTxManager(t, chain, newTestJournal(tt.transactions...), key)
+
+			nonce, err := manager.nextJournalNonce(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if nonce != tt.want {
+				t.Errorf("got = %d, want %d", nonce, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignWithNextNonceAfterRestart(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chain := &testChain{nonce: 5, receipts: make(map[common.Hash]*types.Receipt)}
+	journal := newTestJournal(newTestTransaction(t, key, "taxer", 5, schema.ManagedTransactionStatePending))
+
+	for _, want := range []uint64{6, 7} {
+		// Every manager starts without a nonce in memory, as after a restart.
+		manager := newTestTxManager(t, chain, journal, key)
+
+		tx, _, err := manager.signWithNextNonce(context.Background(), &types.DynamicFeeTx{
+			ChainID:   testChainID,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(2),
+			Gas:       21000,
+			To:        &common.Address{},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if tx.Nonce() != want {
+			t.Errorf("nonce = %d, want %d", tx.Nonce(), want)
+		}
+	}
+
+	transactions, err := journal.FindManagedTransactions(context.Background(), schema.ManagedTransactionQuery{
+		States: []schema.ManagedTransactionState{schema.ManagedTransactionStatePending},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The journal holds every nonce from the chain nonce on without gaps.
+	for i, transaction := range transactions {
+		if want := uint64(7 - i); transaction.Nonce != want {
+			t.Errorf("journaled nonce = %d, want %d", transaction.Nonce, want)
+		}
+	}
+
+	if len(transactions) != 3 {
+		t.Errorf("journaled %d pending transactions, want 3", len(transactions))
+	}
+}
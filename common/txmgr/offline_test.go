@@ -0,0 +1,189 @@
+package txmgr
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	gicrypto "github.com/rss3-network/global-indexer/common/crypto"
+	"github.com/rss3-network/global-indexer/schema"
+)
+
+func TestMatchCandidate(t *testing.T) {
+	t.Parallel()
+
+	to := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	transaction := &schema.ManagedTransaction{To: &to, Data: []byte{0x01, 0x02}, Value: big.NewInt(0)}
+
+	tests := []struct {
+		name      string
+		candidate *TxCandidate
+		want      bool
+	}{
+		{
+			name: "no candidate",
+		},
+		{
+			name:      "same candidate",
+			candidate: &TxCandidate{To: &to, TxData: []byte{0x01, 0x02}, Value: big.NewInt(0)},
+			want:      true,
+		},
+		{
+			name:      "nil value",
+			candidate: &TxCandidate{To: &to, TxData: []byte{0x01, 0x02}},
+			want:      true,
+		},
+		{
+			name:      "different recipient",
+			candidate: &TxCandidate{To: &common.Address{}, TxData: []byte{0x01, 0x02}},
+		},
+		{
+			name:      "contract creation",
+			candidate: &TxCandidate{TxData: []byte{0x01, 0x02}},
+		},
+		{
+			name:      "different data",
+			candidate: &TxCandidate{To: &to, TxData: []byte{0x01}},
+		},
+		{
+			name:      "different value",
+			candidate: &TxCandidate{To: &to, TxData: []byte{0x01, 0x02}, Value: big.NewInt(1)},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := matchCandidate(transaction, tt.candidate); got != tt.want {
+				t.Errorf("got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportOfflineSignedTransaction(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	directory := t.TempDir()
+
+	signerFactory, from, err := gicrypto.NewOfflineSignerFactory(directory, crypto.PubkeyToAddress(key.PublicKey).String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chain := &testChain{nonce: 3, receipts: make(map[common.Hash]*types.Receipt)}
+	journal := newTestJournal()
+
+	manager := newTestTxManager(t, chain, journal, key)
+	manager.signer = signerFactory(testChainID)
+
+	to := common.HexToAddress("0x0000000000000000000000000000000000000001")
+
+	// The transaction is exported and its nonce is kept allocated until the signed transaction is imported.
+	if _, _, err := manager.signWithNextNonce(ctx, &types.DynamicFeeTx{
+		ChainID:   testChainID,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       21000,
+		To:        &to,
+		Data:      []byte{0x01},
+	}); !errors.Is(err, ErrAwaitingSignature) || !errors.Is(err, gicrypto.ErrOfflineSigning) {
+		t.Fatalf("got = %v, want %v", err, ErrAwaitingSignature)
+	}
+
+	if state := journal.transaction(3).State; state != schema.ManagedTransactionStateUnsigned {
+		t.Fatalf("state = %s, want %s", state, schema.ManagedTransactionStateUnsigned)
+	}
+
+	data, err := os.ReadFile(filepath.Join(directory, "unsigned-2331-"+from.String()+"-3.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var exported types.Transaction
+	if err := exported.UnmarshalJSON(data); err != nil {
+		t.Fatal(err)
+	}
+
+	stranger, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sign := func(t *testing.T, chainID *big.Int, key *ecdsa.PrivateKey, update func(tx *types.DynamicFeeTx)) *types.Transaction {
+		t.Helper()
+
+		rawTx := &types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     exported.Nonce(),
+			GasTipCap: exported.GasTipCap(),
+			GasFeeCap: exported.GasFeeCap(),
+			Gas:       exported.Gas(),
+			To:        exported.To(),
+			Value:     exported.Value(),
+			Data:      exported.Data(),
+		}
+
+		if update != nil {
+			update(rawTx)
+		}
+
+		tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), rawTx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return tx
+	}
+
+	rejected := map[string]*types.Transaction{
+		"different chain":     sign(t, big.NewInt(1), key, nil),
+		"different signer":    sign(t, testChainID, stranger, nil),
+		"different data":      sign(t, testChainID, key, func(tx *types.DynamicFeeTx) { tx.Data = []byte{0x02} }),
+		"different recipient": sign(t, testChainID, key, func(tx *types.DynamicFeeTx) { tx.To = &common.Address{} }),
+		"different gas limit": sign(t, testChainID, key, func(tx *types.DynamicFeeTx) { tx.Gas = 50000 }),
+		"unknown nonce":       sign(t, testChainID, key, func(tx *types.DynamicFeeTx) { tx.Nonce = 4 }),
+	}
+
+	for name, tx := range rejected {
+		if err := manager.Import(ctx, tx); err == nil {
+			t.Errorf("%s: imported a mismatched transaction", name)
+		}
+	}
+
+	if state := journal.transaction(3).State; state != schema.ManagedTransactionStateUnsigned {
+		t.Fatalf("state = %s, want %s", state, schema.ManagedTransactionStateUnsigned)
+	}
+
+	signed := sign(t, testChainID, key, nil)
+
+	if err := manager.Import(ctx, signed); err != nil {
+		t.Fatal(err)
+	}
+
+	transaction := journal.transaction(3)
+
+	if transaction.State != schema.ManagedTransactionStatePending || transaction.TransactionHash != signed.Hash() {
+		t.Errorf("journaled %s transaction %s, want pending %s", transaction.State, transaction.TransactionHash, signed.Hash())
+	}
+
+	if chain.GetTransactionReceipt(signed.Hash()) == nil {
+		t.Errorf("imported transaction %s was not published", signed.Hash())
+	}
+}
@@ -0,0 +1,375 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// ErrJournalRequired is returned by the operations that work on the Journal of a SimpleTxManager without one.
+var ErrJournalRequired = errors.New("transaction manager has no journal")
+
+// Nonces are the nonces of the wallet on chain.
+type Nonces struct {
+	// Latest is the nonce of the wallet at the latest block, i.e. the next nonce to be mined.
+	Latest uint64 `json:"latest"`
+	// Pending is the nonce of the wallet including the transactions in the mempool of the node.
+	Pending uint64 `json:"pending"`
+}
+
+// TransactionStatus is the on-chain status of a signed transaction of a journal entry.
+type TransactionStatus struct {
+	Hash    common.Hash    `json:"hash"`
+	Pending bool           `json:"pending"`
+	Found   bool           `json:"found"`
+	Receipt *types.Receipt `json:"receipt,omitempty"`
+}
+
+// From returns the address of the wallet.
+func (m *SimpleTxManager) From() common.Address {
+	return m.from
+}
+
+// Nonces returns the nonces of the wallet on chain.
+func (m *SimpleTxManager) Nonces(ctx context.Context) (*Nonces, error) {
+	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+
+	latest, err := m.ethereumClient.NonceAt(cCtx, m.from, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+
+	pending, err := m.ethereumClient.PendingNonceAt(cCtx, m.from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending nonce: %w", err)
+	}
+
+	return &Nonces{Latest: latest, Pending: pending}, nil
+}
+
+// Transactions returns the journal entries of the wallet of all owners, with the highest nonce first.
+func (m *SimpleTxManager) Transactions(ctx context.Context, states []schema.ManagedTransactionState, limit int) ([]*schema.ManagedTransaction, error) {
+	if m.journal == nil {
+		return nil, ErrJournalRequired
+	}
+
+	query := schema.ManagedTransactionQuery{
+		ChainID: lo.ToPtr(m.chainID.Uint64()),
+		From:    lo.ToPtr(m.from),
+		States:  states,
+	}
+
+	if limit > 0 {
+		query.Limit = lo.ToPtr(limit)
+	}
+
+	return m.journal.FindManagedTransactions(ctx, query)
+}
+
+// Transaction returns the journal entry of the nonce and the on-chain status of all its signed transactions.
+func (m *SimpleTxManager) Transaction(ctx context.Context, nonce uint64) (*schema.ManagedTransaction, []*TransactionStatus, error) {
+	transaction, err := m.findTransaction(ctx, nonce)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	statuses := make([]*TransactionStatus, 0, len(transaction.TransactionHashes))
+
+	for _, transactionHash := range transaction.TransactionHashes {
+		status, err := m.transactionStatus(ctx, transactionHash)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return transaction, statuses, nil
+}
+
+// SpeedUp resends the pending transaction of the nonce with bumped fees.
+// The new transaction is journaled before it is published, the owner of the transaction picks it up
+// at its next resubmission and monitors all signed transactions of the nonce, including the new one.
+// It fails with ErrJournalConflict if the owner changed the journal entry meanwhile, and can be retried.
+func (m *SimpleTxManager) SpeedUp(ctx context.Context, nonce uint64) (*types.Transaction, error) {
+	transaction, err := m.findTransaction(ctx, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction.State != schema.ManagedTransactionStatePending {
+		return nil, fmt.Errorf("transaction of nonce %d is %s, only pending transactions can be sped up", nonce, transaction.State)
+	}
+
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(transaction.RawTransaction); err != nil {
+		return nil, fmt.Errorf("unmarshal raw transaction: %w", err)
+	}
+
+	newTx, err := m.increaseGasPrice(ctx, &tx, m.cfg.MaxFeePerTransaction)
+	if err != nil {
+		return nil, fmt.Errorf("increase gas price: %w", err)
+	}
+
+	if newTx.Hash() == tx.Hash() {
+		return nil, fmt.Errorf("failed to sign the replacement transaction of nonce %d", nonce)
+	}
+
+	if err := applyTransaction(transaction, newTx); err != nil {
+		return nil, err
+	}
+
+	if err := m.saveTransaction(ctx, transaction); err != nil {
+		return nil, err
+	}
+
+	if err := m.publishOnce(ctx, newTx); err != nil {
+		return nil, err
+	}
+
+	return newTx, nil
+}
+
+// Cancel replaces the transaction of the nonce with a zero-value self-transfer, which consumes the nonce.
+// The journal entry is marked as cancelled before the cancellation is published,
+// its owner then stops monitoring it at its next resubmission and gives up the original transaction.
+// A nonce without a journal entry can be cancelled as well, as long as it has not been consumed on chain.
+// It fails with ErrJournalConflict if the owner changed the journal entry meanwhile, and can be retried.
+func (m *SimpleTxManager) Cancel(ctx context.Context, nonce uint64) (*types.Transaction, error) {
+	if m.journal == nil {
+		return nil, ErrJournalRequired
+	}
+
+	transaction, err := m.findTransaction(ctx, nonce)
+	if err != nil && !errors.Is(err, ethereum.NotFound) {
+		return nil, err
+	}
+
+	if transaction != nil && transaction.State != schema.ManagedTransactionStatePending && transaction.State != schema.ManagedTransactionStateCancelled {
+		return nil, fmt.Errorf("transaction of nonce %d is %s, only pending transactions can be cancelled", nonce, transaction.State)
+	}
+
+	nonces, err := m.Nonces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if nonces.Latest > nonce {
+		return nil, fmt.Errorf("nonce %d has been consumed on chain, the latest nonce is %d", nonce, nonces.Latest)
+	}
+
+	tip, baseFee, err := m.suggestGasPriceCaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	gasTipCap, gasFeeCap := tip, calcGasFeeCap(baseFee, tip)
+
+	// The replacement must outbid the transaction in the mempool.
+	if transaction != nil {
+		gasTipCap, gasFeeCap = updateFees(transaction.GasTipCap, transaction.GasFeeCap, tip, baseFee)
+	}
+
+	if err := checkFeeBudget(params.TxGas, gasFeeCap, m.cfg.MaxFeePerTransaction); err != nil {
+		return nil, err
+	}
+
+	signCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+
+	tx, err := m.signer(signCtx, m.from, types.NewTx(&types.DynamicFeeTx{
+		ChainID:   m.chainID,
+		Nonce:     nonce,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+		Gas:       params.TxGas,
+		To:        lo.ToPtr(m.from),
+		Value:     big.NewInt(0),
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("sign cancellation transaction: %w", err)
+	}
+
+	cause := fmt.Sprintf("cancelled by transaction %s", tx.Hash())
+
+	if transaction == nil {
+		if transaction, err = m.newTransaction(tx); err != nil {
+			return nil, err
+		}
+	} else if err = applyTransaction(transaction, tx); err != nil {
+		return nil, err
+	}
+
+	transaction.State, transaction.Error = schema.ManagedTransactionStateCancelled, cause
+
+	if err := m.saveTransaction(ctx, transaction); err != nil {
+		return nil, err
+	}
+
+	if err := m.publishOnce(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	zap.L().Info("cancelled transaction", zap.Uint64("nonce", nonce), zap.String("hash", tx.Hash().String()))
+
+	return tx, nil
+}
+
+// Resolve marks the halted transaction of the nonce as resolved, so that its owner can send transactions again.
+func (m *SimpleTxManager) Resolve(ctx context.Context, nonce uint64) (*schema.ManagedTransaction, error) {
+	transaction, err := m.findTransaction(ctx, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction.State != schema.ManagedTransactionStateHalted {
+		return nil, fmt.Errorf("transaction of nonce %d is %s, only halted transactions can be resolved", nonce, transaction.State)
+	}
+
+	transaction.State = schema.ManagedTransactionStateResolved
+
+	if err := m.saveTransaction(ctx, transaction); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// Import publishes a transaction signed offline, either for a nonce awaiting signature or as a fee bump of a pending nonce.
+// The signed transaction must be sent from the wallet and carry the journaled candidate of the nonce,
+// it is journaled before it is published and its owner then monitors it like any other pending transaction.
+func (m *SimpleTxManager) Import(ctx context.Context, tx *types.Transaction) error {
+	if tx.ChainId().Cmp(m.chainID) != 0 {
+		return fmt.Errorf("transaction is signed for chain %s, expected %s", tx.ChainId(), m.chainID)
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(m.chainID), tx)
+	if err != nil {
+		return fmt.Errorf("recover transaction sender: %w", err)
+	}
+
+	if sender != m.from {
+		return fmt.Errorf("transaction is signed by %s, expected %s", sender, m.from)
+	}
+
+	transaction, err := m.findTransaction(ctx, tx.Nonce())
+	if err != nil {
+		return err
+	}
+
+	if transaction.State != schema.ManagedTransactionStateUnsigned && transaction.State != schema.ManagedTransactionStatePending {
+		return fmt.Errorf("transaction of nonce %d is %s, only unsigned and pending transactions can be imported", tx.Nonce(), transaction.State)
+	}
+
+	if !matchCandidate(transaction, &TxCandidate{To: tx.To(), TxData: tx.Data(), Value: tx.Value()}) || transaction.GasLimit != tx.Gas() {
+		return fmt.Errorf("transaction does not match the journaled transaction of nonce %d", tx.Nonce())
+	}
+
+	if err := checkFeeBudget(tx.Gas(), tx.GasFeeCap(), m.cfg.MaxFeePerTransaction); err != nil {
+		return err
+	}
+
+	if transaction.State == schema.ManagedTransactionStatePending {
+		if err := applyTransaction(transaction, tx); err != nil {
+			return err
+		}
+
+		if err := m.saveTransaction(ctx, transaction); err != nil {
+			return err
+		}
+
+		return m.publishOnce(ctx, tx)
+	}
+
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("marshal transaction: %w", err)
+	}
+
+	// Journal the signed transaction before publishing, the owner publishes it again if this attempt fails.
+	transaction.GasTipCap = tx.GasTipCap()
+	transaction.GasFeeCap = tx.GasFeeCap()
+	transaction.RawTransaction = raw
+	transaction.TransactionHash = tx.Hash()
+	transaction.TransactionHashes = []common.Hash{tx.Hash()}
+	transaction.State = schema.ManagedTransactionStatePending
+
+	if err := m.saveTransaction(ctx, transaction); err != nil {
+		return err
+	}
+
+	return m.publishOnce(ctx, tx)
+}
+
+// findTransaction returns the journal entry of the nonce, or ethereum.NotFound if the nonce is not in the Journal.
+func (m *SimpleTxManager) findTransaction(ctx context.Context, nonce uint64) (*schema.ManagedTransaction, error) {
+	if m.journal == nil {
+		return nil, ErrJournalRequired
+	}
+
+	transactions, err := m.journal.FindManagedTransactions(ctx, schema.ManagedTransactionQuery{
+		ChainID: lo.ToPtr(m.chainID.Uint64()),
+		From:    lo.ToPtr(m.from),
+		Nonce:   lo.ToPtr(nonce),
+		Limit:   lo.ToPtr(1),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find managed transactions: %w", err)
+	}
+
+	if len(transactions) == 0 {
+		return nil, fmt.Errorf("transaction of nonce %d: %w", nonce, ethereum.NotFound)
+	}
+
+	return transactions[0], nil
+}
+
+func (m *SimpleTxManager) transactionStatus(ctx context.Context, transactionHash common.Hash) (*TransactionStatus, error) {
+	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+
+	status := TransactionStatus{Hash: transactionHash}
+
+	_, pending, err := m.ethereumClient.TransactionByHash(cCtx, transactionHash)
+	if errors.Is(err, ethereum.NotFound) {
+		return &status, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("get transaction %s: %w", transactionHash, err)
+	}
+
+	status.Found, status.Pending = true, pending
+
+	if pending {
+		return &status, nil
+	}
+
+	if status.Receipt, err = m.ethereumClient.TransactionReceipt(cCtx, transactionHash); err != nil && !errors.Is(err, ethereum.NotFound) {
+		return nil, fmt.Errorf("get transaction receipt %s: %w", transactionHash, err)
+	}
+
+	return &status, nil
+}
+
+// publishOnce publishes a transaction once, without monitoring it or bumping its fees.
+func (m *SimpleTxManager) publishOnce(ctx context.Context, tx *types.Transaction) error {
+	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+
+	if err := m.ethereumClient.SendTransaction(cCtx, tx); err != nil {
+		return fmt.Errorf("publish transaction %s of nonce %d: %w", tx.Hash(), tx.Nonce(), err)
+	}
+
+	zap.L().Info("published replacement transaction", zap.Uint64("nonce", tx.Nonce()), zap.String("hash", tx.Hash().String()), zap.String("gasTipCap", tx.GasTipCap().String()), zap.String("gasFeeCap", tx.GasFeeCap().String()))
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+)
+
+func TestCancelMonitoredTransaction(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	chain := &testChain{nonce: 5, receipts: make(map[common.Hash]*types.Receipt)}
+	journal := newTestJournal(newTestTransaction(t, key, "settler", 5, schema.ManagedTransactionStatePending))
+
+	owner := newTestTxManager(t, chain, journal, key)
+	operator := newTestTxManager(t, chain, journal, key)
+
+	// The entries held in memory by the owner while the operator cancels the nonce.
+	bumped, synced, dropped := journal.transaction(5), journal.transaction(5), journal.transaction(5)
+
+	cancellation, err := operator.Cancel(ctx, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(testChainID), &types.DynamicFeeTx{
+		ChainID:   testChainID,
+		Nonce:     5,
+		GasTipCap: big.NewInt(10),
+		GasFeeCap: big.NewInt(20),
+		Gas:       21000,
+		To:        bumped.To,
+		Value:     big.NewInt(0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := owner.replaceTransaction(ctx, &bumped, tx); !errors.Is(err, ErrCancelled) {
+		t.Errorf("replace the cancelled transaction: got = %v, want %v", err, ErrCancelled)
+	}
+
+	if _, err := owner.syncTransaction(ctx, &synced); !errors.Is(err, ErrCancelled) {
+		t.Errorf("sync the cancelled transaction: got = %v, want %v", err, ErrCancelled)
+	}
+
+	if err := owner.dropTransaction(ctx, &dropped, errors.New("aborted transaction sending")); err != nil {
+		t.Errorf("drop the cancelled transaction: %v", err)
+	}
+
+	// The cancellation is never overwritten by the owner.
+	transaction := journal.transaction(5)
+
+	if transaction.State != schema.ManagedTransactionStateCancelled {
+		t.Errorf("state = %s, want %s", transaction.State, schema.ManagedTransactionStateCancelled)
+	}
+
+	if transaction.TransactionHash != cancellation.Hash() {
+		t.Errorf("hash = %s, want %s", transaction.TransactionHash, cancellation.Hash())
+	}
+}
+
+func TestSpeedUpMonitoredTransaction(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	chain := &testChain{nonce: 5, receipts: make(map[common.Hash]*types.Receipt)}
+	original := newTestTransaction(t, key, "settler", 5, schema.ManagedTransactionStatePending)
+	journal := newTestJournal(original)
+
+	owner := newTestTxManager(t, chain, journal, key)
+	operator := newTestTxManager(t, chain, journal, key)
+
+	// The entries held in memory by the owner while the operator speeds up the nonce.
+	synced, settled := journal.transaction(5), journal.transaction(5)
+
+	tx, err := operator.SpeedUp(ctx, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The owner picks up the new transaction to monitor and bump.
+	latest, err := owner.syncTransaction(ctx, &synced)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest == nil || latest.Hash() != tx.Hash() {
+		t.Errorf("latest transaction = %v, want %s", latest, tx.Hash())
+	}
+
+	if err := owner.settleTransaction(ctx, &settled, chain.GetTransactionReceipt(tx.Hash())); err != nil {
+		t.Fatal(err)
+	}
+
+	transaction := journal.transaction(5)
+
+	if transaction.State != schema.ManagedTransactionStateConfirmed {
+		t.Errorf("state = %s, want %s", transaction.State, schema.ManagedTransactionStateConfirmed)
+	}
+
+	// The receipt of the owner is recorded on top of the transaction of the operator.
+	for _, hash := range []common.Hash{original.TransactionHash, tx.Hash()} {
+		if !lo.Contains(transaction.TransactionHashes, hash) {
+			t.Errorf("hashes = %v, want to contain %s", transaction.TransactionHashes, hash)
+		}
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	gicrypto "github.com/rss3-network/global-indexer/common/crypto"
+	"github.com/rss3-network/global-indexer/schema"
 	"go.uber.org/zap"
 )
 
@@ -32,6 +33,8 @@ var oneHundred = big.NewInt(100)
 
 type TxManager interface {
 	Send(ctx context.Context, candidate TxCandidate) (*types.Receipt, error)
+	Recover(ctx context.Context) error
+	Halted(ctx context.Context) (*schema.ManagedTransaction, error)
 }
 
 type SimpleTxManager struct {
@@ -42,6 +45,9 @@ type SimpleTxManager struct {
 	from           common.Address
 	nonce          *uint64
 	nonceLock      sync.RWMutex
+	nonceLocker    NonceLocker
+	journal        Journal
+	feeOracle      FeeOracle
 
 	signer gicrypto.SignerFn
 }
@@ -55,10 +61,43 @@ type TxCandidate struct {
 	GasLimit uint64
 	// Value is the value to be used in the constructed tx.
 	Value *big.Int
+	// FeeBudget caps the cost of the constructed tx including fee bumps, in addition to Config.MaxFeePerTransaction.
+	// Nil means no additional cap.
+	FeeBudget *big.Int
 }
 
 // Send sends a candidate to the VSL.
+// Pending transactions of the owner are settled first, and it refuses to send
+// if a previous transaction of the owner has halted the transaction manager or is awaiting offline signature.
+// If a pending transaction was sent for the same candidate, its receipt is returned instead of sending again.
 func (m *SimpleTxManager) Send(ctx context.Context, candidate TxCandidate) (*types.Receipt, error) {
+	unsigned, err := m.AwaitingSignature(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if unsigned != nil {
+		return nil, fmt.Errorf("%w: transaction of nonce %d", ErrAwaitingSignature, unsigned.Nonce)
+	}
+
+	candidateReceipt, err := m.recover(ctx, &candidate)
+	if err != nil {
+		return nil, err
+	}
+
+	halted, err := m.Halted(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if halted != nil {
+		return nil, fmt.Errorf("%w by transaction %s of nonce %d", ErrHalted, halted.TransactionHash, halted.Nonce)
+	}
+
+	if candidateReceipt != nil {
+		return candidateReceipt, nil
+	}
+
 	receipt, err := m.send(ctx, candidate)
 	if err != nil {
 		m.resetNonce()
@@ -83,31 +122,36 @@ func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*typ
 	}
 
 	var (
-		tx *types.Transaction
+		tx          *types.Transaction
+		transaction *schema.ManagedTransaction
 
 		err error
 	)
 
-	if tx, err = retry.DoWithData(func() (*types.Transaction, error) {
-		tx, err = m.craftTx(ctx, candidate)
+	if err = retry.Do(func() error {
+		tx, transaction, err = m.craftTx(ctx, candidate)
+		if errors.Is(err, ErrFeeBudgetExceeded) || errors.Is(err, ErrAwaitingSignature) {
+			return retry.Unrecoverable(err)
+		}
+
 		if err != nil {
 			zap.L().Warn("Failed to create a transaction, will retry", zap.Error(err))
 
-			return nil, err
+			return err
 		}
 
-		return tx, nil
+		return nil
 	}, retry.Delay(2*time.Second), retry.Attempts(30)); err != nil {
 		return nil, fmt.Errorf("failed to create the tx: %w", err)
 	}
 
-	return m.sendTx(ctx, tx)
+	return m.sendTx(ctx, tx, transaction, minFeeBudget(m.cfg.MaxFeePerTransaction, candidate.FeeBudget))
 }
 
-func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*types.Transaction, error) {
+func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*types.Transaction, *schema.ManagedTransaction, error) {
 	gasTipCap, basefee, err := m.suggestGasPriceCaps(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get gas price info: %w", err)
+		return nil, nil, fmt.Errorf("failed to get gas price info: %w", err)
 	}
 
 	gasFeeCap := calcGasFeeCap(basefee, gasTipCap)
@@ -135,16 +179,25 @@ func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*
 			Value:     rawTx.Value,
 		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to estimate gas: %w", err)
+			return nil, nil, fmt.Errorf("failed to estimate gas: %w", err)
 		}
 
 		rawTx.Gas = gas
 	}
 
+	// Refuse to sign the transaction if its projected cost exceeds the budget
+	if err := checkFeeBudget(rawTx.Gas, gasFeeCap, minFeeBudget(m.cfg.MaxFeePerTransaction, candidate.FeeBudget)); err != nil {
+		zap.L().Error("refuse to send the transaction", zap.Error(err), zap.Uint64("gas", rawTx.Gas), zap.String("gasFeeCap", gasFeeCap.String()))
+
+		return nil, nil, err
+	}
+
 	return m.signWithNextNonce(ctx, rawTx)
 }
 
-func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+// sendTx publishes the transaction and waits for its receipt, the progress is recorded in the journal entry.
+// The fees are never bumped beyond the maxFee budget.
+func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction, transaction *schema.ManagedTransaction, maxFee *big.Int) (*types.Receipt, error) {
 	var wg sync.WaitGroup
 	defer wg.Wait()
 
@@ -154,18 +207,30 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 
 	sendState := NewSendState(m.cfg.SafeAbortNonceTooLowCount, m.cfg.TxNotInMempoolTimeout)
 	receiptChan := make(chan *types.Receipt, 1)
-	publishAndWait := func(tx *types.Transaction, bumpFees bool) *types.Transaction {
+	monitored := make(map[common.Hash]bool)
+	monitor := func(hash common.Hash) {
+		if monitored[hash] {
+			return
+		}
+
+		monitored[hash] = true
+
 		wg.Add(1)
 
-		tx, published := m.publishTx(ctx, tx, sendState, bumpFees)
+		go func() {
+			defer wg.Done()
+			m.waitForTx(ctx, hash, sendState, receiptChan)
+		}()
+	}
+	publishAndWait := func(tx *types.Transaction, bumpFees bool) *types.Transaction {
+		tx, published := m.publishTx(ctx, tx, sendState, bumpFees, maxFee)
+
+		if err := m.replaceTransaction(ctx, transaction, tx); err != nil {
+			zap.L().Error("failed to journal the replacement transaction", zap.Error(err), zap.String("hash", tx.Hash().String()))
+		}
 
 		if published {
-			go func() {
-				defer wg.Done()
-				m.waitForTx(ctx, tx, sendState, receiptChan)
-			}()
-		} else {
-			wg.Done()
+			monitor(tx.Hash())
 		}
 
 		return tx
@@ -184,11 +249,38 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 			if sendState.IsWaitingForConfirmation() {
 				continue
 			}
+
+			// Pick up the transactions of the nonce sent by an operator, and stop if the nonce has been cancelled.
+			if transaction != nil {
+				latest, err := m.syncTransaction(ctx, transaction)
+				if errors.Is(err, ErrCancelled) {
+					zap.L().Warn("stop monitoring the cancelled transaction", zap.Uint64("nonce", transaction.Nonce), zap.Error(err))
+
+					return nil, err
+				} else if err != nil {
+					zap.L().Error("failed to sync the journaled transaction", zap.Error(err), zap.Uint64("nonce", transaction.Nonce))
+				}
+
+				for _, transactionHash := range transaction.TransactionHashes {
+					monitor(transactionHash)
+				}
+
+				if latest != nil {
+					tx = latest
+				}
+			}
+
 			// If we see lots of unrecoverable errors (and no pending transactions) abort sending the transaction.
 			if sendState.ShouldAbortImmediately() {
 				zap.L().Error("aborting transaction submission")
 
-				return nil, errors.New("aborted transaction sending")
+				err := errors.New("aborted transaction sending")
+
+				if dropErr := m.dropTransaction(ctx, transaction, err); dropErr != nil {
+					zap.L().Error("failed to journal the dropped transaction", zap.Error(dropErr))
+				}
+
+				return nil, err
 			}
 
 			tx = publishAndWait(tx, true)
@@ -197,6 +289,10 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 			return nil, ctx.Err()
 
 		case receipt := <-receiptChan:
+			if err := m.settleTransaction(ctx, transaction, receipt); err != nil {
+				zap.L().Error("failed to journal the transaction receipt", zap.Error(err), zap.String("hash", receipt.TxHash.String()))
+			}
+
 			return receipt, nil
 		}
 	}
@@ -206,7 +302,7 @@ func (m *SimpleTxManager) suggestGasPriceCaps(ctx context.Context) (*big.Int, *b
 	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 	defer cancel()
 
-	tip, err := m.ethereumClient.SuggestGasTipCap(cCtx)
+	tip, err := m.feeOracle.SuggestGasTipCap(cCtx)
 
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to fetch the suggested gas tip cap: %w", err)
@@ -235,18 +331,38 @@ func calcGasFeeCap(baseFee, gasTipCap *big.Int) *big.Int {
 	)
 }
 
-func (m *SimpleTxManager) signWithNextNonce(ctx context.Context, rawTx *types.DynamicFeeTx) (*types.Transaction, error) {
+func (m *SimpleTxManager) signWithNextNonce(ctx context.Context, rawTx *types.DynamicFeeTx) (*types.Transaction, *schema.ManagedTransaction, error) {
 	m.nonceLock.Lock()
 	defer m.nonceLock.Unlock()
 
-	if m.nonce == nil {
+	// The nonce is allocated and journaled under the lock shared with the other owners of the wallet.
+	if m.nonceLocker != nil {
+		if err := m.nonceLocker.Lock(); err != nil {
+			return nil, nil, fmt.Errorf("failed to lock nonce: %w", err)
+		}
+
+		defer func() {
+			if _, err := m.nonceLocker.Unlock(); err != nil {
+				zap.L().Error("failed to unlock nonce", zap.Error(err))
+			}
+		}()
+	}
+
+	if m.journal != nil {
+		nonce, err := m.nextJournalNonce(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		m.nonce = &nonce
+	} else if m.nonce == nil {
 		// Fetch the sender's nonce from the latest known block (nil `blockNumber`)
 		childCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 		defer cancel()
 
 		nonce, err := m.ethereumClient.NonceAt(childCtx, m.from, nil)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get nonce: %w", err)
+			return nil, nil, fmt.Errorf("failed to get nonce: %w", err)
 		}
 
 		m.nonce = &nonce
@@ -255,25 +371,45 @@ func (m *SimpleTxManager) signWithNextNonce(ctx context.Context, rawTx *types.Dy
 	}
 
 	rawTx.Nonce = *m.nonce
-	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	signCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 
 	defer cancel()
 
-	tx, err := m.signer(ctx, m.from, types.NewTx(rawTx))
+	tx, err := m.signer(signCtx, m.from, types.NewTx(rawTx))
+
+	if errors.Is(err, gicrypto.ErrOfflineSigning) && m.journal != nil {
+		return nil, nil, m.awaitSignature(ctx, types.NewTx(rawTx), err)
+	}
 
 	if err != nil {
 		// decrement the nonce, so we can retry signing with the same nonce next time
 		// signWithNextNonce is called
 		*m.nonce--
+
+		return nil, nil, err
 	}
 
-	return tx, err
+	if m.journal == nil {
+		return tx, nil, nil
+	}
+
+	// Journal the transaction before publishing, so that it can be recovered after a restart.
+	transaction, err := m.newTransaction(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := m.saveTransaction(ctx, transaction); err != nil {
+		return nil, nil, err
+	}
+
+	return tx, transaction, nil
 }
 
-func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction, sendState *SendState, bumpFeesImmediately bool) (*types.Transaction, bool) {
+func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction, sendState *SendState, bumpFeesImmediately bool, maxFee *big.Int) (*types.Transaction, bool) {
 	for {
 		if bumpFeesImmediately {
-			newTx, err := m.increaseGasPrice(ctx, tx)
+			newTx, err := m.increaseGasPrice(ctx, tx, maxFee)
 			if err != nil {
 				return tx, false
 			}
@@ -334,13 +470,13 @@ func errStringMatch(err, target error) bool {
 	return strings.Contains(err.Error(), target.Error())
 }
 
-func (m *SimpleTxManager) waitForTx(ctx context.Context, tx *types.Transaction, sendState *SendState, receiptChan chan *types.Receipt) {
+func (m *SimpleTxManager) waitForTx(ctx context.Context, txHash common.Hash, sendState *SendState, receiptChan chan *types.Receipt) {
 	t := time.Now()
 	// Poll for the transaction to be ready & then send the result to receiptChan
-	receipt, err := m.waitMined(ctx, tx, sendState)
+	receipt, err := m.waitMined(ctx, txHash, sendState)
 	if err != nil {
 		// this will happen if the tx was successfully replaced by a tx with bumped fees
-		zap.L().Info("Transaction receipt not found", zap.Error(err), zap.String("hash", tx.Hash().String()))
+		zap.L().Info("Transaction receipt not found", zap.Error(err), zap.String("hash", txHash.String()))
 		return
 	}
 	select {
@@ -350,8 +486,7 @@ func (m *SimpleTxManager) waitForTx(ctx context.Context, tx *types.Transaction,
 	}
 }
 
-func (m *SimpleTxManager) waitMined(ctx context.Context, tx *types.Transaction, sendState *SendState) (*types.Receipt, error) {
-	txHash := tx.Hash()
+func (m *SimpleTxManager) waitMined(ctx context.Context, txHash common.Hash, sendState *SendState) (*types.Receipt, error) {
 	queryTicker := time.NewTicker(m.cfg.ReceiptQueryInterval)
 
 	defer queryTicker.Stop()
@@ -454,7 +589,7 @@ func calcThresholdValue(x *big.Int) *big.Int {
 	return threshold
 }
 
-func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transaction, maxFee *big.Int) (*types.Transaction, error) {
 	zap.L().Info("bumping gas price for tx", zap.String("hash", tx.Hash().String()), zap.Uint64("tip", tx.GasTipCap().Uint64()), zap.Uint64("fee", tx.GasFeeCap().Uint64()), zap.Uint64("gaslimit", tx.Gas()))
 
 	tip, basefee, err := m.suggestGasPriceCaps(ctx)
@@ -472,12 +607,19 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 		return nil, fmt.Errorf("bumped tip 0x%s is over %dx multiple of the suggested value", bumpedTip.Text(16), m.cfg.FeeLimitMultiplier)
 	}
 
-	maxFee := calcGasFeeCap(new(big.Int).Mul(basefee, big.NewInt(int64(m.cfg.FeeLimitMultiplier))), maxTip)
+	maxFeeCap := calcGasFeeCap(new(big.Int).Mul(basefee, big.NewInt(int64(m.cfg.FeeLimitMultiplier))), maxTip)
 
-	if bumpedFee.Cmp(maxFee) > 0 {
+	if bumpedFee.Cmp(maxFeeCap) > 0 {
 		return nil, fmt.Errorf("bumped fee 0x%s is over %dx multiple of the suggested value", bumpedFee.Text(16), m.cfg.FeeLimitMultiplier)
 	}
 
+	// Make sure the bumped transaction stays within the fee budget
+	if err := checkFeeBudget(tx.Gas(), bumpedFee, maxFee); err != nil {
+		zap.L().Error("refuse to bump the gas price", zap.Error(err), zap.String("hash", tx.Hash().String()))
+
+		return nil, err
+	}
+
 	rawTx := &types.DynamicFeeTx{
 		ChainID:    tx.ChainId(),
 		Nonce:      tx.Nonce(),
@@ -498,6 +640,13 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 
 	newTx, err := m.signer(ctx, m.from, types.NewTx(rawTx))
 
+	// The bumped transaction has been exported, it is published once the signed transaction is imported.
+	if errors.Is(err, gicrypto.ErrOfflineSigning) {
+		zap.L().Warn("bumped transaction is awaiting offline signature", zap.Error(err))
+
+		return nil, err
+	}
+
 	if err != nil {
 		zap.L().Warn("failed to sign new transaction", zap.Error(err))
 		return tx, nil
@@ -521,18 +670,27 @@ func EncodeInput(contractABI, methodName string, args ...interface{}) ([]byte, e
 	return encodedArgs, nil
 }
 
-func NewSimpleTxManager(conf Config, chainID *big.Int, nonce *uint64, ethereumClient *ethclient.Client, from common.Address, singer gicrypto.SignerFn) (*SimpleTxManager, error) {
+// NewSimpleTxManager creates a SimpleTxManager.
+// The journal and the nonceLocker are optional, without a journal the nonce is only kept in memory.
+func NewSimpleTxManager(conf Config, chainID *big.Int, journal Journal, nonceLocker NonceLocker, ethereumClient *ethclient.Client, from common.Address, singer gicrypto.SignerFn) (*SimpleTxManager, error) {
 	if err := conf.Check(); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
+	feeOracle, err := NewFeeOracle(conf.FeeOracle, ethereumClient)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fee oracle: %w", err)
+	}
+
 	return &SimpleTxManager{
 		cfg: conf,
 
 		chainID:        chainID,
 		ethereumClient: ethereumClient,
 		from:           from,
-		nonce:          nonce,
+		nonceLocker:    nonceLocker,
+		journal:        journal,
+		feeOracle:      feeOracle,
 
 		signer: singer,
 	}, nil
@@ -64,6 +64,7 @@ var (
 	EventHashStakingV1RewardDistributed = crypto.Keccak256Hash([]byte("RewardDistributed(uint256,uint256,uint256,address[],uint256[],uint256[],uint256[],uint256[])"))
 	EventHashStakingV1NodeCreated       = crypto.Keccak256Hash([]byte("NodeCreated(uint256,address,string,string,uint64,bool,bool)"))
 	EventHashStakingV1NodeUpdated       = crypto.Keccak256Hash([]byte("NodeUpdated(address,string,string)"))
+	EventHashStakingV1NodeSlashed       = crypto.Keccak256Hash([]byte("NodeSlashed(address,uint256,uint256)"))
 
 	EventHashStakingV2ChipsMerged       = crypto.Keccak256Hash([]byte("ChipsMerged(address,address,uint256,uint256[])"))
 	EventHashStakingV2WithdrawalClaimed = crypto.Keccak256Hash([]byte("WithdrawalClaimed(uint256,address,uint256)"))
@@ -3,8 +3,12 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"strings"
 	"time"
 
+	"github.com/go-redsync/redsync/v4"
+	"github.com/go-redsync/redsync/v4/redis/goredis/v9"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -12,11 +16,42 @@ type Client interface {
 	Get(ctx context.Context, key string, dest interface{}) error
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
 	IncrBy(ctx context.Context, key string, value int64) error
-	PSubscribe(ctx context.Context, pattern string) *redis.PubSub
+	PSubscribe(ctx context.Context, pattern string) PubSub
 	ZAdd(ctx context.Context, key string, members ...redis.Z) error
 	ZRem(ctx context.Context, key string, members ...interface{}) error
 	ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) ([]redis.Z, error)
+	// ZRevRankWithScore returns the rank of the member in the sorted set ordered from the highest score and its score,
+	// it returns redis.Nil if the member does not exist.
+	ZRevRankWithScore(ctx context.Context, key, member string) (int64, float64, error)
 	Exists(ctx context.Context, key string) (int64, error)
+	HGetAll(ctx context.Context, key string) (map[string]string, error)
+	// HMGet returns the values of the fields that exist in the hash.
+	HMGet(ctx context.Context, key string, fields ...string) (map[string]string, error)
+	// RunScript runs the script atomically.
+	RunScript(ctx context.Context, script *Script, keys []string, args ...interface{}) (interface{}, error)
+	// NewMutex returns a distributed lock backed by the cache.
+	NewMutex(name string, options ...redsync.Option) *redsync.Mutex
+	// XAdd appends a message to the stream, the stream is trimmed to about maxLen messages if maxLen is positive.
+	XAdd(ctx context.Context, stream string, maxLen int64, values map[string]interface{}) (string, error)
+	// XGroupCreate creates a consumer group reading the stream from the beginning, the stream is created if it does not exist.
+	// It does nothing if the group already exists.
+	XGroupCreate(ctx context.Context, stream, group string) error
+	// XReadGroup reads up to count new messages of the stream for the consumer of the group,
+	// it blocks for up to block if there is no new message, and does not block if block is not positive.
+	XReadGroup(ctx context.Context, stream, group, consumer string, count int64, block time.Duration) ([]redis.XMessage, error)
+	XAck(ctx context.Context, stream, group string, ids ...string) error
+	// XAutoClaim transfers up to count pending messages of the group that have been idle for at least minIdle to the consumer.
+	XAutoClaim(ctx context.Context, stream, group, consumer string, minIdle time.Duration, count int64) ([]redis.XMessage, error)
+	XLen(ctx context.Context, stream string) (int64, error)
+	// XPendingCount returns the number of messages delivered to the consumers of the group but not acknowledged yet.
+	XPendingCount(ctx context.Context, stream, group string) (int64, error)
+}
+
+// PubSub is a subscription to the channels matching a pattern, it is satisfied by *redis.PubSub.
+type PubSub interface {
+	Receive(ctx context.Context) (interface{}, error)
+	Channel(options ...redis.ChannelOption) <-chan *redis.Message
+	Close() error
 }
 
 var _ Client = (*client)(nil)
@@ -47,7 +82,7 @@ func (c *client) IncrBy(ctx context.Context, key string, value int64) error {
 	return c.redisClient.IncrBy(ctx, key, value).Err()
 }
 
-func (c *client) PSubscribe(ctx context.Context, pattern string) *redis.PubSub {
+func (c *client) PSubscribe(ctx context.Context, pattern string) PubSub {
 	return c.redisClient.PSubscribe(ctx, pattern)
 }
 
@@ -63,10 +98,136 @@ func (c *client) ZRevRangeWithScores(ctx context.Context, key string, start, sto
 	return c.redisClient.ZRevRangeWithScores(ctx, key, start, stop).Result()
 }
 
+func (c *client) ZRevRankWithScore(ctx context.Context, key, member string) (int64, float64, error) {
+	var (
+		rank  *redis.IntCmd
+		score *redis.FloatCmd
+	)
+
+	// ZREVRANK WITHSCORE requires Redis 7.2, so the rank and the score are read in a transaction instead.
+	if _, err := c.redisClient.TxPipelined(ctx, func(pipeliner redis.Pipeliner) error {
+		rank = pipeliner.ZRevRank(ctx, key, member)
+		score = pipeliner.ZScore(ctx, key, member)
+
+		return nil
+	}); err != nil {
+		return 0, 0, err
+	}
+
+	return rank.Val(), score.Val(), nil
+}
+
 func (c *client) Exists(ctx context.Context, key string) (int64, error) {
 	return c.redisClient.Exists(ctx, key).Result()
 }
 
+func (c *client) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	return c.redisClient.HGetAll(ctx, key).Result()
+}
+
+func (c *client) HMGet(ctx context.Context, key string, fields ...string) (map[string]string, error) {
+	values, err := c.redisClient.HMGet(ctx, key, fields...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string, len(fields))
+
+	for i, value := range values {
+		if value, ok := value.(string); ok {
+			result[fields[i]] = value
+		}
+	}
+
+	return result, nil
+}
+
+func (c *client) RunScript(ctx context.Context, script *Script, keys []string, args ...interface{}) (interface{}, error) {
+	return script.lua.Run(ctx, c.redisClient, keys, args...).Result()
+}
+
+func (c *client) NewMutex(name string, options ...redsync.Option) *redsync.Mutex {
+	return redsync.New(goredis.NewPool(c.redisClient)).NewMutex(name, options...)
+}
+
+func (c *client) XAdd(ctx context.Context, stream string, maxLen int64, values map[string]interface{}) (string, error) {
+	return c.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		MaxLen: maxLen,
+		Approx: true,
+		Values: values,
+	}).Result()
+}
+
+func (c *client) XGroupCreate(ctx context.Context, stream, group string) error {
+	err := c.redisClient.XGroupCreateMkStream(ctx, stream, group, "0").Err()
+	if err != nil && strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return nil
+	}
+
+	return err
+}
+
+func (c *client) XReadGroup(ctx context.Context, stream, group, consumer string, count int64, block time.Duration) ([]redis.XMessage, error) {
+	// A negative block omits the BLOCK option, while zero blocks forever.
+	if block <= 0 {
+		block = -1
+	}
+
+	streams, err := c.redisClient.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    group,
+		Consumer: consumer,
+		Streams:  []string{stream, ">"},
+		Count:    count,
+		Block:    block,
+	}).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return []redis.XMessage{}, nil
+		}
+
+		return nil, err
+	}
+
+	messages := make([]redis.XMessage, 0, count)
+
+	for _, stream := range streams {
+		messages = append(messages, stream.Messages...)
+	}
+
+	return messages, nil
+}
+
+func (c *client) XAck(ctx context.Context, stream, group string, ids ...string) error {
+	return c.redisClient.XAck(ctx, stream, group, ids...).Err()
+}
+
+func (c *client) XAutoClaim(ctx context.Context, stream, group, consumer string, minIdle time.Duration, count int64) ([]redis.XMessage, error) {
+	messages, _, err := c.redisClient.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   stream,
+		Group:    group,
+		Consumer: consumer,
+		MinIdle:  minIdle,
+		Start:    "0-0",
+		Count:    count,
+	}).Result()
+
+	return messages, err
+}
+
+func (c *client) XLen(ctx context.Context, stream string) (int64, error) {
+	return c.redisClient.XLen(ctx, stream).Result()
+}
+
+func (c *client) XPendingCount(ctx context.Context, stream, group string) (int64, error) {
+	pending, err := c.redisClient.XPending(ctx, stream, group).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	return pending.Count, nil
+}
+
 func New(redisClient *redis.Client) Client {
 	return &client{
 		redisClient: redisClient,
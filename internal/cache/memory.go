@@ -0,0 +1,648 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redsync/redsync/v4"
+	redsyncredis "github.com/go-redsync/redsync/v4/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// MemoryScheme is the URI scheme that selects the in-memory cache.
+const MemoryScheme = "memory"
+
+// memoryPubSubBufferSize is the number of messages buffered for a subscription before they are dropped.
+const memoryPubSubBufferSize = 100
+
+var (
+	ErrWrongType  = errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
+	ErrNotInteger = errors.New("ERR value is not an integer or out of range")
+)
+
+var _ Client = (*memoryClient)(nil)
+
+// memoryClient is an in-process Client, it is only suitable for tests and single instance deployments.
+// It emits keyspace notifications for the writes like Redis with notify-keyspace-events enabled.
+type memoryClient struct {
+	mu            sync.Mutex
+	entries       map[string]*memoryEntry
+	subscriptions map[*memoryPubSub]struct{}
+	// streamAdded is closed and replaced when a message is added to a stream, it wakes up the blocked readers.
+	streamAdded chan struct{}
+}
+
+// memoryEntry is a sorted set if members is not nil, a hash if fields is not nil,
+// a stream if stream is not nil, or a string otherwise.
+type memoryEntry struct {
+	value     string
+	members   map[string]float64
+	fields    map[string]string
+	stream    *memoryStream
+	expiresAt time.Time
+}
+
+func (c *memoryClient) Get(_ context.Context, key string, dest interface{}) error {
+	c.mu.Lock()
+	entry, err := c.lookupString(key)
+	c.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	if entry == nil {
+		return redis.Nil
+	}
+
+	return json.Unmarshal([]byte(entry.value), dest)
+}
+
+func (c *memoryClient) Set(_ context.Context, key string, value interface{}, expiration time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(key, string(data), expiration)
+	c.notify(key, "set")
+
+	return nil
+}
+
+func (c *memoryClient) IncrBy(_ context.Context, key string, value int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, err := c.incrBy(key, value)
+
+	return err
+}
+
+func (c *memoryClient) PSubscribe(_ context.Context, pattern string) PubSub {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pubsub := &memoryPubSub{
+		client:   c,
+		pattern:  pattern,
+		messages: make(chan *redis.Message, memoryPubSubBufferSize),
+	}
+
+	c.subscriptions[pubsub] = struct{}{}
+
+	return pubsub
+}
+
+func (c *memoryClient) ZAdd(_ context.Context, key string, members ...redis.Z) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.zadd(key, members...)
+}
+
+func (c *memoryClient) ZRem(_ context.Context, key string, members ...interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.zrem(key, members...)
+}
+
+func (c *memoryClient) ZRevRangeWithScores(_ context.Context, key string, start, stop int64) ([]redis.Z, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, err := c.lookupSortedSet(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry == nil {
+		return []redis.Z{}, nil
+	}
+
+	members := make([]redis.Z, 0, len(entry.members))
+
+	for member, score := range entry.members {
+		members = append(members, redis.Z{Score: score, Member: member})
+	}
+
+	// The members with the same score are ordered lexicographically in reverse.
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].Score != members[j].Score {
+			return members[i].Score > members[j].Score
+		}
+
+		return members[i].Member.(string) > members[j].Member.(string)
+	})
+
+	length := int64(len(members))
+
+	if start < 0 {
+		start = max(start+length, 0)
+	}
+
+	if stop < 0 {
+		stop += length
+	}
+
+	if stop >= length {
+		stop = length - 1
+	}
+
+	if start > stop || start >= length {
+		return []redis.Z{}, nil
+	}
+
+	return members[start : stop+1], nil
+}
+
+func (c *memoryClient) ZRevRankWithScore(_ context.Context, key, member string) (int64, float64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, err := c.lookupSortedSet(key)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if entry == nil {
+		return 0, 0, redis.Nil
+	}
+
+	score, exists := entry.members[member]
+	if !exists {
+		return 0, 0, redis.Nil
+	}
+
+	var rank int64
+
+	// The members with the same score are ordered lexicographically in reverse.
+	for other, otherScore := range entry.members {
+		if otherScore > score || (otherScore == score && other > member) {
+			rank++
+		}
+	}
+
+	return rank, score, nil
+}
+
+func (c *memoryClient) Exists(_ context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.lookup(key) == nil {
+		return 0, nil
+	}
+
+	return 1, nil
+}
+
+func (c *memoryClient) HGetAll(_ context.Context, key string) (map[string]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, err := c.lookupHash(key)
+	if err != nil || entry == nil {
+		return map[string]string{}, err
+	}
+
+	fields := make(map[string]string, len(entry.fields))
+
+	for field, value := range entry.fields {
+		fields[field] = value
+	}
+
+	return fields, nil
+}
+
+func (c *memoryClient) HMGet(_ context.Context, key string, fields ...string) (map[string]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, err := c.lookupHash(key)
+	if err != nil || entry == nil {
+		return map[string]string{}, err
+	}
+
+	result := make(map[string]string, len(fields))
+
+	for _, field := range fields {
+		if value, exists := entry.fields[field]; exists {
+			result[field] = value
+		}
+	}
+
+	return result, nil
+}
+
+func (c *memoryClient) RunScript(_ context.Context, script *Script, keys []string, args ...interface{}) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return script.memory(&memoryScriptTx{client: c}, keys, args)
+}
+
+func (c *memoryClient) NewMutex(name string, options ...redsync.Option) *redsync.Mutex {
+	return redsync.New(&memoryPool{client: c}).NewMutex(name, options...)
+}
+
+// lookup returns the entry of the key, the entry is removed if it has expired.
+func (c *memoryClient) lookup(key string) *memoryEntry {
+	entry, exists := c.entries[key]
+	if !exists {
+		return nil
+	}
+
+	if !entry.expiresAt.IsZero() && !time.Now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		c.notify(key, "expired")
+
+		return nil
+	}
+
+	return entry
+}
+
+func (c *memoryClient) lookupString(key string) (*memoryEntry, error) {
+	entry := c.lookup(key)
+	if entry != nil && (entry.members != nil || entry.fields != nil || entry.stream != nil) {
+		return nil, ErrWrongType
+	}
+
+	return entry, nil
+}
+
+func (c *memoryClient) lookupSortedSet(key string) (*memoryEntry, error) {
+	entry := c.lookup(key)
+	if entry != nil && entry.members == nil {
+		return nil, ErrWrongType
+	}
+
+	return entry, nil
+}
+
+func (c *memoryClient) lookupHash(key string) (*memoryEntry, error) {
+	entry := c.lookup(key)
+	if entry != nil && entry.fields == nil {
+		return nil, ErrWrongType
+	}
+
+	return entry, nil
+}
+
+func (c *memoryClient) set(key, value string, expiration time.Duration) {
+	entry := memoryEntry{value: value}
+
+	if expiration > 0 {
+		entry.expiresAt = time.Now().Add(expiration)
+	}
+
+	c.entries[key] = &entry
+}
+
+func (c *memoryClient) incrBy(key string, value int64) (int64, error) {
+	entry, err := c.lookupString(key)
+	if err != nil {
+		return 0, err
+	}
+
+	if entry == nil {
+		entry = &memoryEntry{value: "0"}
+		c.entries[key] = entry
+	}
+
+	current, err := strconv.ParseInt(entry.value, 10, 64)
+	if err != nil {
+		return 0, ErrNotInteger
+	}
+
+	// The expiration of the key is kept.
+	entry.value = strconv.FormatInt(current+value, 10)
+	c.notify(key, "incrby")
+
+	return current + value, nil
+}
+
+func (c *memoryClient) zadd(key string, members ...redis.Z) error {
+	entry, err := c.lookupSortedSet(key)
+	if err != nil {
+		return err
+	}
+
+	if entry == nil {
+		entry = &memoryEntry{members: make(map[string]float64, len(members))}
+		c.entries[key] = entry
+	}
+
+	for _, member := range members {
+		entry.members[formatMember(member.Member)] = member.Score
+	}
+
+	c.notify(key, "zadd")
+
+	return nil
+}
+
+func (c *memoryClient) zrem(key string, members ...interface{}) error {
+	entry, err := c.lookupSortedSet(key)
+	if err != nil || entry == nil {
+		return err
+	}
+
+	for _, member := range members {
+		// A slice of members is flattened like go-redis does.
+		if members, ok := member.([]string); ok {
+			for _, member := range members {
+				delete(entry.members, member)
+			}
+
+			continue
+		}
+
+		delete(entry.members, formatMember(member))
+	}
+
+	// An empty sorted set is removed like Redis does.
+	if len(entry.members) == 0 {
+		delete(c.entries, key)
+	}
+
+	c.notify(key, "zrem")
+
+	return nil
+}
+
+// notify publishes the keyspace notification of the event on the key.
+func (c *memoryClient) notify(key, event string) {
+	c.publish(fmt.Sprintf("__keyspace@0__:%s", key), event)
+}
+
+// publish publishes the message to the subscriptions matching the channel.
+// The message is dropped if the buffer of a subscription is full.
+func (c *memoryClient) publish(channel, message string) {
+	for pubsub := range c.subscriptions {
+		if matched, _ := path.Match(pubsub.pattern, channel); !matched {
+			continue
+		}
+
+		select {
+		case pubsub.messages <- &redis.Message{Channel: channel, Pattern: pubsub.pattern, Payload: message}:
+		default:
+			zap.L().Warn("drop message", zap.String("channel", channel), zap.String("message", message))
+		}
+	}
+}
+
+// formatMember formats a member of a sorted set like go-redis does for the common types.
+func formatMember(member interface{}) string {
+	switch member := member.(type) {
+	case string:
+		return member
+	case []byte:
+		return string(member)
+	default:
+		return fmt.Sprint(member)
+	}
+}
+
+var _ ScriptTx = (*memoryScriptTx)(nil)
+
+// memoryScriptTx runs the operations of a Script on the in-memory cache, the caller holds the lock of the cache.
+type memoryScriptTx struct {
+	client *memoryClient
+}
+
+func (t *memoryScriptTx) Get(key string) (string, bool, error) {
+	entry, err := t.client.lookupString(key)
+	if err != nil || entry == nil {
+		return "", false, err
+	}
+
+	return entry.value, true, nil
+}
+
+func (t *memoryScriptTx) Del(keys ...string) {
+	for _, key := range keys {
+		if t.client.lookup(key) != nil {
+			delete(t.client.entries, key)
+			t.client.notify(key, "del")
+		}
+	}
+}
+
+func (t *memoryScriptTx) IncrBy(key string, value int64) (int64, error) {
+	return t.client.incrBy(key, value)
+}
+
+func (t *memoryScriptTx) HGet(key, field string) (string, bool, error) {
+	entry, err := t.client.lookupHash(key)
+	if err != nil || entry == nil {
+		return "", false, err
+	}
+
+	value, exists := entry.fields[field]
+
+	return value, exists, nil
+}
+
+func (t *memoryScriptTx) HSet(key, field, value string) error {
+	entry, err := t.client.lookupHash(key)
+	if err != nil {
+		return err
+	}
+
+	if entry == nil {
+		entry = &memoryEntry{fields: make(map[string]string)}
+		t.client.entries[key] = entry
+	}
+
+	entry.fields[field] = value
+	t.client.notify(key, "hset")
+
+	return nil
+}
+
+func (t *memoryScriptTx) ZAdd(key string, members ...redis.Z) error {
+	return t.client.zadd(key, members...)
+}
+
+func (t *memoryScriptTx) ZRem(key string, members ...interface{}) error {
+	return t.client.zrem(key, members...)
+}
+
+func (t *memoryScriptTx) ZMembers(key string) ([]string, error) {
+	entry, err := t.client.lookupSortedSet(key)
+	if err != nil || entry == nil {
+		return nil, err
+	}
+
+	return lo.Keys(entry.members), nil
+}
+
+func (t *memoryScriptTx) Publish(channel, message string) {
+	t.client.publish(channel, message)
+}
+
+var _ PubSub = (*memoryPubSub)(nil)
+
+type memoryPubSub struct {
+	client   *memoryClient
+	pattern  string
+	messages chan *redis.Message
+	closed   bool
+}
+
+// Receive confirms the subscription, the subscription of the in-memory cache is created synchronously.
+func (p *memoryPubSub) Receive(_ context.Context) (interface{}, error) {
+	return &redis.Subscription{Kind: "psubscribe", Channel: p.pattern, Count: 1}, nil
+}
+
+func (p *memoryPubSub) Channel(_ ...redis.ChannelOption) <-chan *redis.Message {
+	return p.messages
+}
+
+func (p *memoryPubSub) Close() error {
+	p.client.mu.Lock()
+	defer p.client.mu.Unlock()
+
+	if !p.closed {
+		p.closed = true
+
+		delete(p.client.subscriptions, p)
+		close(p.messages)
+	}
+
+	return nil
+}
+
+var _ redsyncredis.Pool = (*memoryPool)(nil)
+
+// memoryPool is a redsync pool on the in-memory cache, the locks are only shared within the process.
+type memoryPool struct {
+	client *memoryClient
+}
+
+func (p *memoryPool) Get(_ context.Context) (redsyncredis.Conn, error) {
+	return &memoryConn{client: p.client}, nil
+}
+
+var _ redsyncredis.Conn = (*memoryConn)(nil)
+
+type memoryConn struct {
+	client *memoryClient
+}
+
+func (c *memoryConn) Get(name string) (string, error) {
+	c.client.mu.Lock()
+	defer c.client.mu.Unlock()
+
+	entry, err := c.client.lookupString(name)
+	if err != nil || entry == nil {
+		return "", err
+	}
+
+	return entry.value, nil
+}
+
+func (c *memoryConn) Set(name string, value string) (bool, error) {
+	c.client.mu.Lock()
+	defer c.client.mu.Unlock()
+
+	c.client.set(name, value, 0)
+
+	return true, nil
+}
+
+func (c *memoryConn) SetNX(name string, value string, expiry time.Duration) (bool, error) {
+	c.client.mu.Lock()
+	defer c.client.mu.Unlock()
+
+	if c.client.lookup(name) != nil {
+		return false, nil
+	}
+
+	c.client.set(name, value, expiry)
+
+	return true, nil
+}
+
+// Eval runs the scripts of redsync, releasing and extending a lock held with the value.
+func (c *memoryConn) Eval(script *redsyncredis.Script, keysAndArgs ...interface{}) (interface{}, error) {
+	if script.KeyCount != 1 || len(keysAndArgs) < 2 {
+		return nil, fmt.Errorf("unsupported script %s", script.Hash)
+	}
+
+	c.client.mu.Lock()
+	defer c.client.mu.Unlock()
+
+	name, value := keysAndArgs[0].(string), keysAndArgs[1].(string)
+
+	entry, err := c.client.lookupString(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry == nil || entry.value != value {
+		return int64(0), nil
+	}
+
+	switch {
+	case strings.Contains(script.Src, `"DEL"`):
+		delete(c.client.entries, name)
+	case strings.Contains(script.Src, `"PEXPIRE"`) && len(keysAndArgs) == 3:
+		expiry, ok := keysAndArgs[2].(int)
+		if !ok {
+			return nil, fmt.Errorf("invalid expiry %v", keysAndArgs[2])
+		}
+
+		entry.expiresAt = time.Now().Add(time.Duration(expiry) * time.Millisecond)
+	default:
+		return nil, fmt.Errorf("unsupported script %s", script.Hash)
+	}
+
+	return int64(1), nil
+}
+
+// PTTL returns the remaining time to live of the key, -2 if the key does not exist and -1 if it has no expiration like go-redis.
+func (c *memoryConn) PTTL(name string) (time.Duration, error) {
+	c.client.mu.Lock()
+	defer c.client.mu.Unlock()
+
+	entry := c.client.lookup(name)
+
+	switch {
+	case entry == nil:
+		return -2, nil
+	case entry.expiresAt.IsZero():
+		return -1, nil
+	default:
+		return time.Until(entry.expiresAt), nil
+	}
+}
+
+func (c *memoryConn) Close() error {
+	return nil
+}
+
+// NewMemory returns an in-memory Client.
+func NewMemory() Client {
+	return &memoryClient{
+		entries:       make(map[string]*memoryEntry),
+		subscriptions: make(map[*memoryPubSub]struct{}),
+		streamAdded:   make(chan struct{}),
+	}
+}
@@ -0,0 +1,307 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// memoryStream is a stream of the in-memory cache, the messages are ordered by their IDs.
+type memoryStream struct {
+	messages []redis.XMessage
+	lastID   memoryStreamID
+	groups   map[string]*memoryStreamGroup
+}
+
+type memoryStreamGroup struct {
+	// lastDelivered is the ID of the last message delivered to the consumers of the group.
+	lastDelivered memoryStreamID
+	pending       map[string]*memoryPendingMessage
+}
+
+// memoryPendingMessage is a message delivered to a consumer but not acknowledged yet.
+type memoryPendingMessage struct {
+	id          memoryStreamID
+	consumer    string
+	deliveredAt time.Time
+}
+
+// memoryStreamID is the ID of a stream message in the form of <milliseconds>-<sequence> like Redis.
+type memoryStreamID struct {
+	milliseconds uint64
+	sequence     uint64
+}
+
+func (id memoryStreamID) String() string {
+	return fmt.Sprintf("%d-%d", id.milliseconds, id.sequence)
+}
+
+func (id memoryStreamID) less(other memoryStreamID) bool {
+	if id.milliseconds != other.milliseconds {
+		return id.milliseconds < other.milliseconds
+	}
+
+	return id.sequence < other.sequence
+}
+
+func parseMemoryStreamID(id string) (memoryStreamID, error) {
+	milliseconds, sequence, _ := strings.Cut(id, "-")
+
+	var (
+		result memoryStreamID
+		err    error
+	)
+
+	if result.milliseconds, err = strconv.ParseUint(milliseconds, 10, 64); err != nil {
+		return memoryStreamID{}, fmt.Errorf("invalid stream id %s", id)
+	}
+
+	if result.sequence, err = strconv.ParseUint(sequence, 10, 64); err != nil {
+		return memoryStreamID{}, fmt.Errorf("invalid stream id %s", id)
+	}
+
+	return result, nil
+}
+
+func (c *memoryClient) XAdd(_ context.Context, stream string, maxLen int64, values map[string]interface{}) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, err := c.lookupStream(stream, true)
+	if err != nil {
+		return "", err
+	}
+
+	s := entry.stream
+
+	// The sequence is incremented within the same millisecond, or if the clock goes backwards.
+	id := memoryStreamID{milliseconds: uint64(time.Now().UnixMilli())}
+	if !s.lastID.less(id) {
+		id = memoryStreamID{milliseconds: s.lastID.milliseconds, sequence: s.lastID.sequence + 1}
+	}
+
+	message := redis.XMessage{ID: id.String(), Values: make(map[string]interface{}, len(values))}
+
+	// The values are read back as strings like Redis does.
+	for field, value := range values {
+		message.Values[field] = formatMember(value)
+	}
+
+	s.messages = append(s.messages, message)
+	s.lastID = id
+
+	if maxLen > 0 && int64(len(s.messages)) > maxLen {
+		s.messages = s.messages[int64(len(s.messages))-maxLen:]
+	}
+
+	close(c.streamAdded)
+	c.streamAdded = make(chan struct{})
+
+	c.notify(stream, "xadd")
+
+	return message.ID, nil
+}
+
+func (c *memoryClient) XGroupCreate(_ context.Context, stream, group string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, err := c.lookupStream(stream, true)
+	if err != nil {
+		return err
+	}
+
+	if _, exists := entry.stream.groups[group]; !exists {
+		entry.stream.groups[group] = &memoryStreamGroup{pending: make(map[string]*memoryPendingMessage)}
+	}
+
+	return nil
+}
+
+func (c *memoryClient) XReadGroup(ctx context.Context, stream, group, consumer string, count int64, block time.Duration) ([]redis.XMessage, error) {
+	var deadline <-chan time.Time
+
+	if block > 0 {
+		timer := time.NewTimer(block)
+		defer timer.Stop()
+
+		deadline = timer.C
+	}
+
+	for {
+		c.mu.Lock()
+		messages, err := c.readGroup(stream, group, consumer, count)
+		added := c.streamAdded
+		c.mu.Unlock()
+
+		if err != nil || len(messages) > 0 || deadline == nil {
+			return messages, err
+		}
+
+		select {
+		case <-added:
+		case <-deadline:
+			return []redis.XMessage{}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// readGroup delivers up to count messages after the last delivered message of the group to the consumer.
+func (c *memoryClient) readGroup(stream, group, consumer string, count int64) ([]redis.XMessage, error) {
+	g, err := c.lookupGroup(stream, group)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, _ := c.lookupStream(stream, false)
+
+	messages := make([]redis.XMessage, 0)
+
+	for _, message := range entry.stream.messages {
+		if count > 0 && int64(len(messages)) >= count {
+			break
+		}
+
+		id, _ := parseMemoryStreamID(message.ID)
+		if !g.lastDelivered.less(id) {
+			continue
+		}
+
+		g.lastDelivered = id
+		g.pending[message.ID] = &memoryPendingMessage{id: id, consumer: consumer, deliveredAt: time.Now()}
+
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+func (c *memoryClient) XAck(_ context.Context, stream, group string, ids ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	g, err := c.lookupGroup(stream, group)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		delete(g.pending, id)
+	}
+
+	return nil
+}
+
+func (c *memoryClient) XAutoClaim(_ context.Context, stream, group, consumer string, minIdle time.Duration, count int64) ([]redis.XMessage, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	g, err := c.lookupGroup(stream, group)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, _ := c.lookupStream(stream, false)
+
+	pending := make([]*memoryPendingMessage, 0, len(g.pending))
+
+	for _, message := range g.pending {
+		if time.Since(message.deliveredAt) >= minIdle {
+			pending = append(pending, message)
+		}
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].id.less(pending[j].id)
+	})
+
+	messages := make([]redis.XMessage, 0)
+
+	for _, message := range pending {
+		if count > 0 && int64(len(messages)) >= count {
+			break
+		}
+
+		index := sort.Search(len(entry.stream.messages), func(i int) bool {
+			id, _ := parseMemoryStreamID(entry.stream.messages[i].ID)
+
+			return !id.less(message.id)
+		})
+
+		// The messages trimmed from the stream are removed from the pending messages like Redis does.
+		if index == len(entry.stream.messages) || entry.stream.messages[index].ID != message.id.String() {
+			delete(g.pending, message.id.String())
+
+			continue
+		}
+
+		message.consumer = consumer
+		message.deliveredAt = time.Now()
+
+		messages = append(messages, entry.stream.messages[index])
+	}
+
+	return messages, nil
+}
+
+func (c *memoryClient) XLen(_ context.Context, stream string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, err := c.lookupStream(stream, false)
+	if err != nil || entry == nil {
+		return 0, err
+	}
+
+	return int64(len(entry.stream.messages)), nil
+}
+
+func (c *memoryClient) XPendingCount(_ context.Context, stream, group string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	g, err := c.lookupGroup(stream, group)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(g.pending)), nil
+}
+
+// lookupStream returns the entry of the stream, the stream is created if it does not exist and create is true.
+func (c *memoryClient) lookupStream(key string, create bool) (*memoryEntry, error) {
+	entry := c.lookup(key)
+	if entry != nil && entry.stream == nil {
+		return nil, ErrWrongType
+	}
+
+	if entry == nil && create {
+		entry = &memoryEntry{stream: &memoryStream{groups: make(map[string]*memoryStreamGroup)}}
+		c.entries[key] = entry
+	}
+
+	return entry, nil
+}
+
+// lookupGroup returns the consumer group of the stream, it returns an error like Redis if the group does not exist.
+func (c *memoryClient) lookupGroup(stream, group string) (*memoryStreamGroup, error) {
+	entry, err := c.lookupStream(stream, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry != nil {
+		if g, exists := entry.stream.groups[group]; exists {
+			return g, nil
+		}
+	}
+
+	return nil, fmt.Errorf("NOGROUP No such key '%s' or consumer group '%s'", stream, group)
+}
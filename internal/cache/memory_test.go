@@ -0,0 +1,158 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redsync/redsync/v4"
+	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/internal/cache"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryClient(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx         = context.Background()
+		cacheClient = cache.NewMemory()
+		value       int64
+	)
+
+	require.ErrorIs(t, cacheClient.Get(ctx, "counter", &value), redis.Nil)
+
+	require.NoError(t, cacheClient.Set(ctx, "counter", 1, 0))
+	require.NoError(t, cacheClient.IncrBy(ctx, "counter", 2))
+	require.NoError(t, cacheClient.Get(ctx, "counter", &value))
+	require.Equal(t, int64(3), value)
+
+	require.NoError(t, cacheClient.Set(ctx, "expiring", "value", time.Millisecond))
+	time.Sleep(2 * time.Millisecond)
+
+	exists, err := cacheClient.Exists(ctx, "expiring")
+	require.NoError(t, err)
+	require.Zero(t, exists)
+
+	require.NoError(t, cacheClient.ZAdd(ctx, "scores", redis.Z{Member: "a", Score: 1}, redis.Z{Member: "b", Score: 3}, redis.Z{Member: "c", Score: 2}))
+	require.NoError(t, cacheClient.ZRem(ctx, "scores", "c"))
+
+	members, err := cacheClient.ZRevRangeWithScores(ctx, "scores", 0, -1)
+	require.NoError(t, err)
+	require.Equal(t, []redis.Z{{Member: "b", Score: 3}, {Member: "a", Score: 1}}, members)
+
+	rank, score, err := cacheClient.ZRevRankWithScore(ctx, "scores", "a")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rank)
+	require.Equal(t, float64(1), score)
+
+	_, _, err = cacheClient.ZRevRankWithScore(ctx, "scores", "c")
+	require.ErrorIs(t, err, redis.Nil)
+
+	require.ErrorIs(t, cacheClient.IncrBy(ctx, "scores", 1), cache.ErrWrongType)
+}
+
+func TestMemoryClientPSubscribe(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx         = context.Background()
+		cacheClient = cache.NewMemory()
+	)
+
+	pubsub := cacheClient.PSubscribe(ctx, "__keyspace@*__:epoch")
+
+	_, err := pubsub.Receive(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, cacheClient.Set(ctx, "other", 1, 0))
+	require.NoError(t, cacheClient.Set(ctx, "epoch", 1, 0))
+
+	message := <-pubsub.Channel()
+	require.Equal(t, "__keyspace@0__:epoch", message.Channel)
+	require.Equal(t, "set", message.Payload)
+
+	require.NoError(t, pubsub.Close())
+
+	_, ok := <-pubsub.Channel()
+	require.False(t, ok)
+}
+
+func TestMemoryClientNewMutex(t *testing.T) {
+	t.Parallel()
+
+	cacheClient := cache.NewMemory()
+
+	mutex := cacheClient.NewMutex("lock", redsync.WithExpiry(time.Minute), redsync.WithTries(1))
+	require.NoError(t, mutex.Lock())
+
+	// The lock is held until it is released.
+	require.Error(t, cacheClient.NewMutex("lock", redsync.WithTries(1)).Lock())
+
+	extended, err := mutex.Extend()
+	require.NoError(t, err)
+	require.True(t, extended)
+
+	released, err := mutex.Unlock()
+	require.NoError(t, err)
+	require.True(t, released)
+
+	require.NoError(t, cacheClient.NewMutex("lock", redsync.WithTries(1)).Lock())
+}
+
+func TestMemoryClientStream(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx         = context.Background()
+		cacheClient = cache.NewMemory()
+	)
+
+	require.NoError(t, cacheClient.XGroupCreate(ctx, "stream", "group"))
+	require.NoError(t, cacheClient.XGroupCreate(ctx, "stream", "group"))
+
+	for i := 0; i < 3; i++ {
+		_, err := cacheClient.XAdd(ctx, "stream", 2, map[string]interface{}{"index": i})
+		require.NoError(t, err)
+	}
+
+	length, err := cacheClient.XLen(ctx, "stream")
+	require.NoError(t, err)
+	require.Equal(t, int64(2), length)
+
+	messages, err := cacheClient.XReadGroup(ctx, "stream", "group", "a", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	require.Equal(t, "1", messages[0].Values["index"])
+
+	// The new messages are delivered to a blocked reader.
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+
+		_, _ = cacheClient.XAdd(ctx, "stream", 0, map[string]interface{}{"index": 3})
+	}()
+
+	blocked, err := cacheClient.XReadGroup(ctx, "stream", "group", "a", 10, time.Second)
+	require.NoError(t, err)
+	require.Len(t, blocked, 1)
+
+	require.NoError(t, cacheClient.XAck(ctx, "stream", "group", messages[0].ID, blocked[0].ID))
+
+	pending, err := cacheClient.XPendingCount(ctx, "stream", "group")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), pending)
+
+	// The unacknowledged message is claimed by another consumer once it is idle.
+	claimed, err := cacheClient.XAutoClaim(ctx, "stream", "group", "b", time.Hour, 10)
+	require.NoError(t, err)
+	require.Empty(t, claimed)
+
+	claimed, err = cacheClient.XAutoClaim(ctx, "stream", "group", "b", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.Equal(t, messages[1].ID, claimed[0].ID)
+
+	messages, err = cacheClient.XReadGroup(ctx, "stream", "group", "a", 10, time.Millisecond)
+	require.NoError(t, err)
+	require.Empty(t, messages)
+}
@@ -0,0 +1,34 @@
+package cache
+
+import (
+	"github.com/redis/go-redis/v9"
+)
+
+// Script is a Lua script that Redis runs atomically,
+// the in-memory cache runs the equivalent function instead while holding its lock.
+type Script struct {
+	lua    *redis.Script
+	memory func(tx ScriptTx, keys []string, args []interface{}) (interface{}, error)
+}
+
+// ScriptTx is the in-memory cache as seen by the equivalent function of a Script.
+// The values are the raw strings stored by the script, the sorted sets and hashes are those of the Client.
+type ScriptTx interface {
+	Get(key string) (string, bool, error)
+	Del(keys ...string)
+	IncrBy(key string, value int64) (int64, error)
+	HGet(key, field string) (string, bool, error)
+	HSet(key, field, value string) error
+	ZAdd(key string, members ...redis.Z) error
+	ZRem(key string, members ...interface{}) error
+	ZMembers(key string) ([]string, error)
+	Publish(channel, message string)
+}
+
+// NewScript returns a Script of the Lua source and its in-memory equivalent.
+func NewScript(src string, memory func(tx ScriptTx, keys []string, args []interface{}) (interface{}, error)) *Script {
+	return &Script{
+		lua:    redis.NewScript(src),
+		memory: memory,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"time"
 	"unsafe"
 
 	"github.com/creasty/defaults"
@@ -21,16 +22,23 @@ const (
 )
 
 type File struct {
-	Environment    string          `yaml:"environment" validate:"required" default:"development"`
-	Database       *Database       `yaml:"database"`
-	Redis          *Redis          `yaml:"redis"`
-	RSS3Chain      *RSS3Chain      `yaml:"rss3_chain"`
-	Settler        *Settler        `yaml:"settler"`
-	Distributor    *Distributor    `yaml:"distributor"`
-	SpecialRewards *SpecialRewards `yaml:"special_rewards"`
-	GeoIP          *GeoIP          `yaml:"geo_ip"`
-	RPC            *RPC            `yaml:"rpc"`
-	Telemetry      *Telemetry      `json:"telemetry"`
+	Environment       string                        `yaml:"environment" validate:"required" default:"development"`
+	Database          *Database                     `yaml:"database"`
+	Redis             *Redis                        `yaml:"redis"`
+	RSS3Chain         *RSS3Chain                    `yaml:"rss3_chain"`
+	Settler           *Settler                      `yaml:"settler"`
+	Distributor       *Distributor                  `yaml:"distributor"`
+	SpecialRewards    *SpecialRewards               `yaml:"special_rewards"`
+	GeoIP             *GeoIP                        `yaml:"geo_ip"`
+	RPC               *RPC                          `yaml:"rpc"`
+	Telemetry         *Telemetry                    `json:"telemetry"`
+	Prober            Prober                        `yaml:"prober"`
+	Exiter            Exiter                        `yaml:"exiter"`
+	Taxer             Taxer                         `yaml:"taxer"`
+	Webhook           Webhook                       `yaml:"webhook"`
+	Scheduler         Scheduler                     `yaml:"scheduler"`
+	ReliabilityScore  model.ReliabilityScoreModel   `yaml:"reliability_score"`
+	VerificationQueue model.VerificationQueueConfig `yaml:"verification_queue"`
 }
 
 type Database struct {
@@ -39,6 +47,7 @@ type Database struct {
 }
 
 type Redis struct {
+	// URI of the Redis server, memory:// selects an in-process cache that is only suitable for a single instance deployment.
 	URI string `mapstructure:"uri" validate:"required" default:"redis://localhost:6379/0"`
 }
 
@@ -53,12 +62,49 @@ type Settler struct {
 	PrivateKey     string `yaml:"private_key"`
 	WalletAddress  string `yaml:"wallet_address"`
 	SignerEndpoint string `yaml:"signer_endpoint"`
+	// Keystore loads the private key from a go-ethereum JSON keystore file instead of PrivateKey.
+	Keystore *Keystore `yaml:"keystore"`
+	// Offline exports unsigned transactions to be signed on an air-gapped machine, it requires WalletAddress.
+	Offline *Offline `yaml:"offline"`
 	// EpochIntervalInHours
 	EpochIntervalInHours int    `yaml:"epoch_interval_in_hours" default:"18"`
 	GasLimit             uint64 `yaml:"gas_limit" default:"2500000"`
 	// BatchSize is the number of Nodes to process in each batch.
 	// This is to prevent the contract call from running out of gas.
 	BatchSize int `yaml:"batch_size" default:"200"`
+	// ResubmissionTimeout is the interval at which a pending transaction is resubmitted with bumped fees.
+	ResubmissionTimeout time.Duration `yaml:"resubmission_timeout" default:"20s"`
+	// FeeLimitMultiplier caps fee bumps to a multiple of the suggested fees.
+	FeeLimitMultiplier uint64 `yaml:"fee_limit_multiplier" default:"5"`
+	// NumConfirmations is the number of blocks required to consider a transaction confirmed.
+	NumConfirmations uint64 `yaml:"num_confirmations" default:"5"`
+	// MaxFeePerTransactionInGwei caps the cost of a single transaction, 0 means unlimited.
+	MaxFeePerTransactionInGwei uint64 `yaml:"max_fee_per_transaction_in_gwei"`
+	// MaxFeePerEpochInGwei caps the cost of all settlement transactions of an epoch, 0 means unlimited.
+	MaxFeePerEpochInGwei uint64    `yaml:"max_fee_per_epoch_in_gwei"`
+	FeeOracle            FeeOracle `yaml:"fee_oracle"`
+}
+
+type Keystore struct {
+	Path string `yaml:"path" validate:"required"`
+	// The passphrase is read from PassphraseFile if set, otherwise from the environment variable PassphraseEnv.
+	PassphraseFile string `yaml:"passphrase_file"`
+	PassphraseEnv  string `yaml:"passphrase_env"`
+}
+
+type Offline struct {
+	// Directory is where the unsigned transactions are exported to.
+	Directory string `yaml:"directory" validate:"required"`
+}
+
+type FeeOracle struct {
+	// Mode is one of node, percentile or fixed.
+	Mode string `yaml:"mode" validate:"oneof=node percentile fixed" default:"node"`
+	// Percentile of the priority fees paid in each of the recent Blocks, used by the percentile mode.
+	Percentile float64 `yaml:"percentile" default:"60"`
+	Blocks     uint64  `yaml:"blocks" default:"20"`
+	// GasTipCapInGwei is the gas tip cap used by the fixed mode.
+	GasTipCapInGwei uint64 `yaml:"gas_tip_cap_in_gwei"`
 }
 
 type Distributor struct {
@@ -69,6 +115,78 @@ type Distributor struct {
 	// The number of verification activities selected during the second verification.
 	VerificationCount int `yaml:"verification_count" default:"3"`
 	ToleranceSeconds  int `yaml:"tolerance_seconds" default:"1200"`
+	// The RSS feed items published within RSSFreshnessSeconds are excluded from the verification.
+	RSSFreshnessSeconds int `yaml:"rss_freshness_seconds" default:"3600"`
+	// The secret used to sign the pagination cursors, it must be the same for all hubs.
+	CursorSecret string `yaml:"cursor_secret"`
+}
+
+type Prober struct {
+	// Timeout of a single probe request.
+	Timeout time.Duration `yaml:"timeout" default:"10s"`
+	// CanaryTransaction is the ID of a transaction queried from every Node, the canary probe is skipped if it is empty.
+	CanaryTransaction string `yaml:"canary_transaction"`
+	// A Node is degraded if any probe is slower than DegradedLatency.
+	DegradedLatency time.Duration `yaml:"degraded_latency" default:"3s"`
+	// A Node is degraded if its TLS certificate expires within CertificateExpiry.
+	CertificateExpiry time.Duration `yaml:"certificate_expiry" default:"168h"`
+	// A Node is degraded if the share of its Workers that are ready is below MinReadyWorkerRatio.
+	MinReadyWorkerRatio float64 `yaml:"min_ready_worker_ratio" default:"0.8"`
+	// A Node is offline if all its probes failed in OfflineRounds consecutive rounds.
+	OfflineRounds int `yaml:"offline_rounds" default:"3"`
+	// Retention is how long the probe results are kept.
+	Retention time.Duration `yaml:"retention" default:"168h"`
+}
+
+type Exiter struct {
+	// WaitingPeriod is the mandatory waiting period between the exit announcement of a Node and its exit.
+	WaitingPeriod time.Duration `yaml:"waiting_period" default:"168h"`
+	// A Node is considered as exited if it has been offline for longer than OfflinePeriod.
+	OfflinePeriod time.Duration `yaml:"offline_period" default:"720h"`
+	// SignatureValidity is how long a signed exit announcement is accepted after it is signed.
+	SignatureValidity time.Duration `yaml:"signature_validity" validate:"gt=0" default:"10m"`
+}
+
+type Taxer struct {
+	// Strategy averages the tax rates of the non-public good Nodes, it is one of mean, stake_weighted, median or trimmed_mean.
+	Strategy string `yaml:"strategy" validate:"oneof=mean stake_weighted median trimmed_mean" default:"mean"`
+	// TrimRatio is the share of the lowest and of the highest tax rates excluded by the trimmed_mean strategy.
+	TrimRatio float64 `yaml:"trim_ratio" validate:"gte=0,lt=0.5" default:"0.1"`
+}
+
+type Webhook struct {
+	// Workers is the number of the deliveries sent concurrently.
+	Workers int `yaml:"workers" validate:"min=1" default:"16"`
+	// Timeout of a delivery attempt.
+	Timeout time.Duration `yaml:"timeout" default:"10s"`
+	// MaxAttempts is the number of the attempts before a delivery fails.
+	MaxAttempts int `yaml:"max_attempts" validate:"min=1" default:"8"`
+	// BackoffBase is the delay before the first retry, it doubles with each retry up to BackoffMax.
+	BackoffBase time.Duration `yaml:"backoff_base" default:"30s"`
+	BackoffMax  time.Duration `yaml:"backoff_max" default:"6h"`
+	// MaxSubscriptions is the number of the subscriptions an address can register.
+	MaxSubscriptions int `yaml:"max_subscriptions" validate:"min=1" default:"10"`
+	// SignatureValidity is how long a signed subscription message is accepted before and after its timestamp,
+	// each signature is accepted only once.
+	SignatureValidity time.Duration `yaml:"signature_validity" validate:"gt=0" default:"10m"`
+	// ScoreDropRatio is the relative drop of the reliability score of a Node within an Epoch that emits a node_score_dropped event.
+	ScoreDropRatio float64 `yaml:"score_drop_ratio" validate:"gt=0,lte=1" default:"0.2"`
+	// AllowPrivateNetworks allows delivering to loopback and private addresses, it should only be enabled for development.
+	AllowPrivateNetworks bool `yaml:"allow_private_networks"`
+	// Retention is how long the dispatched events and the finished deliveries are kept.
+	Retention time.Duration `yaml:"retention" default:"720h"`
+}
+
+type Scheduler struct {
+	// Jobs configures the scheduler servers and their cron jobs by name, such as snapshot or apy.
+	Jobs map[string]SchedulerJob `yaml:"jobs"`
+}
+
+type SchedulerJob struct {
+	// Disabled jobs are not run by the scheduler, a disabled cron job can still be run by the run-once command.
+	Disabled bool `yaml:"disabled"`
+	// Spec overrides the schedule of a cron job, it has a seconds field and is in UTC.
+	Spec string `yaml:"spec"`
 }
 
 type SpecialRewards struct {
@@ -90,6 +208,10 @@ type GeoIP struct {
 
 type RPC struct {
 	RPCNetwork *RPCNetwork `yaml:"network"`
+	// CacheTTL is how long a resolved name is cached.
+	CacheTTL time.Duration `yaml:"cache_ttl" default:"10m"`
+	// NegativeCacheTTL is how long an unregistered name is cached.
+	NegativeCacheTTL time.Duration `yaml:"negative_cache_ttl" default:"1m"`
 }
 
 type RPCNetwork struct {
@@ -97,6 +219,16 @@ type RPCNetwork struct {
 	Crossbell *RPCEndpoint `yaml:"crossbell"`
 	Polygon   *RPCEndpoint `yaml:"polygon"`
 	Farcaster *RPCEndpoint `yaml:"farcaster"`
+	// Lens is the endpoint of the Lens API, which is required for the reverse resolution of Lens handles.
+	Lens *RPCEndpoint `yaml:"lens"`
+	// Base is the endpoint of Base, which resolves Basenames.
+	Base *RPCEndpoint `yaml:"base"`
+	// BSC is the endpoint of BNB Smart Chain, which resolves Space ID .bnb names.
+	BSC *RPCEndpoint `yaml:"bsc"`
+	// Arbitrum is the endpoint of Arbitrum One, which resolves Space ID .arb names.
+	Arbitrum *RPCEndpoint `yaml:"arbitrum"`
+	// Unstoppable is the endpoint of the Unstoppable Domains resolution API, the API key is sent as a bearer token.
+	Unstoppable *RPCEndpoint `yaml:"unstoppable"`
 }
 
 type RPCEndpoint struct {
@@ -154,6 +286,11 @@ func initGlobalVars(file *File) {
 	model.RequiredVerificationCount = file.Distributor.VerificationCount
 	model.RequiredQualifiedNodeCount = file.Distributor.QualifiedNodeCount
 	model.ToleranceSeconds = file.Distributor.ToleranceSeconds
+	model.RSSFreshnessSeconds = file.Distributor.RSSFreshnessSeconds
+	model.CursorSecret = file.Distributor.CursorSecret
+	model.ReliabilityScore = &file.ReliabilityScore
+	model.VerificationQueue = &file.VerificationQueue
+	model.ScoreDropRatio = file.Webhook.ScoreDropRatio
 
-	zap.L().Info("init constants", zap.Any("MaxDemotionCount", model.DemotionCountBeforeSlashing), zap.Any("VerificationCount", model.RequiredVerificationCount), zap.Any("QualifiedNodeCount", model.RequiredQualifiedNodeCount), zap.Any("ToleranceSeconds", model.ToleranceSeconds))
+	zap.L().Info("init constants", zap.Any("MaxDemotionCount", model.DemotionCountBeforeSlashing), zap.Any("VerificationCount", model.RequiredVerificationCount), zap.Any("QualifiedNodeCount", model.RequiredQualifiedNodeCount), zap.Any("ToleranceSeconds", model.ToleranceSeconds), zap.Any("RSSFreshnessSeconds", model.RSSFreshnessSeconds), zap.String("ReliabilityScoreModel", model.ReliabilityScore.Version))
 }
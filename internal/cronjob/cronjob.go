@@ -3,47 +3,348 @@ package cronjob
 import (
 	"context"
 	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-redsync/redsync/v4"
-	"github.com/go-redsync/redsync/v4/redis/goredis/v9"
-	"github.com/redis/go-redis/v9"
 	"github.com/robfig/cron/v3"
+	"github.com/rss3-network/global-indexer/internal/cache"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 )
 
+// Job is a scheduler server run by a CronJob, it can be run once outside its schedule.
+type Job interface {
+	Name() string
+	RunOnce(ctx context.Context) error
+}
+
 type CronJob struct {
-	crontab *cron.Cron
-	mutex   *redsync.Mutex
-	timeout time.Duration
+	name           string
+	crontab        *cron.Cron
+	mutex          *redsync.Mutex
+	timeout        time.Duration
+	cacheClient    cache.Client
+	databaseClient database.Client
+	// holder identifies the scheduler in the runs and the holder key of the lock.
+	holder string
+	// catchUp runs the latest missed slot, it is set by AddFunc and called when the crontab starts.
+	catchUp     func()
+	lastCleanup atomic.Int64
+	// frequent is set by AddFunc if the job is scheduled more often than every minute, its runs are sampled.
+	frequent bool
+	sampler  sampler
+}
+
+// sampler tracks the runs of a frequent job recorded by the scheduler.
+type sampler struct {
+	sync.Mutex
+	status      schema.CronJobRunStatus
+	recordedAt  time.Time
+	nextSavedAt time.Time
 }
 
 var KeyPrefix = "cronjob:%s"
 
-func (c *CronJob) AddFunc(ctx context.Context, spec string, cmd func()) error {
-	_, err := c.crontab.AddFunc(spec, func() {
-		ctx, cancel := context.WithCancel(ctx)
-		defer cancel()
+// KeyHolder is the key of the scheduler holding the lock of a job, it expires with the lock.
+var KeyHolder = KeyPrefix + ":holder"
+
+// Retention is the duration the runs of a job are kept for.
+var Retention = 7 * 24 * time.Hour
+
+// SampleInterval is the interval the succeeded and the skipped runs of a frequent job are recorded at.
+var SampleInterval = time.Minute
+
+const cleanupInterval = time.Hour
+
+var parser = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
 
-		if err := c.mutex.Lock(); err != nil {
-			zap.L().Error("lock error", zap.String("key", c.mutex.Name()), zap.Error(err))
+// overrides are the specs of the jobs overridden by the scheduler config.
+var overrides = make(map[string]string)
 
-			return
+// Override overrides the spec of the job, it must be called before the job is added.
+func Override(name, spec string) error {
+	if _, err := parser.Parse(spec); err != nil {
+		return fmt.Errorf("parse spec %s of %s: %w", spec, name, err)
+	}
+
+	overrides[name] = spec
+
+	return nil
+}
+
+// AddFunc schedules the command by the spec. Each run is recorded, including the ones skipped because
+// the lock of the job is held by another scheduler. The runs of a job scheduled more often than every minute are sampled,
+// only its failed runs, the runs changing its status and a run every sample interval are recorded.
+// If a slot was missed since the last run of the job, the latest missed slot is caught up when the crontab starts.
+// The spec is replaced by the override of the job if any.
+func (c *CronJob) AddFunc(ctx context.Context, spec string, cmd func(ctx context.Context) error) error {
+	if override, exists := overrides[c.name]; exists {
+		spec = override
+	}
+
+	schedule, err := parser.Parse(spec)
+	if err != nil {
+		return fmt.Errorf("parse spec %s: %w", spec, err)
+	}
+
+	runs, err := c.databaseClient.FindCronJobRuns(ctx, schema.CronJobRunsQuery{
+		Job:      lo.ToPtr(c.name),
+		Triggers: []schema.CronJobRunTrigger{schema.CronJobRunTriggerSchedule, schema.CronJobRunTriggerCatchUp},
+		Limit:    lo.ToPtr(1),
+	})
+	if err != nil {
+		return fmt.Errorf("find last run: %w", err)
+	}
+
+	now := time.Now().UTC()
+
+	next := schedule.Next(now)
+	c.frequent = schedule.Next(next).Sub(next) < time.Minute
+
+	if err := c.saveJob(ctx, spec, next); err != nil {
+		return fmt.Errorf("save cron job: %w", err)
+	}
+
+	c.crontab.Schedule(schedule, cron.FuncJob(func() {
+		// The crontab fires at the slot in UTC, so the slot is the current second.
+		scheduledAt := time.Now().UTC().Truncate(time.Second)
+
+		if next, save := c.nextSavedSlot(schedule, scheduledAt); save {
+			if err := c.saveJob(ctx, spec, next); err != nil {
+				zap.L().Error("save cron job error", zap.String("job", c.name), zap.Error(err))
+			}
 		}
 
-		defer func() {
-			if _, err := c.mutex.Unlock(); err != nil {
-				zap.L().Error("release lock error", zap.String("key", c.mutex.Name()), zap.Error(err))
+		_ = c.run(ctx, schema.CronJobRunTriggerSchedule, scheduledAt, cmd)
+	}))
+
+	if slot, missed := missedSlot(schedule, lo.FirstOrEmpty(runs), now); missed {
+		zap.L().Info("catch up missed slot", zap.String("job", c.name), zap.Time("slot", slot))
+
+		c.catchUp = func() {
+			go func() {
+				_ = c.run(ctx, schema.CronJobRunTriggerCatchUp, slot, cmd)
+			}()
+		}
+	}
+
+	return nil
+}
+
+// RunOnce runs the command once outside the schedule, the run is recorded as a manual one.
+// It fails if the lock of the job is held by another scheduler.
+func (c *CronJob) RunOnce(ctx context.Context, cmd func(ctx context.Context) error) error {
+	return c.run(ctx, schema.CronJobRunTriggerManual, time.Now(), cmd)
+}
+
+// missedSlot returns the latest slot of the schedule up to now after the slot of the last run,
+// a job that has never run has no missed slot.
+func missedSlot(schedule cron.Schedule, lastRun *schema.CronJobRun, now time.Time) (time.Time, bool) {
+	if lastRun == nil {
+		return time.Time{}, false
+	}
+
+	var slot time.Time
+
+	for next := schedule.Next(time.Unix(lastRun.ScheduledAt, 0).UTC()); !next.After(now); next = schedule.Next(next) {
+		slot = next
+	}
+
+	return slot, !slot.IsZero()
+}
+
+func (c *CronJob) run(ctx context.Context, trigger schema.CronJobRunTrigger, scheduledAt time.Time, cmd func(ctx context.Context) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	startedAt := time.Now()
+
+	run := &schema.CronJobRun{
+		Job:         c.name,
+		Trigger:     trigger,
+		ScheduledAt: scheduledAt.Unix(),
+		StartedAt:   startedAt.Unix(),
+		Holder:      c.holder,
+	}
+
+	if err := c.mutex.LockContext(ctx); err != nil {
+		zap.L().Error("lock error", zap.String("key", c.mutex.Name()), zap.Error(err))
+
+		run.Status = schema.CronJobRunStatusSkipped
+		run.Error = err.Error()
+
+		c.finish(run, startedAt)
+
+		if c.record(run) {
+			run.Holder = c.findHolder(ctx)
+
+			if err := c.databaseClient.SaveCronJobRun(ctx, run); err != nil {
+				zap.L().Error("save cron job run error", zap.String("job", c.name), zap.Error(err))
 			}
-		}()
+		}
 
-		c.Renewal(ctx)
-		cmd()
-	})
+		return fmt.Errorf("lock %s: %w", c.mutex.Name(), err)
+	}
+
+	defer func() {
+		if _, err := c.mutex.Unlock(); err != nil {
+			zap.L().Error("release lock error", zap.String("key", c.mutex.Name()), zap.Error(err))
+		}
+	}()
+
+	c.setHolder(ctx)
+	c.Renewal(ctx)
+
+	run.Status = schema.CronJobRunStatusRunning
+
+	// The sampled runs are saved once they finish, so that a run is a single write.
+	sampled := c.sampled(run)
+
+	var saveErr error
+
+	if !sampled {
+		if saveErr = c.databaseClient.SaveCronJobRun(ctx, run); saveErr != nil {
+			zap.L().Error("save cron job run error", zap.String("job", c.name), zap.Error(saveErr))
+		}
+	}
+
+	err := call(ctx, cmd)
+	if err != nil {
+		zap.L().Error("run cron job error", zap.String("job", c.name), zap.Error(err))
+
+		run.Status = schema.CronJobRunStatusFailed
+		run.Error = err.Error()
+	} else {
+		run.Status = schema.CronJobRunStatusSucceeded
+	}
+
+	c.finish(run, startedAt)
+
+	switch {
+	case sampled:
+		if c.record(run) {
+			if err := c.databaseClient.SaveCronJobRun(ctx, run); err != nil {
+				zap.L().Error("save cron job run error", zap.String("job", c.name), zap.Error(err))
+			}
+		}
+	case saveErr == nil:
+		if err := c.databaseClient.UpdateCronJobRun(ctx, run); err != nil {
+			zap.L().Error("update cron job run error", zap.String("job", c.name), zap.Error(err))
+		}
+	}
+
+	c.cleanup(ctx, startedAt)
 
 	return err
 }
 
+// call calls the command, a panic of the command is returned as an error, so that it does not crash the other jobs.
+func call(ctx context.Context, cmd func(ctx context.Context) error) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+
+	return cmd(ctx)
+}
+
+// sampled returns whether the run is sampled, which is a scheduled run of a frequent job.
+func (c *CronJob) sampled(run *schema.CronJobRun) bool {
+	return c.frequent && run.Trigger == schema.CronJobRunTriggerSchedule
+}
+
+// record returns whether the finished run is recorded. A sampled run is recorded if it failed,
+// its status differs from the last recorded one, or the sample interval has passed since the last recorded one.
+func (c *CronJob) record(run *schema.CronJobRun) bool {
+	if !c.sampled(run) {
+		return true
+	}
+
+	c.sampler.Lock()
+	defer c.sampler.Unlock()
+
+	if run.Status != schema.CronJobRunStatusFailed && run.Status == c.sampler.status && time.Since(c.sampler.recordedAt) < SampleInterval {
+		return false
+	}
+
+	c.sampler.status, c.sampler.recordedAt = run.Status, time.Now()
+
+	return true
+}
+
+// nextSavedSlot returns the next slot to save with the job at the slot, and whether the job is saved.
+// A frequent job is saved once every sample interval, with the slot it is saved at next,
+// so that the job is overdue only if no scheduler reaches that slot.
+func (c *CronJob) nextSavedSlot(schedule cron.Schedule, scheduledAt time.Time) (time.Time, bool) {
+	if !c.frequent {
+		return schedule.Next(scheduledAt), true
+	}
+
+	c.sampler.Lock()
+	defer c.sampler.Unlock()
+
+	if scheduledAt.Before(c.sampler.nextSavedAt) {
+		return time.Time{}, false
+	}
+
+	// The first slot at or after the sample interval, the slots are in whole seconds.
+	c.sampler.nextSavedAt = schedule.Next(scheduledAt.Add(SampleInterval - time.Second))
+
+	return c.sampler.nextSavedAt, true
+}
+
+func (c *CronJob) finish(run *schema.CronJobRun, startedAt time.Time) {
+	finishedAt := time.Now()
+
+	run.FinishedAt = lo.ToPtr(finishedAt.Unix())
+	run.Duration = finishedAt.Sub(startedAt).Milliseconds()
+}
+
+// cleanup deletes the runs of the job older than the retention at most once per interval.
+func (c *CronJob) cleanup(ctx context.Context, now time.Time) {
+	lastCleanup := c.lastCleanup.Load()
+
+	if now.Sub(time.Unix(lastCleanup, 0)) < cleanupInterval || !c.lastCleanup.CompareAndSwap(lastCleanup, now.Unix()) {
+		return
+	}
+
+	if err := c.databaseClient.DeleteCronJobRuns(ctx, c.name, now.Add(-Retention)); err != nil {
+		zap.L().Error("delete cron job runs error", zap.String("job", c.name), zap.Error(err))
+	}
+}
+
+func (c *CronJob) saveJob(ctx context.Context, spec string, nextScheduledAt time.Time) error {
+	return c.databaseClient.SaveCronJob(ctx, &schema.CronJob{
+		Name:            c.name,
+		Spec:            spec,
+		Timeout:         int64(c.timeout.Seconds()),
+		NextScheduledAt: nextScheduledAt.Unix(),
+	})
+}
+
+func (c *CronJob) setHolder(ctx context.Context) {
+	if err := c.cacheClient.Set(ctx, fmt.Sprintf(KeyHolder, c.name), c.holder, c.timeout); err != nil {
+		zap.L().Error("set lock holder error", zap.String("job", c.name), zap.Error(err))
+	}
+}
+
+// findHolder returns the scheduler holding the lock of the job, or an empty string if it is unknown.
+func (c *CronJob) findHolder(ctx context.Context) string {
+	var holder string
+
+	if err := c.cacheClient.Get(ctx, fmt.Sprintf(KeyHolder, c.name), &holder); err != nil {
+		return ""
+	}
+
+	return holder
+}
+
 func (c *CronJob) Renewal(ctx context.Context) {
 	go func(ctx context.Context) {
 		// Renewal lock every half of timeout.
@@ -67,6 +368,8 @@ func (c *CronJob) Renewal(ctx context.Context) {
 
 					continue
 				}
+
+				c.setHolder(ctx)
 			}
 		}
 	}(ctx)
@@ -74,19 +377,34 @@ func (c *CronJob) Renewal(ctx context.Context) {
 
 func (c *CronJob) Start() {
 	c.crontab.Start()
+
+	if c.catchUp != nil {
+		c.catchUp()
+	}
 }
 
 func (c *CronJob) Stop() {
 	c.crontab.Stop()
 }
 
-func New(redisClient *redis.Client, name string, timeout time.Duration) *CronJob {
-	pool := goredis.NewPool(redisClient)
-	rs := redsync.New(pool)
-
+func New(cacheClient cache.Client, databaseClient database.Client, name string, timeout time.Duration) *CronJob {
 	return &CronJob{
-		crontab: cron.New(cron.WithLocation(time.UTC), cron.WithSeconds()),
-		mutex:   rs.NewMutex(fmt.Sprintf(KeyPrefix, name), redsync.WithExpiry(timeout)),
-		timeout: timeout,
+		name:           name,
+		crontab:        cron.New(cron.WithLocation(time.UTC), cron.WithSeconds()),
+		mutex:          cacheClient.NewMutex(fmt.Sprintf(KeyPrefix, name), redsync.WithExpiry(timeout)),
+		timeout:        timeout,
+		cacheClient:    cacheClient,
+		databaseClient: databaseClient,
+		holder:         holder(),
 	}
 }
+
+// holder returns the host name and the process ID of the scheduler.
+func holder() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	return fmt.Sprintf("%s:%d", hostname, os.Getpid())
+}
@@ -0,0 +1,77 @@
+package cronjob
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissedSlot(t *testing.T) {
+	t.Parallel()
+
+	schedule, err := parser.Parse("0 */10 * * * *") // every 10 minutes
+	require.NoError(t, err)
+
+	var (
+		lastSlot = time.Date(2024, time.September, 11, 8, 0, 0, 0, time.UTC)
+		lastRun  = &schema.CronJobRun{ScheduledAt: lastSlot.Unix()}
+	)
+
+	// A job that has never run has no missed slot.
+	_, missed := missedSlot(schedule, nil, lastSlot.Add(time.Hour))
+	assert.False(t, missed)
+
+	// The next slot has not come yet.
+	_, missed = missedSlot(schedule, lastRun, lastSlot.Add(9*time.Minute))
+	assert.False(t, missed)
+
+	// Only the latest of the missed slots is caught up.
+	slot, missed := missedSlot(schedule, lastRun, lastSlot.Add(35*time.Minute))
+	assert.True(t, missed)
+	assert.Equal(t, lastSlot.Add(30*time.Minute), slot)
+
+	// The slot at now is missed.
+	slot, missed = missedSlot(schedule, lastRun, lastSlot.Add(10*time.Minute))
+	assert.True(t, missed)
+	assert.Equal(t, lastSlot.Add(10*time.Minute), slot)
+}
+
+func TestSampleFrequentJob(t *testing.T) {
+	t.Parallel()
+
+	schedule, err := parser.Parse("*/5 * * * * *") // every 5 seconds
+	require.NoError(t, err)
+
+	var (
+		c    = CronJob{frequent: true}
+		slot = time.Date(2024, time.September, 11, 8, 0, 0, 0, time.UTC)
+		run  = func(status schema.CronJobRunStatus) *schema.CronJobRun {
+			return &schema.CronJobRun{Trigger: schema.CronJobRunTriggerSchedule, Status: status}
+		}
+	)
+
+	// Only the runs changing the status and the failed runs are recorded within the sample interval.
+	assert.True(t, c.record(run(schema.CronJobRunStatusSucceeded)))
+	assert.False(t, c.record(run(schema.CronJobRunStatusSucceeded)))
+	assert.True(t, c.record(run(schema.CronJobRunStatusFailed)))
+	assert.True(t, c.record(run(schema.CronJobRunStatusFailed)))
+	assert.True(t, c.record(run(schema.CronJobRunStatusSkipped)))
+	assert.False(t, c.record(run(schema.CronJobRunStatusSkipped)))
+
+	// The manual runs are always recorded.
+	assert.True(t, c.record(&schema.CronJobRun{Trigger: schema.CronJobRunTriggerManual, Status: schema.CronJobRunStatusSkipped}))
+
+	// The job is saved once every sample interval with the slot it is saved at next.
+	next, save := c.nextSavedSlot(schedule, slot)
+	assert.True(t, save)
+	assert.Equal(t, slot.Add(SampleInterval), next)
+
+	_, save = c.nextSavedSlot(schedule, slot.Add(55*time.Second))
+	assert.False(t, save)
+
+	_, save = c.nextSavedSlot(schedule, slot.Add(SampleInterval))
+	assert.True(t, save)
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pressly/goose/v3"
@@ -31,7 +32,7 @@ type Client interface {
 	FindNodes(ctx context.Context, query schema.FindNodesQuery) ([]*schema.Node, error)
 	FindNodeAvatar(ctx context.Context, nodeAddress common.Address) (*l2.ChipsTokenMetadata, error)
 	SaveNode(ctx context.Context, node *schema.Node) error
-	UpdateNodesStatusOffline(ctx context.Context, lastHeartbeatTimestamp int64) error
+	UpdateNodesStatus(ctx context.Context, nodeAddresses []common.Address, status schema.NodeStatus) error
 	UpdateNodesHideTaxRate(ctx context.Context, nodeAddress common.Address, hideTaxRate bool) error
 	UpdateNodesScore(ctx context.Context, nodes []*schema.Node) error
 	UpdateNodePublicGood(ctx context.Context, nodeAddress common.Address, isPublicGood bool) error
@@ -50,10 +51,22 @@ type Client interface {
 	SaveNodeWorkers(ctx context.Context, workers []*schema.Worker) error
 	UpdateNodeWorkerActive(ctx context.Context) error
 	SaveNodeInvalidResponses(ctx context.Context, nodeInvalidResponses []*schema.NodeInvalidResponse) error
-
-	FindNodeCountSnapshots(ctx context.Context) ([]*schema.NodeSnapshot, error)
+	FindNodeInvalidResponses(ctx context.Context, query schema.NodeInvalidResponsesQuery) ([]*schema.NodeInvalidResponse, error)
+	FindNodeInvalidResponseCounts(ctx context.Context, epochIDs []uint64) (map[common.Address]int64, error)
+	SaveNodeProbes(ctx context.Context, probes []*schema.NodeProbe) error
+	FindNodeProbes(ctx context.Context, query schema.NodeProbeQuery) ([]*schema.NodeProbe, error)
+	DeleteNodeProbes(ctx context.Context, before time.Time) error
+	SaveNodeStatusTransitions(ctx context.Context, transitions []*schema.NodeStatusTransition) error
+	FindNodeStatusTransitions(ctx context.Context, query schema.NodeStatusTransitionsQuery) ([]*schema.NodeStatusTransition, error)
+	SaveNodeScores(ctx context.Context, scores []*schema.NodeScore) error
+	FindNodeScores(ctx context.Context, query schema.NodeScoresQuery) ([]*schema.NodeScore, error)
+	SaveNodeRanks(ctx context.Context, epochID uint64, ranks []*schema.NodeRank) error
+	FindNodeRanks(ctx context.Context, query schema.NodeRanksQuery) ([]*schema.NodeRank, error)
+
+	FindNodeCount(ctx context.Context, blockNumber *big.Int) (int64, error)
+	FindNodeCountSnapshots(ctx context.Context, query schema.CountSnapshotsQuery) ([]*schema.NodeSnapshot, error)
 	SaveNodeCountSnapshot(ctx context.Context, nodeSnapshot *schema.NodeSnapshot) error
-	FindStakerCountSnapshots(ctx context.Context) ([]*schema.StakerCountSnapshot, error)
+	FindStakerCountSnapshots(ctx context.Context, query schema.CountSnapshotsQuery) ([]*schema.StakerCountSnapshot, error)
 	SaveStakerCountSnapshot(ctx context.Context, stakeSnapshot *schema.StakerCountSnapshot) error
 	FindStakerProfitSnapshots(ctx context.Context, query schema.StakerProfitSnapshotsQuery) ([]*schema.StakerProfitSnapshot, error)
 	SaveStakerProfitSnapshots(ctx context.Context, stakerProfitSnapshots []*schema.StakerProfitSnapshot) error
@@ -61,8 +74,10 @@ type Client interface {
 	FindOperatorProfitSnapshots(ctx context.Context, query schema.OperatorProfitSnapshotsQuery) ([]*schema.OperatorProfitSnapshot, error)
 	SaveOperatorProfitSnapshots(ctx context.Context, operatorProfitSnapshots []*schema.OperatorProfitSnapshot) error
 	SaveNodeAPYSnapshots(ctx context.Context, nodeAPYSnapshots []*schema.NodeAPYSnapshot) error
+	FindNodeAPYSnapshots(ctx context.Context, query schema.NodeAPYSnapshotQuery) ([]*schema.NodeAPYSnapshot, error)
 	FindEpochAPYSnapshots(ctx context.Context, query schema.EpochAPYSnapshotQuery) ([]*schema.EpochAPYSnapshot, error)
 	SaveEpochAPYSnapshot(ctx context.Context, epochAPYSnapshots *schema.EpochAPYSnapshot) error
+	FindSnapshotPoints(ctx context.Context, query schema.SnapshotPointsQuery) ([]*schema.SnapshotPoint, error)
 	FindEpochAPYSnapshotsAverage(ctx context.Context) (decimal.Decimal, error)
 
 	FindBridgeTransaction(ctx context.Context, query schema.BridgeTransactionQuery) (*schema.BridgeTransaction, error)
@@ -81,6 +96,7 @@ type Client interface {
 	FindStakeChip(ctx context.Context, query schema.StakeChipQuery) (*schema.StakeChip, error)
 	FindStakeChips(ctx context.Context, query schema.StakeChipsQuery) ([]*schema.StakeChip, error)
 	FindStakerCount(ctx context.Context, query schema.StakeChipsQuery) (int64, error)
+	FindNodeStakedValues(ctx context.Context, fromBlockNumber, toBlockNumber *big.Int) (map[common.Address]decimal.Decimal, error)
 	UpdateStakeTransactionsFinalizedByBlockNumber(ctx context.Context, blockNumber uint64) error
 	UpdateStakeEventsFinalizedByBlockNumber(ctx context.Context, blockNumber uint64) error
 	UpdateStakeChipsFinalizedByBlockNumber(ctx context.Context, blockNumber uint64) error
@@ -99,6 +115,7 @@ type Client interface {
 	FindEpochTransactions(ctx context.Context, id uint64, itemsLimit int, cursor *string) ([]*schema.Epoch, error)
 	FindEpochTransaction(ctx context.Context, transactionHash common.Hash, itemsLimit int, cursor *string) (*schema.Epoch, error)
 	FindEpochNodeRewards(ctx context.Context, nodeAddress common.Address, limit int, cursor *string) ([]*schema.Epoch, error)
+	FindEpochRewardedNodes(ctx context.Context, epochID uint64) ([]*schema.RewardedNode, error)
 	UpdateEpochsFinalizedByBlockNumber(ctx context.Context, blockNumber uint64) error
 	DeleteEpochsByBlockNumber(ctx context.Context, blockNumber uint64) error
 
@@ -106,8 +123,39 @@ type Client interface {
 	FindLatestEpochTrigger(ctx context.Context) (*schema.EpochTrigger, error)
 	FindEpochTriggers(ctx context.Context, epochID uint64) ([]*schema.EpochTrigger, error)
 
+	SaveEpochReconciliation(ctx context.Context, reconciliation *schema.EpochReconciliation) error
+	FindEpochReconciliation(ctx context.Context, epochID uint64) (*schema.EpochReconciliation, error)
+	FindEpochReconciliations(ctx context.Context, query schema.EpochReconciliationsQuery) ([]*schema.EpochReconciliation, error)
+
+	SaveSettlementPlan(ctx context.Context, plan *schema.SettlementPlan) error
+	FindSettlementPlan(ctx context.Context, epochID uint64) (*schema.SettlementPlan, error)
+	UpdateSettlementBatch(ctx context.Context, batch *schema.SettlementBatch) error
+
+	SaveManagedTransaction(ctx context.Context, transaction *schema.ManagedTransaction) error
+	FindManagedTransactions(ctx context.Context, query schema.ManagedTransactionQuery) ([]*schema.ManagedTransaction, error)
+
 	FindAverageTaxSubmissions(ctx context.Context, query schema.AverageTaxRateSubmissionQuery) ([]*schema.AverageTaxRateSubmission, error)
 	SaveAverageTaxSubmission(ctx context.Context, averageTaxSubmission *schema.AverageTaxRateSubmission) error
+
+	SaveWebhookSubscription(ctx context.Context, subscription *schema.WebhookSubscription) error
+	FindWebhookSubscription(ctx context.Context, id uint64) (*schema.WebhookSubscription, error)
+	FindWebhookSubscriptions(ctx context.Context, query schema.WebhookSubscriptionsQuery) ([]*schema.WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, id uint64) error
+	SaveWebhookEvents(ctx context.Context, events []*schema.WebhookEvent) error
+	FindWebhookEvents(ctx context.Context, query schema.WebhookEventsQuery) ([]*schema.WebhookEvent, error)
+	UpdateWebhookEventsDispatched(ctx context.Context, ids []uint64) error
+	DeleteWebhookEvents(ctx context.Context, before time.Time) error
+	SaveWebhookDeliveries(ctx context.Context, deliveries []*schema.WebhookDelivery) error
+	UpdateWebhookDelivery(ctx context.Context, delivery *schema.WebhookDelivery) error
+	FindWebhookDeliveries(ctx context.Context, query schema.WebhookDeliveriesQuery) ([]*schema.WebhookDelivery, error)
+	DeleteWebhookDeliveries(ctx context.Context, before time.Time) error
+
+	SaveCronJob(ctx context.Context, job *schema.CronJob) error
+	FindCronJobs(ctx context.Context) ([]*schema.CronJob, error)
+	SaveCronJobRun(ctx context.Context, run *schema.CronJobRun) error
+	UpdateCronJobRun(ctx context.Context, run *schema.CronJobRun) error
+	FindCronJobRuns(ctx context.Context, query schema.CronJobRunsQuery) ([]*schema.CronJobRun, error)
+	DeleteCronJobRuns(ctx context.Context, job string, before time.Time) error
 }
 
 type Session interface {
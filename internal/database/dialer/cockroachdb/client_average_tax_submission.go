@@ -44,6 +44,10 @@ func (c *client) FindAverageTaxSubmissions(ctx context.Context, query schema.Ave
 		databaseStatement = databaseStatement.Where("epoch_id = ?", *query.EpochID)
 	}
 
+	if query.Cursor != nil {
+		databaseStatement = databaseStatement.Where("epoch_id < ?", *query.Cursor)
+	}
+
 	if query.Limit != nil {
 		databaseStatement = databaseStatement.Limit(*query.Limit)
 	}
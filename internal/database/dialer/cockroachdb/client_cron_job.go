@@ -0,0 +1,111 @@
+package cockroachdb
+
+import (
+	"context"
+	"time"
+
+	"github.com/rss3-network/global-indexer/internal/database/dialer/cockroachdb/table"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"gorm.io/gorm/clause"
+)
+
+// SaveCronJob saves the job, the spec, the timeout and the next slot of a job saved before are updated.
+func (c *client) SaveCronJob(ctx context.Context, job *schema.CronJob) error {
+	var tJob table.CronJob
+
+	tJob.Import(job)
+
+	onConflict := clause.OnConflict{
+		Columns: []clause.Column{
+			{
+				Name: "name",
+			},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{"spec", "timeout", "next_scheduled_at", "updated_at"}),
+	}
+
+	return c.database.WithContext(ctx).Clauses(onConflict).Create(&tJob).Error
+}
+
+func (c *client) FindCronJobs(ctx context.Context) ([]*schema.CronJob, error) {
+	var jobs table.CronJobs
+
+	if err := c.database.WithContext(ctx).Order("name").Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+
+	return jobs.Export(), nil
+}
+
+func (c *client) SaveCronJobRun(ctx context.Context, run *schema.CronJobRun) error {
+	var tRun table.CronJobRun
+
+	tRun.Import(run)
+
+	if err := c.database.WithContext(ctx).Create(&tRun).Error; err != nil {
+		return err
+	}
+
+	run.ID = tRun.ID
+
+	return nil
+}
+
+// UpdateCronJobRun updates the outcome of the run.
+func (c *client) UpdateCronJobRun(ctx context.Context, run *schema.CronJobRun) error {
+	updates := map[string]interface{}{
+		"status":     run.Status.String(),
+		"error":      run.Error,
+		"duration":   run.Duration,
+		"updated_at": time.Now(),
+	}
+
+	if run.FinishedAt != nil {
+		updates["finished_at"] = time.Unix(*run.FinishedAt, 0)
+	}
+
+	return c.database.WithContext(ctx).
+		Model(&table.CronJobRun{}).
+		Where("id = ?", run.ID).
+		Updates(updates).Error
+}
+
+func (c *client) FindCronJobRuns(ctx context.Context, query schema.CronJobRunsQuery) ([]*schema.CronJobRun, error) {
+	databaseStatement := c.database.WithContext(ctx)
+
+	if query.Job != nil {
+		databaseStatement = databaseStatement.Where("job = ?", query.Job)
+	}
+
+	if query.Status != nil {
+		databaseStatement = databaseStatement.Where("status = ?", query.Status.String())
+	}
+
+	if len(query.Triggers) > 0 {
+		databaseStatement = databaseStatement.Where("trigger IN ?", lo.Map(query.Triggers, func(trigger schema.CronJobRunTrigger, _ int) string {
+			return trigger.String()
+		}))
+	}
+
+	if query.Cursor != nil {
+		databaseStatement = databaseStatement.Where("id < ?", query.Cursor)
+	}
+
+	if query.Limit != nil {
+		databaseStatement = databaseStatement.Limit(*query.Limit)
+	}
+
+	var runs table.CronJobRuns
+
+	if err := databaseStatement.Order("id DESC").Find(&runs).Error; err != nil {
+		return nil, err
+	}
+
+	return runs.Export(), nil
+}
+
+// DeleteCronJobRuns deletes the runs of the job started before the time.
+func (c *client) DeleteCronJobRuns(ctx context.Context, job string, before time.Time) error {
+	return c.database.WithContext(ctx).Where("job = ? AND started_at < ?", job, before).Delete(&table.CronJobRun{}).Error
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rss3-network/global-indexer/internal/database"
@@ -366,6 +367,80 @@ func (c *client) FindEpochTriggers(ctx context.Context, epochID uint64) ([]*sche
 	return data.Export()
 }
 
+func (c *client) SaveSettlementPlan(ctx context.Context, plan *schema.SettlementPlan) error {
+	var data table.EpochSettlementBatches
+	if err := data.Import(plan); err != nil {
+		zap.L().Error("import settlement plan", zap.Error(err), zap.Uint64("epochID", plan.EpochID))
+
+		return err
+	}
+
+	// The membership of each batch is frozen once planned, an existing plan must never be overwritten.
+	onConflict := clause.OnConflict{
+		Columns: []clause.Column{
+			{
+				Name: "epoch_id",
+			},
+			{
+				Name: "index",
+			},
+		},
+		DoNothing: true,
+	}
+
+	if err := c.database.WithContext(ctx).Clauses(onConflict).Create(&data).Error; err != nil {
+		zap.L().Error("insert settlement plan", zap.Error(err), zap.Uint64("epochID", plan.EpochID))
+
+		return err
+	}
+
+	return nil
+}
+
+func (c *client) FindSettlementPlan(ctx context.Context, epochID uint64) (*schema.SettlementPlan, error) {
+	var data table.EpochSettlementBatches
+
+	if err := c.database.WithContext(ctx).Model(&table.EpochSettlementBatch{}).Where("epoch_id = ?", epochID).Order(`"index" ASC`).Find(&data).Error; err != nil {
+		zap.L().Error("find settlement plan", zap.Error(err), zap.Uint64("epochID", epochID))
+
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, database.ErrorRowNotFound
+	}
+
+	return data.Export(epochID)
+}
+
+func (c *client) UpdateSettlementBatch(ctx context.Context, batch *schema.SettlementBatch) error {
+	var data table.EpochSettlementBatch
+	if err := data.Import(batch); err != nil {
+		zap.L().Error("import settlement batch", zap.Error(err), zap.Uint64("epochID", batch.EpochID), zap.Int("index", batch.Index))
+
+		return err
+	}
+
+	// Only the submission progress of a batch is mutable.
+	if err := c.database.WithContext(ctx).
+		Model(&table.EpochSettlementBatch{}).
+		Where(`epoch_id = ? AND "index" = ?`, batch.EpochID, batch.Index).
+		Updates(map[string]any{
+			"data":             data.Data,
+			"transaction_hash": data.TransactionHash,
+			"receipt_status":   data.ReceiptStatus,
+			"status":           data.Status,
+			"error":            data.Error,
+			"updated_at":       time.Now(),
+		}).Error; err != nil {
+		zap.L().Error("update settlement batch", zap.Error(err), zap.Uint64("epochID", batch.EpochID), zap.Int("index", batch.Index))
+
+		return err
+	}
+
+	return nil
+}
+
 func (c *client) FindEpochAPYSnapshots(ctx context.Context, query schema.EpochAPYSnapshotQuery) ([]*schema.EpochAPYSnapshot, error) {
 	var data table.EpochAPYSnapshots
 
@@ -375,6 +450,14 @@ func (c *client) FindEpochAPYSnapshots(ctx context.Context, query schema.EpochAP
 		databaseStatement = databaseStatement.Where("epoch_id = ?", *query.EpochID)
 	}
 
+	if query.Since != nil {
+		databaseStatement = databaseStatement.Where("date >= ?", query.Since)
+	}
+
+	if query.Until != nil {
+		databaseStatement = databaseStatement.Where("date <= ?", query.Until)
+	}
+
 	if query.Limit != nil {
 		databaseStatement = databaseStatement.Limit(*query.Limit)
 	}
@@ -401,7 +484,22 @@ func (c *client) SaveEpochAPYSnapshot(ctx context.Context, epochAPYSnapshot *sch
 		return err
 	}
 
-	if err := c.database.WithContext(ctx).Create(&data).Error; err != nil {
+	onConflict := clause.OnConflict{
+		Columns: []clause.Column{
+			{
+				Name: "epoch_id",
+			},
+		},
+		UpdateAll: true,
+	}
+
+	if err := c.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(onConflict).Create(&data).Error; err != nil {
+			return err
+		}
+
+		return rollupSnapshots(tx, schema.SnapshotSeriesEpochAPY, nil, epochAPYSnapshot.Date)
+	}); err != nil {
 		zap.L().Error("insert epoch APY snapshot", zap.Error(err), zap.Any("epochAPYSnapshot", epochAPYSnapshot))
 
 		return err
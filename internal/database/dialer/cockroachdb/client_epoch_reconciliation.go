@@ -0,0 +1,113 @@
+package cockroachdb
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/database/dialer/cockroachdb/table"
+	"github.com/rss3-network/global-indexer/schema"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SaveEpochReconciliation saves the report of an Epoch, the mismatches of a previous report of the Epoch are replaced.
+func (c *client) SaveEpochReconciliation(ctx context.Context, reconciliation *schema.EpochReconciliation) error {
+	var tReconciliation table.EpochReconciliation
+
+	tReconciliation.Import(reconciliation)
+
+	var tMismatches table.EpochReconciliationMismatches
+
+	tMismatches.Import(reconciliation.Mismatches)
+
+	return c.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		onConflict := clause.OnConflict{
+			Columns: []clause.Column{
+				{
+					Name: "epoch_id",
+				},
+			},
+			DoUpdates: clause.AssignmentColumns([]string{"distributions", "rewarded_nodes", "mismatch_count", "reconciled_at", "updated_at"}),
+		}
+
+		if err := tx.Clauses(onConflict).Create(&tReconciliation).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("epoch_id = ?", reconciliation.EpochID).Delete(&table.EpochReconciliationMismatch{}).Error; err != nil {
+			return err
+		}
+
+		if len(tMismatches) == 0 {
+			return nil
+		}
+
+		return tx.CreateInBatches(&tMismatches, math.MaxUint8).Error
+	})
+}
+
+// FindEpochReconciliation returns the report of an Epoch with its mismatches.
+func (c *client) FindEpochReconciliation(ctx context.Context, epochID uint64) (*schema.EpochReconciliation, error) {
+	var tReconciliation table.EpochReconciliation
+
+	if err := c.database.WithContext(ctx).First(&tReconciliation, "epoch_id = ?", epochID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, database.ErrorRowNotFound
+		}
+
+		return nil, err
+	}
+
+	var tMismatches table.EpochReconciliationMismatches
+
+	if err := c.database.WithContext(ctx).Where("epoch_id = ?", epochID).Order("id").Find(&tMismatches).Error; err != nil {
+		return nil, err
+	}
+
+	reconciliation := tReconciliation.Export()
+	reconciliation.Mismatches = tMismatches.Export()
+
+	return reconciliation, nil
+}
+
+// FindEpochReconciliations returns the reports without their mismatches, ordered from the latest Epoch.
+func (c *client) FindEpochReconciliations(ctx context.Context, query schema.EpochReconciliationsQuery) ([]*schema.EpochReconciliation, error) {
+	databaseStatement := c.database.WithContext(ctx)
+
+	if query.Inconsistent != nil {
+		if *query.Inconsistent {
+			databaseStatement = databaseStatement.Where("mismatch_count > 0")
+		} else {
+			databaseStatement = databaseStatement.Where("mismatch_count = 0")
+		}
+	}
+
+	if query.Cursor != nil {
+		databaseStatement = databaseStatement.Where("epoch_id < ?", query.Cursor)
+	}
+
+	if query.Limit != nil {
+		databaseStatement = databaseStatement.Limit(*query.Limit)
+	}
+
+	var tReconciliations table.EpochReconciliations
+
+	if err := databaseStatement.Order("epoch_id DESC").Find(&tReconciliations).Error; err != nil {
+		return nil, err
+	}
+
+	return tReconciliations.Export(), nil
+}
+
+// FindEpochRewardedNodes returns all the rewarded Node records of an Epoch, ordered by the transaction and the index.
+func (c *client) FindEpochRewardedNodes(ctx context.Context, epochID uint64) ([]*schema.RewardedNode, error) {
+	var items table.EpochItems
+
+	if err := c.database.WithContext(ctx).Model(&table.NodeRewardRecord{}).Where("epoch_id = ?", epochID).Order("transaction_hash, index").Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	return items.Export()
+}
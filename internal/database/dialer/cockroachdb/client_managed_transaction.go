@@ -0,0 +1,113 @@
+package cockroachdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rss3-network/global-indexer/common/txmgr"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/database/dialer/cockroachdb/table"
+	"github.com/rss3-network/global-indexer/schema"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SaveManagedTransaction inserts a new journal entry if its version is 0, otherwise it updates the entry
+// with a compare-and-set on the version, so that the changes of another process are never overwritten.
+func (c *client) SaveManagedTransaction(ctx context.Context, transaction *schema.ManagedTransaction) error {
+	var data table.ManagedTransaction
+	if err := data.Import(transaction); err != nil {
+		zap.L().Error("import managed transaction", zap.Error(err), zap.Uint64("nonce", transaction.Nonce))
+
+		return err
+	}
+
+	data.Version = transaction.Version + 1
+
+	var result *gorm.DB
+
+	if transaction.Version == 0 {
+		result = c.database.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&data)
+	} else {
+		result = c.database.WithContext(ctx).
+			Model(&data).
+			Where("version = ?", transaction.Version).
+			Select(
+				"owner",
+				"to",
+				"data",
+				"value",
+				"gas_limit",
+				"gas_tip_cap",
+				"gas_fee_cap",
+				"raw_transaction",
+				"transaction_hash",
+				"transaction_hashes",
+				"fee_bumps",
+				"state",
+				"receipt",
+				"error",
+				"version",
+				"updated_at",
+			).
+			Updates(&data)
+	}
+
+	if result.Error != nil {
+		zap.L().Error("save managed transaction", zap.Error(result.Error), zap.Uint64("nonce", transaction.Nonce), zap.String("hash", transaction.TransactionHash.String()))
+
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("transaction of nonce %d of version %d: %w", transaction.Nonce, transaction.Version, txmgr.ErrJournalConflict)
+	}
+
+	transaction.Version = data.Version
+
+	return nil
+}
+
+func (c *client) FindManagedTransactions(ctx context.Context, query schema.ManagedTransactionQuery) ([]*schema.ManagedTransaction, error) {
+	databaseStatement := c.database.WithContext(ctx).Model(&table.ManagedTransaction{})
+
+	if query.ChainID != nil {
+		databaseStatement = databaseStatement.Where("chain_id = ?", *query.ChainID)
+	}
+
+	if query.From != nil {
+		databaseStatement = databaseStatement.Where(`"from" = ?`, *query.From)
+	}
+
+	if query.Owner != nil {
+		databaseStatement = databaseStatement.Where("owner = ?", *query.Owner)
+	}
+
+	if query.Nonce != nil {
+		databaseStatement = databaseStatement.Where("nonce = ?", *query.Nonce)
+	}
+
+	if len(query.States) > 0 {
+		databaseStatement = databaseStatement.Where("state IN ?", query.States)
+	}
+
+	if query.Limit != nil {
+		databaseStatement = databaseStatement.Limit(*query.Limit)
+	}
+
+	var data table.ManagedTransactions
+
+	if err := databaseStatement.Order("nonce DESC").Find(&data).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, database.ErrorRowNotFound
+		}
+
+		zap.L().Error("find managed transactions", zap.Error(err), zap.Any("query", query))
+
+		return nil, err
+	}
+
+	return data.Export()
+}
@@ -6,10 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/lib/pq"
 	"github.com/rss3-network/global-indexer/contract/l2"
 	"github.com/rss3-network/global-indexer/internal/database"
 	"github.com/rss3-network/global-indexer/internal/database/dialer/cockroachdb/table"
@@ -54,10 +56,18 @@ func (c *client) FindNodes(ctx context.Context, query schema.FindNodesQuery) ([]
 		databaseStatement = databaseStatement.Where("status = ?", query.Status.String())
 	}
 
+	if len(query.Statuses) > 0 {
+		databaseStatement = databaseStatement.Where("status IN ?", query.Statuses)
+	}
+
 	if len(query.NodeAddresses) > 0 {
 		databaseStatement = databaseStatement.Where("address IN ?", query.NodeAddresses)
 	}
 
+	if query.LastHeartbeatBefore != nil {
+		databaseStatement = databaseStatement.Where("last_heartbeat_timestamp < ?", time.Unix(*query.LastHeartbeatBefore, 0))
+	}
+
 	if query.Limit != nil {
 		databaseStatement = databaseStatement.Limit(*query.Limit)
 	}
@@ -120,41 +130,54 @@ func (c *client) SaveNode(ctx context.Context, data *schema.Node) error {
 	return c.database.WithContext(ctx).Clauses(onConflict).Create(&nodes).Error
 }
 
-func (c *client) SaveNodeCountSnapshot(ctx context.Context, nodeSnapshot *schema.NodeSnapshot) error {
-	databaseClient := c.database.WithContext(ctx)
+func (c *client) FindNodeCount(ctx context.Context, blockNumber *big.Int) (int64, error) {
+	var count int64
+
+	// The Nodes registered by a historical block are counted by their creation events.
+	if blockNumber != nil {
+		if err := c.database.WithContext(ctx).
+			Model((*table.NodeEvent)(nil)).
+			Distinct(`"node_id"`).
+			Where(`"type" = ? AND "block_number" <= ?`, schema.NodeEventNodeCreated, blockNumber.Uint64()).
+			Count(&count).
+			Error; err != nil {
+			return 0, fmt.Errorf("query historical count: %w", err)
+		}
 
-	if err := databaseClient.
+		return count, nil
+	}
+
+	if err := c.database.WithContext(ctx).
 		Table((*table.Node).TableName(nil)).
-		Count(&nodeSnapshot.Count).
+		Count(&count).
 		Error; err != nil {
-		return fmt.Errorf("query count: %w", err)
+		return 0, fmt.Errorf("query count: %w", err)
 	}
 
+	return count, nil
+}
+
+func (c *client) SaveNodeCountSnapshot(ctx context.Context, nodeSnapshot *schema.NodeSnapshot) error {
 	var value table.NodeSnapshot
 	if err := value.Import(*nodeSnapshot); err != nil {
 		return fmt.Errorf("import node snapshot: %w", err)
 	}
 
-	return databaseClient.
-		Table((*table.NodeSnapshot).TableName(nil)).
-		Create(nodeSnapshot).
-		Error
-}
-
-func (c *client) UpdateNodesStatusOffline(ctx context.Context, lastHeartbeatTimestamp int64) error {
-	return c.WithTransaction(ctx, func(ctx context.Context, _ database.Client) error {
-		for {
-			result := c.database.WithContext(ctx).Model(&table.Node{}).
-				Where("last_heartbeat_timestamp < ? and status = ?", time.Unix(lastHeartbeatTimestamp, 0), schema.NodeStatusOnline).
-				Update("status", schema.NodeStatusOffline).Limit(1000)
-			if result.Error != nil {
-				return result.Error
-			}
+	onConflict := clause.OnConflict{
+		Columns: []clause.Column{
+			{
+				Name: "date",
+			},
+		},
+		UpdateAll: true,
+	}
 
-			if result.RowsAffected == 0 {
-				return nil
-			}
+	return c.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(onConflict).Create(&value).Error; err != nil {
+			return err
 		}
+
+		return rollupSnapshots(tx, schema.SnapshotSeriesNodeCount, nil, nodeSnapshot.Date)
 	})
 }
 
@@ -313,6 +336,16 @@ func (c *client) buildNodeStatQuery(ctx context.Context, query *schema.StatQuery
 	return databaseStatement, nil
 }
 
+func (c *client) UpdateNodesStatus(ctx context.Context, nodeAddresses []common.Address, status schema.NodeStatus) error {
+	if len(nodeAddresses) == 0 {
+		return nil
+	}
+
+	return c.database.WithContext(ctx).Model(&table.Node{}).
+		Where("address IN ?", nodeAddresses).
+		Update("status", status).Error
+}
+
 func (c *client) SaveNodeInvalidResponses(ctx context.Context, nodeInvalidResponse []*schema.NodeInvalidResponse) error {
 	var tNodeInvalidResponses table.NodeInvalidResponses
 
@@ -321,14 +354,92 @@ func (c *client) SaveNodeInvalidResponses(ctx context.Context, nodeInvalidRespon
 	return c.database.WithContext(ctx).CreateInBatches(tNodeInvalidResponses, math.MaxUint8).Error
 }
 
-func (c *client) FindNodeCountSnapshots(ctx context.Context) ([]*schema.NodeSnapshot, error) {
+func (c *client) FindNodeInvalidResponses(ctx context.Context, query schema.NodeInvalidResponsesQuery) ([]*schema.NodeInvalidResponse, error) {
+	databaseStatement := c.database.WithContext(ctx)
+
+	if query.Node != nil {
+		databaseStatement = databaseStatement.Where("node = ?", query.Node)
+	}
+
+	if query.EpochID != nil {
+		databaseStatement = databaseStatement.Where("epoch_id = ?", query.EpochID)
+	}
+
+	if query.Type != nil {
+		databaseStatement = databaseStatement.Where("type = ?", query.Type.String())
+	}
+
+	if query.Verifier != nil {
+		databaseStatement = databaseStatement.Where("verifier_nodes @> ?", pq.ByteaArray{query.Verifier.Bytes()})
+	}
+
+	if query.BeforeDate != nil {
+		databaseStatement = databaseStatement.Where("created_at <= ?", query.BeforeDate)
+	}
+
+	if query.AfterDate != nil {
+		databaseStatement = databaseStatement.Where("created_at >= ?", query.AfterDate)
+	}
+
+	if query.Cursor != nil {
+		databaseStatement = databaseStatement.Where("id < ?", *query.Cursor)
+	}
+
+	if query.Limit != nil {
+		databaseStatement = databaseStatement.Limit(*query.Limit)
+	}
+
+	var responses table.NodeInvalidResponses
+
+	if err := databaseStatement.Order("id DESC").Find(&responses).Error; err != nil {
+		return nil, err
+	}
+
+	return responses.Export(), nil
+}
+
+// FindNodeInvalidResponseCounts returns the number of the invalid responses of each Node in the Epochs.
+func (c *client) FindNodeInvalidResponseCounts(ctx context.Context, epochIDs []uint64) (map[common.Address]int64, error) {
+	type row struct {
+		Node  common.Address `gorm:"column:node"`
+		Count int64          `gorm:"column:count"`
+	}
+
+	var rows []row
+
+	if err := c.database.WithContext(ctx).
+		Model(&table.NodeInvalidResponse{}).
+		Select(`"node", count(*) AS "count"`).
+		Where(`"epoch_id" IN ?`, epochIDs).
+		Group(`"node"`).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return lo.SliceToMap(rows, func(row row) (common.Address, int64) {
+		return row.Node, row.Count
+	}), nil
+}
+
+func (c *client) FindNodeCountSnapshots(ctx context.Context, query schema.CountSnapshotsQuery) ([]*schema.NodeSnapshot, error) {
 	databaseClient := c.database.WithContext(ctx)
 
+	if query.Since != nil {
+		databaseClient = databaseClient.Where(`"date" >= ?`, query.Since)
+	}
+
+	if query.Until != nil {
+		databaseClient = databaseClient.Where(`"date" <= ?`, query.Until)
+	}
+
+	if query.Limit != nil {
+		databaseClient = databaseClient.Limit(*query.Limit)
+	}
+
 	var nodeSnapshots []*table.NodeSnapshot
 
 	if err := databaseClient.
 		Order(`"date" DESC`).
-		Limit(100). // TODO Replace this constant with a query parameter.
 		Find(&nodeSnapshots).Error; err != nil {
 		return nil, err
 	}
@@ -493,9 +604,9 @@ func (c *client) FindNodeEvents(ctx context.Context, query *schema.NodeEventsQue
 			return nil, fmt.Errorf("get Node cursor: %w", err)
 		}
 
-		databaseStatement = databaseStatement.Where("block_number < ?", nodeEvent.BlockNumber).
-			Or("block_number = ? AND transaction_index < ?", nodeEvent.BlockNumber, nodeEvent.TransactionIndex).
-			Or("block_number = ? AND transaction_index < ? AND log_index < ?", nodeEvent.BlockNumber, nodeEvent.TransactionIndex, nodeEvent.LogIndex)
+		// The status changed events are not on the VSL, so the events are ordered by the block timestamp first.
+		databaseStatement = databaseStatement.Where("(block_timestamp, block_number, transaction_index, log_index) < (?, ?, ?, ?)",
+			nodeEvent.BlockTimestamp, nodeEvent.BlockNumber, nodeEvent.TransactionIndex, nodeEvent.LogIndex)
 	}
 
 	if query.NodeAddress != nil {
@@ -516,7 +627,7 @@ func (c *client) FindNodeEvents(ctx context.Context, query *schema.NodeEventsQue
 
 	var events table.NodeEvents
 
-	if err := databaseStatement.Order("block_number DESC, transaction_index DESC, log_index DESC").Find(&events).Error; err != nil {
+	if err := databaseStatement.Order("block_timestamp DESC, block_number DESC, transaction_index DESC, log_index DESC").Find(&events).Error; err != nil {
 		return nil, err
 	}
 
@@ -602,7 +713,19 @@ func (c *client) SaveOperatorProfitSnapshots(ctx context.Context, snapshots []*s
 		UpdateAll: true,
 	}
 
-	return c.database.WithContext(ctx).Clauses(onConflict).CreateInBatches(value, math.MaxUint8).Error
+	return c.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(onConflict).CreateInBatches(value, math.MaxUint8).Error; err != nil {
+			return err
+		}
+
+		operators := lo.Map(snapshots, func(snapshot *schema.OperatorProfitSnapshot, _ int) common.Address {
+			return snapshot.Operator
+		})
+
+		return rollupSnapshots(tx, schema.SnapshotSeriesOperationPool, operators, lo.Map(snapshots, func(snapshot *schema.OperatorProfitSnapshot, _ int) time.Time {
+			return snapshot.Date
+		})...)
+	})
 }
 
 func (c *client) SaveNodeAPYSnapshots(ctx context.Context, nodeAPYSnapshots []*schema.NodeAPYSnapshot) error {
@@ -627,6 +750,26 @@ func (c *client) SaveNodeAPYSnapshots(ctx context.Context, nodeAPYSnapshots []*s
 	return c.database.WithContext(ctx).Clauses(onConflict).CreateInBatches(value, math.MaxUint8).Error
 }
 
+func (c *client) FindNodeAPYSnapshots(ctx context.Context, query schema.NodeAPYSnapshotQuery) ([]*schema.NodeAPYSnapshot, error) {
+	databaseStatement := c.database.WithContext(ctx)
+
+	if query.NodeAddress != nil {
+		databaseStatement = databaseStatement.Where("node_address = ?", query.NodeAddress)
+	}
+
+	if len(query.EpochIDs) > 0 {
+		databaseStatement = databaseStatement.Where("epoch_id IN ?", query.EpochIDs)
+	}
+
+	var snapshots table.NodeAPYSnapshots
+
+	if err := databaseStatement.Order("epoch_id DESC").Find(&snapshots).Error; err != nil {
+		return nil, err
+	}
+
+	return snapshots.Export()
+}
+
 func (c *client) DeleteNodeEventsByBlockNumber(ctx context.Context, blockNumber uint64) error {
 	return c.database.
 		WithContext(ctx).
@@ -642,3 +785,142 @@ func (c *client) UpdateNodeEventsFinalizedByBlockNumber(ctx context.Context, blo
 		Update("finalized", true).
 		Error
 }
+
+func (c *client) SaveNodeProbes(ctx context.Context, probes []*schema.NodeProbe) error {
+	var tProbes table.NodeProbes
+
+	tProbes.Import(probes)
+
+	return c.database.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(tProbes, math.MaxUint8).Error
+}
+
+func (c *client) FindNodeProbes(ctx context.Context, query schema.NodeProbeQuery) ([]*schema.NodeProbe, error) {
+	databaseStatement := c.database.WithContext(ctx)
+
+	if len(query.NodeAddresses) > 0 {
+		databaseStatement = databaseStatement.Where("node_address IN ?", query.NodeAddresses)
+	}
+
+	if query.Since != nil {
+		databaseStatement = databaseStatement.Where("probed_at >= ?", *query.Since)
+	}
+
+	if query.Limit != nil {
+		databaseStatement = databaseStatement.Limit(*query.Limit)
+	}
+
+	var probes table.NodeProbes
+
+	if err := databaseStatement.Order("probed_at DESC, target").Find(&probes).Error; err != nil {
+		return nil, err
+	}
+
+	return probes.Export(), nil
+}
+
+func (c *client) DeleteNodeProbes(ctx context.Context, before time.Time) error {
+	return c.database.WithContext(ctx).Where("probed_at < ?", before).Delete(&table.NodeProbe{}).Error
+}
+
+func (c *client) SaveNodeStatusTransitions(ctx context.Context, transitions []*schema.NodeStatusTransition) error {
+	if len(transitions) == 0 {
+		return nil
+	}
+
+	var tTransitions table.NodeStatusTransitions
+
+	tTransitions.Import(transitions)
+
+	if err := c.database.WithContext(ctx).CreateInBatches(&tTransitions, math.MaxUint8).Error; err != nil {
+		return err
+	}
+
+	for i, transition := range tTransitions {
+		transitions[i].ID = transition.ID
+	}
+
+	return nil
+}
+
+func (c *client) FindNodeStatusTransitions(ctx context.Context, query schema.NodeStatusTransitionsQuery) ([]*schema.NodeStatusTransition, error) {
+	databaseStatement := c.database.WithContext(ctx)
+
+	if len(query.NodeAddresses) > 0 {
+		databaseStatement = databaseStatement.Where("node_address IN ?", query.NodeAddresses)
+	}
+
+	if query.To != nil {
+		databaseStatement = databaseStatement.Where(`"to" = ?`, query.To.String())
+	}
+
+	if query.Until != nil {
+		databaseStatement = databaseStatement.Where(`"timestamp" <= ?`, time.Unix(*query.Until, 0))
+	}
+
+	if query.Cursor != nil {
+		databaseStatement = databaseStatement.Where("id < ?", *query.Cursor)
+	}
+
+	if query.Limit != nil {
+		databaseStatement = databaseStatement.Limit(*query.Limit)
+	}
+
+	var transitions table.NodeStatusTransitions
+
+	if err := databaseStatement.Order("id DESC").Find(&transitions).Error; err != nil {
+		return nil, err
+	}
+
+	return transitions.Export(), nil
+}
+
+func (c *client) SaveNodeScores(ctx context.Context, scores []*schema.NodeScore) error {
+	var tScores table.NodeScores
+
+	if err := tScores.Import(scores); err != nil {
+		return err
+	}
+
+	// The score of a Node in an Epoch is updated until the Epoch ends.
+	onConflict := clause.OnConflict{
+		Columns: []clause.Column{
+			{
+				Name: "node_address",
+			},
+			{
+				Name: "epoch_id",
+			},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{"model_version", "score", "components", "updated_at"}),
+	}
+
+	return c.database.WithContext(ctx).Clauses(onConflict).CreateInBatches(tScores, math.MaxUint8).Error
+}
+
+func (c *client) FindNodeScores(ctx context.Context, query schema.NodeScoresQuery) ([]*schema.NodeScore, error) {
+	databaseStatement := c.database.WithContext(ctx)
+
+	if query.NodeAddress != nil {
+		databaseStatement = databaseStatement.Where("node_address = ?", query.NodeAddress)
+	}
+
+	if len(query.EpochIDs) > 0 {
+		databaseStatement = databaseStatement.Where("epoch_id IN ?", query.EpochIDs)
+	}
+
+	if query.Cursor != nil {
+		databaseStatement = databaseStatement.Where("epoch_id < ?", query.Cursor)
+	}
+
+	if query.Limit != nil {
+		databaseStatement = databaseStatement.Limit(*query.Limit)
+	}
+
+	var scores table.NodeScores
+
+	if err := databaseStatement.Order("epoch_id DESC").Find(&scores).Error; err != nil {
+		return nil, err
+	}
+
+	return scores.Export()
+}
@@ -0,0 +1,74 @@
+package cockroachdb
+
+import (
+	"context"
+	"math"
+
+	"github.com/rss3-network/global-indexer/internal/database/dialer/cockroachdb/table"
+	"github.com/rss3-network/global-indexer/schema"
+	"gorm.io/gorm"
+)
+
+// SaveNodeRanks saves the ranks of the leaderboards of an Epoch, the previous ranks of the Epoch are replaced.
+func (c *client) SaveNodeRanks(ctx context.Context, epochID uint64, ranks []*schema.NodeRank) error {
+	var tRanks table.NodeRanks
+
+	tRanks.Import(ranks)
+
+	return c.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("epoch_id = ?", epochID).Delete(&table.NodeRank{}).Error; err != nil {
+			return err
+		}
+
+		if len(tRanks) == 0 {
+			return nil
+		}
+
+		return tx.CreateInBatches(&tRanks, math.MaxUint8).Error
+	})
+}
+
+// FindNodeRanks returns the ranks ordered from the latest Epoch and the top rank.
+func (c *client) FindNodeRanks(ctx context.Context, query schema.NodeRanksQuery) ([]*schema.NodeRank, error) {
+	databaseStatement := c.database.WithContext(ctx).Model(&table.NodeRank{})
+
+	if query.Metric != nil {
+		databaseStatement = databaseStatement.Where("metric = ?", query.Metric.String())
+	}
+
+	if query.Epochs != nil {
+		databaseStatement = databaseStatement.Where("epochs = ?", query.Epochs)
+	}
+
+	if query.EpochID != nil {
+		databaseStatement = databaseStatement.Where("epoch_id = ?", query.EpochID)
+	} else {
+		latestEpochID := c.database.WithContext(ctx).Model(&table.NodeRank{}).Select("max(epoch_id)")
+
+		if query.Metric != nil {
+			latestEpochID = latestEpochID.Where("metric = ?", query.Metric.String())
+		}
+
+		databaseStatement = databaseStatement.Where("epoch_id = (?)", latestEpochID)
+	}
+
+	if query.NodeAddress != nil {
+		databaseStatement = databaseStatement.Where("node_address = ?", query.NodeAddress)
+	}
+
+	if query.Cursor != nil {
+		databaseStatement = databaseStatement.Where("rank > ?", query.Cursor)
+	}
+
+	if query.Limit != nil {
+		databaseStatement = databaseStatement.Limit(*query.Limit)
+	}
+
+	var tRanks table.NodeRanks
+
+	if err := databaseStatement.Order("epoch_id DESC, rank").Find(&tRanks).Error; err != nil {
+		return nil, err
+	}
+
+	return tRanks.Export(), nil
+}
@@ -0,0 +1,131 @@
+package cockroachdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/database/dialer/cockroachdb/table"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+// globalAddress is the address of a series that is not per address.
+const globalAddress = `''::bytea`
+
+// snapshotSource is the table a series of snapshots is rolled up from.
+type snapshotSource struct {
+	table string
+	// address is the column of the address of a series per address.
+	address string
+	value   string
+}
+
+// perAddress returns true if the source has a series per address.
+func (s snapshotSource) perAddress() bool {
+	return s.address != globalAddress
+}
+
+var snapshotSources = map[schema.SnapshotSeries]snapshotSource{
+	schema.SnapshotSeriesNodeCount:        {table: `"node"."count_snapshots"`, address: globalAddress, value: `"count"`},
+	schema.SnapshotSeriesStakerCount:      {table: `"stake"."count_snapshots"`, address: globalAddress, value: `"count"`},
+	schema.SnapshotSeriesOperationPool:    {table: `"node"."operator_profit_snapshots"`, address: `"operator"`, value: `"operation_pool"`},
+	schema.SnapshotSeriesTotalChipAmounts: {table: `"stake"."profit_snapshots"`, address: `"owner_address"`, value: `"total_chip_amounts"`},
+	schema.SnapshotSeriesTotalChipValues:  {table: `"stake"."profit_snapshots"`, address: `"owner_address"`, value: `"total_chip_values"`},
+	schema.SnapshotSeriesEpochAPY:         {table: `"epoch"."apy_snapshots"`, address: globalAddress, value: `"apy"`},
+}
+
+// rollupSnapshotsStatement aggregates the snapshots in a window into the buckets of every granularity,
+// and upserts the buckets from the one containing the first snapshot saved to the one containing the last.
+// The snapshots of a series per address are filtered by the addresses saved.
+const rollupSnapshotsStatement = `INSERT INTO "snapshot"."rollups" ("series", "address", "granularity", "bucket", "last", "average", "minimum", "maximum", "samples")
+SELECT ?, "address", "granularity", "bucket", (array_agg("value" ORDER BY "date" DESC))[1], avg("value"), min("value"), max("value"), count(*)
+FROM (SELECT %s AS "address", "date"::timestamptz AS "date", %s::decimal AS "value", "g"."granularity", date_trunc("g"."granularity", "date"::timestamptz) AS "bucket"
+      FROM %s
+               CROSS JOIN (VALUES ('day'), ('week'), ('month')) AS "g" ("granularity")
+      WHERE "date" >= ? AND "date" < ?%s) AS "points"
+WHERE "bucket" >= date_trunc("granularity", ?::timestamptz) AND "bucket" <= date_trunc("granularity", ?::timestamptz)
+GROUP BY "address", "granularity", "bucket"
+ON CONFLICT ("series", "address", "granularity", "bucket") DO UPDATE SET "last"       = excluded."last",
+                                                                         "average"    = excluded."average",
+                                                                         "minimum"    = excluded."minimum",
+                                                                         "maximum"    = excluded."maximum",
+                                                                         "samples"    = excluded."samples",
+                                                                         "updated_at" = now()`
+
+// rollupSnapshots recomputes the buckets of the series containing the snapshots dated from the first to the last date,
+// only the buckets of the addresses are recomputed for a series per address.
+// A bucket is always aggregated from all its snapshots, so that saving a snapshot again leaves the rollups unchanged.
+func rollupSnapshots(databaseClient *gorm.DB, series schema.SnapshotSeries, addresses []common.Address, dates ...time.Time) error {
+	if len(dates) == 0 {
+		return nil
+	}
+
+	source, exists := snapshotSources[series]
+	if !exists {
+		return fmt.Errorf("unknown snapshot series %s", series)
+	}
+
+	from, to := lo.MinBy(dates, func(a, b time.Time) bool { return a.Before(b) }), lo.MaxBy(dates, func(a, b time.Time) bool { return a.After(b) })
+
+	// The window covers the whole months and weeks of the buckets, as a week may span two months.
+	var (
+		lower = time.Date(from.UTC().Year(), from.UTC().Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -7)
+		upper = time.Date(to.UTC().Year(), to.UTC().Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 7)
+	)
+
+	var (
+		filter string
+		values = []interface{}{series, lower, upper}
+	)
+
+	if source.perAddress() {
+		if len(addresses) == 0 {
+			return nil
+		}
+
+		filter = fmt.Sprintf(` AND %s IN ?`, source.address)
+		values = append(values, lo.Uniq(addresses))
+	}
+
+	statement := fmt.Sprintf(rollupSnapshotsStatement, source.address, source.value, source.table, filter)
+
+	if err := databaseClient.Exec(statement, append(values, from, to)...).Error; err != nil {
+		return fmt.Errorf("rollup %s snapshots: %w", series, err)
+	}
+
+	return nil
+}
+
+func (c *client) FindSnapshotPoints(ctx context.Context, query schema.SnapshotPointsQuery) ([]*schema.SnapshotPoint, error) {
+	address := make([]byte, 0)
+
+	if query.Address != nil {
+		address = query.Address.Bytes()
+	}
+
+	databaseStatement := c.database.WithContext(ctx).
+		Where(`"series" = ? AND "address" = ? AND "granularity" = ?`, query.Series, address, query.Granularity)
+
+	if query.Since != nil {
+		databaseStatement = databaseStatement.Where(`"bucket" >= date_trunc(?, ?::timestamptz)`, query.Granularity.String(), query.Since)
+	}
+
+	if query.Until != nil {
+		databaseStatement = databaseStatement.Where(`"bucket" <= ?`, query.Until)
+	}
+
+	if query.Limit != nil {
+		databaseStatement = databaseStatement.Limit(*query.Limit)
+	}
+
+	var rollups table.SnapshotRollups
+
+	if err := databaseStatement.Order(`"bucket" DESC`).Find(&rollups).Error; err != nil {
+		return nil, fmt.Errorf("find snapshot rollups: %w", err)
+	}
+
+	return rollups.Export(query.Aggregation), nil
+}
@@ -9,6 +9,7 @@ import (
 	"math/big"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rss3-network/global-indexer/common/ethereum"
@@ -218,15 +219,33 @@ func (c *client) FindStakeChips(ctx context.Context, query schema.StakeChipsQuer
 	return results, nil
 }
 
+// stakerCountStatement counts the stakers holding a Chip at a block height from the stake transactions.
+// A Chip is minted by a stake or by a merge as its last Chip, and burned by an unstake or by a merge as the other Chips.
+// The Chip transfers are not indexed with their heights, so a Chip is attributed to the staker who minted it.
+const stakerCountStatement = `WITH "chips" AS (SELECT "transactions"."type", "transactions"."user", "transactions"."node", "chip"."id",
+                        "chip"."ordinal" = array_length("transactions"."chips", 1) AS "last"
+                 FROM "stake"."transactions" AS "transactions",
+                      unnest("transactions"."chips") WITH ORDINALITY AS "chip" ("id", "ordinal")
+                 WHERE "transactions"."block_number" <= ?)
+SELECT count(DISTINCT "minted"."user")
+FROM "chips" AS "minted"
+WHERE ("minted"."type" = 'stake' OR ("minted"."type" = 'merge_chips' AND "minted"."last"))
+  AND NOT EXISTS (SELECT 1
+                  FROM "chips" AS "burned"
+                  WHERE "burned"."id" = "minted"."id"
+                    AND ("burned"."type" = 'unstake' OR ("burned"."type" = 'merge_chips' AND NOT "burned"."last")))`
+
+// FindStakerCount counts the owners of the Chips, or the stakers at the block height of the query if any,
+// which only supports the Node and the owner filters.
 func (c *client) FindStakerCount(ctx context.Context, query schema.StakeChipsQuery) (int64, error) {
+	if query.BlockNumber != nil {
+		return c.findStakerCountAt(ctx, query)
+	}
+
 	databaseClient := c.database.WithContext(ctx).Table((*table.StakeChip).TableName(nil)).
 		Distinct(`"owner"`).
 		Where(`"owner" != ?`, ethereum.AddressGenesis.String())
 
-	if query.BlockNumber != nil {
-		databaseClient = databaseClient.Where(`"block_number" <= ?`, query.BlockNumber)
-	}
-
 	if query.Cursor != nil {
 		databaseClient = databaseClient.Where(`"id" > ?`, query.Cursor.String())
 	}
@@ -256,6 +275,33 @@ func (c *client) FindStakerCount(ctx context.Context, query schema.StakeChipsQue
 	return count, nil
 }
 
+// findStakerCountAt counts the stakers at the block height of the query from the stake transactions,
+// as the Chips only keep their current owners.
+func (c *client) findStakerCountAt(ctx context.Context, query schema.StakeChipsQuery) (int64, error) {
+	var (
+		statement = stakerCountStatement
+		values    = []interface{}{query.BlockNumber.Uint64()}
+	)
+
+	if query.Node != nil {
+		statement += ` AND "minted"."node" = ?`
+		values = append(values, query.Node.String())
+	}
+
+	if query.Owner != nil {
+		statement += ` AND "minted"."user" = ?`
+		values = append(values, query.Owner.String())
+	}
+
+	var count int64
+
+	if err := c.database.WithContext(ctx).Raw(statement, values...).Scan(&count).Error; err != nil {
+		return 0, fmt.Errorf("count stakers at block %s: %w", query.BlockNumber, err)
+	}
+
+	return count, nil
+}
+
 func (c *client) FindStakeChip(ctx context.Context, query schema.StakeChipQuery) (*schema.StakeChip, error) {
 	databaseClient := c.database.WithContext(ctx)
 
@@ -456,14 +502,25 @@ func (c *client) FindStakeStaker(ctx context.Context, address common.Address) (*
 	return &stakeStaker, nil
 }
 
-func (c *client) FindStakerCountSnapshots(ctx context.Context) ([]*schema.StakerCountSnapshot, error) {
+func (c *client) FindStakerCountSnapshots(ctx context.Context, query schema.CountSnapshotsQuery) ([]*schema.StakerCountSnapshot, error) {
 	databaseClient := c.database.WithContext(ctx)
 
+	if query.Since != nil {
+		databaseClient = databaseClient.Where(`"date" >= ?`, query.Since)
+	}
+
+	if query.Until != nil {
+		databaseClient = databaseClient.Where(`"date" <= ?`, query.Until)
+	}
+
+	if query.Limit != nil {
+		databaseClient = databaseClient.Limit(*query.Limit)
+	}
+
 	var stakeSnapshots []*table.StakerCountSnapshot
 
 	if err := databaseClient.
 		Order(`"date" DESC`).
-		Limit(100). // FIXME: Replace this constant with a query parameter.
 		Find(&stakeSnapshots).Error; err != nil {
 		return nil, err
 	}
@@ -529,6 +586,31 @@ func (c *client) FindStakerCountRecentEpochs(ctx context.Context, recentEpochs i
 	return result, nil
 }
 
+// FindNodeStakedValues returns the values staked to the Nodes minus the values unstaked from them
+// in the blocks after the from block up to the to block.
+func (c *client) FindNodeStakedValues(ctx context.Context, fromBlockNumber, toBlockNumber *big.Int) (map[common.Address]decimal.Decimal, error) {
+	databaseClient := c.database.
+		WithContext(ctx).
+		Table((*table.StakeTransaction).TableName(nil)).
+		Select(`"node", sum(CASE WHEN "type" = 'stake' THEN "value" ELSE -"value" END) AS "value"`).
+		Where(`"block_number" > ? AND "block_number" <= ? AND "type" IN ('stake', 'unstake')`, fromBlockNumber.Uint64(), toBlockNumber.Uint64()).
+		Group(`"node"`)
+
+	type row struct {
+		Node  string          `gorm:"column:node"`
+		Value decimal.Decimal `gorm:"column:value"`
+	}
+
+	var rows []row
+	if err := databaseClient.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("find node staked values: %w", err)
+	}
+
+	return lo.SliceToMap(rows, func(row row) (common.Address, decimal.Decimal) {
+		return common.HexToAddress(row.Node), row.Value
+	}), nil
+}
+
 func (c *client) SaveStakeTransaction(ctx context.Context, stakeTransaction *schema.StakeTransaction) error {
 	var value table.StakeTransaction
 	if err := value.Import(*stakeTransaction); err != nil {
@@ -612,26 +694,27 @@ func (c *client) UpdateStakeChipsOwner(ctx context.Context, owner common.Address
 }
 
 func (c *client) SaveStakerCountSnapshot(ctx context.Context, stakeSnapshot *schema.StakerCountSnapshot) error {
-	databaseClient := c.database.WithContext(ctx)
-
-	if err := databaseClient.
-		Table((*table.StakeChip).TableName(nil)).
-		Distinct(`"owner"`).
-		Where(`"owner" != ?`, ethereum.AddressGenesis.String()).
-		Count(&stakeSnapshot.Count).
-		Error; err != nil {
-		return fmt.Errorf("query count: %w", err)
-	}
-
 	var value table.StakerCountSnapshot
 	if err := value.Import(*stakeSnapshot); err != nil {
 		return fmt.Errorf("import stakers_count snapshot: %w", err)
 	}
 
-	return databaseClient.
-		Table((*table.StakerCountSnapshot).TableName(nil)).
-		Create(stakeSnapshot).
-		Error
+	onConflict := clause.OnConflict{
+		Columns: []clause.Column{
+			{
+				Name: "date",
+			},
+		},
+		UpdateAll: true,
+	}
+
+	return c.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(onConflict).Create(&value).Error; err != nil {
+			return err
+		}
+
+		return rollupSnapshots(tx, schema.SnapshotSeriesStakerCount, nil, stakeSnapshot.Date)
+	})
 }
 
 func (c *client) FindStakerProfitSnapshots(ctx context.Context, query schema.StakerProfitSnapshotsQuery) ([]*schema.StakerProfitSnapshot, error) {
@@ -732,7 +815,26 @@ func (c *client) SaveStakerProfitSnapshots(ctx context.Context, snapshots []*sch
 		UpdateAll: true,
 	}
 
-	return c.database.WithContext(ctx).Clauses(onConflict).Create(&value).Error
+	dates := lo.Map(snapshots, func(snapshot *schema.StakerProfitSnapshot, _ int) time.Time {
+		return snapshot.Date
+	})
+
+	// Only the stakers of the batch are rolled up, as the snapshots of an Epoch are saved in batches.
+	owners := lo.Map(snapshots, func(snapshot *schema.StakerProfitSnapshot, _ int) common.Address {
+		return snapshot.OwnerAddress
+	})
+
+	return c.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(onConflict).Create(&value).Error; err != nil {
+			return err
+		}
+
+		if err := rollupSnapshots(tx, schema.SnapshotSeriesTotalChipAmounts, owners, dates...); err != nil {
+			return err
+		}
+
+		return rollupSnapshots(tx, schema.SnapshotSeriesTotalChipValues, owners, dates...)
+	})
 }
 
 func (c *client) DeleteStakeTransactionsByBlockNumber(ctx context.Context, blockNumber uint64) error {
@@ -0,0 +1,234 @@
+package cockroachdb
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/database/dialer/cockroachdb/table"
+	"github.com/rss3-network/global-indexer/schema"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (c *client) SaveWebhookSubscription(ctx context.Context, subscription *schema.WebhookSubscription) error {
+	var tSubscription table.WebhookSubscription
+
+	tSubscription.Import(subscription)
+
+	if err := c.database.WithContext(ctx).Create(&tSubscription).Error; err != nil {
+		return err
+	}
+
+	subscription.ID = tSubscription.ID
+	subscription.CreatedAt = tSubscription.CreatedAt.Unix()
+
+	return nil
+}
+
+func (c *client) FindWebhookSubscription(ctx context.Context, id uint64) (*schema.WebhookSubscription, error) {
+	var subscription table.WebhookSubscription
+
+	if err := c.database.WithContext(ctx).First(&subscription, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, database.ErrorRowNotFound
+		}
+
+		return nil, err
+	}
+
+	return subscription.Export()
+}
+
+func (c *client) FindWebhookSubscriptions(ctx context.Context, query schema.WebhookSubscriptionsQuery) ([]*schema.WebhookSubscription, error) {
+	databaseStatement := c.database.WithContext(ctx)
+
+	if len(query.IDs) > 0 {
+		databaseStatement = databaseStatement.Where("id IN ?", query.IDs)
+	}
+
+	if query.Address != nil {
+		databaseStatement = databaseStatement.Where("address = ?", query.Address)
+	}
+
+	if len(query.Addresses) > 0 {
+		databaseStatement = databaseStatement.Where("address IN ?", query.Addresses)
+	}
+
+	if query.EventType != nil {
+		databaseStatement = databaseStatement.Where("event_types @> ?", pq.StringArray{query.EventType.String()})
+	}
+
+	if query.Cursor != nil {
+		databaseStatement = databaseStatement.Where("id < ?", query.Cursor)
+	}
+
+	if query.Limit != nil {
+		databaseStatement = databaseStatement.Limit(*query.Limit)
+	}
+
+	var subscriptions table.WebhookSubscriptions
+
+	if err := databaseStatement.Order("id DESC").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+
+	return subscriptions.Export()
+}
+
+// DeleteWebhookSubscription deletes the subscription with its deliveries.
+func (c *client) DeleteWebhookSubscription(ctx context.Context, id uint64) error {
+	return c.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&table.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", id).Delete(&table.WebhookSubscription{}).Error
+	})
+}
+
+// SaveWebhookEvents saves the events, the events already saved with the same keys are ignored.
+func (c *client) SaveWebhookEvents(ctx context.Context, events []*schema.WebhookEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	var tEvents table.WebhookEvents
+
+	tEvents.Import(events)
+
+	onConflict := clause.OnConflict{
+		Columns: []clause.Column{
+			{
+				Name: "key",
+			},
+		},
+		DoNothing: true,
+	}
+
+	return c.database.WithContext(ctx).Clauses(onConflict).CreateInBatches(&tEvents, math.MaxUint8).Error
+}
+
+func (c *client) FindWebhookEvents(ctx context.Context, query schema.WebhookEventsQuery) ([]*schema.WebhookEvent, error) {
+	databaseStatement := c.database.WithContext(ctx)
+
+	if query.Dispatched != nil {
+		databaseStatement = databaseStatement.Where("dispatched = ?", query.Dispatched)
+	}
+
+	if query.ScheduledBefore != nil {
+		databaseStatement = databaseStatement.Where("scheduled_at <= ?", query.ScheduledBefore)
+	}
+
+	if query.Limit != nil {
+		databaseStatement = databaseStatement.Limit(*query.Limit)
+	}
+
+	var events table.WebhookEvents
+
+	if err := databaseStatement.Order("scheduled_at, id").Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	return events.Export(), nil
+}
+
+func (c *client) UpdateWebhookEventsDispatched(ctx context.Context, ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return c.database.WithContext(ctx).
+		Model(&table.WebhookEvent{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{"dispatched": true, "updated_at": time.Now()}).Error
+}
+
+// DeleteWebhookEvents deletes the dispatched events created before the time.
+func (c *client) DeleteWebhookEvents(ctx context.Context, before time.Time) error {
+	return c.database.WithContext(ctx).Where("dispatched AND created_at < ?", before).Delete(&table.WebhookEvent{}).Error
+}
+
+// SaveWebhookDeliveries saves the deliveries, an event is delivered to a subscription once.
+func (c *client) SaveWebhookDeliveries(ctx context.Context, deliveries []*schema.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	var tDeliveries table.WebhookDeliveries
+
+	tDeliveries.Import(deliveries)
+
+	onConflict := clause.OnConflict{
+		Columns: []clause.Column{
+			{
+				Name: "subscription_id",
+			},
+			{
+				Name: "event_id",
+			},
+		},
+		DoNothing: true,
+	}
+
+	return c.database.WithContext(ctx).Clauses(onConflict).CreateInBatches(&tDeliveries, math.MaxUint8).Error
+}
+
+// UpdateWebhookDelivery updates the result of the last attempt of the delivery.
+func (c *client) UpdateWebhookDelivery(ctx context.Context, delivery *schema.WebhookDelivery) error {
+	return c.database.WithContext(ctx).
+		Model(&table.WebhookDelivery{}).
+		Where("id = ?", delivery.ID).
+		Updates(map[string]interface{}{
+			"status":          delivery.Status.String(),
+			"attempts":        delivery.Attempts,
+			"next_attempt_at": time.Unix(delivery.NextAttemptAt, 0),
+			"response_status": delivery.ResponseStatus,
+			"error":           delivery.Error,
+			"updated_at":      time.Now(),
+		}).Error
+}
+
+func (c *client) FindWebhookDeliveries(ctx context.Context, query schema.WebhookDeliveriesQuery) ([]*schema.WebhookDelivery, error) {
+	databaseStatement := c.database.WithContext(ctx)
+
+	if query.SubscriptionID != nil {
+		databaseStatement = databaseStatement.Where("subscription_id = ?", query.SubscriptionID)
+	}
+
+	if query.Status != nil {
+		databaseStatement = databaseStatement.Where("status = ?", query.Status.String())
+	}
+
+	if query.Cursor != nil {
+		databaseStatement = databaseStatement.Where("id < ?", query.Cursor)
+	}
+
+	if query.Limit != nil {
+		databaseStatement = databaseStatement.Limit(*query.Limit)
+	}
+
+	if query.NextAttemptBefore != nil {
+		databaseStatement = databaseStatement.Where("next_attempt_at <= ?", query.NextAttemptBefore).Order("next_attempt_at, id")
+	} else {
+		databaseStatement = databaseStatement.Order("id DESC")
+	}
+
+	var deliveries table.WebhookDeliveries
+
+	if err := databaseStatement.Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+
+	return deliveries.Export(), nil
+}
+
+// DeleteWebhookDeliveries deletes the finished deliveries created before the time.
+func (c *client) DeleteWebhookDeliveries(ctx context.Context, before time.Time) error {
+	return c.database.WithContext(ctx).
+		Where("status <> ? AND created_at < ?", schema.WebhookDeliveryStatusPending.String(), before).
+		Delete(&table.WebhookDelivery{}).Error
+}
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "epoch_settlement_batch"
+(
+    "epoch_id"         bigint      NOT NULL,
+    "index"            int         NOT NULL,
+    "node_addresses"   bytea[]     NOT NULL,
+    "is_final"         bool        NOT NULL DEFAULT false,
+    "data"             jsonb,
+    "transaction_hash" text,
+    "receipt_status"   bigint,
+    "status"           text        NOT NULL DEFAULT 'pending',
+    "created_at"       timestamptz NOT NULL DEFAULT now(),
+    "updated_at"       timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT "pk_epoch_settlement_batch" PRIMARY KEY ("epoch_id" DESC, "index" ASC)
+);
+
+CREATE INDEX IF NOT EXISTS "idx_epoch_settlement_batch_status" ON "epoch_settlement_batch" ("status");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "epoch_settlement_batch";
+-- +goose StatementEnd
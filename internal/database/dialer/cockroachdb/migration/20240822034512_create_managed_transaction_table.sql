@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "managed_transaction"
+(
+    "chain_id"           bigint      NOT NULL,
+    "from"               bytea       NOT NULL,
+    "nonce"              bigint      NOT NULL,
+    "owner"              text        NOT NULL,
+    "to"                 bytea,
+    "data"               bytea,
+    "value"              decimal     NOT NULL DEFAULT 0,
+    "gas_limit"          bigint      NOT NULL,
+    "gas_tip_cap"        decimal     NOT NULL,
+    "gas_fee_cap"        decimal     NOT NULL,
+    "raw_transaction"    bytea       NOT NULL,
+    "transaction_hash"   text        NOT NULL,
+    "transaction_hashes" text[]      NOT NULL,
+    "fee_bumps"          int         NOT NULL DEFAULT 0,
+    "state"              text        NOT NULL DEFAULT 'pending',
+    "receipt"            jsonb,
+    "error"              text,
+    "created_at"         timestamptz NOT NULL DEFAULT now(),
+    "updated_at"         timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT "pk_managed_transaction" PRIMARY KEY ("chain_id", "from", "nonce" DESC)
+);
+
+CREATE INDEX IF NOT EXISTS "idx_managed_transaction_state" ON "managed_transaction" ("chain_id", "from", "state");
+CREATE INDEX IF NOT EXISTS "idx_managed_transaction_transaction_hash" ON "managed_transaction" ("transaction_hash");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "managed_transaction";
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "epoch_settlement_batch" ADD COLUMN IF NOT EXISTS "error" text NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "epoch_settlement_batch" DROP COLUMN IF EXISTS "error";
+-- +goose StatementEnd
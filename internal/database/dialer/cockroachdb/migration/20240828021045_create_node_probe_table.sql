@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "node_probe"
+(
+    "node_address"           bytea       NOT NULL,
+    "target"                 text        NOT NULL,
+    "probed_at"              timestamptz NOT NULL,
+    "status_code"            int         NOT NULL DEFAULT 0,
+    "latency"                bigint      NOT NULL DEFAULT 0,
+    "error"                  text,
+    "certificate_expires_at" timestamptz,
+    "workers"                int         NOT NULL DEFAULT 0,
+    "ready_workers"          int         NOT NULL DEFAULT 0,
+
+    CONSTRAINT "pk_node_probe" PRIMARY KEY ("node_address", "probed_at" DESC, "target")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_node_probe_probed_at" ON "node_probe" ("probed_at");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "node_probe";
+-- +goose StatementEnd
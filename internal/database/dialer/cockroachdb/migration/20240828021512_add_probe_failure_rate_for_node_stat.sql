@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "node_stat" ADD COLUMN IF NOT EXISTS "probe_failure_rate" float NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "node_stat" DROP COLUMN IF EXISTS "probe_failure_rate";
+-- +goose StatementEnd
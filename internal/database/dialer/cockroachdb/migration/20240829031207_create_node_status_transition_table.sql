@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "node_status_transition"
+(
+    "id"           bigint      GENERATED BY DEFAULT AS IDENTITY (INCREMENT 1 MINVALUE 0 START 0),
+    "node_address" bytea       NOT NULL,
+    "from"         text        NOT NULL,
+    "to"           text        NOT NULL,
+    "reason"       text        NOT NULL,
+    "timestamp"    timestamptz NOT NULL,
+    "created_at"   timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT "pk_node_status_transition" PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_node_status_transition_node_address" ON "node_status_transition" ("node_address", "id" DESC);
+CREATE INDEX IF NOT EXISTS "idx_node_status_transition_to" ON "node_status_transition" ("to", "node_address", "id" DESC);
+
+CREATE INDEX IF NOT EXISTS "events_index_order" ON "node"."events" ("block_timestamp" DESC, "block_number" DESC, "transaction_index" DESC, "log_index" DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS "node"."events"@"events_index_order";
+
+DROP TABLE IF EXISTS "node_status_transition";
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "node_score"
+(
+    "node_address"  bytea       NOT NULL,
+    "epoch_id"      bigint      NOT NULL,
+    "model_version" text        NOT NULL,
+    "score"         decimal     NOT NULL DEFAULT 0,
+    "components"    jsonb       NOT NULL DEFAULT '[]',
+    "created_at"    timestamptz NOT NULL DEFAULT now(),
+    "updated_at"    timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT "pk_node_score" PRIMARY KEY ("node_address", "epoch_id" DESC)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "node_score";
+-- +goose StatementEnd
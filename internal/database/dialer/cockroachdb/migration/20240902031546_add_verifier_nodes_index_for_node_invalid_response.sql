@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INVERTED INDEX IF NOT EXISTS "idx_verifier_nodes" ON "node_invalid_response" ("verifier_nodes");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS "node_invalid_response"@"idx_verifier_nodes";
+-- +goose StatementEnd
@@ -0,0 +1,66 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "webhook_subscription"
+(
+    "id"          bigint      GENERATED BY DEFAULT AS IDENTITY (INCREMENT 1 MINVALUE 0 START 0),
+    "address"     bytea       NOT NULL,
+    "url"         text        NOT NULL,
+    "event_types" text[]      NOT NULL,
+    "secret"      text        NOT NULL,
+    "created_at"  timestamptz NOT NULL DEFAULT now(),
+    "updated_at"  timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT "pk_webhook_subscription" PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_webhook_subscription_address" ON "webhook_subscription" ("address", "id" DESC);
+
+CREATE TABLE IF NOT EXISTS "webhook_event"
+(
+    "id"           bigint      GENERATED BY DEFAULT AS IDENTITY (INCREMENT 1 MINVALUE 0 START 0),
+    "key"          text        NOT NULL,
+    "type"         text        NOT NULL,
+    "addresses"    bytea[]     NOT NULL,
+    "data"         jsonb       NOT NULL,
+    "timestamp"    timestamptz NOT NULL,
+    "scheduled_at" timestamptz NOT NULL,
+    "dispatched"   bool        NOT NULL DEFAULT false,
+    "created_at"   timestamptz NOT NULL DEFAULT now(),
+    "updated_at"   timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT "pk_webhook_event" PRIMARY KEY ("id"),
+    CONSTRAINT "uk_webhook_event_key" UNIQUE ("key")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_webhook_event_undispatched" ON "webhook_event" ("scheduled_at", "id") WHERE NOT "dispatched";
+CREATE INDEX IF NOT EXISTS "idx_webhook_event_created_at" ON "webhook_event" ("created_at");
+
+CREATE TABLE IF NOT EXISTS "webhook_delivery"
+(
+    "id"              bigint      GENERATED BY DEFAULT AS IDENTITY (INCREMENT 1 MINVALUE 0 START 0),
+    "subscription_id" bigint      NOT NULL,
+    "event_id"        bigint      NOT NULL,
+    "event_type"      text        NOT NULL,
+    "payload"         jsonb       NOT NULL,
+    "status"          text        NOT NULL,
+    "attempts"        int         NOT NULL DEFAULT 0,
+    "next_attempt_at" timestamptz NOT NULL,
+    "response_status" int         NOT NULL DEFAULT 0,
+    "error"           text        NOT NULL DEFAULT '',
+    "created_at"      timestamptz NOT NULL DEFAULT now(),
+    "updated_at"      timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT "pk_webhook_delivery" PRIMARY KEY ("id"),
+    CONSTRAINT "uk_webhook_delivery_subscription_event" UNIQUE ("subscription_id", "event_id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_webhook_delivery_subscription_id" ON "webhook_delivery" ("subscription_id", "id" DESC);
+CREATE INDEX IF NOT EXISTS "idx_webhook_delivery_status" ON "webhook_delivery" ("status", "next_attempt_at");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "webhook_delivery";
+DROP TABLE IF EXISTS "webhook_event";
+DROP TABLE IF EXISTS "webhook_subscription";
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "epoch_reconciliation"
+(
+    "epoch_id"       bigint      NOT NULL,
+    "distributions"  int         NOT NULL DEFAULT 0,
+    "rewarded_nodes" int         NOT NULL DEFAULT 0,
+    "mismatch_count" int         NOT NULL DEFAULT 0,
+    "reconciled_at"  timestamptz NOT NULL,
+    "created_at"     timestamptz NOT NULL DEFAULT now(),
+    "updated_at"     timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT "pk_epoch_reconciliation" PRIMARY KEY ("epoch_id" DESC)
+);
+
+CREATE INDEX IF NOT EXISTS "idx_epoch_reconciliation_inconsistent" ON "epoch_reconciliation" ("epoch_id" DESC) WHERE "mismatch_count" > 0;
+
+CREATE TABLE IF NOT EXISTS "epoch_reconciliation_mismatch"
+(
+    "id"               bigint      GENERATED BY DEFAULT AS IDENTITY (INCREMENT 1 MINVALUE 0 START 0),
+    "epoch_id"         bigint      NOT NULL,
+    "type"             text        NOT NULL,
+    "transaction_hash" text,
+    "node_address"     bytea,
+    "expected"         text        NOT NULL DEFAULT '',
+    "actual"           text        NOT NULL DEFAULT '',
+    "created_at"       timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT "pk_epoch_reconciliation_mismatch" PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_epoch_reconciliation_mismatch_epoch_id" ON "epoch_reconciliation_mismatch" ("epoch_id", "id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "epoch_reconciliation_mismatch";
+DROP TABLE IF EXISTS "epoch_reconciliation";
+-- +goose StatementEnd
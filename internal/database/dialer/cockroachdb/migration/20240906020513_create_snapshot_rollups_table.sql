@@ -0,0 +1,69 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE SCHEMA IF NOT EXISTS "snapshot";
+
+CREATE TABLE "snapshot"."rollups"
+(
+    "series"      text        NOT NULL,
+    "address"     bytea       NOT NULL DEFAULT '',
+    "granularity" text        NOT NULL,
+    "bucket"      timestamptz NOT NULL,
+    "last"        decimal     NOT NULL,
+    "average"     decimal     NOT NULL,
+    "minimum"     decimal     NOT NULL,
+    "maximum"     decimal     NOT NULL,
+    "samples"     bigint      NOT NULL,
+    "created_at"  timestamptz NOT NULL DEFAULT now(),
+    "updated_at"  timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT "pkey" PRIMARY KEY ("series", "address", "granularity", "bucket" DESC)
+);
+
+INSERT INTO "snapshot"."rollups" ("series", "address", "granularity", "bucket", "last", "average", "minimum", "maximum", "samples")
+SELECT 'node_count', "address", "granularity", "bucket", (array_agg("value" ORDER BY "date" DESC))[1], avg("value"), min("value"), max("value"), count(*)
+FROM (SELECT ''::bytea AS "address", "date"::timestamptz AS "date", "count"::decimal AS "value", "g"."granularity", date_trunc("g"."granularity", "date"::timestamptz) AS "bucket"
+      FROM "node"."count_snapshots"
+               CROSS JOIN (VALUES ('day'), ('week'), ('month')) AS "g" ("granularity")) AS "points"
+GROUP BY "address", "granularity", "bucket";
+
+INSERT INTO "snapshot"."rollups" ("series", "address", "granularity", "bucket", "last", "average", "minimum", "maximum", "samples")
+SELECT 'staker_count', "address", "granularity", "bucket", (array_agg("value" ORDER BY "date" DESC))[1], avg("value"), min("value"), max("value"), count(*)
+FROM (SELECT ''::bytea AS "address", "date"::timestamptz AS "date", "count"::decimal AS "value", "g"."granularity", date_trunc("g"."granularity", "date"::timestamptz) AS "bucket"
+      FROM "stake"."count_snapshots"
+               CROSS JOIN (VALUES ('day'), ('week'), ('month')) AS "g" ("granularity")) AS "points"
+GROUP BY "address", "granularity", "bucket";
+
+INSERT INTO "snapshot"."rollups" ("series", "address", "granularity", "bucket", "last", "average", "minimum", "maximum", "samples")
+SELECT 'operation_pool', "address", "granularity", "bucket", (array_agg("value" ORDER BY "date" DESC))[1], avg("value"), min("value"), max("value"), count(*)
+FROM (SELECT "operator" AS "address", "date"::timestamptz AS "date", "operation_pool"::decimal AS "value", "g"."granularity", date_trunc("g"."granularity", "date"::timestamptz) AS "bucket"
+      FROM "node"."operator_profit_snapshots"
+               CROSS JOIN (VALUES ('day'), ('week'), ('month')) AS "g" ("granularity")) AS "points"
+GROUP BY "address", "granularity", "bucket";
+
+INSERT INTO "snapshot"."rollups" ("series", "address", "granularity", "bucket", "last", "average", "minimum", "maximum", "samples")
+SELECT 'total_chip_amounts', "address", "granularity", "bucket", (array_agg("value" ORDER BY "date" DESC))[1], avg("value"), min("value"), max("value"), count(*)
+FROM (SELECT "owner_address" AS "address", "date"::timestamptz AS "date", "total_chip_amounts"::decimal AS "value", "g"."granularity", date_trunc("g"."granularity", "date"::timestamptz) AS "bucket"
+      FROM "stake"."profit_snapshots"
+               CROSS JOIN (VALUES ('day'), ('week'), ('month')) AS "g" ("granularity")) AS "points"
+GROUP BY "address", "granularity", "bucket";
+
+INSERT INTO "snapshot"."rollups" ("series", "address", "granularity", "bucket", "last", "average", "minimum", "maximum", "samples")
+SELECT 'total_chip_values', "address", "granularity", "bucket", (array_agg("value" ORDER BY "date" DESC))[1], avg("value"), min("value"), max("value"), count(*)
+FROM (SELECT "owner_address" AS "address", "date"::timestamptz AS "date", "total_chip_values"::decimal AS "value", "g"."granularity", date_trunc("g"."granularity", "date"::timestamptz) AS "bucket"
+      FROM "stake"."profit_snapshots"
+               CROSS JOIN (VALUES ('day'), ('week'), ('month')) AS "g" ("granularity")) AS "points"
+GROUP BY "address", "granularity", "bucket";
+
+INSERT INTO "snapshot"."rollups" ("series", "address", "granularity", "bucket", "last", "average", "minimum", "maximum", "samples")
+SELECT 'epoch_apy', "address", "granularity", "bucket", (array_agg("value" ORDER BY "date" DESC))[1], avg("value"), min("value"), max("value"), count(*)
+FROM (SELECT ''::bytea AS "address", "date"::timestamptz AS "date", "apy"::decimal AS "value", "g"."granularity", date_trunc("g"."granularity", "date"::timestamptz) AS "bucket"
+      FROM "epoch"."apy_snapshots"
+               CROSS JOIN (VALUES ('day'), ('week'), ('month')) AS "g" ("granularity")) AS "points"
+GROUP BY "address", "granularity", "bucket";
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "snapshot"."rollups";
+DROP SCHEMA "snapshot";
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "node"."ranks"
+(
+    "metric"        text        NOT NULL,
+    "epochs"        int         NOT NULL,
+    "epoch_id"      bigint      NOT NULL,
+    "node_address"  bytea       NOT NULL,
+    "value"         decimal     NOT NULL,
+    "rank"          int         NOT NULL,
+    "previous_rank" int,
+    "created_at"    timestamptz NOT NULL DEFAULT now(),
+    "updated_at"    timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT "pk_ranks" PRIMARY KEY ("metric", "epochs", "epoch_id" DESC, "node_address")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_ranks_rank" ON "node"."ranks" ("metric", "epochs", "epoch_id" DESC, "rank");
+CREATE INDEX IF NOT EXISTS "idx_ranks_epoch_id" ON "node"."ranks" ("epoch_id" DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "node"."ranks";
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "cron_job"
+(
+    "name"              text        NOT NULL,
+    "spec"              text        NOT NULL,
+    "timeout"           bigint      NOT NULL,
+    "next_scheduled_at" timestamptz NOT NULL,
+    "created_at"        timestamptz NOT NULL DEFAULT now(),
+    "updated_at"        timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT "pk_cron_job" PRIMARY KEY ("name")
+);
+
+CREATE TABLE IF NOT EXISTS "cron_job_run"
+(
+    "id"           bigint      GENERATED BY DEFAULT AS IDENTITY (INCREMENT 1 MINVALUE 0 START 0),
+    "job"          text        NOT NULL,
+    "trigger"      text        NOT NULL,
+    "status"       text        NOT NULL,
+    "error"        text        NOT NULL DEFAULT '',
+    "holder"       text        NOT NULL DEFAULT '',
+    "scheduled_at" timestamptz NOT NULL,
+    "started_at"   timestamptz NOT NULL,
+    "finished_at"  timestamptz,
+    "duration"     bigint      NOT NULL DEFAULT 0,
+    "created_at"   timestamptz NOT NULL DEFAULT now(),
+    "updated_at"   timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT "pk_cron_job_run" PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_cron_job_run_job" ON "cron_job_run" ("job", "id" DESC);
+CREATE INDEX IF NOT EXISTS "idx_cron_job_run_job_status" ON "cron_job_run" ("job", "status", "id" DESC);
+CREATE INDEX IF NOT EXISTS "idx_cron_job_run_started_at" ON "cron_job_run" ("started_at");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "cron_job_run";
+DROP TABLE IF EXISTS "cron_job";
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "managed_transaction" ADD COLUMN IF NOT EXISTS "version" bigint NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "managed_transaction" DROP COLUMN IF EXISTS "version";
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "node_stat" ADD COLUMN IF NOT EXISTS "last_epoch_request_count" bigint NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "node_stat" DROP COLUMN IF EXISTS "last_epoch_request_count";
+-- +goose StatementEnd
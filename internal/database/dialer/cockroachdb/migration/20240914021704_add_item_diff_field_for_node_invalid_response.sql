@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "node_invalid_response" ADD COLUMN IF NOT EXISTS "item_diff" jsonb;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "node_invalid_response" DROP COLUMN IF EXISTS "item_diff";
+-- +goose StatementEnd
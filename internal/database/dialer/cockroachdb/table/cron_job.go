@@ -0,0 +1,117 @@
+package table
+
+import (
+	"time"
+
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+)
+
+type CronJob struct {
+	Name            string    `gorm:"column:name;primaryKey"`
+	Spec            string    `gorm:"column:spec"`
+	Timeout         int64     `gorm:"column:timeout"`
+	NextScheduledAt time.Time `gorm:"column:next_scheduled_at"`
+	CreatedAt       time.Time `gorm:"column:created_at"`
+	UpdatedAt       time.Time `gorm:"column:updated_at"`
+}
+
+func (*CronJob) TableName() string {
+	return "cron_job"
+}
+
+func (c *CronJob) Import(job *schema.CronJob) {
+	c.Name = job.Name
+	c.Spec = job.Spec
+	c.Timeout = job.Timeout
+	c.NextScheduledAt = time.Unix(job.NextScheduledAt, 0)
+}
+
+func (c *CronJob) Export() *schema.CronJob {
+	return &schema.CronJob{
+		Name:            c.Name,
+		Spec:            c.Spec,
+		Timeout:         c.Timeout,
+		NextScheduledAt: c.NextScheduledAt.Unix(),
+		UpdatedAt:       c.UpdatedAt.Unix(),
+	}
+}
+
+type CronJobs []CronJob
+
+func (c *CronJobs) Export() []*schema.CronJob {
+	jobs := make([]*schema.CronJob, 0, len(*c))
+
+	for _, job := range *c {
+		jobs = append(jobs, job.Export())
+	}
+
+	return jobs
+}
+
+type CronJobRun struct {
+	ID          uint64                   `gorm:"column:id;primaryKey"`
+	Job         string                   `gorm:"column:job"`
+	Trigger     schema.CronJobRunTrigger `gorm:"column:trigger"`
+	Status      schema.CronJobRunStatus  `gorm:"column:status"`
+	Error       string                   `gorm:"column:error"`
+	Holder      string                   `gorm:"column:holder"`
+	ScheduledAt time.Time                `gorm:"column:scheduled_at"`
+	StartedAt   time.Time                `gorm:"column:started_at"`
+	FinishedAt  *time.Time               `gorm:"column:finished_at"`
+	Duration    int64                    `gorm:"column:duration"`
+	CreatedAt   time.Time                `gorm:"column:created_at"`
+	UpdatedAt   time.Time                `gorm:"column:updated_at"`
+}
+
+func (*CronJobRun) TableName() string {
+	return "cron_job_run"
+}
+
+func (c *CronJobRun) Import(run *schema.CronJobRun) {
+	c.ID = run.ID
+	c.Job = run.Job
+	c.Trigger = run.Trigger
+	c.Status = run.Status
+	c.Error = run.Error
+	c.Holder = run.Holder
+	c.ScheduledAt = time.Unix(run.ScheduledAt, 0)
+	c.StartedAt = time.Unix(run.StartedAt, 0)
+	c.Duration = run.Duration
+
+	if run.FinishedAt != nil {
+		c.FinishedAt = lo.ToPtr(time.Unix(*run.FinishedAt, 0))
+	}
+}
+
+func (c *CronJobRun) Export() *schema.CronJobRun {
+	run := schema.CronJobRun{
+		ID:          c.ID,
+		Job:         c.Job,
+		Trigger:     c.Trigger,
+		Status:      c.Status,
+		Error:       c.Error,
+		Holder:      c.Holder,
+		ScheduledAt: c.ScheduledAt.Unix(),
+		StartedAt:   c.StartedAt.Unix(),
+		Duration:    c.Duration,
+	}
+
+	if c.FinishedAt != nil {
+		run.FinishedAt = lo.ToPtr(c.FinishedAt.Unix())
+	}
+
+	return &run
+}
+
+type CronJobRuns []CronJobRun
+
+func (c *CronJobRuns) Export() []*schema.CronJobRun {
+	runs := make([]*schema.CronJobRun, 0, len(*c))
+
+	for _, run := range *c {
+		runs = append(runs, run.Export())
+	}
+
+	return runs
+}
@@ -0,0 +1,112 @@
+package table
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+)
+
+type EpochReconciliation struct {
+	EpochID       uint64    `gorm:"column:epoch_id;primaryKey"`
+	Distributions int       `gorm:"column:distributions"`
+	RewardedNodes int       `gorm:"column:rewarded_nodes"`
+	MismatchCount int       `gorm:"column:mismatch_count"`
+	ReconciledAt  time.Time `gorm:"column:reconciled_at"`
+	CreatedAt     time.Time `gorm:"column:created_at"`
+	UpdatedAt     time.Time `gorm:"column:updated_at"`
+}
+
+func (*EpochReconciliation) TableName() string {
+	return "epoch_reconciliation"
+}
+
+func (e *EpochReconciliation) Import(reconciliation *schema.EpochReconciliation) {
+	e.EpochID = reconciliation.EpochID
+	e.Distributions = reconciliation.Distributions
+	e.RewardedNodes = reconciliation.RewardedNodes
+	e.MismatchCount = len(reconciliation.Mismatches)
+	e.ReconciledAt = time.Unix(reconciliation.ReconciledAt, 0)
+}
+
+func (e *EpochReconciliation) Export() *schema.EpochReconciliation {
+	return &schema.EpochReconciliation{
+		EpochID:       e.EpochID,
+		Distributions: e.Distributions,
+		RewardedNodes: e.RewardedNodes,
+		MismatchCount: e.MismatchCount,
+		ReconciledAt:  e.ReconciledAt.Unix(),
+	}
+}
+
+type EpochReconciliations []EpochReconciliation
+
+func (e EpochReconciliations) Export() []*schema.EpochReconciliation {
+	return lo.Map(e, func(reconciliation EpochReconciliation, _ int) *schema.EpochReconciliation {
+		return reconciliation.Export()
+	})
+}
+
+type EpochReconciliationMismatch struct {
+	ID              uint64                                 `gorm:"column:id;primaryKey"`
+	EpochID         uint64                                 `gorm:"column:epoch_id"`
+	Type            schema.EpochReconciliationMismatchType `gorm:"column:type"`
+	TransactionHash *string                                `gorm:"column:transaction_hash"`
+	NodeAddress     *common.Address                        `gorm:"column:node_address"`
+	Expected        string                                 `gorm:"column:expected"`
+	Actual          string                                 `gorm:"column:actual"`
+	CreatedAt       time.Time                              `gorm:"column:created_at"`
+}
+
+func (*EpochReconciliationMismatch) TableName() string {
+	return "epoch_reconciliation_mismatch"
+}
+
+func (e *EpochReconciliationMismatch) Import(mismatch *schema.EpochReconciliationMismatch) {
+	e.EpochID = mismatch.EpochID
+	e.Type = mismatch.Type
+	e.NodeAddress = mismatch.NodeAddress
+	e.Expected = mismatch.Expected
+	e.Actual = mismatch.Actual
+
+	if mismatch.TransactionHash != nil {
+		e.TransactionHash = lo.ToPtr(mismatch.TransactionHash.String())
+	}
+}
+
+func (e *EpochReconciliationMismatch) Export() *schema.EpochReconciliationMismatch {
+	mismatch := schema.EpochReconciliationMismatch{
+		EpochID:     e.EpochID,
+		Type:        e.Type,
+		NodeAddress: e.NodeAddress,
+		Expected:    e.Expected,
+		Actual:      e.Actual,
+	}
+
+	if e.TransactionHash != nil {
+		mismatch.TransactionHash = lo.ToPtr(common.HexToHash(*e.TransactionHash))
+	}
+
+	return &mismatch
+}
+
+type EpochReconciliationMismatches []EpochReconciliationMismatch
+
+func (e *EpochReconciliationMismatches) Import(mismatches []*schema.EpochReconciliationMismatch) {
+	*e = make([]EpochReconciliationMismatch, 0, len(mismatches))
+
+	for _, mismatch := range mismatches {
+		var tMismatch EpochReconciliationMismatch
+
+		tMismatch.Import(mismatch)
+
+		*e = append(*e, tMismatch)
+	}
+}
+
+func (e EpochReconciliationMismatches) Export() []*schema.EpochReconciliationMismatch {
+	return lo.Map(e, func(mismatch EpochReconciliationMismatch, _ int) *schema.EpochReconciliationMismatch {
+		return mismatch.Export()
+	})
+}
@@ -0,0 +1,125 @@
+package table
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lib/pq"
+	"github.com/rss3-network/global-indexer/schema"
+)
+
+type EpochSettlementBatch struct {
+	EpochID         uint64                       `gorm:"column:epoch_id;primaryKey"`
+	Index           int                          `gorm:"column:index;primaryKey"`
+	NodeAddresses   pq.ByteaArray                `gorm:"column:node_addresses;type:bytea[]"`
+	IsFinal         bool                         `gorm:"column:is_final"`
+	Data            json.RawMessage              `gorm:"column:data;type:jsonb"`
+	TransactionHash *string                      `gorm:"column:transaction_hash"`
+	ReceiptStatus   *uint64                      `gorm:"column:receipt_status"`
+	Status          schema.SettlementBatchStatus `gorm:"column:status"`
+	Error           string                       `gorm:"column:error"`
+	CreatedAt       time.Time                    `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt       time.Time                    `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (e *EpochSettlementBatch) TableName() string {
+	return "epoch_settlement_batch"
+}
+
+func (e *EpochSettlementBatch) Import(batch *schema.SettlementBatch) (err error) {
+	e.EpochID = batch.EpochID
+	e.Index = batch.Index
+	e.IsFinal = batch.IsFinal
+	e.ReceiptStatus = batch.ReceiptStatus
+	e.Status = batch.Status
+	e.Error = batch.Error
+	e.CreatedAt = batch.CreatedAt
+	e.UpdatedAt = batch.UpdatedAt
+
+	e.NodeAddresses = make(pq.ByteaArray, 0, len(batch.NodeAddresses))
+	for _, address := range batch.NodeAddresses {
+		e.NodeAddresses = append(e.NodeAddresses, address.Bytes())
+	}
+
+	if batch.TransactionHash != nil {
+		transactionHash := batch.TransactionHash.String()
+		e.TransactionHash = &transactionHash
+	}
+
+	if batch.Data != nil {
+		if e.Data, err = json.Marshal(batch.Data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *EpochSettlementBatch) Export() (*schema.SettlementBatch, error) {
+	batch := schema.SettlementBatch{
+		EpochID:       e.EpochID,
+		Index:         e.Index,
+		NodeAddresses: make([]common.Address, 0, len(e.NodeAddresses)),
+		IsFinal:       e.IsFinal,
+		ReceiptStatus: e.ReceiptStatus,
+		Status:        e.Status,
+		Error:         e.Error,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}
+
+	for _, address := range e.NodeAddresses {
+		batch.NodeAddresses = append(batch.NodeAddresses, common.BytesToAddress(address))
+	}
+
+	if e.TransactionHash != nil {
+		transactionHash := common.HexToHash(*e.TransactionHash)
+		batch.TransactionHash = &transactionHash
+	}
+
+	if len(e.Data) > 0 {
+		var data schema.SettlementData
+		if err := json.Unmarshal(e.Data, &data); err != nil {
+			return nil, err
+		}
+
+		batch.Data = &data
+	}
+
+	return &batch, nil
+}
+
+type EpochSettlementBatches []*EpochSettlementBatch
+
+func (e *EpochSettlementBatches) Import(plan *schema.SettlementPlan) error {
+	for _, batch := range plan.Batches {
+		var imported EpochSettlementBatch
+
+		if err := imported.Import(batch); err != nil {
+			return err
+		}
+
+		*e = append(*e, &imported)
+	}
+
+	return nil
+}
+
+func (e EpochSettlementBatches) Export(epochID uint64) (*schema.SettlementPlan, error) {
+	plan := schema.SettlementPlan{
+		EpochID: epochID,
+		Batches: make([]*schema.SettlementBatch, 0, len(e)),
+	}
+
+	for _, batch := range e {
+		exported, err := batch.Export()
+		if err != nil {
+			return nil, err
+		}
+
+		plan.Batches = append(plan.Batches, exported)
+	}
+
+	return &plan, nil
+}
@@ -0,0 +1,127 @@
+package table
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lib/pq"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+type ManagedTransaction struct {
+	ChainID           uint64                         `gorm:"column:chain_id;primaryKey"`
+	From              common.Address                 `gorm:"column:from;primaryKey"`
+	Nonce             uint64                         `gorm:"column:nonce;primaryKey"`
+	Owner             string                         `gorm:"column:owner"`
+	To                *common.Address                `gorm:"column:to"`
+	Data              []byte                         `gorm:"column:data"`
+	Value             decimal.Decimal                `gorm:"column:value"`
+	GasLimit          uint64                         `gorm:"column:gas_limit"`
+	GasTipCap         decimal.Decimal                `gorm:"column:gas_tip_cap"`
+	GasFeeCap         decimal.Decimal                `gorm:"column:gas_fee_cap"`
+	RawTransaction    []byte                         `gorm:"column:raw_transaction"`
+	TransactionHash   string                         `gorm:"column:transaction_hash"`
+	TransactionHashes pq.StringArray                 `gorm:"column:transaction_hashes;type:text[]"`
+	FeeBumps          int                            `gorm:"column:fee_bumps"`
+	State             schema.ManagedTransactionState `gorm:"column:state"`
+	Receipt           json.RawMessage                `gorm:"column:receipt;type:jsonb"`
+	Error             string                         `gorm:"column:error"`
+	Version           uint64                         `gorm:"column:version"`
+	CreatedAt         time.Time                      `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt         time.Time                      `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (m *ManagedTransaction) TableName() string {
+	return "managed_transaction"
+}
+
+func (m *ManagedTransaction) Import(transaction *schema.ManagedTransaction) (err error) {
+	m.ChainID = transaction.ChainID
+	m.From = transaction.From
+	m.Nonce = transaction.Nonce
+	m.Owner = transaction.Owner
+	m.To = transaction.To
+	m.Data = transaction.Data
+	m.Value = decimal.NewFromBigInt(lo.Ternary(transaction.Value != nil, transaction.Value, common.Big0), 0)
+	m.GasLimit = transaction.GasLimit
+	m.GasTipCap = decimal.NewFromBigInt(lo.Ternary(transaction.GasTipCap != nil, transaction.GasTipCap, common.Big0), 0)
+	m.GasFeeCap = decimal.NewFromBigInt(lo.Ternary(transaction.GasFeeCap != nil, transaction.GasFeeCap, common.Big0), 0)
+	m.RawTransaction = transaction.RawTransaction
+	m.TransactionHash = transaction.TransactionHash.String()
+	m.FeeBumps = transaction.FeeBumps
+	m.State = transaction.State
+	m.Error = transaction.Error
+	m.Version = transaction.Version
+	m.CreatedAt = transaction.CreatedAt
+	m.UpdatedAt = transaction.UpdatedAt
+
+	m.TransactionHashes = lo.Map(transaction.TransactionHashes, func(hash common.Hash, _ int) string {
+		return hash.String()
+	})
+
+	if transaction.Receipt != nil {
+		if m.Receipt, err = json.Marshal(transaction.Receipt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *ManagedTransaction) Export() (*schema.ManagedTransaction, error) {
+	transaction := schema.ManagedTransaction{
+		ChainID:         m.ChainID,
+		From:            m.From,
+		Nonce:           m.Nonce,
+		Owner:           m.Owner,
+		To:              m.To,
+		Data:            m.Data,
+		Value:           m.Value.BigInt(),
+		GasLimit:        m.GasLimit,
+		GasTipCap:       m.GasTipCap.BigInt(),
+		GasFeeCap:       m.GasFeeCap.BigInt(),
+		RawTransaction:  m.RawTransaction,
+		TransactionHash: common.HexToHash(m.TransactionHash),
+		TransactionHashes: lo.Map(m.TransactionHashes, func(hash string, _ int) common.Hash {
+			return common.HexToHash(hash)
+		}),
+		FeeBumps:  m.FeeBumps,
+		State:     m.State,
+		Error:     m.Error,
+		Version:   m.Version,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+
+	if len(m.Receipt) > 0 {
+		var receipt types.Receipt
+		if err := json.Unmarshal(m.Receipt, &receipt); err != nil {
+			return nil, err
+		}
+
+		transaction.Receipt = &receipt
+	}
+
+	return &transaction, nil
+}
+
+type ManagedTransactions []*ManagedTransaction
+
+func (m ManagedTransactions) Export() ([]*schema.ManagedTransaction, error) {
+	result := make([]*schema.ManagedTransaction, 0, len(m))
+
+	for _, transaction := range m {
+		exported, err := transaction.Export()
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, exported)
+	}
+
+	return result, nil
+}
@@ -58,3 +58,18 @@ func (s *NodeAPYSnapshots) Import(snapshots []*schema.NodeAPYSnapshot) error {
 
 	return nil
 }
+
+func (s *NodeAPYSnapshots) Export() ([]*schema.NodeAPYSnapshot, error) {
+	snapshots := make([]*schema.NodeAPYSnapshot, 0, len(*s))
+
+	for _, snapshot := range *s {
+		exported, err := snapshot.Export()
+		if err != nil {
+			return nil, err
+		}
+
+		snapshots = append(snapshots, exported)
+	}
+
+	return snapshots, nil
+}
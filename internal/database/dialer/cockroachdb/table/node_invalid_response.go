@@ -18,6 +18,7 @@ type NodeInvalidResponse struct {
 	VerifierResponse json.RawMessage                `gorm:"column:verifier_response;type:jsonb"`
 	Node             common.Address                 `gorm:"column:node"`
 	Response         json.RawMessage                `gorm:"column:response;type:jsonb"`
+	ItemDiff         json.RawMessage                `gorm:"column:item_diff;type:jsonb"`
 	CreatedAt        time.Time                      `gorm:"column:created_at"`
 	UpdatedAt        time.Time                      `gorm:"column:updated_at"`
 }
@@ -38,6 +39,7 @@ func (n *NodeInvalidResponse) Import(nodeInvalidResponse *schema.NodeInvalidResp
 	n.VerifierResponse = nodeInvalidResponse.VerifierResponse
 	n.Node = nodeInvalidResponse.Node
 	n.Response = nodeInvalidResponse.Response
+	n.ItemDiff = nodeInvalidResponse.ItemDiff
 }
 
 func (n *NodeInvalidResponse) Export() *schema.NodeInvalidResponse {
@@ -56,6 +58,7 @@ func (n *NodeInvalidResponse) Export() *schema.NodeInvalidResponse {
 		VerifierResponse: n.VerifierResponse,
 		Node:             n.Node,
 		Response:         n.Response,
+		ItemDiff:         n.ItemDiff,
 		CreatedAt:        n.CreatedAt.Unix(),
 	}
 }
@@ -73,3 +76,13 @@ func (ns *NodeInvalidResponses) Import(nodeInvalidResponses []*schema.NodeInvali
 		*ns = append(*ns, tNodeInvalidResponse)
 	}
 }
+
+func (ns *NodeInvalidResponses) Export() []*schema.NodeInvalidResponse {
+	nodeInvalidResponses := make([]*schema.NodeInvalidResponse, 0, len(*ns))
+
+	for _, nodeInvalidResponse := range *ns {
+		nodeInvalidResponses = append(nodeInvalidResponses, nodeInvalidResponse.Export())
+	}
+
+	return nodeInvalidResponses
+}
@@ -0,0 +1,83 @@
+package table
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+)
+
+type NodeProbe struct {
+	NodeAddress          common.Address         `gorm:"column:node_address;primaryKey"`
+	Target               schema.NodeProbeTarget `gorm:"column:target;primaryKey"`
+	ProbedAt             time.Time              `gorm:"column:probed_at;primaryKey"`
+	StatusCode           int                    `gorm:"column:status_code"`
+	Latency              int64                  `gorm:"column:latency"`
+	Error                string                 `gorm:"column:error"`
+	CertificateExpiresAt sql.NullTime           `gorm:"column:certificate_expires_at"`
+	Workers              int                    `gorm:"column:workers"`
+	ReadyWorkers         int                    `gorm:"column:ready_workers"`
+}
+
+func (*NodeProbe) TableName() string {
+	return "node_probe"
+}
+
+func (n *NodeProbe) Import(probe *schema.NodeProbe) {
+	n.NodeAddress = probe.NodeAddress
+	n.Target = probe.Target
+	n.ProbedAt = probe.ProbedAt
+	n.StatusCode = probe.StatusCode
+	n.Latency = probe.Latency.Milliseconds()
+	n.Error = probe.Error
+	n.Workers = probe.Workers
+	n.ReadyWorkers = probe.ReadyWorkers
+
+	if probe.CertificateExpiresAt != nil {
+		n.CertificateExpiresAt = sql.NullTime{Time: *probe.CertificateExpiresAt, Valid: true}
+	}
+}
+
+func (n *NodeProbe) Export() *schema.NodeProbe {
+	probe := schema.NodeProbe{
+		NodeAddress:  n.NodeAddress,
+		Target:       n.Target,
+		StatusCode:   n.StatusCode,
+		Latency:      time.Duration(n.Latency) * time.Millisecond,
+		Error:        n.Error,
+		Workers:      n.Workers,
+		ReadyWorkers: n.ReadyWorkers,
+		ProbedAt:     n.ProbedAt,
+	}
+
+	if n.CertificateExpiresAt.Valid {
+		probe.CertificateExpiresAt = &n.CertificateExpiresAt.Time
+	}
+
+	return &probe
+}
+
+type NodeProbes []NodeProbe
+
+func (n *NodeProbes) Import(probes []*schema.NodeProbe) {
+	*n = make([]NodeProbe, 0, len(probes))
+
+	for _, probe := range probes {
+		var tProbe NodeProbe
+
+		tProbe.Import(probe)
+
+		*n = append(*n, tProbe)
+	}
+}
+
+func (n *NodeProbes) Export() []*schema.NodeProbe {
+	probes := make([]*schema.NodeProbe, 0, len(*n))
+
+	for _, probe := range *n {
+		probes = append(probes, probe.Export())
+	}
+
+	return probes
+}
@@ -0,0 +1,66 @@
+package table
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+type NodeRank struct {
+	Metric       schema.LeaderboardMetric `gorm:"column:metric;primaryKey"`
+	Epochs       int                      `gorm:"column:epochs;primaryKey"`
+	EpochID      uint64                   `gorm:"column:epoch_id;primaryKey"`
+	NodeAddress  common.Address           `gorm:"column:node_address;primaryKey"`
+	Value        decimal.Decimal          `gorm:"column:value"`
+	Rank         int                      `gorm:"column:rank"`
+	PreviousRank *int                     `gorm:"column:previous_rank"`
+	CreatedAt    time.Time                `gorm:"column:created_at"`
+	UpdatedAt    time.Time                `gorm:"column:updated_at"`
+}
+
+func (*NodeRank) TableName() string {
+	return "node.ranks"
+}
+
+func (n *NodeRank) Import(rank *schema.NodeRank) {
+	n.Metric = rank.Metric
+	n.Epochs = rank.Epochs
+	n.EpochID = rank.EpochID
+	n.NodeAddress = rank.NodeAddress
+	n.Value = rank.Value
+	n.Rank = rank.Rank
+	n.PreviousRank = rank.PreviousRank
+}
+
+func (n *NodeRank) Export() *schema.NodeRank {
+	return &schema.NodeRank{
+		Metric:       n.Metric,
+		Epochs:       n.Epochs,
+		EpochID:      n.EpochID,
+		NodeAddress:  n.NodeAddress,
+		Value:        n.Value,
+		Rank:         n.Rank,
+		PreviousRank: n.PreviousRank,
+	}
+}
+
+type NodeRanks []NodeRank
+
+func (n *NodeRanks) Import(ranks []*schema.NodeRank) {
+	*n = lo.Map(ranks, func(rank *schema.NodeRank, _ int) NodeRank {
+		var tRank NodeRank
+
+		tRank.Import(rank)
+
+		return tRank
+	})
+}
+
+func (n NodeRanks) Export() []*schema.NodeRank {
+	return lo.Map(n, func(rank NodeRank, _ int) *schema.NodeRank {
+		return rank.Export()
+	})
+}
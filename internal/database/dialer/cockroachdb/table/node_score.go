@@ -0,0 +1,85 @@
+package table
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+)
+
+type NodeScore struct {
+	NodeAddress  common.Address  `gorm:"column:node_address;primaryKey"`
+	EpochID      uint64          `gorm:"column:epoch_id;primaryKey"`
+	ModelVersion string          `gorm:"column:model_version"`
+	Score        float64         `gorm:"column:score"`
+	Components   json.RawMessage `gorm:"column:components;type:jsonb"`
+	UpdatedAt    time.Time       `gorm:"column:updated_at"`
+}
+
+func (*NodeScore) TableName() string {
+	return "node_score"
+}
+
+func (n *NodeScore) Import(score *schema.NodeScore) (err error) {
+	n.NodeAddress = score.NodeAddress
+	n.EpochID = score.EpochID
+	n.ModelVersion = score.ModelVersion
+	n.Score = score.Score
+	n.UpdatedAt = time.Unix(score.UpdatedAt, 0)
+
+	if n.Components, err = json.Marshal(score.Components); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *NodeScore) Export() (*schema.NodeScore, error) {
+	score := schema.NodeScore{
+		NodeAddress:  n.NodeAddress,
+		EpochID:      n.EpochID,
+		ModelVersion: n.ModelVersion,
+		Score:        n.Score,
+		UpdatedAt:    n.UpdatedAt.Unix(),
+	}
+
+	if err := json.Unmarshal(n.Components, &score.Components); err != nil {
+		return nil, err
+	}
+
+	return &score, nil
+}
+
+type NodeScores []NodeScore
+
+func (n *NodeScores) Import(scores []*schema.NodeScore) error {
+	*n = make([]NodeScore, 0, len(scores))
+
+	for _, score := range scores {
+		var tScore NodeScore
+
+		if err := tScore.Import(score); err != nil {
+			return err
+		}
+
+		*n = append(*n, tScore)
+	}
+
+	return nil
+}
+
+func (n *NodeScores) Export() ([]*schema.NodeScore, error) {
+	scores := make([]*schema.NodeScore, 0, len(*n))
+
+	for _, score := range *n {
+		exported, err := score.Export()
+		if err != nil {
+			return nil, err
+		}
+
+		scores = append(scores, exported)
+	}
+
+	return scores, nil
+}
@@ -19,11 +19,13 @@ type Stat struct {
 	Epoch                int64          `gorm:"column:epoch"`
 	TotalRequest         int64          `gorm:"column:total_request_count"`
 	EpochRequest         int64          `gorm:"column:epoch_request_count"`
+	LastEpochRequest     int64          `gorm:"column:last_epoch_request_count"`
 	EpochInvalidRequest  int64          `gorm:"column:epoch_invalid_request_count"`
 	DecentralizedNetwork int            `gorm:"column:decentralized_network_count"`
 	FederatedNetwork     int            `gorm:"column:federated_network_count"`
 	Indexer              int            `gorm:"column:indexer_count"`
 	ResetAt              time.Time      `gorm:"column:reset_at"`
+	ProbeFailureRate     float64        `gorm:"column:probe_failure_rate"`
 	CreatedAt            time.Time      `gorm:"column:created_at"`
 	UpdatedAt            time.Time      `gorm:"column:updated_at"`
 }
@@ -44,11 +46,13 @@ func (s *Stat) Import(stat *schema.Stat) (err error) {
 	s.Epoch = stat.Epoch
 	s.TotalRequest = stat.TotalRequest
 	s.EpochRequest = stat.EpochRequest
+	s.LastEpochRequest = stat.LastEpochRequest
 	s.EpochInvalidRequest = stat.EpochInvalidRequest
 	s.DecentralizedNetwork = stat.DecentralizedNetwork
 	s.FederatedNetwork = stat.FederatedNetwork
 	s.Indexer = stat.Indexer
 	s.ResetAt = stat.ResetAt
+	s.ProbeFailureRate = stat.ProbeFailureRate
 
 	return nil
 }
@@ -66,11 +70,13 @@ func (s *Stat) Export() (*schema.Stat, error) {
 		Epoch:                s.Epoch,
 		TotalRequest:         s.TotalRequest,
 		EpochRequest:         s.EpochRequest,
+		LastEpochRequest:     s.LastEpochRequest,
 		EpochInvalidRequest:  s.EpochInvalidRequest,
 		DecentralizedNetwork: s.DecentralizedNetwork,
 		FederatedNetwork:     s.FederatedNetwork,
 		Indexer:              s.Indexer,
 		ResetAt:              s.ResetAt,
+		ProbeFailureRate:     s.ProbeFailureRate,
 	}
 
 	return &stat, nil
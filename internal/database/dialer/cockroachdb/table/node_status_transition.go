@@ -0,0 +1,65 @@
+package table
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+)
+
+type NodeStatusTransition struct {
+	ID          uint64                            `gorm:"column:id;primaryKey"`
+	NodeAddress common.Address                    `gorm:"column:node_address"`
+	From        schema.NodeStatus                 `gorm:"column:from"`
+	To          schema.NodeStatus                 `gorm:"column:to"`
+	Reason      schema.NodeStatusTransitionReason `gorm:"column:reason"`
+	Timestamp   time.Time                         `gorm:"column:timestamp"`
+}
+
+func (*NodeStatusTransition) TableName() string {
+	return "node_status_transition"
+}
+
+func (n *NodeStatusTransition) Import(transition *schema.NodeStatusTransition) {
+	n.ID = transition.ID
+	n.NodeAddress = transition.NodeAddress
+	n.From = transition.From
+	n.To = transition.To
+	n.Reason = transition.Reason
+	n.Timestamp = time.Unix(transition.Timestamp, 0)
+}
+
+func (n *NodeStatusTransition) Export() *schema.NodeStatusTransition {
+	return &schema.NodeStatusTransition{
+		ID:          n.ID,
+		NodeAddress: n.NodeAddress,
+		From:        n.From,
+		To:          n.To,
+		Reason:      n.Reason,
+		Timestamp:   n.Timestamp.Unix(),
+	}
+}
+
+type NodeStatusTransitions []NodeStatusTransition
+
+func (n *NodeStatusTransitions) Import(transitions []*schema.NodeStatusTransition) {
+	*n = make([]NodeStatusTransition, 0, len(transitions))
+
+	for _, transition := range transitions {
+		var tTransition NodeStatusTransition
+
+		tTransition.Import(transition)
+
+		*n = append(*n, tTransition)
+	}
+}
+
+func (n *NodeStatusTransitions) Export() []*schema.NodeStatusTransition {
+	transitions := make([]*schema.NodeStatusTransition, 0, len(*n))
+
+	for _, transition := range *n {
+		transitions = append(transitions, transition.Export())
+	}
+
+	return transitions
+}
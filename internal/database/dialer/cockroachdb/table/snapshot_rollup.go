@@ -0,0 +1,59 @@
+package table
+
+import (
+	"time"
+
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/shopspring/decimal"
+)
+
+type SnapshotRollup struct {
+	Series      schema.SnapshotSeries      `gorm:"column:series"`
+	Address     []byte                     `gorm:"column:address"`
+	Granularity schema.SnapshotGranularity `gorm:"column:granularity"`
+	Bucket      time.Time                  `gorm:"column:bucket"`
+	Last        decimal.Decimal            `gorm:"column:last"`
+	Average     decimal.Decimal            `gorm:"column:average"`
+	Minimum     decimal.Decimal            `gorm:"column:minimum"`
+	Maximum     decimal.Decimal            `gorm:"column:maximum"`
+	Samples     int64                      `gorm:"column:samples"`
+	CreatedAt   time.Time                  `gorm:"column:created_at"`
+	UpdatedAt   time.Time                  `gorm:"column:updated_at"`
+}
+
+func (*SnapshotRollup) TableName() string {
+	return "snapshot.rollups"
+}
+
+// Export returns the point of the bucket by the aggregation.
+func (s *SnapshotRollup) Export(aggregation schema.SnapshotAggregation) *schema.SnapshotPoint {
+	point := schema.SnapshotPoint{
+		Date:    s.Bucket,
+		Samples: s.Samples,
+	}
+
+	switch aggregation {
+	case schema.SnapshotAggregationAvg:
+		point.Value = s.Average
+	case schema.SnapshotAggregationMin:
+		point.Value = s.Minimum
+	case schema.SnapshotAggregationMax:
+		point.Value = s.Maximum
+	default:
+		point.Value = s.Last
+	}
+
+	return &point
+}
+
+type SnapshotRollups []*SnapshotRollup
+
+func (s SnapshotRollups) Export(aggregation schema.SnapshotAggregation) []*schema.SnapshotPoint {
+	points := make([]*schema.SnapshotPoint, 0, len(s))
+
+	for _, rollup := range s {
+		points = append(points, rollup.Export(aggregation))
+	}
+
+	return points
+}
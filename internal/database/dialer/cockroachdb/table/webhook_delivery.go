@@ -0,0 +1,81 @@
+package table
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/rss3-network/global-indexer/schema"
+)
+
+type WebhookDelivery struct {
+	ID             uint64                       `gorm:"column:id;primaryKey"`
+	SubscriptionID uint64                       `gorm:"column:subscription_id"`
+	EventID        uint64                       `gorm:"column:event_id"`
+	EventType      schema.WebhookEventType      `gorm:"column:event_type"`
+	Payload        json.RawMessage              `gorm:"column:payload;type:jsonb"`
+	Status         schema.WebhookDeliveryStatus `gorm:"column:status"`
+	Attempts       int                          `gorm:"column:attempts"`
+	NextAttemptAt  time.Time                    `gorm:"column:next_attempt_at"`
+	ResponseStatus int                          `gorm:"column:response_status"`
+	Error          string                       `gorm:"column:error"`
+	CreatedAt      time.Time                    `gorm:"column:created_at"`
+	UpdatedAt      time.Time                    `gorm:"column:updated_at"`
+}
+
+func (*WebhookDelivery) TableName() string {
+	return "webhook_delivery"
+}
+
+func (w *WebhookDelivery) Import(delivery *schema.WebhookDelivery) {
+	w.ID = delivery.ID
+	w.SubscriptionID = delivery.SubscriptionID
+	w.EventID = delivery.EventID
+	w.EventType = delivery.EventType
+	w.Payload = delivery.Payload
+	w.Status = delivery.Status
+	w.Attempts = delivery.Attempts
+	w.NextAttemptAt = time.Unix(delivery.NextAttemptAt, 0)
+	w.ResponseStatus = delivery.ResponseStatus
+	w.Error = delivery.Error
+}
+
+func (w *WebhookDelivery) Export() *schema.WebhookDelivery {
+	return &schema.WebhookDelivery{
+		ID:             w.ID,
+		SubscriptionID: w.SubscriptionID,
+		EventID:        w.EventID,
+		EventType:      w.EventType,
+		Payload:        w.Payload,
+		Status:         w.Status,
+		Attempts:       w.Attempts,
+		NextAttemptAt:  w.NextAttemptAt.Unix(),
+		ResponseStatus: w.ResponseStatus,
+		Error:          w.Error,
+		CreatedAt:      w.CreatedAt.Unix(),
+		UpdatedAt:      w.UpdatedAt.Unix(),
+	}
+}
+
+type WebhookDeliveries []WebhookDelivery
+
+func (w *WebhookDeliveries) Import(deliveries []*schema.WebhookDelivery) {
+	*w = make([]WebhookDelivery, 0, len(deliveries))
+
+	for _, delivery := range deliveries {
+		var tDelivery WebhookDelivery
+
+		tDelivery.Import(delivery)
+
+		*w = append(*w, tDelivery)
+	}
+}
+
+func (w *WebhookDeliveries) Export() []*schema.WebhookDelivery {
+	deliveries := make([]*schema.WebhookDelivery, 0, len(*w))
+
+	for _, delivery := range *w {
+		deliveries = append(deliveries, delivery.Export())
+	}
+
+	return deliveries
+}
@@ -0,0 +1,86 @@
+package table
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lib/pq"
+	"github.com/rss3-network/global-indexer/schema"
+)
+
+type WebhookEvent struct {
+	ID          uint64                  `gorm:"column:id;primaryKey"`
+	Key         string                  `gorm:"column:key"`
+	Type        schema.WebhookEventType `gorm:"column:type"`
+	Addresses   pq.ByteaArray           `gorm:"column:addresses;type:bytea[]"`
+	Data        json.RawMessage         `gorm:"column:data;type:jsonb"`
+	Timestamp   time.Time               `gorm:"column:timestamp"`
+	ScheduledAt time.Time               `gorm:"column:scheduled_at"`
+	Dispatched  bool                    `gorm:"column:dispatched"`
+	CreatedAt   time.Time               `gorm:"column:created_at"`
+	UpdatedAt   time.Time               `gorm:"column:updated_at"`
+}
+
+func (*WebhookEvent) TableName() string {
+	return "webhook_event"
+}
+
+func (w *WebhookEvent) Import(event *schema.WebhookEvent) {
+	w.ID = event.ID
+	w.Key = event.Key
+	w.Type = event.Type
+	w.Addresses = make(pq.ByteaArray, 0, len(event.Addresses))
+
+	for _, address := range event.Addresses {
+		w.Addresses = append(w.Addresses, address.Bytes())
+	}
+
+	w.Data = event.Data
+	w.Timestamp = time.Unix(event.Timestamp, 0)
+	w.ScheduledAt = time.Unix(event.ScheduledAt, 0)
+	w.Dispatched = event.Dispatched
+}
+
+func (w *WebhookEvent) Export() *schema.WebhookEvent {
+	addresses := make([]common.Address, len(w.Addresses))
+
+	for i, address := range w.Addresses {
+		addresses[i] = common.BytesToAddress(address)
+	}
+
+	return &schema.WebhookEvent{
+		ID:          w.ID,
+		Key:         w.Key,
+		Type:        w.Type,
+		Addresses:   addresses,
+		Data:        w.Data,
+		Timestamp:   w.Timestamp.Unix(),
+		ScheduledAt: w.ScheduledAt.Unix(),
+		Dispatched:  w.Dispatched,
+	}
+}
+
+type WebhookEvents []WebhookEvent
+
+func (w *WebhookEvents) Import(events []*schema.WebhookEvent) {
+	*w = make([]WebhookEvent, 0, len(events))
+
+	for _, event := range events {
+		var tEvent WebhookEvent
+
+		tEvent.Import(event)
+
+		*w = append(*w, tEvent)
+	}
+}
+
+func (w *WebhookEvents) Export() []*schema.WebhookEvent {
+	events := make([]*schema.WebhookEvent, 0, len(*w))
+
+	for _, event := range *w {
+		events = append(events, event.Export())
+	}
+
+	return events
+}
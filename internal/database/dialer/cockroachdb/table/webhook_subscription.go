@@ -0,0 +1,75 @@
+package table
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lib/pq"
+	"github.com/rss3-network/global-indexer/schema"
+)
+
+type WebhookSubscription struct {
+	ID         uint64         `gorm:"column:id;primaryKey"`
+	Address    common.Address `gorm:"column:address"`
+	URL        string         `gorm:"column:url"`
+	EventTypes pq.StringArray `gorm:"column:event_types;type:text[]"`
+	Secret     string         `gorm:"column:secret"`
+	CreatedAt  time.Time      `gorm:"column:created_at"`
+	UpdatedAt  time.Time      `gorm:"column:updated_at"`
+}
+
+func (*WebhookSubscription) TableName() string {
+	return "webhook_subscription"
+}
+
+func (w *WebhookSubscription) Import(subscription *schema.WebhookSubscription) {
+	w.ID = subscription.ID
+	w.Address = subscription.Address
+	w.URL = subscription.URL
+	w.EventTypes = make(pq.StringArray, 0, len(subscription.EventTypes))
+
+	for _, eventType := range subscription.EventTypes {
+		w.EventTypes = append(w.EventTypes, eventType.String())
+	}
+
+	w.Secret = subscription.Secret
+}
+
+func (w *WebhookSubscription) Export() (*schema.WebhookSubscription, error) {
+	subscription := schema.WebhookSubscription{
+		ID:         w.ID,
+		Address:    w.Address,
+		URL:        w.URL,
+		EventTypes: make([]schema.WebhookEventType, 0, len(w.EventTypes)),
+		Secret:     w.Secret,
+		CreatedAt:  w.CreatedAt.Unix(),
+	}
+
+	for _, value := range w.EventTypes {
+		eventType, err := schema.WebhookEventTypeString(value)
+		if err != nil {
+			return nil, err
+		}
+
+		subscription.EventTypes = append(subscription.EventTypes, eventType)
+	}
+
+	return &subscription, nil
+}
+
+type WebhookSubscriptions []WebhookSubscription
+
+func (w *WebhookSubscriptions) Export() ([]*schema.WebhookSubscription, error) {
+	subscriptions := make([]*schema.WebhookSubscription, 0, len(*w))
+
+	for _, subscription := range *w {
+		exported, err := subscription.Export()
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, exported)
+	}
+
+	return subscriptions, nil
+}
@@ -0,0 +1,105 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/internal/webhook"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+)
+
+// CanTransit returns true if a Node in the from status is allowed to transit to the to status.
+func CanTransit(from, to schema.NodeStatus) bool {
+	return nta.UpdateNodeStatus(&schema.Node{Status: from}, to) == nil
+}
+
+// Transit transits the Nodes to the status for the reason, the Nodes already in the status are skipped.
+// It persists the status of the Nodes, records the transitions and emits a NodeEvent and a webhook event for each of them.
+// It fails without changing any Node if one of them cannot transit to the status,
+// the caller should run it within a database transaction.
+func Transit(ctx context.Context, databaseClient database.Client, nodes []*schema.Node, to schema.NodeStatus, reason schema.NodeStatusTransitionReason) ([]*schema.NodeStatusTransition, error) {
+	nodes = lo.Filter(nodes, func(node *schema.Node, _ int) bool {
+		return node.Status != to
+	})
+
+	for _, node := range nodes {
+		if !CanTransit(node.Status, to) {
+			return nil, &nta.NodeStatusTransitionError{From: node.Status, To: to}
+		}
+	}
+
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+
+	timestamp := time.Now().Unix()
+
+	transitions := lo.Map(nodes, func(node *schema.Node, _ int) *schema.NodeStatusTransition {
+		return &schema.NodeStatusTransition{
+			NodeAddress: node.Address,
+			From:        node.Status,
+			To:          to,
+			Reason:      reason,
+			Timestamp:   timestamp,
+		}
+	})
+
+	if err := databaseClient.UpdateNodesStatus(ctx, lo.Map(nodes, func(node *schema.Node, _ int) common.Address { return node.Address }), to); err != nil {
+		return nil, fmt.Errorf("update nodes status: %w", err)
+	}
+
+	if err := databaseClient.SaveNodeStatusTransitions(ctx, transitions); err != nil {
+		return nil, fmt.Errorf("save node status transitions: %w", err)
+	}
+
+	events := make([]*schema.WebhookEvent, 0, len(transitions))
+
+	for i, node := range nodes {
+		node.Status = to
+
+		if err := databaseClient.SaveNodeEvent(ctx, newNodeEvent(node, transitions[i])); err != nil {
+			return nil, fmt.Errorf("save node event: %w", err)
+		}
+
+		event, err := webhook.NewNodeStatusChangedEvent(transitions[i])
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	if err := databaseClient.SaveWebhookEvents(ctx, events); err != nil {
+		return nil, fmt.Errorf("save webhook events: %w", err)
+	}
+
+	return transitions, nil
+}
+
+// newNodeEvent builds the NodeEvent of a status transition, the transaction hash is derived from the transition ID.
+func newNodeEvent(node *schema.Node, transition *schema.NodeStatusTransition) *schema.NodeEvent {
+	return &schema.NodeEvent{
+		TransactionHash: crypto.Keccak256Hash([]byte(fmt.Sprintf("node_status_transition:%d", transition.ID))),
+		NodeID:          lo.Ternary(node.ID != nil, node.ID, big.NewInt(0)),
+		AddressFrom:     node.Address,
+		Type:            schema.NodeEventNodeStatusChanged,
+		BlockNumber:     big.NewInt(0),
+		BlockTimestamp:  transition.Timestamp,
+		Metadata: schema.NodeEventMetadata{
+			NodeStatusChangedMetadata: &schema.NodeStatusChangedMetadata{
+				Address: node.Address,
+				From:    transition.From,
+				To:      transition.To,
+				Reason:  transition.Reason,
+			},
+		},
+		Finalized: true,
+	}
+}
@@ -0,0 +1,101 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/lifecycle"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/stretchr/testify/require"
+)
+
+type databaseClient struct {
+	database.Client
+
+	statuses      map[common.Address]schema.NodeStatus
+	transitions   []*schema.NodeStatusTransition
+	events        []*schema.NodeEvent
+	webhookEvents []*schema.WebhookEvent
+}
+
+func (c *databaseClient) UpdateNodesStatus(_ context.Context, nodeAddresses []common.Address, status schema.NodeStatus) error {
+	for _, address := range nodeAddresses {
+		c.statuses[address] = status
+	}
+
+	return nil
+}
+
+func (c *databaseClient) SaveNodeStatusTransitions(_ context.Context, transitions []*schema.NodeStatusTransition) error {
+	for _, transition := range transitions {
+		transition.ID = uint64(len(c.transitions))
+		c.transitions = append(c.transitions, transition)
+	}
+
+	return nil
+}
+
+func (c *databaseClient) SaveNodeEvent(_ context.Context, nodeEvent *schema.NodeEvent) error {
+	c.events = append(c.events, nodeEvent)
+
+	return nil
+}
+
+func (c *databaseClient) SaveWebhookEvents(_ context.Context, events []*schema.WebhookEvent) error {
+	c.webhookEvents = append(c.webhookEvents, events...)
+
+	return nil
+}
+
+func TestTransit(t *testing.T) {
+	t.Parallel()
+
+	var (
+		online  = &schema.Node{Address: common.HexToAddress("0x01"), Status: schema.NodeStatusOnline}
+		offline = &schema.Node{Address: common.HexToAddress("0x02"), Status: schema.NodeStatusOffline}
+		exiting = &schema.Node{Address: common.HexToAddress("0x03"), Status: schema.NodeStatusExiting}
+		client  = &databaseClient{statuses: make(map[common.Address]schema.NodeStatus)}
+		ctx     = context.Background()
+	)
+
+	// An exiting Node cannot go offline, so none of the Nodes transits.
+	_, err := lifecycle.Transit(ctx, client, []*schema.Node{online, exiting}, schema.NodeStatusOffline, schema.NodeStatusTransitionReasonHeartbeatTimeout)
+
+	var transitionError *nta.NodeStatusTransitionError
+	require.True(t, errors.As(err, &transitionError))
+	require.Equal(t, schema.NodeStatusOnline, online.Status)
+	require.Empty(t, client.statuses)
+
+	// The offline Node is skipped.
+	transitions, err := lifecycle.Transit(ctx, client, []*schema.Node{online, offline}, schema.NodeStatusOffline, schema.NodeStatusTransitionReasonHeartbeatTimeout)
+	require.NoError(t, err)
+	require.Len(t, transitions, 1)
+	require.Equal(t, schema.NodeStatusOffline, online.Status)
+	require.Equal(t, map[common.Address]schema.NodeStatus{online.Address: schema.NodeStatusOffline}, client.statuses)
+
+	transitions, err = lifecycle.Transit(ctx, client, []*schema.Node{online, exiting}, schema.NodeStatusExited, schema.NodeStatusTransitionReasonLongOffline)
+	require.NoError(t, err)
+	require.Len(t, transitions, 2)
+	require.Len(t, client.events, 3)
+
+	event := client.events[2]
+	require.Equal(t, schema.NodeEventNodeStatusChanged, event.Type)
+	require.Equal(t, exiting.Address, event.AddressFrom)
+	require.Equal(t, &schema.NodeStatusChangedMetadata{
+		Address: exiting.Address,
+		From:    schema.NodeStatusExiting,
+		To:      schema.NodeStatusExited,
+		Reason:  schema.NodeStatusTransitionReasonLongOffline,
+	}, event.Metadata.NodeStatusChangedMetadata)
+	require.NotEqual(t, client.events[1].TransactionHash, event.TransactionHash)
+
+	// A webhook event is emitted for each transition.
+	require.Len(t, client.webhookEvents, 3)
+	require.Equal(t, schema.WebhookEventTypeNodeStatusChanged, client.webhookEvents[2].Type)
+	require.Equal(t, []common.Address{exiting.Address}, client.webhookEvents[2].Addresses)
+	require.NotEqual(t, client.webhookEvents[1].Key, client.webhookEvents[2].Key)
+}
@@ -0,0 +1,123 @@
+package nameresolver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/config"
+)
+
+var (
+	// AddressENSRegistry is the ENS registry on Ethereum.
+	AddressENSRegistry = common.HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e")
+	// AddressBasenamesRegistry is the Basenames registry on Base.
+	AddressBasenamesRegistry = common.HexToAddress("0xB94704422c2a1E396835A571837Aa5AE53285a95")
+	// AddressSpaceIDRegistryBSC is the Space ID registry of .bnb names on BNB Smart Chain.
+	AddressSpaceIDRegistryBSC = common.HexToAddress("0x08CEd32a7f3eeC915Ba84415e9C07a7286977956")
+	// AddressSpaceIDRegistryArbitrum is the Space ID registry of .arb names on Arbitrum One.
+	AddressSpaceIDRegistryArbitrum = common.HexToAddress("0x4a067EE58e73ac5E4a43722E008DFdf65B2bF348")
+)
+
+// unstoppableSuffixes are the top-level domains of Unstoppable Domains.
+var unstoppableSuffixes = []string{
+	"crypto", "nft", "x", "wallet", "bitcoin", "dao", "888", "zil", "blockchain", "polygon", "unstoppable", "klever", "hi", "kresus", "anime", "manga", "binanceus", "go", "pudgy",
+}
+
+// Backend resolves the names of a name service to EVM addresses.
+// A name that is not registered on the name service is reported with ErrUnregisterName.
+type Backend interface {
+	Resolve(ctx context.Context, name string) (string, error)
+}
+
+// BackendFunc adapts a function to a Backend.
+type BackendFunc func(ctx context.Context, name string) (string, error)
+
+func (f BackendFunc) Resolve(ctx context.Context, name string) (string, error) {
+	return f(ctx, name)
+}
+
+// Register registers the backend of the names ending with the suffix, e.g. base.eth or bnb.
+// A name is resolved by the backend of its longest registered suffix, so that alice.base.eth goes to base.eth rather than eth.
+// Register must not be called concurrently with Resolve.
+func (n *NameResolver) Register(suffix string, backend Backend) {
+	n.backends[strings.ToLower(strings.Trim(suffix, "."))] = backend
+}
+
+// lookup finds the backend of the longest registered suffix of a name.
+func (n *NameResolver) lookup(name string) (Backend, bool) {
+	labels := strings.Split(strings.ToLower(name), ".")
+
+	for i := 1; i < len(labels); i++ {
+		if backend, ok := n.backends[strings.Join(labels[i:], ".")]; ok {
+			return backend, true
+		}
+	}
+
+	return nil, false
+}
+
+type unstoppableDomainResponse struct {
+	Meta struct {
+		Owner *string `json:"owner"`
+	} `json:"meta"`
+	Records map[string]string `json:"records"`
+}
+
+// unstoppableBackend resolves Unstoppable Domains with the resolution API, which covers both Ethereum and Polygon.
+type unstoppableBackend struct {
+	client *apiClient
+}
+
+func (b *unstoppableBackend) Resolve(ctx context.Context, name string) (string, error) {
+	var response unstoppableDomainResponse
+
+	if err := b.client.get(ctx, fmt.Sprintf("/resolve/domains/%s", url.PathEscape(strings.ToLower(name))), &response); err != nil {
+		return "", fmt.Errorf("failed to get unstoppable domain: %w", err)
+	}
+
+	// The address record takes precedence over the owner, as the owner may be a different wallet.
+	if address := response.Records["crypto.ETH.address"]; common.IsHexAddress(address) {
+		return common.HexToAddress(address).String(), nil
+	}
+
+	if response.Meta.Owner == nil || !common.IsHexAddress(*response.Meta.Owner) || common.HexToAddress(*response.Meta.Owner) == (common.Address{}) {
+		return "", fmt.Errorf("%s", ErrUnregisterName)
+	}
+
+	return common.HexToAddress(*response.Meta.Owner).String(), nil
+}
+
+func newUnstoppableBackend(endpoint *config.RPCEndpoint) (*unstoppableBackend, error) {
+	client, err := newAPIClient(&config.RPCEndpoint{Endpoint: endpoint.Endpoint})
+	if err != nil {
+		return nil, err
+	}
+
+	// The resolution API authenticates with a bearer token instead of the api_key header.
+	if endpoint.APIkey != "" {
+		client.httpClient = &http.Client{
+			Transport: &bearerTransport{
+				token:        endpoint.APIkey,
+				roundTripper: http.DefaultTransport,
+			},
+		}
+	}
+
+	return &unstoppableBackend{client: client}, nil
+}
+
+type bearerTransport struct {
+	token string
+
+	roundTripper http.RoundTripper
+}
+
+func (b *bearerTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	request.Header.Set("Authorization", "Bearer "+b.token)
+
+	return b.roundTripper.RoundTrip(request)
+}
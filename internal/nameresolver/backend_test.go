@@ -0,0 +1,95 @@
+package nameresolver_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/config"
+	"github.com/rss3-network/global-indexer/internal/nameresolver"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNameResolver_Register(t *testing.T) {
+	t.Parallel()
+
+	nr, err := nameresolver.NewNameResolver(context.Background(), &config.RPC{RPCNetwork: &config.RPCNetwork{}}, nil)
+	require.NoError(t, err)
+
+	backend := func(address string) nameresolver.Backend {
+		return nameresolver.BackendFunc(func(context.Context, string) (string, error) {
+			return address, nil
+		})
+	}
+
+	nr.Register("eth", backend("ens"))
+	nr.Register(".base.eth", backend("basenames"))
+
+	tests := []struct {
+		name   string
+		input  string
+		output string
+	}{
+		{name: "ens", input: "vitalik.eth", output: "ens"},
+		{name: "basenames", input: "alice.base.eth", output: "basenames"},
+		{name: "basenames uppercase", input: "Alice.Base.ETH", output: "basenames"},
+		{name: "ens parent of basenames", input: "base.eth", output: "ens"},
+		{name: "ens subname", input: "alice.vitalik.eth", output: "ens"},
+	}
+
+	for _, tt := range tests {
+		address, err := nr.Resolve(context.Background(), tt.input)
+		require.NoError(t, err, tt.name)
+		require.Equal(t, tt.output, address, tt.name)
+	}
+
+	_, err = nr.Resolve(context.Background(), "alice.bnb")
+	require.ErrorContains(t, err, nameresolver.ErrUnSupportName)
+}
+
+func TestNameResolver_Unstoppable(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		response := map[string]any{"meta": map[string]any{"owner": nil}, "records": map[string]string{}}
+
+		switch strings.TrimPrefix(r.URL.Path, "/resolve/domains/") {
+		case "owner.crypto":
+			response["meta"] = map[string]any{"owner": "0xe5d6216f0085a7f6b9b692e06cf5856e6fa41b55"}
+		case "record.x":
+			response["meta"] = map[string]any{"owner": "0xe5d6216f0085a7f6b9b692e06cf5856e6fa41b55"}
+			response["records"] = map[string]string{"crypto.ETH.address": "0x8aad44321a86b170879d7a244c1e8d360c99dda8"}
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	nr, err := nameresolver.NewNameResolver(context.Background(), &config.RPC{
+		RPCNetwork: &config.RPCNetwork{
+			Unstoppable: &config.RPCEndpoint{Endpoint: server.URL, APIkey: "secret"},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	address, err := nr.Resolve(context.Background(), "owner.crypto")
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0xe5d6216f0085a7f6b9b692e06cf5856e6fa41b55").String(), address)
+
+	address, err = nr.Resolve(context.Background(), "record.x")
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0x8aad44321a86b170879d7a244c1e8d360c99dda8").String(), address)
+
+	_, err = nr.Resolve(context.Background(), "missing.nft")
+	require.ErrorContains(t, err, nameresolver.ErrUnregisterName)
+}
@@ -0,0 +1,94 @@
+package nameresolver_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/internal/cache"
+	"github.com/rss3-network/global-indexer/internal/config"
+	"github.com/rss3-network/global-indexer/internal/nameresolver"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryCache struct {
+	cache.Client
+
+	locker sync.Mutex
+	values map[string][]byte
+}
+
+func (m *memoryCache) Get(_ context.Context, key string, dest interface{}) error {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	data, ok := m.values[key]
+	if !ok {
+		return redis.Nil
+	}
+
+	return json.Unmarshal(data, dest)
+}
+
+func (m *memoryCache) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	m.values[key] = data
+
+	return nil
+}
+
+func TestNameResolver_Cache(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		if r.URL.Query().Get("name") != "registered" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(nameresolver.UserNameProof{Name: "registered", Owner: "0xe5d6216f0085a7f6b9b692e06cf5856e6fa41b55"})
+	}))
+	defer server.Close()
+
+	nr, err := nameresolver.NewNameResolver(context.Background(), &config.RPC{
+		RPCNetwork: &config.RPCNetwork{
+			Farcaster: &config.RPCEndpoint{Endpoint: server.URL},
+		},
+	}, &memoryCache{values: make(map[string][]byte)})
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		address, err := nr.Resolve(context.Background(), "registered.fc")
+		require.NoError(t, err)
+		require.Equal(t, "0xe5d6216f0085a7f6b9b692e06cf5856e6fa41b55", address)
+
+		_, err = nr.Resolve(context.Background(), "unregistered.fc")
+		require.Error(t, err)
+		require.True(t, strings.Contains(err.Error(), nameresolver.ErrUnregisterName))
+	}
+
+	// Both the resolved and the unregistered names are served from the cache the second time.
+	require.Equal(t, int64(2), requests.Load())
+
+	addresses := nr.BatchResolve(context.Background(), []string{"registered.fc", "unregistered.fc", "unsupported.xxx"})
+	require.Equal(t, map[string]string{"registered.fc": "0xe5d6216f0085a7f6b9b692e06cf5856e6fa41b55"}, addresses)
+}
@@ -4,9 +4,13 @@ package nameresolver
 type NameService int
 
 const (
-	NameServiceUnknown   NameService = iota // unknown
-	NameServiceENS                          // eth
-	NameServiceCSB                          // csb
-	NameServiceLens                         // lens
-	NameServiceFarcaster                    // fc
+	NameServiceUnknown     NameService = iota // unknown
+	NameServiceENS                            // eth
+	NameServiceCSB                            // csb
+	NameServiceLens                           // lens
+	NameServiceFarcaster                      // fc
+	NameServiceBasenames                      // base.eth
+	NameServiceSpaceIDBNB                     // bnb
+	NameServiceSpaceIDARB                     // arb
+	NameServiceUnstoppable                    // unstoppable
 )
@@ -9,11 +9,11 @@ import (
 	"strings"
 )
 
-const _NameServiceName = "unknownethcsblensfc"
+const _NameServiceName = "unknownethcsblensfcbase.ethbnbarbunstoppable"
 
-var _NameServiceIndex = [...]uint8{0, 7, 10, 13, 17, 19}
+var _NameServiceIndex = [...]uint8{0, 7, 10, 13, 17, 19, 27, 30, 33, 44}
 
-const _NameServiceLowerName = "unknownethcsblensfc"
+const _NameServiceLowerName = "unknownethcsblensfcbase.ethbnbarbunstoppable"
 
 func (i NameService) String() string {
 	if i < 0 || i >= NameService(len(_NameServiceIndex)-1) {
@@ -35,9 +35,13 @@ func _NameServiceNoOp() {
 	_ = x[NameServiceCSB-(2)]
 	_ = x[NameServiceLens-(3)]
 	_ = x[NameServiceFarcaster-(4)]
+	_ = x[NameServiceBasenames-(5)]
+	_ = x[NameServiceSpaceIDBNB-(6)]
+	_ = x[NameServiceSpaceIDARB-(7)]
+	_ = x[NameServiceUnstoppable-(8)]
 }
 
-var _NameServiceValues = []NameService{NameServiceUnknown, NameServiceENS, NameServiceCSB, NameServiceLens, NameServiceFarcaster}
+var _NameServiceValues = []NameService{NameServiceUnknown, NameServiceENS, NameServiceCSB, NameServiceLens, NameServiceFarcaster, NameServiceBasenames, NameServiceSpaceIDBNB, NameServiceSpaceIDARB, NameServiceUnstoppable}
 
 var _NameServiceNameToValueMap = map[string]NameService{
 	_NameServiceName[0:7]:        NameServiceUnknown,
@@ -50,6 +54,14 @@ var _NameServiceNameToValueMap = map[string]NameService{
 	_NameServiceLowerName[13:17]: NameServiceLens,
 	_NameServiceName[17:19]:      NameServiceFarcaster,
 	_NameServiceLowerName[17:19]: NameServiceFarcaster,
+	_NameServiceName[19:27]:      NameServiceBasenames,
+	_NameServiceLowerName[19:27]: NameServiceBasenames,
+	_NameServiceName[27:30]:      NameServiceSpaceIDBNB,
+	_NameServiceLowerName[27:30]: NameServiceSpaceIDBNB,
+	_NameServiceName[30:33]:      NameServiceSpaceIDARB,
+	_NameServiceLowerName[30:33]: NameServiceSpaceIDARB,
+	_NameServiceName[33:44]:      NameServiceUnstoppable,
+	_NameServiceLowerName[33:44]: NameServiceUnstoppable,
 }
 
 var _NameServiceNames = []string{
@@ -58,6 +70,10 @@ var _NameServiceNames = []string{
 	_NameServiceName[10:13],
 	_NameServiceName[13:17],
 	_NameServiceName[17:19],
+	_NameServiceName[19:27],
+	_NameServiceName[27:30],
+	_NameServiceName[30:33],
+	_NameServiceName[33:44],
 }
 
 // NameServiceString retrieves an enum value from the enum constants string name.
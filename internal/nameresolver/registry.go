@@ -0,0 +1,380 @@
+package nameresolver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	goens "github.com/wealdtech/go-ens/v3"
+)
+
+const (
+	// maxOffchainLookups is the maximum number of offchain lookups followed by a single call, as recommended by EIP-3668.
+	maxOffchainLookups = 4
+	// gatewayTimeout is the timeout of a request to a CCIP read gateway.
+	gatewayTimeout = 10 * time.Second
+)
+
+// extendedResolverInterfaceID is the interface ID of resolve(bytes,bytes) defined by ENSIP-10.
+var extendedResolverInterfaceID = [4]byte{0x90, 0x61, 0xb9, 0x23}
+
+const registryABIJSON = `[
+	{"type":"function","name":"resolver","stateMutability":"view","inputs":[{"name":"node","type":"bytes32"}],"outputs":[{"name":"","type":"address"}]},
+	{"type":"function","name":"supportsInterface","stateMutability":"view","inputs":[{"name":"interfaceID","type":"bytes4"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"addr","stateMutability":"view","inputs":[{"name":"node","type":"bytes32"}],"outputs":[{"name":"","type":"address"}]},
+	{"type":"function","name":"resolve","stateMutability":"view","inputs":[{"name":"name","type":"bytes"},{"name":"data","type":"bytes"}],"outputs":[{"name":"","type":"bytes"}]},
+	{"type":"error","name":"OffchainLookup","inputs":[{"name":"sender","type":"address"},{"name":"urls","type":"string[]"},{"name":"callData","type":"bytes"},{"name":"callbackFunction","type":"bytes4"},{"name":"extraData","type":"bytes"}]}
+]`
+
+var registryABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(registryABIJSON))
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+}()
+
+// registryBackend resolves names with an ENS compatible registry, which is shared by ENS, Basenames and Space ID.
+// A name without a resolver of its own is resolved by the resolver of its closest ancestor (ENSIP-10 wildcard resolution),
+// and the offchain lookups requested by a resolver are followed (EIP-3668 CCIP read), which is how most subnames are served.
+type registryBackend struct {
+	ethClient  *ethclient.Client
+	registry   common.Address
+	httpClient *http.Client
+}
+
+func newRegistryBackend(ethClient *ethclient.Client, registry common.Address) *registryBackend {
+	return &registryBackend{
+		ethClient:  ethClient,
+		registry:   registry,
+		httpClient: &http.Client{Timeout: gatewayTimeout},
+	}
+}
+
+func (b *registryBackend) Resolve(ctx context.Context, name string) (string, error) {
+	name, err := goens.Normalize(name)
+	if err != nil {
+		return "", fmt.Errorf("normalize name: %w", err)
+	}
+
+	node, err := goens.NameHash(name)
+	if err != nil {
+		return "", fmt.Errorf("hash name: %w", err)
+	}
+
+	resolver, exact, err := b.findResolver(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	if resolver == (common.Address{}) {
+		return "", fmt.Errorf("%s", ErrUnregisterName)
+	}
+
+	addrData, err := registryABI.Pack("addr", node)
+	if err != nil {
+		return "", fmt.Errorf("pack addr: %w", err)
+	}
+
+	var result []byte
+
+	switch {
+	case b.supportsInterface(ctx, resolver, extendedResolverInterfaceID):
+		encodedName, err := dnsEncode(name)
+		if err != nil {
+			return "", err
+		}
+
+		data, err := registryABI.Pack("resolve", encodedName, addrData)
+		if err != nil {
+			return "", fmt.Errorf("pack resolve: %w", err)
+		}
+
+		if result, err = b.call(ctx, resolver, data); err != nil {
+			return "", fmt.Errorf("resolve %s: %w", name, err)
+		}
+
+		if result, err = unpackBytes("resolve", result); err != nil {
+			return "", err
+		}
+	case exact:
+		if result, err = b.call(ctx, resolver, addrData); err != nil {
+			return "", fmt.Errorf("resolve %s: %w", name, err)
+		}
+	default:
+		// The resolver of an ancestor only resolves the names it has no resolver for if it supports wildcard resolution.
+		return "", fmt.Errorf("%s", ErrUnregisterName)
+	}
+
+	values, err := registryABI.Unpack("addr", result)
+	if err != nil || len(values) == 0 {
+		return "", fmt.Errorf("unpack addr: %w", err)
+	}
+
+	address, ok := values[0].(common.Address)
+	if !ok || address == (common.Address{}) {
+		return "", fmt.Errorf("%s", ErrUnregisterName)
+	}
+
+	return address.String(), nil
+}
+
+// findResolver finds the resolver of the name, or of its closest ancestor that has one, exact reports the former.
+func (b *registryBackend) findResolver(ctx context.Context, name string) (resolver common.Address, exact bool, err error) {
+	labels := strings.Split(name, ".")
+
+	for i := 0; i < len(labels); i++ {
+		node, err := goens.NameHash(strings.Join(labels[i:], "."))
+		if err != nil {
+			return common.Address{}, false, fmt.Errorf("hash name: %w", err)
+		}
+
+		data, err := registryABI.Pack("resolver", node)
+		if err != nil {
+			return common.Address{}, false, fmt.Errorf("pack resolver: %w", err)
+		}
+
+		result, err := b.ethClient.CallContract(ctx, ethereum.CallMsg{To: &b.registry, Data: data}, nil)
+		if err != nil {
+			return common.Address{}, false, fmt.Errorf("get resolver: %w", err)
+		}
+
+		if len(result) < common.HashLength {
+			return common.Address{}, false, fmt.Errorf("invalid resolver of %s", name)
+		}
+
+		if resolver = common.BytesToAddress(result[:common.HashLength]); resolver != (common.Address{}) {
+			return resolver, i == 0, nil
+		}
+	}
+
+	return common.Address{}, false, nil
+}
+
+func (b *registryBackend) supportsInterface(ctx context.Context, contract common.Address, interfaceID [4]byte) bool {
+	data, err := registryABI.Pack("supportsInterface", interfaceID)
+	if err != nil {
+		return false
+	}
+
+	// A contract without ERC-165 reverts or returns nothing, either way it does not support the interface.
+	result, err := b.ethClient.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: data}, nil)
+	if err != nil || len(result) < common.HashLength {
+		return false
+	}
+
+	return result[common.HashLength-1] == 1
+}
+
+// offchainLookup is the OffchainLookup revert defined by EIP-3668.
+type offchainLookup struct {
+	Sender           common.Address `abi:"sender"`
+	URLs             []string       `abi:"urls"`
+	CallData         []byte         `abi:"callData"`
+	CallbackFunction [4]byte        `abi:"callbackFunction"`
+	ExtraData        []byte         `abi:"extraData"`
+}
+
+// call calls the contract and follows the offchain lookups it requests.
+func (b *registryBackend) call(ctx context.Context, contract common.Address, data []byte) ([]byte, error) {
+	lookupError := registryABI.Errors["OffchainLookup"]
+
+	for i := 0; i <= maxOffchainLookups; i++ {
+		result, err := b.ethClient.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: data}, nil)
+		if err == nil {
+			return result, nil
+		}
+
+		reverted, ok := revertData(err)
+		if !ok || !bytes.HasPrefix(reverted, lookupError.ID[:4]) {
+			return nil, err
+		}
+
+		if i == maxOffchainLookups {
+			return nil, fmt.Errorf("too many offchain lookups")
+		}
+
+		values, err := lookupError.Inputs.Unpack(reverted[4:])
+		if err != nil {
+			return nil, fmt.Errorf("unpack offchain lookup: %w", err)
+		}
+
+		var lookup offchainLookup
+
+		if err := lookupError.Inputs.Copy(&lookup, values); err != nil {
+			return nil, fmt.Errorf("copy offchain lookup: %w", err)
+		}
+
+		if lookup.Sender != contract {
+			return nil, fmt.Errorf("offchain lookup sender %s is not %s", lookup.Sender, contract)
+		}
+
+		response, err := b.fetch(ctx, &lookup)
+		if err != nil {
+			return nil, err
+		}
+
+		arguments, err := registryABI.Methods["resolve"].Inputs.Pack(response, lookup.ExtraData)
+		if err != nil {
+			return nil, fmt.Errorf("pack offchain lookup callback: %w", err)
+		}
+
+		data = append(lookup.CallbackFunction[:], arguments...)
+	}
+
+	return nil, fmt.Errorf("too many offchain lookups")
+}
+
+type gatewayRequest struct {
+	Data   string `json:"data"`
+	Sender string `json:"sender"`
+}
+
+type gatewayResponse struct {
+	Data string `json:"data"`
+}
+
+// fetch fetches the response of an offchain lookup from its gateways in order,
+// a gateway failing with a server error is skipped, while a client error fails the lookup.
+func (b *registryBackend) fetch(ctx context.Context, lookup *offchainLookup) ([]byte, error) {
+	var (
+		sender = strings.ToLower(lookup.Sender.String())
+		data   = hexutil.Encode(lookup.CallData)
+		errs   []error
+	)
+
+	for _, gatewayURL := range lookup.URLs {
+		gatewayURL = strings.ReplaceAll(gatewayURL, "{sender}", sender)
+
+		var (
+			request *http.Request
+			err     error
+		)
+
+		if strings.Contains(gatewayURL, "{data}") {
+			request, err = http.NewRequestWithContext(ctx, http.MethodGet, strings.ReplaceAll(gatewayURL, "{data}", data), nil)
+		} else {
+			body, _ := json.Marshal(gatewayRequest{Data: data, Sender: sender})
+
+			if request, err = http.NewRequestWithContext(ctx, http.MethodPost, gatewayURL, bytes.NewReader(body)); err == nil {
+				request.Header.Set("Content-Type", "application/json")
+			}
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("create gateway request: %w", err))
+
+			continue
+		}
+
+		result, err := b.fetchGateway(request)
+		if err == nil {
+			return result, nil
+		}
+
+		var statusError *gatewayStatusError
+		if errors.As(err, &statusError) && statusError.code >= http.StatusBadRequest && statusError.code < http.StatusInternalServerError {
+			return nil, err
+		}
+
+		errs = append(errs, err)
+	}
+
+	return nil, fmt.Errorf("offchain lookup: %w", errors.Join(errs...))
+}
+
+type gatewayStatusError struct {
+	host string
+	code int
+}
+
+func (e *gatewayStatusError) Error() string {
+	return fmt.Sprintf("gateway %s responded with status %d", e.host, e.code)
+}
+
+func (b *registryBackend) fetchGateway(request *http.Request) ([]byte, error) {
+	response, err := b.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("request gateway: %w", err)
+	}
+
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, &gatewayStatusError{host: request.URL.Host, code: response.StatusCode}
+	}
+
+	var result gatewayResponse
+
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode gateway response: %w", err)
+	}
+
+	return hexutil.Decode(result.Data)
+}
+
+// revertData extracts the revert data of a failed call.
+func revertData(err error) ([]byte, bool) {
+	var dataError rpc.DataError
+	if !errors.As(err, &dataError) {
+		return nil, false
+	}
+
+	encoded, ok := dataError.ErrorData().(string)
+	if !ok {
+		return nil, false
+	}
+
+	data, err := hexutil.Decode(encoded)
+	if err != nil {
+		return nil, false
+	}
+
+	return data, true
+}
+
+func unpackBytes(method string, data []byte) ([]byte, error) {
+	values, err := registryABI.Unpack(method, data)
+	if err != nil || len(values) == 0 {
+		return nil, fmt.Errorf("unpack %s: %w", method, err)
+	}
+
+	result, ok := values[0].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unpack %s: unexpected type %T", method, values[0])
+	}
+
+	return result, nil
+}
+
+// dnsEncode encodes a name in the DNS wire format, as required by resolve(bytes,bytes) of ENSIP-10.
+func dnsEncode(name string) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	for _, label := range strings.Split(name, ".") {
+		if len(label) == 0 || len(label) > 255 {
+			return nil, fmt.Errorf("invalid label %q of %s", label, name)
+		}
+
+		buffer.WriteByte(byte(len(label)))
+		buffer.WriteString(label)
+	}
+
+	buffer.WriteByte(0)
+
+	return buffer.Bytes(), nil
+}
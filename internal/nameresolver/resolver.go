@@ -1,21 +1,28 @@
 package nameresolver
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/avast/retry-go/v4"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/redis/go-redis/v9"
 	"github.com/rss3-network/global-indexer/contract/crossbell"
 	"github.com/rss3-network/global-indexer/contract/lens"
+	"github.com/rss3-network/global-indexer/internal/cache"
 	"github.com/rss3-network/global-indexer/internal/config"
-	goens "github.com/wealdtech/go-ens/v3"
+	"github.com/samber/lo"
+	"github.com/sourcegraph/conc/pool"
 	"go.uber.org/zap"
 )
 
@@ -24,44 +31,121 @@ const (
 	ErrUnSupportName  = "unsupport name service resolution"
 )
 
+const (
+	DefaultCacheTTL         = 10 * time.Minute
+	DefaultNegativeCacheTTL = time.Minute
+
+	// batchConcurrency is the maximum number of names resolved concurrently by BatchResolve.
+	batchConcurrency = 10
+)
+
 type NameResolver struct {
 	ensEthClient       *ethclient.Client
 	csbHandleContract  *crossbell.Character
 	lensHandleContract *lens.LensHandle
-	fcClient           *fcClient
+	fcClient           *apiClient
+	lensClient         *apiClient
+
+	// backends are the name service backends by the suffixes of their names.
+	backends map[string]Backend
+
+	cacheClient      cache.Client
+	cacheTTL         time.Duration
+	negativeCacheTTL time.Duration
 }
 
-type fcClient struct {
+type apiClient struct {
 	endpointURL *url.URL
 	httpClient  *http.Client
 }
 
+// Resolve resolves a name to an EVM address.
+// Both resolved and unregistered names are cached, the latter for a shorter time.
 func (n *NameResolver) Resolve(ctx context.Context, input string) (string, error) {
-	splits := strings.Split(input, ".")
+	key := buildNameKey(input)
 
-	var (
-		address string
-		err     error
-	)
+	if n.cacheClient != nil {
+		var address string
+
+		err := n.cacheClient.Get(ctx, key, &address)
+		if err == nil {
+			if address == "" {
+				return "", fmt.Errorf("%s", ErrUnregisterName)
+			}
+
+			return address, nil
+		}
+
+		if !errors.Is(err, redis.Nil) {
+			zap.L().Warn("get resolved name from cache", zap.Error(err), zap.String("name", input))
+		}
+	}
 
-	suffix := splits[len(splits)-1]
+	address, err := n.resolve(ctx, input)
 
 	switch {
-	case suffix == NameServiceENS.String() && n.ensEthClient != nil:
-		address, err = n.resolveENS(ctx, input)
-	case suffix == NameServiceCSB.String() && n.csbHandleContract != nil:
-		address, err = n.resolveCSB(ctx, input)
-	case suffix == NameServiceLens.String() && n.lensHandleContract != nil:
-		address, err = n.resolveLens(ctx, input)
-	case suffix == NameServiceFarcaster.String() && n.fcClient != nil:
-		address, err = n.resolveFarcaster(ctx, input)
-	default:
-		err = fmt.Errorf("%s:%s", ErrUnSupportName, input)
+	case n.cacheClient == nil:
+	case err == nil:
+		n.setCache(ctx, key, address, n.cacheTTL)
+	case strings.Contains(err.Error(), ErrUnregisterName):
+		n.setCache(ctx, key, "", n.negativeCacheTTL)
 	}
 
 	return address, err
 }
 
+// BatchResolve resolves the names concurrently, the names that cannot be resolved are left out of the result.
+func (n *NameResolver) BatchResolve(ctx context.Context, names []string) map[string]string {
+	var (
+		locker    sync.Mutex
+		addresses = make(map[string]string, len(names))
+	)
+
+	resolvePool := pool.New().WithMaxGoroutines(batchConcurrency)
+
+	for _, name := range lo.Uniq(names) {
+		name := name
+
+		resolvePool.Go(func() {
+			address, err := n.Resolve(ctx, name)
+			if err != nil {
+				zap.L().Debug("resolve name", zap.Error(err), zap.String("name", name))
+
+				return
+			}
+
+			locker.Lock()
+			defer locker.Unlock()
+
+			addresses[name] = address
+		})
+	}
+
+	resolvePool.Wait()
+
+	return addresses
+}
+
+func (n *NameResolver) setCache(ctx context.Context, key string, value any, ttl time.Duration) {
+	if err := n.cacheClient.Set(ctx, key, value, ttl); err != nil {
+		zap.L().Warn("set name resolution cache", zap.Error(err), zap.String("key", key))
+	}
+}
+
+// buildNameKey builds the cache key of a resolved name.
+func buildNameKey(name string) string {
+	return fmt.Sprintf("name:service:%s", strings.ToLower(name))
+}
+
+func (n *NameResolver) resolve(ctx context.Context, input string) (string, error) {
+	backend, ok := n.lookup(input)
+	if !ok {
+		return "", fmt.Errorf("%s:%s", ErrUnSupportName, input)
+	}
+
+	return backend.Resolve(ctx, input)
+}
+
 func (n *NameResolver) resolveCSB(_ context.Context, domain string) (string, error) {
 	cData, err := n.csbHandleContract.GetCharacterByHandle(&bind.CallOpts{}, strings.TrimSuffix(domain, ".csb"))
 	if err != nil {
@@ -76,15 +160,6 @@ func (n *NameResolver) resolveCSB(_ context.Context, domain string) (string, err
 	return characterOwner.String(), nil
 }
 
-func (n *NameResolver) resolveENS(_ context.Context, domain string) (string, error) {
-	address, err := goens.Resolve(n.ensEthClient, domain)
-	if err != nil {
-		return "", err
-	}
-
-	return address.String(), nil
-}
-
 func (n *NameResolver) resolveLens(_ context.Context, domain string) (string, error) {
 	label := strings.Split(domain, "."+NameServiceLens.String())[0]
 	tokenID, err := n.lensHandleContract.GetTokenId(&bind.CallOpts{}, label)
@@ -132,22 +207,40 @@ func (n *NameResolver) resolveFarcaster(ctx context.Context, domain string) (str
 		return err.Error() != fmt.Errorf("%s", ErrUnregisterName).Error()
 	})
 
-	if err = retry.Do(func() error { return n.call(ctx, str, &response) }, retry.Delay(time.Second), retry.Attempts(10), onRetry, retryIf); err != nil {
+	if err = retry.Do(func() error { return n.fcClient.get(ctx, str, &response) }, retry.Delay(time.Second), retry.Attempts(10), onRetry, retryIf); err != nil {
 		return "", err
 	}
 
 	return response.Owner, nil
 }
 
-func (n *NameResolver) call(ctx context.Context, url string, result any) error {
-	url = fmt.Sprintf("%s%s", n.fcClient.endpointURL, url)
+func (c *apiClient) get(ctx context.Context, url string, result any) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s", c.endpointURL, url), nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	return c.do(request, result)
+}
+
+func (c *apiClient) post(ctx context.Context, url string, body, result any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
 
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s%s", c.endpointURL, url), bytes.NewReader(data))
 	if err != nil {
 		return fmt.Errorf("create request: %w", err)
 	}
 
-	response, err := n.fcClient.httpClient.Do(request)
+	request.Header.Set("Content-Type", "application/json")
+
+	return c.do(request, result)
+}
+
+func (c *apiClient) do(request *http.Request, result any) error {
+	response, err := c.httpClient.Do(request)
 	if err != nil {
 		return fmt.Errorf("do request: %w", err)
 	}
@@ -171,69 +264,134 @@ func (n *NameResolver) call(ctx context.Context, url string, result any) error {
 	return nil
 }
 
-func NewNameResolver(ctx context.Context, config *config.RPCNetwork) (*NameResolver, error) {
+// NewNameResolver creates a NameResolver with the backends of the configured name services, the cache client is optional.
+func NewNameResolver(ctx context.Context, rpcConfig *config.RPC, cacheClient cache.Client) (*NameResolver, error) {
 	var (
-		err                error
-		ensEthClient       *ethclient.Client
-		characterContract  *crossbell.Character
-		lensHandleContract *lens.LensHandle
-		farcasterClient    *fcClient
+		err     error
+		network = rpcConfig.RPCNetwork
 	)
 
-	if config.Ethereum != nil {
-		ensEthClient, err = ethclient.DialContext(ctx, config.Ethereum.Endpoint)
+	nameResolver := NameResolver{
+		backends:         make(map[string]Backend),
+		cacheClient:      cacheClient,
+		cacheTTL:         rpcConfig.CacheTTL,
+		negativeCacheTTL: rpcConfig.NegativeCacheTTL,
+	}
+
+	if nameResolver.cacheTTL == 0 {
+		nameResolver.cacheTTL = DefaultCacheTTL
+	}
+
+	if nameResolver.negativeCacheTTL == 0 {
+		nameResolver.negativeCacheTTL = DefaultNegativeCacheTTL
+	}
+
+	if network.Ethereum != nil {
+		nameResolver.ensEthClient, err = ethclient.DialContext(ctx, network.Ethereum.Endpoint)
 		if err != nil {
 			return nil, fmt.Errorf("dial ens ethereum client: %w", err)
 		}
+
+		// Basenames are also resolved by ENS through offchain lookups if Base is not configured.
+		nameResolver.Register(NameServiceENS.String(), newRegistryBackend(nameResolver.ensEthClient, AddressENSRegistry))
 	}
 
-	if config.Crossbell != nil {
-		csbEthClient, err := ethclient.DialContext(ctx, config.Crossbell.Endpoint)
+	if network.Crossbell != nil {
+		csbEthClient, err := ethclient.DialContext(ctx, network.Crossbell.Endpoint)
 		if err != nil {
 			return nil, fmt.Errorf("dial csb ethereum client: %w", err)
 		}
 
-		characterContract, err = crossbell.NewCharacter(crossbell.AddressCharacter, csbEthClient)
+		nameResolver.csbHandleContract, err = crossbell.NewCharacter(crossbell.AddressCharacter, csbEthClient)
 		if err != nil {
 			return nil, fmt.Errorf("failed to connect to crossbell character contract: %w", err)
 		}
+
+		nameResolver.Register(NameServiceCSB.String(), BackendFunc(nameResolver.resolveCSB))
 	}
 
-	if config.Polygon != nil {
-		lensEthClient, err := ethclient.DialContext(ctx, config.Polygon.Endpoint)
+	if network.Polygon != nil {
+		lensEthClient, err := ethclient.DialContext(ctx, network.Polygon.Endpoint)
 		if err != nil {
 			return nil, fmt.Errorf("dial lens ethereum client: %w", err)
 		}
 
-		lensHandleContract, err = lens.NewLensHandle(lens.AddressLensHandle, lensEthClient)
+		nameResolver.lensHandleContract, err = lens.NewLensHandle(lens.AddressLensHandle, lensEthClient)
 		if err != nil {
 			return nil, fmt.Errorf("failed to connect to lens handle contract: %w", err)
 		}
-	}
 
-	if config.Farcaster != nil {
-		farcasterClient = &fcClient{}
+		nameResolver.Register(NameServiceLens.String(), BackendFunc(nameResolver.resolveLens))
+	}
 
-		if farcasterClient.endpointURL, err = url.Parse(config.Farcaster.Endpoint); err != nil {
+	if network.Farcaster != nil {
+		if nameResolver.fcClient, err = newAPIClient(network.Farcaster); err != nil {
 			return nil, fmt.Errorf("parse farcaster endpoint: %w", err)
 		}
 
-		var httpClient http.Client
+		nameResolver.Register(NameServiceFarcaster.String(), BackendFunc(nameResolver.resolveFarcaster))
+	}
+
+	if network.Lens != nil {
+		if nameResolver.lensClient, err = newAPIClient(network.Lens); err != nil {
+			return nil, fmt.Errorf("parse lens endpoint: %w", err)
+		}
+	}
+
+	registries := []struct {
+		endpoint    *config.RPCEndpoint
+		nameService NameService
+		registry    common.Address
+	}{
+		{network.Base, NameServiceBasenames, AddressBasenamesRegistry},
+		{network.BSC, NameServiceSpaceIDBNB, AddressSpaceIDRegistryBSC},
+		{network.Arbitrum, NameServiceSpaceIDARB, AddressSpaceIDRegistryArbitrum},
+	}
+
+	for _, registry := range registries {
+		if registry.endpoint == nil {
+			continue
+		}
+
+		ethClient, err := ethclient.DialContext(ctx, registry.endpoint.Endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("dial %s ethereum client: %w", registry.nameService, err)
+		}
+
+		nameResolver.Register(registry.nameService.String(), newRegistryBackend(ethClient, registry.registry))
+	}
+
+	if network.Unstoppable != nil {
+		backend, err := newUnstoppableBackend(network.Unstoppable)
+		if err != nil {
+			return nil, fmt.Errorf("parse unstoppable domains endpoint: %w", err)
+		}
 
-		if config.Farcaster.APIkey != "" {
-			httpClient.Transport = NewAuthenticationTransport(config.Farcaster.APIkey)
-		} else {
-			httpClient = *http.DefaultClient
+		for _, suffix := range unstoppableSuffixes {
+			nameResolver.Register(suffix, backend)
 		}
+	}
+
+	return &nameResolver, nil
+}
+
+func newAPIClient(endpoint *config.RPCEndpoint) (*apiClient, error) {
+	endpointURL, err := url.Parse(endpoint.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var httpClient http.Client
 
-		farcasterClient.httpClient = &httpClient
+	if endpoint.APIkey != "" {
+		httpClient.Transport = NewAuthenticationTransport(endpoint.APIkey)
+	} else {
+		httpClient = *http.DefaultClient
 	}
 
-	return &NameResolver{
-		ensEthClient:       ensEthClient,
-		csbHandleContract:  characterContract,
-		lensHandleContract: lensHandleContract,
-		fcClient:           farcasterClient,
+	return &apiClient{
+		endpointURL: endpointURL,
+		httpClient:  &httpClient,
 	}, nil
 }
 
@@ -0,0 +1,210 @@
+package nameresolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/redis/go-redis/v9"
+	"github.com/sourcegraph/conc/pool"
+	goens "github.com/wealdtech/go-ens/v3"
+	"go.uber.org/zap"
+)
+
+// Names are the primary names of an address on each name service, a name is empty if the address has none.
+type Names struct {
+	Address   common.Address `json:"address"`
+	ENS       string         `json:"ens,omitempty"`
+	Crossbell string         `json:"crossbell,omitempty"`
+	Lens      string         `json:"lens,omitempty"`
+	Farcaster string         `json:"farcaster,omitempty"`
+}
+
+// IsEmpty returns true if the address has no primary name on any name service.
+func (n *Names) IsEmpty() bool {
+	return n.ENS == "" && n.Crossbell == "" && n.Lens == "" && n.Farcaster == ""
+}
+
+// ReverseResolve resolves an address to its primary names on all configured name services.
+// A failing name service is skipped, so that the others can still be returned.
+func (n *NameResolver) ReverseResolve(ctx context.Context, address common.Address) (*Names, error) {
+	key := buildReverseKey(address)
+
+	if n.cacheClient != nil {
+		var names Names
+
+		err := n.cacheClient.Get(ctx, key, &names)
+		if err == nil {
+			return &names, nil
+		}
+
+		if !errors.Is(err, redis.Nil) {
+			zap.L().Warn("get reverse resolution from cache", zap.Error(err), zap.String("address", address.String()))
+		}
+	}
+
+	names := Names{Address: address}
+
+	reversePool := pool.New().WithContext(ctx)
+
+	reverse := func(nameService NameService, target *string, reverseFunc func(context.Context, common.Address) (string, error)) {
+		reversePool.Go(func(ctx context.Context) error {
+			name, err := reverseFunc(ctx, address)
+			if err != nil {
+				zap.L().Debug("reverse resolve name", zap.Error(err), zap.Stringer("name_service", nameService), zap.String("address", address.String()))
+
+				return nil
+			}
+
+			*target = name
+
+			return nil
+		})
+	}
+
+	if n.ensEthClient != nil {
+		reverse(NameServiceENS, &names.ENS, n.reverseENS)
+	}
+
+	if n.csbHandleContract != nil {
+		reverse(NameServiceCSB, &names.Crossbell, n.reverseCSB)
+	}
+
+	if n.lensClient != nil {
+		reverse(NameServiceLens, &names.Lens, n.reverseLens)
+	}
+
+	if n.fcClient != nil {
+		reverse(NameServiceFarcaster, &names.Farcaster, n.reverseFarcaster)
+	}
+
+	if err := reversePool.Wait(); err != nil {
+		return nil, err
+	}
+
+	if n.cacheClient != nil {
+		ttl := n.cacheTTL
+		if names.IsEmpty() {
+			ttl = n.negativeCacheTTL
+		}
+
+		n.setCache(ctx, key, names, ttl)
+	}
+
+	return &names, nil
+}
+
+// buildReverseKey builds the cache key of the reverse resolution of an address.
+func buildReverseKey(address common.Address) string {
+	return fmt.Sprintf("name:reverse:%s", strings.ToLower(address.String()))
+}
+
+func (n *NameResolver) reverseENS(_ context.Context, address common.Address) (string, error) {
+	name, err := goens.ReverseResolve(n.ensEthClient, address)
+	if err != nil {
+		return "", err
+	}
+
+	// The reverse record is set by the owner of the address, it is only trusted if the name resolves back to the address.
+	resolved, err := goens.Resolve(n.ensEthClient, name)
+	if err != nil {
+		return "", err
+	}
+
+	if resolved != address {
+		return "", fmt.Errorf("%s does not resolve to %s", name, address)
+	}
+
+	return name, nil
+}
+
+func (n *NameResolver) reverseCSB(ctx context.Context, address common.Address) (string, error) {
+	characterID, err := n.csbHandleContract.GetPrimaryCharacterId(&bind.CallOpts{Context: ctx}, address)
+	if err != nil {
+		return "", fmt.Errorf("failed to get crossbell primary character: %w", err)
+	}
+
+	if characterID.Sign() == 0 {
+		return "", fmt.Errorf("%s", ErrUnregisterName)
+	}
+
+	handle, err := n.csbHandleContract.GetHandle(&bind.CallOpts{Context: ctx}, characterID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get crossbell handle: %w", err)
+	}
+
+	return handle + "." + NameServiceCSB.String(), nil
+}
+
+type lensDefaultProfileResponse struct {
+	Data struct {
+		DefaultProfile *struct {
+			Handle *struct {
+				LocalName string `json:"localName"`
+			} `json:"handle"`
+		} `json:"defaultProfile"`
+	} `json:"data"`
+}
+
+func (n *NameResolver) reverseLens(ctx context.Context, address common.Address) (string, error) {
+	request := map[string]any{
+		"query":     "query DefaultProfile($for: EvmAddress!) { defaultProfile(request: { for: $for }) { handle { localName } } }",
+		"variables": map[string]any{"for": address.String()},
+	}
+
+	var response lensDefaultProfileResponse
+
+	if err := n.lensClient.post(ctx, "/graphql", request, &response); err != nil {
+		return "", fmt.Errorf("failed to get lens default profile: %w", err)
+	}
+
+	if response.Data.DefaultProfile == nil || response.Data.DefaultProfile.Handle == nil || response.Data.DefaultProfile.Handle.LocalName == "" {
+		return "", fmt.Errorf("%s", ErrUnregisterName)
+	}
+
+	return response.Data.DefaultProfile.Handle.LocalName + "." + NameServiceLens.String(), nil
+}
+
+type farcasterIDRegistryEvent struct {
+	Fid uint64 `json:"fid"`
+}
+
+type farcasterUserNameProofs struct {
+	Proofs []UserNameProof `json:"proofs"`
+}
+
+func (n *NameResolver) reverseFarcaster(ctx context.Context, address common.Address) (string, error) {
+	var event farcasterIDRegistryEvent
+
+	params := url.Values{}
+	params.Add("address", address.String())
+
+	if err := n.fcClient.get(ctx, fmt.Sprintf("/v1/onChainIdRegistryEventByAddress?%s", params.Encode()), &event); err != nil {
+		return "", fmt.Errorf("failed to get farcaster id: %w", err)
+	}
+
+	if event.Fid == 0 {
+		return "", fmt.Errorf("%s", ErrUnregisterName)
+	}
+
+	var proofs farcasterUserNameProofs
+
+	params = url.Values{}
+	params.Add("fid", fmt.Sprint(event.Fid))
+
+	if err := n.fcClient.get(ctx, fmt.Sprintf("/v1/userNameProofsByFid?%s", params.Encode()), &proofs); err != nil {
+		return "", fmt.Errorf("failed to get farcaster user name proofs: %w", err)
+	}
+
+	for _, proof := range proofs.Proofs {
+		if proof.Type == "USERNAME_TYPE_FNAME" {
+			return proof.Name + "." + NameServiceFarcaster.String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("%s", ErrUnregisterName)
+}
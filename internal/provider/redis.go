@@ -2,16 +2,23 @@ package provider
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/internal/cache"
 	"github.com/rss3-network/global-indexer/internal/config"
 )
 
-func ProvideRedisClient(config *config.File) (*redis.Client, error) {
+// ProvideCacheClient provides the cache client on Redis, or an in-memory one if the URI is memory://.
+func ProvideCacheClient(config *config.File) (cache.Client, error) {
+	if strings.HasPrefix(config.Redis.URI, cache.MemoryScheme+"://") {
+		return cache.NewMemory(), nil
+	}
+
 	options, err := redis.ParseURL(config.Redis.URI)
 	if err != nil {
 		return nil, fmt.Errorf("parse redis uri: %w", err)
 	}
 
-	return redis.NewClient(options), nil
+	return cache.New(redis.NewClient(options)), nil
 }
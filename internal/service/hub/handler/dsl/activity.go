@@ -6,12 +6,10 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
-	"time"
 
 	"github.com/creasty/defaults"
 	"github.com/labstack/echo/v4"
-	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/distributor"
 	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
 	"github.com/rss3-network/global-indexer/internal/service/hub/model/dsl"
 	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
@@ -20,7 +18,6 @@ import (
 	"github.com/rss3-network/protocol-go/schema/network"
 	"github.com/rss3-network/protocol-go/schema/tag"
 	"github.com/samber/lo"
-	"github.com/sourcegraph/conc/pool"
 	"go.uber.org/zap"
 )
 
@@ -75,13 +72,17 @@ func (d *DSL) GetAccountActivities(c echo.Context) (err error) {
 
 	// Resolve name to EVM address
 	if !validEvmAddress(request.Account) {
-		resolvedName, err := d.getEVMAddress(c.Request().Context(), request.Account)
+		resolvedName, err := d.nameService.Resolve(c.Request().Context(), request.Account)
 		if err == nil {
 			request.Account = resolvedName
 		}
 	}
 
 	activities, err := d.distributor.DistributeDecentralizedData(c.Request().Context(), model.DistributorRequestAccountActivities, request, c.QueryParams(), workers, networks)
+	if errors.Is(err, distributor.ErrInvalidCursor) {
+		return errorx.BadRequestError(c, err)
+	}
+
 	if err != nil {
 		zap.L().Error("distribute activities data error", zap.Error(err))
 
@@ -116,13 +117,15 @@ func (d *DSL) BatchGetAccountsActivities(c echo.Context) (err error) {
 	}
 
 	// Resolve names to EVM addresses
-	if err = d.transformAccounts(c.Request().Context(), request.Accounts); err != nil {
-		return errorx.BadRequestError(c, err)
-	}
+	d.transformAccounts(c.Request().Context(), request.Accounts)
 
 	request.Accounts = lo.Uniq(request.Accounts)
 
 	activities, err := d.distributor.DistributeDecentralizedData(c.Request().Context(), model.DistributorRequestBatchAccountActivities, request, nil, workers, networks)
+	if errors.Is(err, distributor.ErrInvalidCursor) {
+		return errorx.BadRequestError(c, err)
+	}
+
 	if err != nil {
 		zap.L().Error("distribute batch activities data error", zap.Error(err))
 
@@ -157,6 +160,10 @@ func (d *DSL) GetNetworkActivities(c echo.Context) (err error) {
 	}
 
 	activities, err := d.distributor.DistributeDecentralizedData(c.Request().Context(), model.DistributorRequestNetworkActivities, request, c.QueryParams(), workers, networks)
+	if errors.Is(err, distributor.ErrInvalidCursor) {
+		return errorx.BadRequestError(c, err)
+	}
+
 	if err != nil {
 		zap.L().Error("distribute network activities data error", zap.Error(err))
 
@@ -191,6 +198,10 @@ func (d *DSL) GetPlatformActivities(c echo.Context) (err error) {
 	}
 
 	activities, err := d.distributor.DistributeDecentralizedData(c.Request().Context(), model.DistributorRequestPlatformActivities, request, c.QueryParams(), workers, networks)
+	if errors.Is(err, distributor.ErrInvalidCursor) {
+		return errorx.BadRequestError(c, err)
+	}
+
 	if err != nil {
 		zap.L().Error("distribute platform activities data error", zap.Error(err))
 
@@ -200,63 +211,23 @@ func (d *DSL) GetPlatformActivities(c echo.Context) (err error) {
 	return c.JSONBlob(http.StatusOK, activities)
 }
 
-func (d *DSL) transformAccounts(ctx context.Context, accounts []string) error {
-	nsPool := pool.New().WithContext(ctx).WithCancelOnError().WithFirstError()
-
-	for i := range accounts {
-		i := i
+// transformAccounts resolves the names in accounts to EVM addresses in place, unresolvable names are kept as is.
+func (d *DSL) transformAccounts(ctx context.Context, accounts []string) {
+	names := lo.Filter(accounts, func(account string, _ int) bool {
+		return !validEvmAddress(account)
+	})
 
-		nsPool.Go(func(ctx context.Context) error {
-			if !validEvmAddress(accounts[i]) {
-				resolvedName, err := d.getEVMAddress(ctx, accounts[i])
-				if err == nil {
-					accounts[i] = resolvedName
-				}
-			}
-
-			return nil
-		})
-	}
-
-	return nsPool.Wait()
-}
-
-func (d *DSL) getEVMAddress(ctx context.Context, account string) (string, error) {
-	var (
-		err error
-
-		key = buildNameServiceKey(account)
-	)
-	// Try to get the resolved address from Redis cache first
-	if err = d.cacheClient.Get(ctx, key, &account); err == nil {
-		return account, nil
+	if len(names) == 0 {
+		return
 	}
 
-	// If not found in cache, resolve the name to an EVM address
-	if errors.Is(err, redis.Nil) {
-		// Cache miss, need to resolve the name
-		account, err = d.nameService.Resolve(ctx, account)
-		if err != nil {
-			zap.L().Error("name service resolve error", zap.Error(err), zap.String("account", account))
-
-			return "", err
-		}
+	addresses := d.nameService.BatchResolve(ctx, names)
 
-		// Cache the resolved address, with a TTL of 10 minutes
-		// Fixme: The TTL should be configurable
-		if err = d.cacheClient.Set(ctx, key, account, 10*time.Minute); err != nil {
-			return "", err
+	for i, account := range accounts {
+		if address, ok := addresses[account]; ok {
+			accounts[i] = address
 		}
-
-		return account, nil
 	}
-
-	return "", err
-}
-
-// buildNameServiceKey builds the key for the name service cache.
-func buildNameServiceKey(account string) string {
-	return fmt.Sprintf("name:service:%s", strings.ToLower(account))
 }
 
 // validEvmAddress checks if the address is a valid EVM address.
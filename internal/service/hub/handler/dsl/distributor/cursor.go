@@ -0,0 +1,229 @@
+package distributor
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/dsl"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+)
+
+// ErrInvalidCursor is returned when a cursor issued by the hubs does not belong to the request.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// A pagination cursor returned by a Node is only meaningful to the Node, since the databases of the Nodes page differently.
+// The hub wraps the Node cursor in a signed opaque cursor, which records the Node that served the page,
+// the Nodes the request was distributed to and the fingerprint of the query.
+// A follow-up page is routed to the serving Node if it is still qualified, otherwise to the first still qualified Node
+// of the original Nodes, and to the currently qualified Nodes if none of them is. The Node cursor identifies the last
+// activity of the page, so the other Nodes can continue from it, though their pages may slightly differ.
+// A cursor that cannot be verified, such as a raw Node cursor held by a client from before the cursors were wrapped,
+// or a cursor signed by a hub with another secret, is unbound. Only its Node cursor is used,
+// and the page is routed to the currently qualified Nodes as the pages were before.
+
+// cursorToken is the content of an opaque cursor.
+type cursorToken struct {
+	// Node is the Node that served the page.
+	Node common.Address `json:"node"`
+	// Nodes are the Nodes that the first page was distributed to.
+	Nodes []common.Address `json:"nodes"`
+	// Fingerprint is the fingerprint of the query, a cursor is only valid for the same query.
+	Fingerprint string `json:"fingerprint"`
+	// Cursor is the cursor returned by the Node.
+	Cursor string `json:"cursor"`
+}
+
+// encodeCursor signs the token and encodes it into an opaque cursor.
+func (d *Distributor) encodeCursor(token *cursorToken) (string, error) {
+	payload, err := json.Marshal(token)
+	if err != nil {
+		return "", fmt.Errorf("marshal cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(d.signCursor(payload)), nil
+}
+
+// decodeCursor decodes the token from the opaque cursor, it returns whether the cursor is bound,
+// which is whether it is verified. An unbound token only carries the Node cursor.
+func (d *Distributor) decodeCursor(cursor, fingerprint string) (*cursorToken, bool, error) {
+	unbound := &cursorToken{Cursor: cursor}
+
+	encodedPayload, encodedSignature, found := bytes.Cut([]byte(cursor), []byte("."))
+	if !found {
+		return unbound, false, nil
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(string(encodedPayload))
+	if err != nil {
+		return unbound, false, nil
+	}
+
+	var token cursorToken
+
+	if err := json.Unmarshal(payload, &token); err != nil {
+		return unbound, false, nil
+	}
+
+	if token.Fingerprint != fingerprint {
+		return nil, false, fmt.Errorf("%w: the cursor belongs to another query", ErrInvalidCursor)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(string(encodedSignature))
+	if err != nil || !hmac.Equal(signature, d.signCursor(payload)) {
+		return &cursorToken{Cursor: token.Cursor}, false, nil
+	}
+
+	return &token, true, nil
+}
+
+func (d *Distributor) signCursor(payload []byte) []byte {
+	mac := hmac.New(sha256.New, d.cursorSecret)
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
+
+// retrieveCursorNodes returns the Node that a follow-up page should be routed to,
+// it returns nil if neither the serving Node nor any of the original Nodes is still qualified.
+func (d *Distributor) retrieveCursorNodes(ctx context.Context, token *cursorToken) ([]*model.NodeEndpointCache, error) {
+	stats, err := d.databaseClient.FindNodeStats(ctx, &schema.StatQuery{
+		Addresses:    lo.Uniq(append([]common.Address{token.Node}, token.Nodes...)),
+		ValidRequest: lo.ToPtr(model.DemotionCountBeforeSlashing),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	qualifiedStats := lo.SliceToMap(stats, func(stat *schema.Stat) (common.Address, *schema.Stat) {
+		return stat.Address, stat
+	})
+
+	for _, address := range append([]common.Address{token.Node}, token.Nodes...) {
+		if stat, exists := qualifiedStats[address]; exists {
+			return []*model.NodeEndpointCache{
+				{
+					Address:     stat.Address.String(),
+					Endpoint:    stat.Endpoint,
+					AccessToken: stat.AccessToken,
+				},
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// wrapResponseCursor replaces the Node cursor in the response data with an opaque cursor.
+func (d *Distributor) wrapResponseCursor(data []byte, node common.Address, nodes []common.Address, fingerprint string) ([]byte, error) {
+	var response map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	var meta model.MetaCursor
+
+	if rawMeta, exists := response["meta"]; !exists || json.Unmarshal(rawMeta, &meta) != nil || meta.Cursor == "" {
+		return data, nil
+	}
+
+	cursor, err := d.encodeCursor(&cursorToken{
+		Node:        node,
+		Nodes:       nodes,
+		Fingerprint: fingerprint,
+		Cursor:      meta.Cursor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response["meta"], err = json.Marshal(model.MetaCursor{Cursor: cursor}); err != nil {
+		return nil, fmt.Errorf("marshal meta: %w", err)
+	}
+
+	return json.Marshal(response)
+}
+
+// fingerprintRequest returns the fingerprint of the query of a paginated request, the cursor is excluded from it.
+// It returns false if the request is not paginated.
+func fingerprintRequest(requestType string, request interface{}) (string, bool) {
+	request, paginated := withCursor(request, nil)
+	if !paginated {
+		return "", false
+	}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return "", false
+	}
+
+	hash := sha256.Sum256(append([]byte(requestType+"\n"), data...))
+
+	return hex.EncodeToString(hash[:]), true
+}
+
+// requestCursor returns the cursor of a paginated request.
+func requestCursor(request interface{}) *string {
+	switch req := request.(type) {
+	case dsl.ActivitiesRequest:
+		return req.Cursor
+	case dsl.AccountsActivitiesRequest:
+		return req.Cursor
+	case dsl.NetworkActivitiesRequest:
+		return req.Cursor
+	case dsl.PlatformActivitiesRequest:
+		return req.Cursor
+	default:
+		return nil
+	}
+}
+
+// withCursor returns the request with the cursor replaced, and false if the request is not paginated.
+func withCursor(request interface{}, cursor *string) (interface{}, bool) {
+	switch req := request.(type) {
+	case dsl.ActivitiesRequest:
+		req.Cursor = cursor
+		return req, true
+	case dsl.AccountsActivitiesRequest:
+		req.Cursor = cursor
+		return req, true
+	case dsl.NetworkActivitiesRequest:
+		req.Cursor = cursor
+		return req, true
+	case dsl.PlatformActivitiesRequest:
+		req.Cursor = cursor
+		return req, true
+	default:
+		return request, false
+	}
+}
+
+// withCursorParam returns a copy of the params with the cursor replaced.
+func withCursorParam(params url.Values, cursor string) url.Values {
+	if params == nil {
+		return nil
+	}
+
+	result := make(url.Values, len(params))
+
+	for key, values := range params {
+		result[key] = append([]string(nil), values...)
+	}
+
+	if result.Has("cursor") {
+		result.Set("cursor", cursor)
+	}
+
+	return result
+}
@@ -0,0 +1,67 @@
+package distributor
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/dsl"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	t.Parallel()
+
+	var (
+		distributor = &Distributor{cursorSecret: []byte("secret")}
+		node        = common.HexToAddress("0x01")
+		nodes       = []common.Address{node, common.HexToAddress("0x02")}
+		request     = dsl.ActivitiesRequest{Account: "0x03", Cursor: lo.ToPtr("previous")}
+	)
+
+	fingerprint, paginated := fingerprintRequest(model.DistributorRequestAccountActivities, request)
+	require.True(t, paginated)
+
+	// The cursor is excluded from the fingerprint.
+	otherFingerprint, _ := fingerprintRequest(model.DistributorRequestAccountActivities, dsl.ActivitiesRequest{Account: "0x03"})
+	require.Equal(t, fingerprint, otherFingerprint)
+
+	data, err := distributor.wrapResponseCursor([]byte(`{"data":[],"meta":{"cursor":"0x04:ethereum"}}`), node, nodes, fingerprint)
+	require.NoError(t, err)
+
+	var response model.ActivitiesResponse
+
+	require.NoError(t, json.Unmarshal(data, &response))
+	require.NotEqual(t, "0x04:ethereum", response.Meta.Cursor)
+
+	token, bound, err := distributor.decodeCursor(response.Meta.Cursor, fingerprint)
+	require.NoError(t, err)
+	assert.True(t, bound)
+	assert.Equal(t, &cursorToken{Node: node, Nodes: nodes, Fingerprint: fingerprint, Cursor: "0x04:ethereum"}, token)
+
+	// The cursor is only valid for the same query.
+	anotherFingerprint, _ := fingerprintRequest(model.DistributorRequestAccountActivities, dsl.ActivitiesRequest{Account: "0x05"})
+	_, _, err = distributor.decodeCursor(response.Meta.Cursor, anotherFingerprint)
+	require.True(t, errors.Is(err, ErrInvalidCursor))
+
+	// The cursor signed by a hub with another secret is unbound, only its Node cursor is used.
+	token, bound, err = (&Distributor{cursorSecret: []byte("another secret")}).decodeCursor(response.Meta.Cursor, fingerprint)
+	require.NoError(t, err)
+	assert.False(t, bound)
+	assert.Equal(t, &cursorToken{Cursor: "0x04:ethereum"}, token)
+
+	// The raw Node cursor is unbound.
+	token, bound, err = distributor.decodeCursor("0x04:ethereum", fingerprint)
+	require.NoError(t, err)
+	assert.False(t, bound)
+	assert.Equal(t, &cursorToken{Cursor: "0x04:ethereum"}, token)
+
+	// The response without a cursor is kept as is.
+	data, err = distributor.wrapResponseCursor([]byte(`{"data":[]}`), node, nodes, fingerprint)
+	require.NoError(t, err)
+	assert.Equal(t, `{"data":[]}`, string(data))
+}
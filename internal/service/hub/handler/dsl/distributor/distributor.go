@@ -2,6 +2,7 @@ package distributor
 
 import (
 	"context"
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -16,6 +17,7 @@ import (
 	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
 	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/router"
 	"github.com/rss3-network/global-indexer/internal/service/hub/model/dsl"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 )
 
@@ -24,6 +26,7 @@ type Distributor struct {
 	simpleRouter   *router.SimpleRouter
 	databaseClient database.Client
 	cacheClient    cache.Client
+	cursorSecret   []byte
 }
 
 // DistributeRSSHubData distributes RSSHub requests to qualified Nodes.
@@ -40,7 +43,7 @@ func (d *Distributor) DistributeRSSHubData(ctx context.Context, path, query stri
 		return nil, err
 	}
 
-	nodeResponse, err := d.simpleRouter.DistributeRequest(ctx, nodeMap, d.processRSSHubResponses)
+	nodeResponse, err := d.simpleRouter.DistributeRequest(ctx, nodeMap, d.verifyResponses(model.VerificationTaskTypeRSSHub))
 
 	if err != nil {
 		return nil, err
@@ -66,32 +69,44 @@ func (d *Distributor) generateRSSHubPath(param, query string, nodes []*model.Nod
 }
 
 // DistributeDecentralizedData distributes decentralized requests to qualified Nodes.
+// The follow-up pages of a paginated request are routed by the opaque cursor, see cursor.go.
 func (d *Distributor) DistributeDecentralizedData(ctx context.Context, requestType string, request interface{}, params url.Values, workers, networks []string) ([]byte, error) {
 	var (
-		nodes          []*model.NodeEndpointCache
-		processResults = d.processActivitiesResponses
+		nodes    []*model.NodeEndpointCache
+		taskType = model.VerificationTaskTypeActivities
+		token    *cursorToken
+		bound    bool
 
 		err error
 	)
 
-	switch requestType {
-	case model.DistributorRequestActivity:
-		nodes, err = d.simpleEnforcer.RetrieveQualifiedNodes(ctx, model.FullNodeCacheKey)
-		processResults = d.processActivityResponses
-	case model.DistributorRequestAccountActivities:
-		nodes, err = d.getQualifiedNodes(ctx, workers, networks)
-	case model.DistributorRequestBatchAccountActivities:
-		nodes, err = d.getQualifiedNodes(ctx, workers, networks)
-	case model.DistributorRequestNetworkActivities:
-		nodes, err = d.getQualifiedNodes(ctx, workers, networks)
-	case model.DistributorRequestPlatformActivities:
-		nodes, err = d.getQualifiedNodes(ctx, workers, networks)
-	default:
-		return nil, fmt.Errorf("invalid request type: %s", requestType)
+	if requestType == model.DistributorRequestActivity {
+		taskType = model.VerificationTaskTypeActivity
 	}
 
-	if err != nil {
-		return nil, err
+	fingerprint, paginated := fingerprintRequest(requestType, request)
+
+	// Unwrap the opaque cursor and route the follow-up page to the Node that served the previous page.
+	if cursor := requestCursor(request); paginated && cursor != nil && *cursor != "" {
+		if token, bound, err = d.decodeCursor(*cursor, fingerprint); err != nil {
+			return nil, err
+		}
+
+		request, _ = withCursor(request, &token.Cursor)
+		params = withCursorParam(params, token.Cursor)
+
+		if bound {
+			if nodes, err = d.retrieveCursorNodes(ctx, token); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Fall back to the currently qualified Nodes.
+	if len(nodes) == 0 {
+		if nodes, err = d.retrieveDecentralizedNodes(ctx, requestType, workers, networks); err != nil {
+			return nil, err
+		}
 	}
 
 	nodeMap, err := d.generateDecentralizedPath(requestType, request, params, nodes)
@@ -99,7 +114,7 @@ func (d *Distributor) DistributeDecentralizedData(ctx context.Context, requestTy
 		return nil, err
 	}
 
-	nodeResponse, err := d.simpleRouter.DistributeRequest(ctx, nodeMap, processResults)
+	nodeResponse, err := d.simpleRouter.DistributeRequest(ctx, nodeMap, d.verifyResponses(taskType))
 	if err != nil {
 		return nil, err
 	}
@@ -110,7 +125,35 @@ func (d *Distributor) DistributeDecentralizedData(ctx context.Context, requestTy
 		return nil, nodeResponse.Err
 	}
 
-	return nodeResponse.Data, nil
+	if !paginated {
+		return nodeResponse.Data, nil
+	}
+
+	// The original Nodes are kept through all the pages.
+	distributedNodes := lo.Map(nodes, func(node *model.NodeEndpointCache, _ int) common.Address {
+		return common.HexToAddress(node.Address)
+	})
+
+	if token != nil {
+		distributedNodes = token.Nodes
+	}
+
+	return d.wrapResponseCursor(nodeResponse.Data, nodeResponse.Address, distributedNodes, fingerprint)
+}
+
+// retrieveDecentralizedNodes retrieves the qualified Nodes for decentralized requests.
+func (d *Distributor) retrieveDecentralizedNodes(ctx context.Context, requestType string, workers, networks []string) ([]*model.NodeEndpointCache, error) {
+	switch requestType {
+	case model.DistributorRequestActivity:
+		return d.simpleEnforcer.RetrieveQualifiedNodes(ctx, model.FullNodeCacheKey)
+	case model.DistributorRequestAccountActivities,
+		model.DistributorRequestBatchAccountActivities,
+		model.DistributorRequestNetworkActivities,
+		model.DistributorRequestPlatformActivities:
+		return d.getQualifiedNodes(ctx, workers, networks)
+	default:
+		return nil, fmt.Errorf("invalid request type: %s", requestType)
+	}
 }
 
 // generateDecentralizedPath builds the path for decentralized requests.
@@ -160,10 +203,24 @@ func NewDistributor(ctx context.Context, database database.Client, cache cache.C
 		return nil, err
 	}
 
+	cursorSecret := []byte(model.CursorSecret)
+
+	// The cursors issued by a hub without a configured secret are only bound for the hub until it restarts.
+	if len(cursorSecret) == 0 {
+		cursorSecret = make([]byte, 32)
+
+		if _, err = rand.Read(cursorSecret); err != nil {
+			return nil, fmt.Errorf("generate cursor secret: %w", err)
+		}
+
+		zap.L().Warn("cursor secret is not configured, the cursors of the other hubs are routed to the qualified nodes")
+	}
+
 	return &Distributor{
 		simpleEnforcer: simpleEnforcer,
 		simpleRouter:   router.NewSimpleRouter(httpClient),
 		databaseClient: database,
 		cacheClient:    cache,
+		cursorSecret:   cursorSecret,
 	}, nil
 }
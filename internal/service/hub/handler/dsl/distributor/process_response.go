@@ -11,78 +11,36 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rss3-network/global-indexer/internal/database"
 	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/rss3-network/global-indexer/internal/webhook"
 	"github.com/rss3-network/global-indexer/schema"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 )
 
-// processRSSHubResults processes responses for RSSHub requests.
-func (d *Distributor) processRSSHubResponses(_ []*model.DataResponse) {
-	// No rewards or slash for RSS responses due to unstable RSSHub server.
-
-	//if err := d.simpleEnforcer.VerifyResponses(context.Background(), responses); err != nil {
-	//	zap.L().Error("fail to verify rss hub responses", zap.Any("responses", len(responses)))
-	//} else {
-	//	_ = d.processNodeInvalidResponse(context.Background(), responses)
-	//
-	//	zap.L().Info("complete rss hub responses verify", zap.Any("responses", len(responses)))
-	//}
-}
-
-// processActivityResults processes responses for Activity requests.
-func (d *Distributor) processActivityResponses(responses []*model.DataResponse) {
-	if err := d.simpleEnforcer.VerifyResponses(context.Background(), responses); err != nil {
-		zap.L().Error("fail to verify activity id responses ", zap.Any("responses", len(responses)))
-	} else {
-		_ = d.processNodeInvalidResponse(context.Background(), responses)
-
-		zap.L().Info("complete activity id responses verify", zap.Any("responses", len(responses)))
-	}
-}
-
-// processActivitiesResponses processes responses for Activities requests.
-func (d *Distributor) processActivitiesResponses(responses []*model.DataResponse) {
-	ctx := context.Background()
-
-	if err := d.simpleEnforcer.VerifyResponses(ctx, responses); err != nil {
-		zap.L().Error("fail to verify activity responses", zap.Any("responses", len(responses)))
-
-		return
-	}
-
-	epochID := d.processNodeInvalidResponse(context.Background(), responses)
-
-	if epochID == 0 {
-		return
-	}
-
-	zap.L().Info("complete activity responses verify", zap.Any("responses", len(responses)))
-
-	d.simpleEnforcer.VerifyPartialResponses(ctx, epochID, responses)
-}
-
 // processNodeInvalidResponse finds the valid response data and saves the invalid responses.
-func (d *Distributor) processNodeInvalidResponse(ctx context.Context, responses []*model.DataResponse) uint64 {
+// It returns the epoch ID the invalid responses are saved in, or 0 if all responses are valid.
+func (d *Distributor) processNodeInvalidResponse(ctx context.Context, responses []*model.DataResponse) (uint64, error) {
 	verifierNodes, request, verifierResponse, err := getValidResponseData(responses)
 	if err != nil {
 		zap.L().Error("get valid response data", zap.Error(err))
-		return 0
+		return 0, nil
 	}
 
 	// If all responses are valid, return 0.
 	if len(verifierNodes) == len(responses) {
-		return 0
+		return 0, nil
 	}
 
 	epochID, err := d.getLatestEpochID(ctx)
 	if err != nil {
-		zap.L().Error("get latest epoch event from database", zap.Error(err))
-		return 0
+		return 0, fmt.Errorf("get latest epoch event from database: %w", err)
 	}
 
-	d.saveInvalidResponses(ctx, epochID, verifierNodes, request, verifierResponse, responses)
+	if err := d.saveInvalidResponses(ctx, epochID, verifierNodes, request, verifierResponse, responses); err != nil {
+		return 0, err
+	}
 
-	return epochID
+	return epochID, nil
 }
 
 // getLatestEpochID returns the recent epoch ID.
@@ -133,7 +91,7 @@ func extractPathAndParams(endpoint string) (string, error) {
 }
 
 // saveInvalidResponses saves the responses which invalid points are greater than 0.
-func (d *Distributor) saveInvalidResponses(ctx context.Context, epochID uint64, verifierNodes []common.Address, request string, verifierResponse json.RawMessage, responses []*model.DataResponse) {
+func (d *Distributor) saveInvalidResponses(ctx context.Context, epochID uint64, verifierNodes []common.Address, request string, verifierResponse json.RawMessage, responses []*model.DataResponse) error {
 	var (
 		nodeInvalidResponses = make([]*schema.NodeInvalidResponse, 0, len(responses))
 		err                  error
@@ -166,12 +124,27 @@ func (d *Distributor) saveInvalidResponses(ctx context.Context, epochID uint64,
 			VerifierResponse: verifierResponse,
 			Node:             response.Address,
 			Response:         responseValue,
+			ItemDiff:         response.Diff,
 		}
 
 		nodeInvalidResponses = append(nodeInvalidResponses, nodeInvalidResponse)
 	}
 
 	if err := d.databaseClient.SaveNodeInvalidResponses(ctx, nodeInvalidResponses); err != nil {
-		zap.L().Error("save node invalid response", zap.Error(err))
+		return fmt.Errorf("save node invalid response: %w", err)
+	}
+
+	// The invalid responses are not saved again for the webhook events, so their errors are only logged.
+	events, err := webhook.NewNodeInvalidResponseEvents(nodeInvalidResponses)
+	if err != nil {
+		zap.L().Error("new node invalid response events", zap.Error(err))
+
+		return nil
 	}
+
+	if err := d.databaseClient.SaveWebhookEvents(ctx, events); err != nil {
+		zap.L().Error("save node invalid response events", zap.Error(err))
+	}
+
+	return nil
 }
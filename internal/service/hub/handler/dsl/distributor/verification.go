@@ -0,0 +1,141 @@
+package distributor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"go.uber.org/zap"
+)
+
+// verifyResponses returns the callback of the router that hands the responses over to the verifiers.
+// The responses are verified in-process if the queue is not enabled, or if they cannot be enqueued.
+func (d *Distributor) verifyResponses(taskType string) func([]*model.DataResponse) {
+	return func(responses []*model.DataResponse) {
+		ctx := context.Background()
+
+		task := model.NewVerificationTask(uuid.NewString(), taskType, responses)
+
+		if model.VerificationQueue.Enabled {
+			err := d.enqueueVerificationTask(ctx, task)
+			if err == nil {
+				return
+			}
+
+			zap.L().Error("enqueue verification task, verify in-process instead", zap.String("id", task.ID), zap.Error(err))
+		}
+
+		// The responses verified in-process are never retried, so their progress is not saved.
+		if err := d.processVerificationTask(ctx, task, &model.VerificationProgress{}, func(*model.VerificationProgress) error { return nil }); err != nil {
+			zap.L().Error("process responses", zap.String("type", taskType), zap.Any("responses", len(responses)), zap.Error(err))
+		}
+	}
+}
+
+// enqueueVerificationTask adds the task to the stream consumed by the verifiers.
+func (d *Distributor) enqueueVerificationTask(ctx context.Context, task *model.VerificationTask) error {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("marshal verification task: %w", err)
+	}
+
+	if _, err := d.cacheClient.XAdd(ctx, model.VerificationStreamKey, model.VerificationQueue.MaxLength, map[string]interface{}{"task": data}); err != nil {
+		return fmt.Errorf("add verification task: %w", err)
+	}
+
+	return nil
+}
+
+// ProcessVerificationTask verifies the responses of a task dequeued by a verifier.
+// The progress of the task is saved after each step, so that a retried or redelivered task resumes from the step
+// it failed at, and the points of the responses and the invalid responses are never applied twice.
+func (d *Distributor) ProcessVerificationTask(ctx context.Context, task *model.VerificationTask) error {
+	progressKey := fmt.Sprintf("%s:%s", model.VerificationProgressKey, task.ID)
+
+	var progress model.VerificationProgress
+
+	if err := d.cacheClient.Get(ctx, progressKey, &progress); err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("get verification progress: %w", err)
+	}
+
+	return d.processVerificationTask(ctx, task, &progress, func(progress *model.VerificationProgress) error {
+		if err := d.cacheClient.Set(ctx, progressKey, progress, model.VerificationQueue.IdempotencyTTL); err != nil {
+			return fmt.Errorf("save verification progress: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// processVerificationTask runs the steps of the task that have not completed in the progress,
+// the progress is saved after each step.
+func (d *Distributor) processVerificationTask(ctx context.Context, task *model.VerificationTask, progress *model.VerificationProgress, saveProgress func(progress *model.VerificationProgress) error) error {
+	// The responses are given points, which updates the request counters and the scores of the Nodes.
+	if progress.Responses == nil {
+		responses := task.DataResponses()
+
+		if err := d.verifyVerificationResponses(ctx, task.Type, responses); err != nil {
+			return err
+		}
+
+		progress.Responses = model.NewVerificationResponses(responses)
+
+		if err := saveProgress(progress); err != nil {
+			return err
+		}
+	}
+
+	responses := model.NewDataResponses(progress.Responses)
+
+	if !progress.Saved {
+		epochID, err := d.processNodeInvalidResponse(ctx, responses)
+		if err != nil {
+			return err
+		}
+
+		progress.Saved, progress.EpochID = true, epochID
+
+		if err := saveProgress(progress); err != nil {
+			return err
+		}
+	}
+
+	zap.L().Info("complete responses verify", zap.String("type", task.Type), zap.Any("responses", len(responses)))
+
+	// The activities are partially verified only if some responses are invalid.
+	if task.Type != model.VerificationTaskTypeActivities || progress.EpochID == 0 || progress.PartiallyVerified {
+		return nil
+	}
+
+	d.simpleEnforcer.VerifyPartialResponses(ctx, progress.EpochID, responses)
+
+	progress.PartiallyVerified = true
+
+	return saveProgress(progress)
+}
+
+// verifyVerificationResponses gives points to the responses by the verification of the task type.
+func (d *Distributor) verifyVerificationResponses(ctx context.Context, taskType string, responses []*model.DataResponse) error {
+	switch taskType {
+	case model.VerificationTaskTypeRSSHub:
+		if err := d.simpleEnforcer.VerifyRSSHubResponses(ctx, responses); err != nil {
+			return fmt.Errorf("verify rss hub responses: %w", err)
+		}
+	case model.VerificationTaskTypeActivity:
+		if err := d.simpleEnforcer.VerifyResponses(ctx, responses); err != nil {
+			return fmt.Errorf("verify activity id responses: %w", err)
+		}
+	case model.VerificationTaskTypeActivities:
+		if err := d.simpleEnforcer.VerifyResponses(ctx, responses); err != nil {
+			return fmt.Errorf("verify activity responses: %w", err)
+		}
+	default:
+		return fmt.Errorf("invalid verification task type: %s", taskType)
+	}
+
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 type Enforcer interface {
 	VerifyResponses(ctx context.Context, responses []*model.DataResponse) error
 	VerifyPartialResponses(ctx context.Context, epochID uint64, responses []*model.DataResponse)
+	VerifyRSSHubResponses(ctx context.Context, responses []*model.DataResponse) error
 	MaintainReliabilityScore(ctx context.Context) error
 	MaintainEpochData(ctx context.Context, epoch int64) error
 	ChallengeStates(ctx context.Context) error
@@ -143,9 +144,9 @@ func (e *SimpleEnforcer) RetrieveQualifiedNodes(ctx context.Context, key string)
 
 	switch key {
 	case model.RssNodeCacheKey:
-		nodesCache, err = e.rssNodeScoreMaintainer.retrieveQualifiedNodes(ctx, key, model.RequiredQualifiedNodeCount)
+		nodesCache, err = e.rssNodeScoreMaintainer.retrieveQualifiedNodes(ctx, model.RequiredQualifiedNodeCount)
 	case model.FullNodeCacheKey:
-		nodesCache, err = e.fullNodeScoreMaintainer.retrieveQualifiedNodes(ctx, key, model.RequiredQualifiedNodeCount)
+		nodesCache, err = e.fullNodeScoreMaintainer.retrieveQualifiedNodes(ctx, model.RequiredQualifiedNodeCount)
 	default:
 		return nil, fmt.Errorf("unknown cache key: %s", key)
 	}
@@ -175,7 +176,10 @@ func NewSimpleEnforcer(ctx context.Context, databaseClient database.Client, cach
 			return nil, err
 		}
 
-		subscribeNodeCacheUpdate(ctx, cacheClient, databaseClient, enforcer.fullNodeScoreMaintainer, enforcer.rssNodeScoreMaintainer)
+		subscribeNodeCacheUpdate(ctx, cacheClient, enforcer.fullNodeScoreMaintainer, enforcer.rssNodeScoreMaintainer)
+
+		enforcer.fullNodeScoreMaintainer.subscribeRegistryUpdate(ctx)
+		enforcer.rssNodeScoreMaintainer.subscribeRegistryUpdate(ctx)
 	}
 
 	return enforcer, nil
@@ -183,8 +187,9 @@ func NewSimpleEnforcer(ctx context.Context, databaseClient database.Client, cach
 
 // subscribeNodeCacheUpdate subscribes to updates of the 'epoch' key.
 // Upon updating the 'epoch' key, the Node cache is refreshed.
-// This cache holds the initial reliability scores and related maps of the nodes for the new epoch.
-func subscribeNodeCacheUpdate(ctx context.Context, cacheClient cache.Client, databaseClient database.Client, fullNodeScoreMaintainer, rssNodeScoreMaintainer *ScoreMaintainer) {
+// This cache holds the initial reliability scores and related maps of the nodes for the new epoch,
+// the registries of the node endpoints have been replaced for the new epoch before the 'epoch' key is updated.
+func subscribeNodeCacheUpdate(ctx context.Context, cacheClient cache.Client, fullNodeScoreMaintainer, rssNodeScoreMaintainer *ScoreMaintainer) {
 	go func() {
 		//Subscribe to changes to 'epoch'
 		pubsub := cacheClient.PSubscribe(ctx, fmt.Sprintf("__keyspace@*__:%s", model.SubscribeNodeCacheKey))
@@ -214,8 +219,11 @@ func subscribeNodeCacheUpdate(ctx context.Context, cacheClient cache.Client, dat
 					continue
 				}
 
-				updateQualifiedNodesMap(ctx, model.FullNodeCacheKey, databaseClient, fullNodeScoreMaintainer)
-				updateQualifiedNodesMap(ctx, model.RssNodeCacheKey, databaseClient, rssNodeScoreMaintainer)
+				for _, scoreMaintainer := range []*ScoreMaintainer{fullNodeScoreMaintainer, rssNodeScoreMaintainer} {
+					if err := scoreMaintainer.reload(ctx); err != nil {
+						zap.L().Error("reload node endpoint registry", zap.Error(err), zap.Int64("epoch", epoch))
+					}
+				}
 
 				zap.L().Info("update qualified nodes map completed", zap.Int64("epoch", epoch))
 
@@ -232,18 +240,6 @@ func subscribeNodeCacheUpdate(ctx context.Context, cacheClient cache.Client, dat
 	}()
 }
 
-// updateQualifiedNodesMap retrieves the node endpoint caches from the database and updates the score maintainer's map of qualified nodes.
-func updateQualifiedNodesMap(ctx context.Context, key string, databaseClient database.Client, scoreMaintainer *ScoreMaintainer) {
-	nodes, err := retrieveNodeStatsFromDB(ctx, key, databaseClient)
-	if err != nil {
-		zap.L().Error("get nodes from db", zap.Error(err))
-	}
-
-	if err = scoreMaintainer.updateQualifiedNodesMap(ctx, nodes); err != nil {
-		zap.L().Error("update qualified nodes map", zap.Error(err))
-	}
-}
-
 // initScoreMaintainers initializes the score maintainers for the full and rss nodes.
 func (e *SimpleEnforcer) initScoreMaintainers(ctx context.Context) error {
 	var err error
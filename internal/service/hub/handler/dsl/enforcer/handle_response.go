@@ -12,6 +12,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/rss3-network/global-indexer/internal/webhook"
 	"github.com/rss3-network/global-indexer/schema"
 	"github.com/rss3-network/node/schema/worker/decentralized"
 	"github.com/samber/lo"
@@ -34,13 +35,13 @@ func (e *SimpleEnforcer) updateCacheRequest(ctx context.Context, responses []*mo
 
 		statsPool.Go(func(ctx context.Context) error {
 			if response.InvalidPoint > 0 {
-				if err := e.cacheClient.IncrBy(ctx, formatNodeStatRedisKey(model.InvalidRequestCount, response.Address.String()), int64(response.InvalidPoint)); err != nil {
+				if err := e.cacheClient.IncrBy(ctx, model.FormatNodeStatRedisKey(model.InvalidRequestCount, response.Address.String()), int64(response.InvalidPoint)); err != nil {
 					return err
 				}
 			}
 
 			if response.ValidPoint > 0 {
-				if err := e.cacheClient.IncrBy(ctx, formatNodeStatRedisKey(model.ValidRequestCount, response.Address.String()), int64(response.ValidPoint)); err != nil {
+				if err := e.cacheClient.IncrBy(ctx, model.FormatNodeStatRedisKey(model.ValidRequestCount, response.Address.String()), int64(response.ValidPoint)); err != nil {
 					return err
 				}
 			}
@@ -116,11 +117,11 @@ func (e *SimpleEnforcer) batchUpdateScoreMaintainer(ctx context.Context, respons
 }
 
 func (e *SimpleEnforcer) updateScoreMaintainer(ctx context.Context, nodeStat *schema.Stat) {
-	if err := e.fullNodeScoreMaintainer.addOrUpdateScore(ctx, model.FullNodeCacheKey, nodeStat); err != nil {
+	if err := e.fullNodeScoreMaintainer.addOrUpdateScore(ctx, nodeStat); err != nil {
 		zap.L().Error("failed to update full node score", zap.Error(err), zap.String("address", nodeStat.Address.String()))
 	}
 
-	if err := e.rssNodeScoreMaintainer.addOrUpdateScore(ctx, model.RssNodeCacheKey, nodeStat); err != nil {
+	if err := e.rssNodeScoreMaintainer.addOrUpdateScore(ctx, nodeStat); err != nil {
 		zap.L().Error("failed to update rss node score", zap.Error(err), zap.String("address", nodeStat.Address.String()))
 	}
 }
@@ -255,6 +256,10 @@ func (e *SimpleEnforcer) verifyActivityByStats(ctx context.Context, activity *mo
 
 				if err = e.databaseClient.SaveNodeInvalidResponses(ctx, []*schema.NodeInvalidResponse{nodeInvalidResponse}); err != nil {
 					zap.L().Error("save node invalid response", zap.Error(err))
+				} else if events, err := webhook.NewNodeInvalidResponseEvents([]*schema.NodeInvalidResponse{nodeInvalidResponse}); err != nil {
+					zap.L().Error("new node invalid response events", zap.Error(err))
+				} else if err = e.databaseClient.SaveWebhookEvents(ctx, events); err != nil {
+					zap.L().Error("save node invalid response events", zap.Error(err))
 				}
 			}
 
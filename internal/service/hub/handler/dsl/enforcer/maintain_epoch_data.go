@@ -564,6 +564,10 @@ func (e *SimpleEnforcer) updateSortedSetForNodeType(ctx context.Context, key str
 		return err
 	}
 
+	nodeStats = lo.Filter(nodeStats, func(stat *schema.Stat, _ int) bool {
+		return stat.EpochInvalidRequest < int64(model.DemotionCountBeforeSlashing)
+	})
+
 	nodesEndpointCachesMap := lo.SliceToMap(nodeStats, func(stat *schema.Stat) (string, *EndpointCache) {
 		return stat.Address.String(), &EndpointCache{
 			Endpoint:    stat.Endpoint,
@@ -571,44 +575,17 @@ func (e *SimpleEnforcer) updateSortedSetForNodeType(ctx context.Context, key str
 		}
 	})
 
-	// Get current members from Redis sorted set
-	members, err := e.cacheClient.ZRevRangeWithScores(ctx, key, 0, -1)
-	if err != nil {
-		return err
-	}
-
-	// Prepare batch operations for Redis Sorted Set
-	membersToAdd, membersToRemove := prepareMembers(members, nodesEndpointCachesMap, nodeStats)
-
-	if len(membersToRemove) > 0 {
-		if err = e.cacheClient.ZRem(ctx, key, membersToRemove); err != nil {
-			return fmt.Errorf("failed to remove old members: %w", err)
+	members := lo.Map(nodeStats, func(stat *schema.Stat, _ int) redis.Z {
+		return redis.Z{
+			Member: stat.Address.String(),
+			Score:  stat.Score,
 		}
-	}
+	})
 
-	if len(membersToAdd) > 0 {
-		if err = e.cacheClient.ZAdd(ctx, key, membersToAdd...); err != nil {
-			return fmt.Errorf("failed to add new members: %w", err)
-		}
+	// Replace the registry and the members of the sorted set at once, the hubs reload the registry once the epoch is set.
+	if _, err = replaceNodes(ctx, e.cacheClient, key, nodesEndpointCachesMap, members); err != nil {
+		return fmt.Errorf("failed to replace nodes: %w", err)
 	}
 
 	return nil
 }
-
-// prepareMembers prepares the members to add and remove in the sorted set.
-func prepareMembers(members []redis.Z, nodesEndpointCachesMap map[string]*EndpointCache, nodeStats []*schema.Stat) ([]redis.Z, []string) {
-	membersToRemove := filterMembersToRemove(members, nodesEndpointCachesMap)
-
-	membersToAdd := make([]redis.Z, 0)
-
-	for _, stat := range nodeStats {
-		if stat.EpochInvalidRequest < int64(model.DemotionCountBeforeSlashing) {
-			membersToAdd = append(membersToAdd, redis.Z{
-				Member: stat.Address.String(),
-				Score:  stat.Score,
-			})
-		}
-	}
-
-	return membersToAdd, membersToRemove
-}
@@ -15,6 +15,7 @@ import (
 	stakingv2 "github.com/rss3-network/global-indexer/contract/l2/staking/v2"
 	"github.com/rss3-network/global-indexer/internal/database"
 	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/rss3-network/global-indexer/internal/webhook"
 	"github.com/rss3-network/global-indexer/schema"
 	"github.com/samber/lo"
 	"github.com/sourcegraph/conc/pool"
@@ -22,26 +23,9 @@ import (
 )
 
 const (
-	stakingToScoreRate           float64 = 100000
-	stakingLogBase                       = 2
-	stakingMaxScore                      = 0.2
-	hoursPerEpoch                        = 18
-	activeTimeToScoreRate                = 120
-	activeTimeMaxScore                   = 0.3
-	totalReqToScoreRate                  = 100000
-	totalReqLogBase                      = 100
-	totalReqMaxScore                     = 0.3
-	totalEpochReqToScoreRate             = 1000000
-	totalEpochReqLogBase                 = 5000
-	totalEpochReqMaxScore                = 1
-	perDecentralizedNetworkScore         = 0.1
-	perRssNetworkScore                   = 0.3
-	perFederatedNetworkScore             = 0.1
-	perIndexerScore                      = 0.05
-	indexerMaxScore                      = 0.2
-	perSlashScore                        = 0.5
-	nonExistScore                float64 = 0
-	existScore                           = 1
+	hoursPerEpoch         = 18
+	nonExistScore float64 = 0
+	existScore    float64 = 1
 
 	defaultLimit = 50
 )
@@ -84,41 +68,83 @@ func (e *SimpleEnforcer) getAllNodeStats(ctx context.Context, query *schema.Stat
 	return stats, nil
 }
 
-// processNodeStats processes the node statistics in parallel.
+// processNodeStats processes the node statistics in parallel, and records the breakdowns of their scores.
 func (e *SimpleEnforcer) processNodeStats(ctx context.Context, stats []*schema.Stat, reset bool) error {
-	if err := e.updateNodeStats(ctx, stats, reset); err != nil {
+	previousScores := lo.Map(stats, func(stat *schema.Stat, _ int) float64 {
+		return stat.Score
+	})
+
+	scores, err := e.updateNodeStats(ctx, stats, reset)
+	if err != nil {
+		return err
+	}
+
+	if err := e.databaseClient.SaveNodeStats(ctx, stats); err != nil {
 		return err
 	}
 
-	return e.databaseClient.SaveNodeStats(ctx, stats)
+	if err := e.databaseClient.SaveNodeScores(ctx, scores); err != nil {
+		return err
+	}
+
+	// The scores are expected to drop when the Epoch requests are reset.
+	if !reset {
+		if err := e.databaseClient.SaveWebhookEvents(ctx, newScoreDroppedEvents(scores, previousScores)); err != nil {
+			zap.L().Error("save node score dropped events", zap.Error(err))
+		}
+	}
+
+	return nil
 }
 
-func (e *SimpleEnforcer) updateNodeStats(ctx context.Context, stats []*schema.Stat, reset bool) error {
+// newScoreDroppedEvents returns the webhook events of the scores that dropped by at least the ScoreDropRatio.
+func newScoreDroppedEvents(scores []*schema.NodeScore, previousScores []float64) []*schema.WebhookEvent {
+	events := make([]*schema.WebhookEvent, 0)
+
+	for i, score := range scores {
+		if previousScores[i] <= 0 || (previousScores[i]-score.Score)/previousScores[i] < model.ScoreDropRatio {
+			continue
+		}
+
+		event, err := webhook.NewNodeScoreDroppedEvent(score, previousScores[i])
+		if err != nil {
+			zap.L().Error("new node score dropped event", zap.Error(err), zap.String("address", score.NodeAddress.String()))
+
+			continue
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
+func (e *SimpleEnforcer) updateNodeStats(ctx context.Context, stats []*schema.Stat, reset bool) ([]*schema.NodeScore, error) {
 	// Retrieve all Node addresses.
 	nodeAddresses := extractNodeAddresses(stats)
 
 	// Retrieve node information from the blockchain.
 	nodesInfo, err := e.getNodesInfoFromBlockchain(nodeAddresses)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Check if the length of NodesInfo and stats is the same.
 	// TODO: If not, consider to process the queried nodes as much as possible
 	if len(nodesInfo) != len(stats) {
-		return fmt.Errorf("get Nodes info from blockchain: %d,%d", len(nodesInfo), len(stats))
+		return nil, fmt.Errorf("get Nodes info from blockchain: %d,%d", len(nodesInfo), len(stats))
 	}
 
 	// Retrieve node information from the database.
 	nodes, err := e.getNodesInfoFromDatabase(ctx, nodeAddresses)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Check if the length of Nodes and stats is the same.
 	// TODO: If not, consider to process the queried nodes as much as possible
 	if len(nodes) != len(stats) {
-		return fmt.Errorf("get Nodes info from database: %d,%d", len(nodes), len(stats))
+		return nil, fmt.Errorf("get Nodes info from database: %d,%d", len(nodes), len(stats))
 	}
 
 	nodes = sortNodes(nodeAddresses, nodes)
@@ -150,7 +176,9 @@ func sortNodes(nodeAddresses []common.Address, nodes []*schema.Node) []*schema.N
 }
 
 // updateStatsInPool concurrently updates the stats of the Nodes.
-func (e *SimpleEnforcer) updateStatsInPool(ctx context.Context, stats []*schema.Stat, nodesInfo []stakingv2.DataTypesNode, nodes []*schema.Node, reset bool) error {
+func (e *SimpleEnforcer) updateStatsInPool(ctx context.Context, stats []*schema.Stat, nodesInfo []stakingv2.DataTypesNode, nodes []*schema.Node, reset bool) ([]*schema.NodeScore, error) {
+	scores := make([]*schema.NodeScore, len(stats))
+
 	statsPool := pool.New().WithContext(ctx).WithMaxGoroutines(lo.Ternary(len(stats) < 20*runtime.NumCPU() && len(stats) > 0, len(stats), 20*runtime.NumCPU()))
 
 	for i := range stats {
@@ -160,7 +188,7 @@ func (e *SimpleEnforcer) updateStatsInPool(ctx context.Context, stats []*schema.
 			var validCount int64
 
 			// Get the latest valid request count from the cache.
-			if err := e.cacheClient.Get(ctx, formatNodeStatRedisKey(model.ValidRequestCount, stats[i].Address.String()), &validCount); err != nil && !errors.Is(err, redis.Nil) {
+			if err := e.cacheClient.Get(ctx, model.FormatNodeStatRedisKey(model.ValidRequestCount, stats[i].Address.String()), &validCount); err != nil && !errors.Is(err, redis.Nil) {
 				return fmt.Errorf("get valid request count: %w", err)
 			}
 
@@ -177,81 +205,114 @@ func (e *SimpleEnforcer) updateStatsInPool(ctx context.Context, stats []*schema.
 			// If the reset flag is true, initialize the valid and invalid request counts to zero,
 			// effectively resetting the node's counters for the new epoch.
 			if !reset {
-				if err := e.cacheClient.Get(ctx, formatNodeStatRedisKey(model.InvalidRequestCount, stats[i].Address.String()), &stats[i].EpochInvalidRequest); err != nil && !errors.Is(err, redis.Nil) {
+				if err := e.cacheClient.Get(ctx, model.FormatNodeStatRedisKey(model.InvalidRequestCount, stats[i].Address.String()), &stats[i].EpochInvalidRequest); err != nil && !errors.Is(err, redis.Nil) {
 					return fmt.Errorf("get invalid request count: %w", err)
 				}
 			} else {
+				// The requests counted until the new Epoch are the ones settled in it, so that they can be reconciled with the rewards.
+				stats[i].LastEpochRequest = stats[i].EpochRequest
 				stats[i].EpochRequest = 0
 
-				if err := e.cacheClient.Set(ctx, formatNodeStatRedisKey(model.ValidRequestCount, stats[i].Address.String()), 0, 0); err != nil {
+				if err := e.cacheClient.Set(ctx, model.FormatNodeStatRedisKey(model.ValidRequestCount, stats[i].Address.String()), 0, 0); err != nil {
 					return fmt.Errorf("reset valid request count: %w", err)
 				}
 
-				if err := e.cacheClient.Set(ctx, formatNodeStatRedisKey(model.InvalidRequestCount, stats[i].Address.String()), stats[i].EpochInvalidRequest, 0); err != nil {
+				if err := e.cacheClient.Set(ctx, model.FormatNodeStatRedisKey(model.InvalidRequestCount, stats[i].Address.String()), stats[i].EpochInvalidRequest, 0); err != nil {
 					return fmt.Errorf("reset invalid request count: %w", err)
 				}
 			}
 
-			updateNodeStat(stats[i], nodesInfo[i].StakingPoolTokens, nodes[i].Status)
+			// Get the failure rate of the recent health probes from the cache, it is reset if the prober has not probed the node recently.
+			stats[i].ProbeFailureRate = 0
+
+			if err := e.cacheClient.Get(ctx, model.FormatNodeStatRedisKey(model.NodeProbeFailureRate, stats[i].Address.String()), &stats[i].ProbeFailureRate); err != nil && !errors.Is(err, redis.Nil) {
+				return fmt.Errorf("get probe failure rate: %w", err)
+			}
+
+			scores[i] = updateNodeStat(stats[i], nodesInfo[i].StakingPoolTokens, nodes[i].Status)
 
 			return nil
 		})
 	}
 
-	return statsPool.Wait()
+	if err := statsPool.Wait(); err != nil {
+		return nil, err
+	}
+
+	return scores, nil
 }
 
-// updateNodeStat updates Node's stat with Reliability Score.
-func updateNodeStat(stat *schema.Stat, staking *big.Int, status schema.NodeStatus) {
+// updateNodeStat updates Node's stat with Reliability Score, and returns the breakdown of the score.
+func updateNodeStat(stat *schema.Stat, staking *big.Int, status schema.NodeStatus) *schema.NodeScore {
 	// Convert the staking to float64.
 	stat.Staking, _ = staking.Div(staking, big.NewInt(1e18)).Float64()
 
-	if status != schema.NodeStatusOnline {
-		// If Node's status is not online, then reset the alive time.
+	if status != schema.NodeStatusOnline && status != schema.NodeStatusDegraded {
+		// If Node's status is neither online nor degraded, then reset the alive time.
 		stat.ResetAt = time.Now()
 	}
 
 	// Calculate the Reliability Score.
-	calculateReliabilityScore(stat)
+	components := calculateReliabilityScore(stat)
+
+	return &schema.NodeScore{
+		NodeAddress:  stat.Address,
+		EpochID:      uint64(stat.Epoch),
+		ModelVersion: model.ReliabilityScore.Version,
+		Score:        stat.Score,
+		Components:   components,
+		UpdatedAt:    time.Now().Unix(),
+	}
 }
 
-// calculateReliabilityScore calculates the Reliability Score σ of a given Node.
+// calculateReliabilityScore calculates the Reliability Score σ of a given Node with the reliability score model.
 // σ is used to determine the probability of a Node receiving a request on DSL.
-func calculateReliabilityScore(stat *schema.Stat) {
-	// staking pool tokens
-	// maximum score is 0.2
-	stat.Score = math.Min(math.Log(stat.Staking/stakingToScoreRate+1)/math.Log(stakingLogBase), stakingMaxScore)
-
-	// public good node
-	// If the Node is a public good node, then the score is 0
-	// Otherwise, the score is 1
-	stat.Score += lo.Ternary(stat.IsPublicGood, nonExistScore, existScore)
+// It returns the breakdown of σ into the scores of the components of the model.
+func calculateReliabilityScore(stat *schema.Stat) []*schema.NodeScoreComponent {
+	scoreModel := model.ReliabilityScore
+
+	components := []*schema.NodeScoreComponent{
+		// staking pool tokens
+		newNodeScoreComponent("staking", scoreModel.Staking, stat.Staking),
+		// public good node
+		newNodeScoreComponent("public_good", scoreModel.PublicGood, lo.Ternary(stat.IsPublicGood, nonExistScore, existScore)),
+		// node active time in epochs
+		newNodeScoreComponent("active_time", scoreModel.ActiveTime, math.Ceil(time.Since(stat.ResetAt).Hours()/hoursPerEpoch)),
+		// total requests
+		newNodeScoreComponent("total_requests", scoreModel.TotalRequests, float64(stat.TotalRequest)),
+		// epoch requests
+		newNodeScoreComponent("epoch_requests", scoreModel.EpochRequests, float64(stat.EpochRequest)),
+		// network count
+		newNodeScoreComponent("networks", scoreModel.Networks, float64(stat.DecentralizedNetwork+stat.FederatedNetwork)),
+		newNodeScoreComponent("rss_network", scoreModel.RSSNetwork, lo.Ternary(stat.IsRssNode, existScore, nonExistScore)),
+		// indexer count
+		newNodeScoreComponent("indexers", scoreModel.Indexers, float64(stat.Indexer)),
+		// health probes
+		newNodeScoreComponent("probe_failures", scoreModel.ProbeFailures, stat.ProbeFailureRate),
+	}
 
-	// node active time
-	// maximum score is 0.3
-	// If node is active for about 2 epochs, then the score is 0.3
-	stat.Score += math.Min(math.Ceil(time.Since(stat.ResetAt).Hours()/hoursPerEpoch)/activeTimeToScoreRate, activeTimeMaxScore)
+	stat.Score = lo.SumBy(components, func(component *schema.NodeScoreComponent) float64 {
+		return component.Score
+	})
 
-	// total requests
-	// maximum score is 0.3
-	stat.Score += math.Min(math.Log(float64(stat.TotalRequest)/totalReqToScoreRate+1)/math.Log(totalReqLogBase), totalReqMaxScore)
+	// invalid request count in the current Epoch
+	invalidRequests := newNodeScoreComponent("invalid_requests", scoreModel.InvalidRequests, float64(stat.EpochInvalidRequest))
 
-	// epoch requests
-	// maximum score is 1
-	stat.Score += math.Min(math.Log(float64(stat.EpochRequest)/totalEpochReqToScoreRate+1)/math.Log(totalEpochReqLogBase), totalEpochReqMaxScore)
+	if stat.EpochInvalidRequest >= int64(model.DemotionCountBeforeSlashing) {
+		// If the number of invalid requests in the epoch is greater than the threshold, then the score is 0.
+		invalidRequests.Score = -stat.Score
+	}
 
-	// network count
-	stat.Score += perDecentralizedNetworkScore*float64(stat.DecentralizedNetwork+stat.FederatedNetwork) + perRssNetworkScore*lo.Ternary(stat.IsRssNode, existScore, nonExistScore)
+	stat.Score += invalidRequests.Score
 
-	// indexer count
-	// maximum score is 0.2
-	stat.Score += math.Min(float64(stat.Indexer)*perIndexerScore, indexerMaxScore)
+	return append(components, invalidRequests)
+}
 
-	// invalid request count in the current Epoch
-	if stat.EpochInvalidRequest >= int64(model.DemotionCountBeforeSlashing) {
-		// If the number of invalid requests in the epoch is greater than the threshold, then the score is 0.
-		stat.Score = 0
-	} else {
-		stat.Score -= perSlashScore * float64(stat.EpochInvalidRequest)
+// newNodeScoreComponent scores the value with the component of the reliability score model.
+func newNodeScoreComponent(name string, component *model.ScoreComponent, value float64) *schema.NodeScoreComponent {
+	return &schema.NodeScoreComponent{
+		Name:  name,
+		Value: value,
+		Score: component.Score(value),
 	}
 }
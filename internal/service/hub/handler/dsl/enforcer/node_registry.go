@@ -0,0 +1,283 @@
+package enforcer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/internal/cache"
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+)
+
+// registryEntry is an entry of the registry of the Node endpoints shared by all hubs,
+// it is stamped with the version of the registry that wrote it.
+type registryEntry struct {
+	Endpoint    string `json:"endpoint"`
+	AccessToken string `json:"access_token"`
+	Version     int64  `json:"version"`
+}
+
+// upsertNodeScript adds or updates a Node in the sorted set and in the registry atomically.
+// The version of the registry is bumped and published only if the endpoint or the access token of the Node changed.
+// KEYS: the sorted set, the registry and the version of the registry.
+// ARGV: the channel, the address, the score and the registry entry of the Node.
+// It returns the new version of the registry, or 0 if the registry is unchanged.
+var upsertNodeScript = cache.NewScript(`
+local entry = cjson.decode(ARGV[4])
+redis.call("ZADD", KEYS[1], ARGV[3], ARGV[2])
+
+local current = redis.call("HGET", KEYS[2], ARGV[2])
+if current then
+	current = cjson.decode(current)
+	if current.endpoint == entry.endpoint and current.access_token == entry.access_token then
+		return 0
+	end
+end
+
+local version = redis.call("INCR", KEYS[3])
+entry.version = version
+redis.call("HSET", KEYS[2], ARGV[2], cjson.encode(entry))
+redis.call("PUBLISH", ARGV[1], version)
+
+return version
+`, func(tx cache.ScriptTx, keys []string, args []interface{}) (interface{}, error) {
+	address := args[1].(string)
+
+	score, err := strconv.ParseFloat(args[2].(string), 64)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry registryEntry
+
+	if err := json.Unmarshal([]byte(args[3].(string)), &entry); err != nil {
+		return nil, err
+	}
+
+	if err := tx.ZAdd(keys[0], redis.Z{Member: address, Score: score}); err != nil {
+		return nil, err
+	}
+
+	data, exists, err := tx.HGet(keys[1], address)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		var current registryEntry
+
+		if err := json.Unmarshal([]byte(data), &current); err != nil {
+			return nil, err
+		}
+
+		if current.Endpoint == entry.Endpoint && current.AccessToken == entry.AccessToken {
+			return int64(0), nil
+		}
+	}
+
+	return putRegistryEntries(tx, keys, args[0].(string), []string{address}, []registryEntry{entry})
+})
+
+// replaceNodesScript replaces the registry, adds the members to the sorted set
+// and removes the members that are not in the registry from the sorted set atomically.
+// KEYS: the sorted set, the registry and the version of the registry.
+// ARGV: the channel, the number of the registry entries n, n pairs of address and registry entry, and pairs of address and score.
+// It returns the new version of the registry.
+var replaceNodesScript = cache.NewScript(`
+local version = redis.call("INCR", KEYS[3])
+redis.call("DEL", KEYS[2])
+
+local count = tonumber(ARGV[2])
+local registered = {}
+
+for i = 3, 2 + 2 * count, 2 do
+	local entry = cjson.decode(ARGV[i + 1])
+	entry.version = version
+	redis.call("HSET", KEYS[2], ARGV[i], cjson.encode(entry))
+	registered[ARGV[i]] = true
+end
+
+for i = 3 + 2 * count, #ARGV, 2 do
+	redis.call("ZADD", KEYS[1], ARGV[i + 1], ARGV[i])
+end
+
+for _, member in ipairs(redis.call("ZRANGE", KEYS[1], 0, -1)) do
+	if not registered[member] then
+		redis.call("ZREM", KEYS[1], member)
+	end
+end
+
+redis.call("PUBLISH", ARGV[1], version)
+
+return version
+`, func(tx cache.ScriptTx, keys []string, args []interface{}) (interface{}, error) {
+	count, err := strconv.Atoi(args[1].(string))
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, 0, count)
+	entries := make([]registryEntry, 0, count)
+
+	for i := 2; i < 2+2*count; i += 2 {
+		var entry registryEntry
+
+		if err := json.Unmarshal([]byte(args[i+1].(string)), &entry); err != nil {
+			return nil, err
+		}
+
+		addresses = append(addresses, args[i].(string))
+		entries = append(entries, entry)
+	}
+
+	for i := 2 + 2*count; i < len(args); i += 2 {
+		score, err := strconv.ParseFloat(args[i+1].(string), 64)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := tx.ZAdd(keys[0], redis.Z{Member: args[i], Score: score}); err != nil {
+			return nil, err
+		}
+	}
+
+	members, err := tx.ZMembers(keys[0])
+	if err != nil {
+		return nil, err
+	}
+
+	registered := make(map[string]struct{}, count)
+
+	for _, address := range addresses {
+		registered[address] = struct{}{}
+	}
+
+	for _, member := range members {
+		if _, ok := registered[member]; !ok {
+			if err := tx.ZRem(keys[0], member); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	tx.Del(keys[1])
+
+	return putRegistryEntries(tx, keys, args[0].(string), addresses, entries)
+})
+
+// putRegistryEntries bumps the version of the registry, puts the entries stamped with it and publishes it.
+func putRegistryEntries(tx cache.ScriptTx, keys []string, channel string, addresses []string, entries []registryEntry) (int64, error) {
+	version, err := tx.IncrBy(keys[2], 1)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, entry := range entries {
+		entry.Version = version
+
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return 0, err
+		}
+
+		if err := tx.HSet(keys[1], addresses[i], string(data)); err != nil {
+			return 0, err
+		}
+	}
+
+	tx.Publish(channel, strconv.FormatInt(version, 10))
+
+	return version, nil
+}
+
+// upsertNode adds or updates the Node in the sorted set and in the registry.
+// It returns the new version of the registry, or 0 if the registry is unchanged.
+func upsertNode(ctx context.Context, cacheClient cache.Client, setKey, address string, score float64, endpointCache *EndpointCache) (int64, error) {
+	data, err := json.Marshal(registryEntry{Endpoint: endpointCache.Endpoint, AccessToken: endpointCache.AccessToken})
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := cacheClient.RunScript(ctx, upsertNodeScript, registryKeys(setKey), formatRegistryChannel(setKey), address, formatScore(score), string(data))
+	if err != nil {
+		return 0, err
+	}
+
+	return parseRegistryVersion(result)
+}
+
+// replaceNodes replaces the registry with the endpoint caches and adds the members to the sorted set,
+// the members without an endpoint cache are removed from the sorted set. It returns the new version of the registry.
+func replaceNodes(ctx context.Context, cacheClient cache.Client, setKey string, endpointCaches map[string]*EndpointCache, members []redis.Z) (int64, error) {
+	args := make([]interface{}, 0, 2+2*len(endpointCaches)+2*len(members))
+	args = append(args, formatRegistryChannel(setKey), strconv.Itoa(len(endpointCaches)))
+
+	for address, endpointCache := range endpointCaches {
+		data, err := json.Marshal(registryEntry{Endpoint: endpointCache.Endpoint, AccessToken: endpointCache.AccessToken})
+		if err != nil {
+			return 0, err
+		}
+
+		args = append(args, address, string(data))
+	}
+
+	for _, member := range members {
+		args = append(args, member.Member, formatScore(member.Score))
+	}
+
+	result, err := cacheClient.RunScript(ctx, replaceNodesScript, registryKeys(setKey), args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return parseRegistryVersion(result)
+}
+
+// parseRegistryVersion parses the version of the registry returned by a script.
+func parseRegistryVersion(result interface{}) (int64, error) {
+	version, ok := result.(int64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected registry version %v", result)
+	}
+
+	return version, nil
+}
+
+// parseRegistry parses the entries of the registry into endpoint caches.
+func parseRegistry(entries map[string]string) (map[string]*EndpointCache, error) {
+	endpointCaches := make(map[string]*EndpointCache, len(entries))
+
+	for address, data := range entries {
+		var entry registryEntry
+
+		if err := json.Unmarshal([]byte(data), &entry); err != nil {
+			return nil, fmt.Errorf("parse registry entry of %s: %w", address, err)
+		}
+
+		endpointCaches[address] = &EndpointCache{
+			Endpoint:    entry.Endpoint,
+			AccessToken: entry.AccessToken,
+		}
+	}
+
+	return endpointCaches, nil
+}
+
+// registryKeys returns the keys of the sorted set, the registry and the version of the registry.
+func registryKeys(setKey string) []string {
+	return []string{
+		setKey,
+		fmt.Sprintf(model.NodeEndpointRegistryKey, setKey),
+		fmt.Sprintf(model.NodeEndpointRegistryVersionKey, setKey),
+	}
+}
+
+func formatRegistryChannel(setKey string) string {
+	return fmt.Sprintf(model.NodeEndpointRegistryChannel, setKey)
+}
+
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', -1, 64)
+}
@@ -56,10 +56,10 @@ func retrieveNodeStatsFromDB(ctx context.Context, key string, databaseClient dat
 func getQualifiedNodes(ctx context.Context, stats []*schema.Stat, databaseClient database.Client) ([]*schema.Stat, error) {
 	nodeAddresses := extractNodeAddresses(stats)
 
-	// Retrieve the online Nodes from the database.
+	// Retrieve the online and degraded Nodes from the database.
 	nodes, err := databaseClient.FindNodes(ctx, schema.FindNodesQuery{
 		NodeAddresses: nodeAddresses,
-		Status:        lo.ToPtr(schema.NodeStatusOnline),
+		Statuses:      []schema.NodeStatus{schema.NodeStatusOnline, schema.NodeStatusDegraded},
 	})
 
 	if err != nil {
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"runtime"
+	"strconv"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -18,12 +20,15 @@ import (
 )
 
 // ScoreMaintainer is a structure used to maintain a sorted set and a quick lookup map.
-// It uses Redis to keep a sorted set based on node scores,
-// and a map in memory for fast access to each node endpoint's cached data.
+// It uses Redis to keep a sorted set based on node scores, and a registry of the node endpoints shared by all hubs,
+// the map in memory mirrors the registry for fast access to each node endpoint's cached data.
+// Every change of the registry bumps its version and is published, so that the replicas reload their map.
 // This structure helps in quickly and efficiently updating and retrieving scores and statuses of nodes in distributed systems.
 type ScoreMaintainer struct {
 	cacheClient        cache.Client
+	setKey             string
 	nodeEndpointCaches map[string]*EndpointCache
+	version            int64
 	lock               sync.RWMutex
 }
 
@@ -33,52 +38,80 @@ type EndpointCache struct {
 }
 
 // addOrUpdateScore updates or adds a nodeEndpointCache in the data structure.
-// If the invalidCount is greater than or equal to DemotionCountBeforeSlashing, the nodeEndpointCache is removed.
-func (sm *ScoreMaintainer) addOrUpdateScore(ctx context.Context, setKey string, nodeStat *schema.Stat) error {
+// If the invalidCount is greater than or equal to DemotionCountBeforeSlashing, the node is removed from the sorted set.
+func (sm *ScoreMaintainer) addOrUpdateScore(ctx context.Context, nodeStat *schema.Stat) error {
 	if nodeStat.EpochInvalidRequest >= int64(model.DemotionCountBeforeSlashing) {
-		sm.lock.RLock()
-		_, ok := sm.nodeEndpointCaches[nodeStat.Address.String()]
-		sm.lock.RUnlock()
-
-		if ok {
-			// Fixme: add redis lock
-			// Remove from sorted set.
-			if err := sm.cacheClient.ZRem(ctx, setKey, nodeStat.Address.String()); err != nil {
-				return err
-			}
-		}
-
-		return nil
+		// Remove from sorted set.
+		return sm.cacheClient.ZRem(ctx, sm.setKey, nodeStat.Address.String())
 	}
 
-	sm.lock.Lock()
-	sm.nodeEndpointCaches[nodeStat.Address.String()] = &EndpointCache{
+	endpointCache := &EndpointCache{
 		Endpoint:    nodeStat.Endpoint,
 		AccessToken: nodeStat.AccessToken,
 	}
-	sm.lock.Unlock()
 
-	return sm.cacheClient.ZAdd(ctx, setKey, redis.Z{
-		Member: nodeStat.Address.String(),
-		Score:  nodeStat.Score,
-	})
+	version, err := upsertNode(ctx, sm.cacheClient, sm.setKey, nodeStat.Address.String(), nodeStat.Score, endpointCache)
+	if err != nil {
+		return err
+	}
+
+	sm.lock.Lock()
+	defer sm.lock.Unlock()
+
+	sm.nodeEndpointCaches[nodeStat.Address.String()] = endpointCache
+
+	// The map is up to date with the version only if no other hub changed the registry in between.
+	if version == sm.version+1 {
+		sm.version = version
+	}
+
+	return nil
 }
 
 // retrieveQualifiedNodes returns the top n NodeEndpointCaches from the sorted set.
-func (sm *ScoreMaintainer) retrieveQualifiedNodes(ctx context.Context, setKey string, n int) ([]*model.NodeEndpointCache, error) {
+// The nodes missing from the map are looked up in the shared registry.
+func (sm *ScoreMaintainer) retrieveQualifiedNodes(ctx context.Context, n int) ([]*model.NodeEndpointCache, error) {
 	// Get the top n nodes from the sorted set.
-	result, err := sm.cacheClient.ZRevRangeWithScores(ctx, setKey, 0, int64(n-1))
+	result, err := sm.cacheClient.ZRevRangeWithScores(ctx, sm.setKey, 0, int64(n-1))
 	if err != nil {
 		return nil, err
 	}
 
-	qualifiedNodes := make([]*model.NodeEndpointCache, 0, n)
+	endpointCaches := make(map[string]*EndpointCache, len(result))
+	missing := make([]string, 0)
 
 	sm.lock.RLock()
-	defer sm.lock.RUnlock()
 
 	for _, item := range result {
 		if endpointCache, ok := sm.nodeEndpointCaches[item.Member.(string)]; ok {
+			endpointCaches[item.Member.(string)] = endpointCache
+		} else {
+			missing = append(missing, item.Member.(string))
+		}
+	}
+
+	sm.lock.RUnlock()
+
+	if len(missing) > 0 {
+		entries, err := sm.cacheClient.HMGet(ctx, registryKeys(sm.setKey)[1], missing...)
+		if err != nil {
+			return nil, err
+		}
+
+		registered, err := parseRegistry(entries)
+		if err != nil {
+			return nil, err
+		}
+
+		for address, endpointCache := range registered {
+			endpointCaches[address] = endpointCache
+		}
+	}
+
+	qualifiedNodes := make([]*model.NodeEndpointCache, 0, n)
+
+	for _, item := range result {
+		if endpointCache, ok := endpointCaches[item.Member.(string)]; ok {
 			qualifiedNodes = append(qualifiedNodes, &model.NodeEndpointCache{
 				Address:     item.Member.(string),
 				Endpoint:    endpointCache.Endpoint,
@@ -90,43 +123,77 @@ func (sm *ScoreMaintainer) retrieveQualifiedNodes(ctx context.Context, setKey st
 	return qualifiedNodes, nil
 }
 
-// updateQualifiedNodesMap replaces the current nodeEndpointCaches.
-func (sm *ScoreMaintainer) updateQualifiedNodesMap(ctx context.Context, nodeStats []*schema.Stat) error {
-	nodeStatsCaches := make(map[string]*EndpointCache, len(nodeStats))
-
-	var mu sync.Mutex
-
-	statsPool := pool.New().WithContext(ctx).WithMaxGoroutines(lo.Ternary(len(nodeStats) < 20*runtime.NumCPU() && len(nodeStats) > 0, len(nodeStats), 20*runtime.NumCPU()))
+// reload replaces the nodeEndpointCaches with the shared registry if the registry is not older than the map.
+func (sm *ScoreMaintainer) reload(ctx context.Context) error {
+	keys := registryKeys(sm.setKey)
 
-	for _, stat := range nodeStats {
-		stat := stat
+	var version int64
 
-		statsPool.Go(func(_ context.Context) error {
-			if stat.EpochInvalidRequest < int64(model.DemotionCountBeforeSlashing) {
-				mu.Lock()
-				nodeStatsCaches[stat.Address.String()] = &EndpointCache{
-					Endpoint:    stat.Endpoint,
-					AccessToken: stat.AccessToken,
-				}
-				mu.Unlock()
-			}
+	if err := sm.cacheClient.Get(ctx, keys[2], &version); err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("get registry version: %w", err)
+	}
 
-			return nil
-		})
+	entries, err := sm.cacheClient.HGetAll(ctx, keys[1])
+	if err != nil {
+		return fmt.Errorf("get registry: %w", err)
 	}
 
-	if err := statsPool.Wait(); err != nil {
+	nodeEndpointCaches, err := parseRegistry(entries)
+	if err != nil {
 		return err
 	}
 
 	sm.lock.Lock()
-	sm.nodeEndpointCaches = nodeStatsCaches
-	sm.lock.Unlock()
+	defer sm.lock.Unlock()
+
+	if version >= sm.version {
+		sm.nodeEndpointCaches = nodeEndpointCaches
+		sm.version = version
+	}
 
 	return nil
 }
 
-// newScoreMaintainer creates a new ScoreMaintainer with the nodeEndpointCaches and redis sorted set.
+// subscribeRegistryUpdate subscribes to the new versions of the shared registry,
+// and reloads the nodeEndpointCaches whenever another hub changed the registry.
+// The subscription is created before it returns, so that no version published afterward is missed.
+func (sm *ScoreMaintainer) subscribeRegistryUpdate(ctx context.Context) {
+	pubsub := sm.cacheClient.PSubscribe(ctx, formatRegistryChannel(sm.setKey))
+
+	// Wait for confirmation that subscription is created before proceeding.
+	if _, err := pubsub.Receive(ctx); err != nil {
+		zap.L().Error("subscribe node endpoint registry failed:", zap.Error(err), zap.String("key", sm.setKey))
+
+		os.Exit(1)
+	}
+
+	go func() {
+		defer pubsub.Close()
+
+		for msg := range pubsub.Channel() {
+			version, err := strconv.ParseInt(msg.Payload, 10, 64)
+			if err != nil {
+				zap.L().Error("parse registry version", zap.Error(err), zap.String("payload", msg.Payload))
+
+				continue
+			}
+
+			sm.lock.RLock()
+			outdated := version > sm.version
+			sm.lock.RUnlock()
+
+			if !outdated {
+				continue
+			}
+
+			if err = sm.reload(ctx); err != nil {
+				zap.L().Error("reload node endpoint registry", zap.Error(err), zap.String("key", sm.setKey))
+			}
+		}
+	}()
+}
+
+// newScoreMaintainer creates a new ScoreMaintainer with the nodeEndpointCaches, the shared registry and redis sorted set.
 func newScoreMaintainer(ctx context.Context, setKey string, nodeStats []*schema.Stat, cacheClient cache.Client) (*ScoreMaintainer, error) {
 	// Prepare the node caches and members for the sorted set.
 	nodeEndpointCaches, newMembers, err := prepareNodeCachesAndMembers(ctx, nodeStats, cacheClient)
@@ -134,14 +201,17 @@ func newScoreMaintainer(ctx context.Context, setKey string, nodeStats []*schema.
 		return nil, err
 	}
 
-	// Adjust the members in the sorted set.
-	if err = adjustMembersToSet(ctx, setKey, newMembers, nodeEndpointCaches, cacheClient); err != nil {
+	// Replace the registry and adjust the members in the sorted set.
+	version, err := replaceNodes(ctx, cacheClient, setKey, nodeEndpointCaches, newMembers)
+	if err != nil {
 		return nil, err
 	}
 
 	return &ScoreMaintainer{
 		cacheClient:        cacheClient,
+		setKey:             setKey,
 		nodeEndpointCaches: nodeEndpointCaches,
+		version:            version,
 	}, nil
 }
 
@@ -219,10 +289,10 @@ func prepareNodeCachesAndMembers(ctx context.Context, nodeStats []*schema.Stat,
 // If the key is not found in the cache, it initializes the cache with the provided statCount value.
 // This ensures that all keys have a corresponding value in the cache for accurate tracking and operations.
 func getCacheCount(ctx context.Context, cacheClient cache.Client, key string, address common.Address, resCount *int64, statCount int64) error {
-	if err := cacheClient.Get(ctx, formatNodeStatRedisKey(key, address.String()), resCount); err != nil {
+	if err := cacheClient.Get(ctx, model.FormatNodeStatRedisKey(key, address.String()), resCount); err != nil {
 		if errors.Is(err, redis.Nil) {
 			*resCount = statCount
-			return cacheClient.Set(ctx, formatNodeStatRedisKey(key, address.String()), resCount, 0)
+			return cacheClient.Set(ctx, model.FormatNodeStatRedisKey(key, address.String()), resCount, 0)
 		}
 
 		return err
@@ -230,45 +300,3 @@ func getCacheCount(ctx context.Context, cacheClient cache.Client, key string, ad
 
 	return nil
 }
-
-// adjustMembersToSet modifies the existing members of a sorted set based on specific criteria or updates.
-// This function may add, update, or remove members to ensure the set reflects current data states or conditions.
-func adjustMembersToSet(ctx context.Context, setKey string, newMembers []redis.Z, nodeEndpointCaches map[string]*EndpointCache, cacheClient cache.Client) error {
-	if len(newMembers) > 0 {
-		if err := cacheClient.ZAdd(ctx, setKey, newMembers...); err != nil {
-			return err
-		}
-	}
-
-	members, err := cacheClient.ZRevRangeWithScores(ctx, setKey, 0, -1)
-	if err != nil {
-		return err
-	}
-
-	membersToRemove := filterMembersToRemove(members, nodeEndpointCaches)
-	if len(membersToRemove) > 0 {
-		if err = cacheClient.ZRem(ctx, setKey, membersToRemove); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-// filterMembers filters out the members that are not in the nodeEndpointCaches.
-func filterMembersToRemove(members []redis.Z, nodeEndpointCaches map[string]*EndpointCache) []string {
-	membersToRemove := make([]string, 0, len(members))
-
-	for _, member := range members {
-		if _, ok := nodeEndpointCaches[member.Member.(string)]; !ok {
-			membersToRemove = append(membersToRemove, member.Member.(string))
-		}
-	}
-
-	return membersToRemove
-}
-
-// formatNodeStatRedisKey formats the redis key.
-func formatNodeStatRedisKey(key string, address string) string {
-	return fmt.Sprintf("%s:%s", key, address)
-}
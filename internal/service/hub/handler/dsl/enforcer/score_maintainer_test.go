@@ -3,6 +3,7 @@ package enforcer
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/orlangure/gnomock"
@@ -87,7 +88,7 @@ func TestScoreMaintainer(t *testing.T) {
 	require.NoError(t, err)
 
 	// Retrieve qualified nodes
-	nodes, err := sm.retrieveQualifiedNodes(context.Background(), setKey, 3)
+	nodes, err := sm.retrieveQualifiedNodes(context.Background(), 3)
 	require.NoError(t, err)
 	assert.Equal(t, 3, len(nodes))
 	assert.Equal(t, nodeStats[3].Address.String(), nodes[0].Address)
@@ -96,21 +97,21 @@ func TestScoreMaintainer(t *testing.T) {
 	assert.Equal(t, 4, len(sm.nodeEndpointCaches))
 
 	// Add a new node
-	err = sm.addOrUpdateScore(context.Background(), setKey, &schema.Stat{
+	err = sm.addOrUpdateScore(context.Background(), &schema.Stat{
 		Address:  common.Address{5},
 		Endpoint: "addr5",
 		Score:    5.0,
 	})
 	require.NoError(t, err)
 	// Update the score of an existing node
-	err = sm.addOrUpdateScore(context.Background(), setKey, &schema.Stat{
+	err = sm.addOrUpdateScore(context.Background(), &schema.Stat{
 		Address:  common.Address{0},
 		Endpoint: "addr0",
 		Score:    6.0,
 	})
 	require.NoError(t, err)
 	assert.Equal(t, 5, len(sm.nodeEndpointCaches))
-	nodes, err = sm.retrieveQualifiedNodes(context.Background(), setKey, 10)
+	nodes, err = sm.retrieveQualifiedNodes(context.Background(), 10)
 	require.NoError(t, err)
 	assert.Equal(t, common.Address{0}.String(), nodes[0].Address)
 	assert.Equal(t, common.Address{5}.String(), nodes[1].Address)
@@ -119,7 +120,7 @@ func TestScoreMaintainer(t *testing.T) {
 	assert.Equal(t, common.Address{1}.String(), nodes[4].Address)
 
 	//// Add a new node with invalid count greater than DemotionCountBeforeSlashing
-	err = sm.addOrUpdateScore(context.Background(), setKey, &schema.Stat{
+	err = sm.addOrUpdateScore(context.Background(), &schema.Stat{
 		Address:             common.Address{0},
 		Score:               7.0,
 		EpochInvalidRequest: int64(model.DemotionCountBeforeSlashing),
@@ -128,7 +129,7 @@ func TestScoreMaintainer(t *testing.T) {
 	assert.Equal(t, 5, len(sm.nodeEndpointCaches))
 
 	// Retrieve qualified nodes
-	nodes, err = sm.retrieveQualifiedNodes(context.Background(), setKey, 10)
+	nodes, err = sm.retrieveQualifiedNodes(context.Background(), 10)
 	require.NoError(t, err)
 	assert.Equal(t, 4, len(nodes))
 	assert.Equal(t, common.Address{5}.String(), nodes[0].Address)
@@ -136,18 +137,69 @@ func TestScoreMaintainer(t *testing.T) {
 	assert.Equal(t, common.Address{2}.String(), nodes[2].Address)
 	assert.Equal(t, common.Address{1}.String(), nodes[3].Address)
 
-	// Update all qualified nodes
-	newNodeCaches := make([]*schema.Stat, 0)
-	node3 := &schema.Stat{
-		Address:             common.Address{100},
-		Endpoint:            "addr100",
-		Score:               7.0,
-		EpochInvalidRequest: 1,
-	}
-	newNodeCaches = append(newNodeCaches, node3)
-	err = sm.updateQualifiedNodesMap(context.Background(), newNodeCaches)
+	// Replace all qualified nodes
+	_, err = replaceNodes(context.Background(), cacheClient, setKey, map[string]*EndpointCache{
+		common.Address{100}.String(): {Endpoint: "addr100"},
+	}, []redis.Z{{Member: common.Address{100}.String(), Score: 7.0}})
 	require.NoError(t, err)
+	require.NoError(t, sm.reload(context.Background()))
 	assert.Equal(t, 1, len(sm.nodeEndpointCaches))
+
+	nodes, err = sm.retrieveQualifiedNodes(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(nodes))
+}
+
+func TestScoreMaintainerReplicas(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx         = context.Background()
+		cacheClient = cache.NewMemory()
+	)
+
+	nodeStats := []*schema.Stat{
+		{Address: common.Address{1}, Endpoint: "addr1", Score: 1.0},
+		{Address: common.Address{2}, Endpoint: "addr2", Score: 2.0},
+	}
+
+	primary, err := newScoreMaintainer(ctx, setKey, nodeStats, cacheClient)
+	require.NoError(t, err)
+
+	replica, err := newScoreMaintainer(ctx, setKey, nodeStats, cacheClient)
+	require.NoError(t, err)
+	require.Greater(t, replica.version, primary.version)
+
+	replica.subscribeRegistryUpdate(ctx)
+
+	// A Node registered by the primary is served by the replica before the replica reloads.
+	require.NoError(t, primary.addOrUpdateScore(ctx, &schema.Stat{
+		Address:  common.Address{3},
+		Endpoint: "addr3",
+		Score:    3.0,
+	}))
+
+	nodes, err := replica.retrieveQualifiedNodes(ctx, 3)
+	require.NoError(t, err)
+	require.Len(t, nodes, 3)
+	assert.Equal(t, common.Address{3}.String(), nodes[0].Address)
+	assert.Equal(t, "addr3", nodes[0].Endpoint)
+
+	// The replica reloads the registry once the endpoint of a Node changed.
+	require.NoError(t, primary.addOrUpdateScore(ctx, &schema.Stat{
+		Address:  common.Address{1},
+		Endpoint: "addr1-updated",
+		Score:    1.0,
+	}))
+
+	assert.Eventually(t, func() bool {
+		replica.lock.RLock()
+		defer replica.lock.RUnlock()
+
+		endpointCache, ok := replica.nodeEndpointCaches[common.Address{1}.String()]
+
+		return ok && endpointCache.Endpoint == "addr1-updated" && len(replica.nodeEndpointCaches) == 3
+	}, time.Second, 10*time.Millisecond)
 }
 
 func createContainer(ctx context.Context) (container *gnomock.Container, err error) {
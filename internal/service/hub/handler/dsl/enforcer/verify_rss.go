@@ -0,0 +1,234 @@
+package enforcer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// VerifyRSSHubResponses verifies the RSS responses from the Nodes by comparing their feed items.
+func (e *SimpleEnforcer) VerifyRSSHubResponses(ctx context.Context, responses []*model.DataResponse) error {
+	if len(responses) == 0 {
+		return fmt.Errorf("no response returned from nodes")
+	}
+
+	// non-error and non-null results are always put in front of the list
+	sortResponseByValidity(responses)
+	// update requests based on feed items compare
+	updatePointsBasedOnRSSItems(responses, time.Now().Unix())
+	// update the cache request
+	e.updateCacheRequest(ctx, responses)
+	// update the score maintainer
+	e.batchUpdateScoreMaintainer(ctx, responses)
+
+	return nil
+}
+
+// updatePointsBasedOnRSSItems updates the points based on the feed items of the responses.
+// The response agreed by the most responses is the verified response, the responses that disagree with it
+// are given invalid points only if another response agrees with it.
+// The error responses are given no points, as they are usually caused by the unstable RSSHub server.
+func updatePointsBasedOnRSSItems(responses []*model.DataResponse, now int64) {
+	var (
+		candidates = make([]*model.DataResponse, 0, len(responses))
+		items      = make([][]*model.RSSItem, 0, len(responses))
+	)
+
+	for _, response := range responses {
+		if response.Err != nil {
+			continue
+		}
+
+		responseItems, ok := parseRSSItems(response.Data)
+		if !ok {
+			continue
+		}
+
+		candidates = append(candidates, response)
+		items = append(items, responseItems)
+	}
+
+	if len(candidates) == 0 {
+		return
+	}
+
+	// diffs[i][j] is the difference of the response j from the response i.
+	diffs := make([][]*model.RSSItemDiff, len(candidates))
+	agreements := make([]int, len(candidates))
+
+	for i := range candidates {
+		diffs[i] = make([]*model.RSSItemDiff, len(candidates))
+
+		for j := range candidates {
+			if i == j {
+				continue
+			}
+
+			diffs[i][j] = diffRSSItems(items[i], items[j], now)
+
+			if diffs[i][j].IsEmpty() {
+				agreements[i]++
+			}
+		}
+	}
+
+	// The first response agreed by the most responses is the verified response.
+	verified := 0
+
+	for i := range agreements {
+		if agreements[i] > agreements[verified] {
+			verified = i
+		}
+	}
+
+	candidates[verified].ValidPoint = validPointUnit
+
+	for j, response := range candidates {
+		if j == verified {
+			continue
+		}
+
+		if diffs[verified][j].IsEmpty() {
+			response.ValidPoint = validPointUnit
+
+			continue
+		}
+
+		// Without another response agreeing with the verified response, it is unknown which one is invalid.
+		if agreements[verified] == 0 {
+			continue
+		}
+
+		diff, err := json.Marshal(diffs[verified][j])
+		if err != nil {
+			zap.L().Error("marshal rss item diff", zap.Error(err))
+		}
+
+		response.InvalidPoint = invalidPointUnit
+		response.Diff = diff
+	}
+}
+
+// parseRSSItems parses the feed items from the RSS response.
+func parseRSSItems(data []byte) ([]*model.RSSItem, bool) {
+	activities := &model.ActivitiesResponse{}
+
+	if !isDataValid(data, activities) {
+		return nil, false
+	}
+
+	items := make([]*model.RSSItem, 0, len(activities.Data))
+
+	for _, activity := range activities.Data {
+		item := &model.RSSItem{
+			GUID:        activity.ID,
+			PublishedAt: activity.Timestamp,
+		}
+
+		if len(activity.Actions) > 0 && len(activity.Actions[0].RelatedURLs) > 0 {
+			item.Link = activity.Actions[0].RelatedURLs[0]
+		}
+
+		// Some feeds have no GUID for their items, and the link identifies the item instead.
+		if item.GUID == "" {
+			item.GUID = item.Link
+		}
+
+		if item.GUID != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items, true
+}
+
+// diffRSSItems returns the difference of the actual items from the expected items.
+// Only the settled items are compared, which are published before the freshness tolerance,
+// and not before the oldest settled item of either feed, since the feeds only keep their latest items.
+func diffRSSItems(expected, actual []*model.RSSItem, now int64) *model.RSSItemDiff {
+	var (
+		diff          = &model.RSSItemDiff{}
+		freshnessTime = uint64(now - int64(model.RSSFreshnessSeconds))
+	)
+
+	expectedSettled, actualSettled := filterSettledRSSItems(expected, freshnessTime), filterSettledRSSItems(actual, freshnessTime)
+
+	// An empty feed misses all the settled items.
+	if len(actual) == 0 {
+		diff.Missing = expectedSettled
+
+		return diff
+	}
+
+	if len(expected) == 0 {
+		diff.Unexpected = actualSettled
+
+		return diff
+	}
+
+	if len(expectedSettled) == 0 || len(actualSettled) == 0 {
+		return diff
+	}
+
+	oldest := max(oldestRSSItem(expectedSettled), oldestRSSItem(actualSettled))
+
+	expectedMap := lo.SliceToMap(expected, func(item *model.RSSItem) (string, *model.RSSItem) {
+		return item.GUID, item
+	})
+
+	actualMap := lo.SliceToMap(actual, func(item *model.RSSItem) (string, *model.RSSItem) {
+		return item.GUID, item
+	})
+
+	for _, item := range expectedSettled {
+		if item.PublishedAt < oldest {
+			continue
+		}
+
+		actualItem, exists := actualMap[item.GUID]
+
+		switch {
+		case !exists:
+			diff.Missing = append(diff.Missing, item)
+		case actualItem.Link != item.Link || actualItem.PublishedAt != item.PublishedAt:
+			diff.Mismatched = append(diff.Mismatched, &model.RSSItemMismatch{Expected: item, Actual: actualItem})
+		}
+	}
+
+	for _, item := range actualSettled {
+		if item.PublishedAt < oldest {
+			continue
+		}
+
+		expectedItem, exists := expectedMap[item.GUID]
+
+		switch {
+		case !exists:
+			diff.Unexpected = append(diff.Unexpected, item)
+		// The item is mismatched but not settled in the expected feed.
+		case expectedItem.PublishedAt > freshnessTime:
+			diff.Mismatched = append(diff.Mismatched, &model.RSSItemMismatch{Expected: expectedItem, Actual: item})
+		}
+	}
+
+	return diff
+}
+
+// filterSettledRSSItems filters the items that have a publish time before the freshness time.
+func filterSettledRSSItems(items []*model.RSSItem, freshnessTime uint64) []*model.RSSItem {
+	return lo.Filter(items, func(item *model.RSSItem, _ int) bool {
+		return item.PublishedAt > 0 && item.PublishedAt <= freshnessTime
+	})
+}
+
+// oldestRSSItem returns the publish time of the oldest item.
+func oldestRSSItem(items []*model.RSSItem) uint64 {
+	return lo.MinBy(items, func(a, b *model.RSSItem) bool {
+		return a.PublishedAt < b.PublishedAt
+	}).PublishedAt
+}
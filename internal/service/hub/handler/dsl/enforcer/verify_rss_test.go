@@ -0,0 +1,142 @@
+package enforcer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const rssNow = int64(1720000000)
+
+// rssResponseData returns an RSS response of the items, each item is a pair of GUID and publish time.
+func rssResponseData(items ...any) []byte {
+	activities := make([]string, 0, len(items)/2)
+
+	for i := 0; i < len(items); i += 2 {
+		activities = append(activities, fmt.Sprintf(`{"id":%q,"network":"rss","tag":"rss","type":"feed","actions":[{"tag":"rss","type":"feed","metadata":{},"related_urls":["https://example.com/%s"]}],"timestamp":%d}`, items[i], items[i], items[i+1]))
+	}
+
+	return []byte(fmt.Sprintf(`{"data":[%s]}`, strings.Join(activities, ",")))
+}
+
+func TestDiffRSSItems(t *testing.T) {
+	t.Parallel()
+
+	var (
+		settled = uint64(rssNow) - uint64(model.RSSFreshnessSeconds) - 60
+		fresh   = uint64(rssNow) - 60
+	)
+
+	testCases := []struct {
+		name       string
+		expected   []byte
+		actual     []byte
+		missing    int
+		unexpected int
+		mismatched int
+	}{
+		{
+			name:     "Identical",
+			expected: rssResponseData("a", settled, "b", settled-10),
+			actual:   rssResponseData("a", settled, "b", settled-10),
+		},
+		{
+			name:     "FreshItemsIgnored",
+			expected: rssResponseData("new", fresh, "a", settled),
+			actual:   rssResponseData("a", settled),
+		},
+		{
+			name:     "OlderItemsDroppedFromFeed",
+			expected: rssResponseData("a", settled, "b", settled-10, "c", settled-20),
+			actual:   rssResponseData("a", settled, "b", settled-10),
+		},
+		{
+			name:     "MissingItem",
+			expected: rssResponseData("a", settled, "b", settled-10, "c", settled-20),
+			actual:   rssResponseData("a", settled, "c", settled-20),
+			missing:  1,
+		},
+		{
+			name:       "FabricatedItem",
+			expected:   rssResponseData("a", settled, "c", settled-20),
+			actual:     rssResponseData("a", settled, "x", settled-10, "c", settled-20),
+			unexpected: 1,
+		},
+		{
+			name:       "StalePublishTime",
+			expected:   rssResponseData("a", settled, "b", settled-10),
+			actual:     rssResponseData("a", settled-5, "b", settled-10),
+			mismatched: 1,
+		},
+		{
+			name:     "EmptyFeed",
+			expected: rssResponseData("a", settled, "b", fresh),
+			actual:   rssResponseData(),
+			missing:  1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			expected, ok := parseRSSItems(testCase.expected)
+			require.True(t, ok)
+
+			actual, ok := parseRSSItems(testCase.actual)
+			require.True(t, ok)
+
+			diff := diffRSSItems(expected, actual, rssNow)
+			assert.Len(t, diff.Missing, testCase.missing)
+			assert.Len(t, diff.Unexpected, testCase.unexpected)
+			assert.Len(t, diff.Mismatched, testCase.mismatched)
+		})
+	}
+}
+
+func TestUpdatePointsBasedOnRSSItems(t *testing.T) {
+	t.Parallel()
+
+	settled := uint64(rssNow) - uint64(model.RSSFreshnessSeconds) - 60
+
+	responses := []*model.DataResponse{
+		{Data: rssResponseData("a", settled, "x", settled-10, "b", settled-20)},
+		{Data: rssResponseData("a", settled, "b", settled-20)},
+		{Data: rssResponseData("a", settled, "b", settled-20)},
+		{Err: errors.New("rsshub error")},
+	}
+
+	updatePointsBasedOnRSSItems(responses, rssNow)
+
+	assert.Equal(t, 0, responses[0].ValidPoint)
+	assert.Equal(t, invalidPointUnit, responses[0].InvalidPoint)
+	assert.Equal(t, validPointUnit, responses[1].ValidPoint)
+	assert.Equal(t, validPointUnit, responses[2].ValidPoint)
+	assert.Equal(t, 0, responses[3].ValidPoint+responses[3].InvalidPoint)
+
+	var diff model.RSSItemDiff
+
+	require.NoError(t, json.Unmarshal(responses[0].Diff, &diff))
+	require.Len(t, diff.Unexpected, 1)
+	assert.Equal(t, "x", diff.Unexpected[0].GUID)
+	assert.Equal(t, "https://example.com/x", diff.Unexpected[0].Link)
+
+	// Without a majority, no response is given invalid points.
+	responses = []*model.DataResponse{
+		{Data: rssResponseData("a", settled, "x", settled-10, "b", settled-20)},
+		{Data: rssResponseData("a", settled, "b", settled-20)},
+	}
+
+	updatePointsBasedOnRSSItems(responses, rssNow)
+
+	assert.Equal(t, validPointUnit, responses[0].ValidPoint)
+	assert.Equal(t, 0, responses[1].ValidPoint+responses[1].InvalidPoint)
+}
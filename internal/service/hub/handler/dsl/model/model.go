@@ -26,11 +26,23 @@ var (
 	// FullNodeCacheKey is the cache key for the full nodes.
 	FullNodeCacheKey = "nodes:full"
 
+	// NodeEndpointRegistryKey is the format of the cache key for the registry of the Node endpoints shared by the hubs, formatted with a node cache key.
+	NodeEndpointRegistryKey = "%s:endpoints"
+	// NodeEndpointRegistryVersionKey is the format of the cache key for the version of a registry of the Node endpoints.
+	NodeEndpointRegistryVersionKey = "%s:endpoints:version"
+	// NodeEndpointRegistryChannel is the format of the channel the new versions of a registry of the Node endpoints are published to.
+	NodeEndpointRegistryChannel = "%s:endpoints:invalidation"
+
 	// InvalidRequestCount is the prefix used for cache keys related to storing invalid request counts in the current epoch.
 	InvalidRequestCount = "node:request:count:invalid"
 	// ValidRequestCount is the prefix used for cache keys related to storing valid request counts in the current epoch.
 	ValidRequestCount = "node:request:count:valid"
 
+	// NodeProbeStatus is the prefix used for cache keys related to storing the status of a Node evaluated by the prober.
+	NodeProbeStatus = "node:probe:status"
+	// NodeProbeFailureRate is the prefix used for cache keys related to storing the share of the failed probes of a Node.
+	NodeProbeFailureRate = "node:probe:failure_rate"
+
 	// WorkerToNetworksMapKey is the cache key for the map of Workers to Networks.
 	WorkerToNetworksMapKey = "map:worker_to_networks"
 	// NetworkToWorkersMapKey is the cache key for the map of Networks to Workers.
@@ -51,6 +63,13 @@ var (
 	DemotionCountBeforeSlashing = 4
 	// ToleranceSeconds is the tolerance seconds for the activity.
 	ToleranceSeconds = 20 * 60
+	// RSSFreshnessSeconds is the tolerance seconds for the RSS feed items,
+	// the Nodes may fetch the feed at different times, so the newer items are excluded from the comparison.
+	RSSFreshnessSeconds = 60 * 60
+	// CursorSecret is the secret used to sign the opaque cursors issued by the hubs.
+	CursorSecret string
+	// ScoreDropRatio is the relative drop of the reliability score of a Node that emits a webhook event.
+	ScoreDropRatio = 0.2
 
 	// MutablePlatformMap is a map of mutable platforms which should be excluded from the data comparison.
 	MutablePlatformMap = map[string]struct{}{
@@ -93,6 +112,9 @@ type DataResponse struct {
 	ValidPoint int
 	// InvalidPoint is the points given to the response when it is invalid
 	InvalidPoint int
+	// Diff is the item-level difference between the response and the verified response of an RSS request,
+	// it is saved along with the data of the invalid response if set.
+	Diff json.RawMessage
 }
 
 type RequestMeta struct {
@@ -118,6 +140,33 @@ type ActivitiesResponse struct {
 	Meta *MetaCursor `json:"meta,omitempty"`
 }
 
+// RSSItem represents an item of an RSS feed, it is parsed from an Activity returned by the RSS Nodes.
+type RSSItem struct {
+	GUID        string `json:"guid"`
+	Link        string `json:"link,omitempty"`
+	PublishedAt uint64 `json:"published_at"`
+}
+
+// RSSItemDiff represents the item-level difference between an RSS response and the verified response.
+type RSSItemDiff struct {
+	// Missing are the items of the verified response that are missing from the response.
+	Missing []*RSSItem `json:"missing,omitempty"`
+	// Unexpected are the items of the response that are not in the verified response.
+	Unexpected []*RSSItem `json:"unexpected,omitempty"`
+	// Mismatched are the items of the response whose link or publish time differs from the verified response.
+	Mismatched []*RSSItemMismatch `json:"mismatched,omitempty"`
+}
+
+type RSSItemMismatch struct {
+	Expected *RSSItem `json:"expected"`
+	Actual   *RSSItem `json:"actual"`
+}
+
+// IsEmpty returns true if the responses are identical.
+func (d *RSSItemDiff) IsEmpty() bool {
+	return len(d.Missing) == 0 && len(d.Unexpected) == 0 && len(d.Mismatched) == 0
+}
+
 type MetaCursor struct {
 	Cursor string `json:"cursor"`
 }
@@ -187,3 +236,8 @@ func (a *Action) UnmarshalJSON(bytes []byte) error {
 
 	return nil
 }
+
+// FormatNodeStatRedisKey formats the cache key of a stat of a Node, such as the request counts in the current epoch.
+func FormatNodeStatRedisKey(key string, address string) string {
+	return fmt.Sprintf("%s:%s", key, address)
+}
@@ -0,0 +1,116 @@
+package model
+
+import (
+	"math"
+)
+
+// ReliabilityScore is the model used to calculate the Reliability Score σ of the Nodes.
+var ReliabilityScore = DefaultReliabilityScoreModel()
+
+// ReliabilityScoreModel is a versioned model of the Reliability Score σ, σ is the sum of the scores of its components.
+// A component that is not configured takes its default, it can be disabled by setting its weight to 0.
+type ReliabilityScoreModel struct {
+	// Version identifies the model, it is recorded with every score calculated by the model.
+	Version string `yaml:"version" json:"version"`
+	// Staking scores the staking pool tokens of the Node.
+	Staking *ScoreComponent `yaml:"staking" json:"staking"`
+	// PublicGood scores 1 if the Node is not a public good Node.
+	PublicGood *ScoreComponent `yaml:"public_good" json:"public_good"`
+	// ActiveTime scores the number of epochs the Node has been active for.
+	ActiveTime *ScoreComponent `yaml:"active_time" json:"active_time"`
+	// TotalRequests scores the total valid request count of the Node.
+	TotalRequests *ScoreComponent `yaml:"total_requests" json:"total_requests"`
+	// EpochRequests scores the valid request count of the Node in the current epoch.
+	EpochRequests *ScoreComponent `yaml:"epoch_requests" json:"epoch_requests"`
+	// Networks scores the number of decentralized and federated networks the Node supports.
+	Networks *ScoreComponent `yaml:"networks" json:"networks"`
+	// RSSNetwork scores 1 if the Node supports the RSS network.
+	RSSNetwork *ScoreComponent `yaml:"rss_network" json:"rss_network"`
+	// Indexers scores the number of indexers of the Node.
+	Indexers *ScoreComponent `yaml:"indexers" json:"indexers"`
+	// ProbeFailures scores the share of the recent health probes of the Node that failed.
+	ProbeFailures *ScoreComponent `yaml:"probe_failures" json:"probe_failures"`
+	// InvalidRequests scores the invalid request count of the Node in the current epoch,
+	// σ is 0 once the count reaches DemotionCountBeforeSlashing.
+	InvalidRequests *ScoreComponent `yaml:"invalid_requests" json:"invalid_requests"`
+}
+
+// ScoreComponent scores a value x as Weight * x / Rate,
+// or Weight * log(x / Rate + 1) / log(LogBase) if LogBase is set, capped at Max if Max is set.
+type ScoreComponent struct {
+	Weight  float64 `yaml:"weight" json:"weight"`
+	Rate    float64 `yaml:"rate" json:"rate"`
+	LogBase float64 `yaml:"log_base" json:"log_base"`
+	Max     float64 `yaml:"max" json:"max"`
+}
+
+// Score returns the score of the value x.
+func (c *ScoreComponent) Score(x float64) float64 {
+	score := x
+
+	if c.Rate > 0 {
+		score /= c.Rate
+	}
+
+	if c.LogBase > 0 {
+		score = math.Log(score+1) / math.Log(c.LogBase)
+	}
+
+	score *= c.Weight
+
+	if c.Max > 0 {
+		score = math.Min(score, c.Max)
+	}
+
+	return score
+}
+
+// SetDefaults sets the default version and components of the model that are not configured.
+func (m *ReliabilityScoreModel) SetDefaults() {
+	defaultModel := DefaultReliabilityScoreModel()
+
+	if m.Version == "" {
+		m.Version = defaultModel.Version
+	}
+
+	for component, defaultComponent := range map[**ScoreComponent]*ScoreComponent{
+		&m.Staking:         defaultModel.Staking,
+		&m.PublicGood:      defaultModel.PublicGood,
+		&m.ActiveTime:      defaultModel.ActiveTime,
+		&m.TotalRequests:   defaultModel.TotalRequests,
+		&m.EpochRequests:   defaultModel.EpochRequests,
+		&m.Networks:        defaultModel.Networks,
+		&m.RSSNetwork:      defaultModel.RSSNetwork,
+		&m.Indexers:        defaultModel.Indexers,
+		&m.ProbeFailures:   defaultModel.ProbeFailures,
+		&m.InvalidRequests: defaultModel.InvalidRequests,
+	} {
+		if *component == nil {
+			*component = defaultComponent
+		}
+	}
+}
+
+// DefaultReliabilityScoreModel returns the default model of the Reliability Score σ.
+func DefaultReliabilityScoreModel() *ReliabilityScoreModel {
+	return &ReliabilityScoreModel{
+		Version: "v1",
+		// The maximum score is 0.2.
+		Staking: &ScoreComponent{Weight: 1, Rate: 100000, LogBase: 2, Max: 0.2},
+		// If the Node is a public good Node, then the score is 0, otherwise the score is 1.
+		PublicGood: &ScoreComponent{Weight: 1},
+		// The maximum score is 0.3.
+		ActiveTime: &ScoreComponent{Weight: 1, Rate: 120, Max: 0.3},
+		// The maximum score is 0.3.
+		TotalRequests: &ScoreComponent{Weight: 1, Rate: 100000, LogBase: 100, Max: 0.3},
+		// The maximum score is 1.
+		EpochRequests: &ScoreComponent{Weight: 1, Rate: 1000000, LogBase: 5000, Max: 1},
+		Networks:      &ScoreComponent{Weight: 0.1},
+		RSSNetwork:    &ScoreComponent{Weight: 0.3},
+		// The maximum score is 0.2.
+		Indexers: &ScoreComponent{Weight: 0.05, Max: 0.2},
+		// The maximum penalty is 1, if all recent probes failed.
+		ProbeFailures:   &ScoreComponent{Weight: -1},
+		InvalidRequests: &ScoreComponent{Weight: -0.5},
+	}
+}
@@ -0,0 +1,71 @@
+package model_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreComponent(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		name      string
+		component model.ScoreComponent
+		value     float64
+		expected  float64
+	}{
+		{
+			name:      "linear",
+			component: model.ScoreComponent{Weight: 0.1},
+			value:     3,
+			expected:  0.3,
+		},
+		{
+			name:      "linear capped",
+			component: model.ScoreComponent{Weight: 0.05, Max: 0.2},
+			value:     10,
+			expected:  0.2,
+		},
+		{
+			name:      "logarithmic",
+			component: model.ScoreComponent{Weight: 1, Rate: 100000, LogBase: 2, Max: 0.2},
+			value:     10000,
+			expected:  math.Log(1.1) / math.Log(2),
+		},
+		{
+			name:      "penalty",
+			component: model.ScoreComponent{Weight: -0.5},
+			value:     2,
+			expected:  -1,
+		},
+	}
+
+	for _, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.InDelta(t, testcase.expected, testcase.component.Score(testcase.value), 1e-9)
+		})
+	}
+}
+
+func TestReliabilityScoreModelSetDefaults(t *testing.T) {
+	t.Parallel()
+
+	scoreModel := model.ReliabilityScoreModel{
+		Version:  "v2",
+		Networks: &model.ScoreComponent{},
+	}
+
+	scoreModel.SetDefaults()
+
+	require.Equal(t, "v2", scoreModel.Version)
+	require.Equal(t, model.DefaultReliabilityScoreModel().Staking, scoreModel.Staking)
+	// A configured component is kept even if it is disabled.
+	require.Zero(t, scoreModel.Networks.Score(3))
+}
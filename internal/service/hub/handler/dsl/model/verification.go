@@ -0,0 +1,157 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// VerificationStreamKey is the cache key for the stream of the verification tasks.
+	VerificationStreamKey = "verification:tasks"
+	// VerificationDeadLetterStreamKey is the cache key for the stream of the verification tasks that failed all their attempts.
+	VerificationDeadLetterStreamKey = "verification:tasks:dead"
+	// VerificationGroup is the consumer group of the verifiers.
+	VerificationGroup = "verifier"
+	// VerificationProcessedKey is the prefix used for cache keys related to marking the processed verification tasks.
+	VerificationProcessedKey = "verification:processed"
+	// VerificationProgressKey is the prefix used for cache keys related to the progress of the verification tasks.
+	VerificationProgressKey = "verification:progress"
+	// VerificationLockKey is the prefix used for cache keys related to locking the verification tasks being processed.
+	VerificationLockKey = "verification:lock"
+)
+
+const (
+	VerificationTaskTypeRSSHub     = "rsshub"
+	VerificationTaskTypeActivity   = "activity"
+	VerificationTaskTypeActivities = "activities"
+)
+
+// VerificationQueue is the queue that the hubs enqueue the responses of the Nodes to for the verifiers.
+var VerificationQueue = &VerificationQueueConfig{
+	Workers:        8,
+	MaxRetries:     3,
+	MaxLength:      100000,
+	ClaimIdle:      5 * time.Minute,
+	IdempotencyTTL: 24 * time.Hour,
+}
+
+type VerificationQueueConfig struct {
+	// Enabled makes the hubs enqueue the responses for the verifiers instead of verifying them in-process,
+	// it requires a scheduler running the verifier server.
+	Enabled bool `yaml:"enabled"`
+	// Workers is the number of the tasks a verifier processes concurrently.
+	Workers int `yaml:"workers" validate:"gt=0" default:"8"`
+	// MaxRetries is the number of retries of a failed task before it is moved to the dead-letter stream.
+	MaxRetries int `yaml:"max_retries" validate:"gt=0" default:"3"`
+	// MaxLength caps the length of the stream, the oldest tasks are dropped if the verifiers fall behind.
+	MaxLength int64 `yaml:"max_length" validate:"gt=0" default:"100000"`
+	// ClaimIdle is how long a task stays unacknowledged before it is claimed by another verifier,
+	// the idle tasks are claimed every half of it, so it is at least a second.
+	ClaimIdle time.Duration `yaml:"claim_idle" validate:"gte=1s" default:"5m"`
+	// IdempotencyTTL is how long a processed task and the progress of a task are remembered.
+	IdempotencyTTL time.Duration `yaml:"idempotency_ttl" validate:"gt=0" default:"24h"`
+}
+
+// VerificationTask is the responses of the Nodes to a request, which are verified by the verifiers.
+type VerificationTask struct {
+	// ID is the idempotency key of the task, it is kept across the retries.
+	ID        string                  `json:"id"`
+	Type      string                  `json:"type"`
+	Responses []*VerificationResponse `json:"responses"`
+	// Attempt is the number of the failed attempts to process the task.
+	Attempt    int   `json:"attempt"`
+	EnqueuedAt int64 `json:"enqueued_at"`
+}
+
+// VerificationResponse is a DataResponse, the error is kept as its message.
+// The points are given by the verification, they are zero before.
+type VerificationResponse struct {
+	Address      common.Address  `json:"address"`
+	Endpoint     string          `json:"endpoint"`
+	Data         []byte          `json:"data,omitempty"`
+	Valid        bool            `json:"valid"`
+	Error        string          `json:"error,omitempty"`
+	ValidPoint   int             `json:"valid_point,omitempty"`
+	InvalidPoint int             `json:"invalid_point,omitempty"`
+	Diff         json.RawMessage `json:"diff,omitempty"`
+}
+
+// VerificationProgress is the steps of a task completed by the verifiers, so that a retried or redelivered task
+// resumes from the step it failed at, instead of applying the side effects of the completed steps again.
+type VerificationProgress struct {
+	// Responses are the responses given points by the verification, nil if the responses have not been verified.
+	Responses []*VerificationResponse `json:"responses,omitempty"`
+	// Saved is true if the invalid responses have been saved in the epoch.
+	Saved   bool   `json:"saved"`
+	EpochID uint64 `json:"epoch_id"`
+	// PartiallyVerified is true if the activities of the responses have been partially verified.
+	PartiallyVerified bool `json:"partially_verified"`
+}
+
+// NewVerificationTask creates a task of the responses.
+func NewVerificationTask(id, taskType string, responses []*DataResponse) *VerificationTask {
+	return &VerificationTask{
+		ID:         id,
+		Type:       taskType,
+		Responses:  NewVerificationResponses(responses),
+		EnqueuedAt: time.Now().Unix(),
+	}
+}
+
+// DataResponses returns the responses of the task to be verified.
+func (t *VerificationTask) DataResponses() []*DataResponse {
+	return NewDataResponses(t.Responses)
+}
+
+// NewVerificationResponses converts the responses to be serialized.
+func NewVerificationResponses(responses []*DataResponse) []*VerificationResponse {
+	verificationResponses := make([]*VerificationResponse, 0, len(responses))
+
+	for _, response := range responses {
+		verificationResponse := &VerificationResponse{
+			Address:      response.Address,
+			Endpoint:     response.Endpoint,
+			Data:         response.Data,
+			Valid:        response.Valid,
+			ValidPoint:   response.ValidPoint,
+			InvalidPoint: response.InvalidPoint,
+			Diff:         response.Diff,
+		}
+
+		if response.Err != nil {
+			verificationResponse.Error = response.Err.Error()
+		}
+
+		verificationResponses = append(verificationResponses, verificationResponse)
+	}
+
+	return verificationResponses
+}
+
+// NewDataResponses converts the serialized responses back.
+func NewDataResponses(verificationResponses []*VerificationResponse) []*DataResponse {
+	responses := make([]*DataResponse, 0, len(verificationResponses))
+
+	for _, response := range verificationResponses {
+		dataResponse := &DataResponse{
+			Address:      response.Address,
+			Endpoint:     response.Endpoint,
+			Data:         response.Data,
+			Valid:        response.Valid,
+			ValidPoint:   response.ValidPoint,
+			InvalidPoint: response.InvalidPoint,
+			Diff:         response.Diff,
+		}
+
+		if response.Error != "" {
+			dataResponse.Err = errors.New(response.Error)
+		}
+
+		responses = append(responses, dataResponse)
+	}
+
+	return responses
+}
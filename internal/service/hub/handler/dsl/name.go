@@ -0,0 +1,65 @@
+package dsl
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rss3-network/global-indexer/internal/nameresolver"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/dsl"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
+	"go.uber.org/zap"
+)
+
+// GetNames returns the primary names of an address on all name services.
+func (d *DSL) GetNames(c echo.Context) error {
+	var request dsl.NamesRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadRequestError(c, err)
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, err)
+	}
+
+	names, err := d.nameService.ReverseResolve(c.Request().Context(), request.Address)
+	if err != nil {
+		zap.L().Error("reverse resolve names", zap.Error(err), zap.String("address", request.Address.String()))
+
+		return errorx.InternalError(c)
+	}
+
+	return c.JSON(http.StatusOK, names)
+}
+
+// ResolveName returns the EVM address a name resolves to.
+func (d *DSL) ResolveName(c echo.Context) error {
+	var request dsl.ResolveNameRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadRequestError(c, err)
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, err)
+	}
+
+	address, err := d.nameService.Resolve(c.Request().Context(), request.Name)
+
+	switch {
+	case err == nil:
+		return c.JSON(http.StatusOK, dsl.ResolveNameResponse{
+			Name:    request.Name,
+			Address: address,
+		})
+	case strings.Contains(err.Error(), nameresolver.ErrUnregisterName):
+		return c.NoContent(http.StatusNotFound)
+	case strings.Contains(err.Error(), nameresolver.ErrUnSupportName):
+		return errorx.BadRequestError(c, err)
+	default:
+		zap.L().Error("resolve name", zap.Error(err), zap.String("name", request.Name))
+
+		return errorx.InternalError(c)
+	}
+}
@@ -0,0 +1,122 @@
+package nta
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/creasty/defaults"
+	"github.com/labstack/echo/v4"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// GetCronJobs returns the status of the cron jobs run by the schedulers.
+func (n *NTA) GetCronJobs(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	jobs, err := n.databaseClient.FindCronJobs(ctx)
+	if err != nil {
+		zap.L().Error("find cron jobs", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	var (
+		now    = time.Now()
+		result = make(nta.GetCronJobsResponseData, 0, len(jobs))
+	)
+
+	for _, job := range jobs {
+		lastRuns, err := n.databaseClient.FindCronJobRuns(ctx, schema.CronJobRunsQuery{
+			Job:   lo.ToPtr(job.Name),
+			Limit: lo.ToPtr(1),
+		})
+		if err != nil {
+			zap.L().Error("find last cron job run", zap.String("job", job.Name), zap.Error(err))
+
+			return errorx.InternalError(c)
+		}
+
+		lastSucceededRuns, err := n.databaseClient.FindCronJobRuns(ctx, schema.CronJobRunsQuery{
+			Job:    lo.ToPtr(job.Name),
+			Status: lo.ToPtr(schema.CronJobRunStatusSucceeded),
+			Limit:  lo.ToPtr(1),
+		})
+		if err != nil {
+			zap.L().Error("find last succeeded cron job run", zap.String("job", job.Name), zap.Error(err))
+
+			return errorx.InternalError(c)
+		}
+
+		result = append(result, nta.NewCronJobStatus(job, lo.FirstOrEmpty(lastRuns), lo.FirstOrEmpty(lastSucceededRuns), now))
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data: result,
+	})
+}
+
+// GetCronJobRuns returns the runs of a cron job from the latest.
+func (n *NTA) GetCronJobRuns(c echo.Context) error {
+	var request nta.GetCronJobRunsRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, fmt.Errorf("set default failed: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	query := schema.CronJobRunsQuery{
+		Job:    lo.ToPtr(request.Name),
+		Cursor: request.Cursor,
+		Limit:  lo.ToPtr(request.Limit),
+	}
+
+	if request.Status != nil {
+		status, err := schema.CronJobRunStatusString(*request.Status)
+		if err != nil {
+			return errorx.ValidationFailedError(c, fmt.Errorf("invalid status: %w", err))
+		}
+
+		query.Status = lo.ToPtr(status)
+	}
+
+	if request.Trigger != nil {
+		trigger, err := schema.CronJobRunTriggerString(*request.Trigger)
+		if err != nil {
+			return errorx.ValidationFailedError(c, fmt.Errorf("invalid trigger: %w", err))
+		}
+
+		query.Triggers = []schema.CronJobRunTrigger{trigger}
+	}
+
+	runs, err := n.databaseClient.FindCronJobRuns(c.Request().Context(), query)
+	if err != nil {
+		zap.L().Error("find cron job runs", zap.String("job", request.Name), zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	var cursor string
+
+	if len(runs) > 0 && len(runs) == request.Limit {
+		last, _ := lo.Last(runs)
+		cursor = strconv.FormatUint(last.ID, 10)
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data:   nta.NewCronJobRuns(runs),
+		Cursor: cursor,
+	})
+}
@@ -0,0 +1,82 @@
+package nta
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/creasty/defaults"
+	"github.com/labstack/echo/v4"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// GetEpochReconciliations returns the reconciliation reports of the Epochs from the latest, without their mismatches.
+func (n *NTA) GetEpochReconciliations(c echo.Context) error {
+	var request nta.GetEpochReconciliationsRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bad request: %w", err))
+	}
+
+	if err := defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, fmt.Errorf("set default failed: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	reconciliations, err := n.databaseClient.FindEpochReconciliations(c.Request().Context(), schema.EpochReconciliationsQuery{
+		Inconsistent: request.Inconsistent,
+		Cursor:       request.Cursor,
+		Limit:        lo.ToPtr(request.Limit),
+	})
+	if err != nil {
+		zap.L().Error("find epoch reconciliations", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	var cursor string
+	if len(reconciliations) > 0 && len(reconciliations) == request.Limit {
+		cursor = fmt.Sprintf("%d", reconciliations[len(reconciliations)-1].EpochID)
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data:   nta.GetEpochReconciliationsResponseData(reconciliations),
+		Cursor: cursor,
+	})
+}
+
+// GetEpochReconciliation returns the reconciliation report of an Epoch with its mismatches.
+func (n *NTA) GetEpochReconciliation(c echo.Context) error {
+	var request nta.GetEpochReconciliationRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bad request: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	reconciliation, err := n.databaseClient.FindEpochReconciliation(c.Request().Context(), request.EpochID)
+	if err != nil {
+		if errors.Is(err, database.ErrorRowNotFound) {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		zap.L().Error("find epoch reconciliation", zap.Uint64("epoch", request.EpochID), zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data: nta.GetEpochReconciliationResponseData(reconciliation),
+	})
+}
@@ -0,0 +1,87 @@
+package nta
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/creasty/defaults"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// GetLeaderboard returns the Nodes ranked by a metric aggregated over the recent Epochs, the ranks are precomputed once an Epoch is distributed.
+func (n *NTA) GetLeaderboard(c echo.Context) error {
+	var request nta.GetLeaderboardRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, fmt.Errorf("set default failed: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	metric, err := schema.LeaderboardMetricString(request.Metric)
+	if err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("invalid metric: %w", err))
+	}
+
+	query := schema.NodeRanksQuery{
+		Metric:  lo.ToPtr(metric),
+		Epochs:  lo.ToPtr(request.Epochs),
+		EpochID: request.EpochID,
+		Limit:   lo.ToPtr(request.Limit),
+	}
+
+	if request.Cursor != nil {
+		epochID, rank, err := request.ParseCursor()
+		if err != nil {
+			return errorx.BadParamsError(c, err)
+		}
+
+		query.EpochID, query.Cursor = lo.ToPtr(epochID), lo.ToPtr(rank)
+	}
+
+	ranks, err := n.databaseClient.FindNodeRanks(c.Request().Context(), query)
+	if err != nil {
+		zap.L().Error("find node ranks", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	if len(ranks) == 0 {
+		return c.JSON(http.StatusOK, nta.Response{
+			Data: nta.GetLeaderboardResponseData{},
+		})
+	}
+
+	nodes, err := n.databaseClient.FindNodes(c.Request().Context(), schema.FindNodesQuery{
+		NodeAddresses: lo.Map(ranks, func(rank *schema.NodeRank, _ int) common.Address {
+			return rank.NodeAddress
+		}),
+	})
+	if err != nil {
+		zap.L().Error("find nodes", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	var cursor string
+	if len(ranks) > 0 && len(ranks) == request.Limit {
+		cursor = nta.NewLeaderboardCursor(ranks[len(ranks)-1])
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data:   nta.NewLeaderboard(ranks, nodes),
+		Cursor: cursor,
+	})
+}
@@ -13,6 +13,11 @@ import (
 var (
 	registrationMessage = "I, %s, am signing this message for registering my intention to operate an RSS3 Node."
 	hideTaxRateMessage  = "I, %s, am signing this message for registering my intention to hide the tax rate on Explorer for my RSS3 Node."
+	exitMessage         = "I, %s, am signing this message at %d for announcing my intention to leave the Network with my RSS3 Node."
+
+	createSubscriptionMessage = "I, %s, am signing this message at %d for subscribing %s to the %s events of my address on the RSS3 Network."
+	deleteSubscriptionMessage = "I, %s, am signing this message for deleting the webhook subscription %d of my address on the RSS3 Network."
+	readDeliveriesMessage     = "I, %s, am signing this message at %d for reading the deliveries of the webhook subscription %d of my address on the RSS3 Network."
 )
 
 func (n *NTA) GetNodeChallenge(c echo.Context) error {
@@ -33,6 +38,12 @@ func (n *NTA) GetNodeChallenge(c echo.Context) error {
 		data = nta.NodeChallengeResponseData(fmt.Sprintf(registrationMessage, strings.ToLower(request.NodeAddress.String())))
 	case "hideTaxRate":
 		data = nta.NodeChallengeResponseData(fmt.Sprintf(hideTaxRateMessage, strings.ToLower(request.NodeAddress.String())))
+	case "exit":
+		if request.Timestamp == nil {
+			return errorx.BadRequestError(c, fmt.Errorf("the exit challenge requires a timestamp"))
+		}
+
+		data = nta.NodeChallengeResponseData(fmt.Sprintf(exitMessage, strings.ToLower(request.NodeAddress.String()), *request.Timestamp))
 	default:
 		return errorx.BadRequestError(c, fmt.Errorf("invalid challenge type: %s", request.Type))
 	}
@@ -0,0 +1,226 @@
+package nta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+const (
+	// dashboardInvalidResponseLimit is the number of the recent invalid responses on the dashboard.
+	dashboardInvalidResponseLimit = 10
+	// dashboardRewardEpochs is the number of the recent Epochs the estimated rewards are based on.
+	dashboardRewardEpochs = 4
+)
+
+// GetNodeDashboard returns the live state of a Node in the Epoch in progress,
+// including the request counts and the Reliability Score that are settled at the end of the Epoch.
+func (n *NTA) GetNodeDashboard(c echo.Context) error {
+	var request nta.NodeDashboardRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	ctx := c.Request().Context()
+
+	node, err := n.databaseClient.FindNode(ctx, request.NodeAddress)
+	if errors.Is(err, database.ErrorRowNotFound) {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	if err != nil {
+		zap.L().Error("find node", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	dashboard, err := n.getNodeDashboard(ctx, node, time.Now())
+	if err != nil {
+		zap.L().Error("get node dashboard", zap.Stringer("node", request.NodeAddress), zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data: nta.NodeDashboardResponseData(dashboard),
+	})
+}
+
+func (n *NTA) getNodeDashboard(ctx context.Context, node *schema.Node, now time.Time) (*nta.NodeDashboard, error) {
+	stat, err := n.databaseClient.FindNodeStat(ctx, node.Address)
+	if err != nil {
+		return nil, fmt.Errorf("find node stat: %w", err)
+	}
+
+	if stat == nil {
+		stat = &schema.Stat{Address: node.Address}
+	}
+
+	epoch, err := n.getDashboardEpoch(ctx, stat, now)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard := nta.NodeDashboard{
+		NodeAddress: node.Address,
+		Status:      node.Status,
+		Epoch:       epoch,
+		Score:       stat.Score,
+		Ranks:       &nta.DashboardRanks{},
+	}
+
+	// The scores in the qualified Node sets are updated with the requests, the stats are updated at the end of the Epoch.
+	for _, set := range []struct {
+		key  string
+		rank **int
+	}{
+		{model.FullNodeCacheKey, &dashboard.Ranks.Full},
+		{model.RssNodeCacheKey, &dashboard.Ranks.RSS},
+	} {
+		rank, score, err := n.cacheClient.ZRevRankWithScore(ctx, set.key, node.Address.String())
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("get rank in %s: %w", set.key, err)
+		}
+
+		*set.rank = lo.ToPtr(int(rank) + 1)
+		dashboard.Score = score
+	}
+
+	if dashboard.Workers, err = n.getDashboardWorkers(ctx, node.Address); err != nil {
+		return nil, err
+	}
+
+	invalidResponses, err := n.databaseClient.FindNodeInvalidResponses(ctx, schema.NodeInvalidResponsesQuery{
+		Node:  lo.ToPtr(node.Address),
+		Limit: lo.ToPtr(dashboardInvalidResponseLimit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find node invalid responses: %w", err)
+	}
+
+	dashboard.InvalidResponses = nta.NewNodeInvalidResponses(invalidResponses, false)
+
+	epochs, err := n.databaseClient.FindEpochNodeRewards(ctx, node.Address, dashboardRewardEpochs, nil)
+	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+		return nil, fmt.Errorf("find epoch node rewards: %w", err)
+	}
+
+	recentRewards := make([]*schema.RewardedNode, 0, len(epochs))
+
+	for _, epoch := range epochs {
+		recentRewards = append(recentRewards, lo.Filter(epoch.RewardedNodes, func(rewardedNode *schema.RewardedNode, _ int) bool {
+			return rewardedNode.NodeAddress == node.Address
+		})...)
+	}
+
+	// The online and the degraded Nodes are settled at the end of the Epoch, see the settler.
+	eligible := node.Status == schema.NodeStatusOnline || node.Status == schema.NodeStatusDegraded
+
+	dashboard.EstimatedRewards = nta.EstimateRewards(recentRewards, eligible)
+
+	return &dashboard, nil
+}
+
+// getDashboardEpoch returns the Epoch in progress, which starts when the latest Epoch is settled,
+// its progress is measured against the average duration of the recent Epochs.
+func (n *NTA) getDashboardEpoch(ctx context.Context, stat *schema.Stat, now time.Time) (*nta.DashboardEpoch, error) {
+	epoch := nta.DashboardEpoch{
+		DemotionThreshold: model.DemotionCountBeforeSlashing,
+	}
+
+	var err error
+
+	if epoch.ValidRequestCount, err = n.getRequestCount(ctx, model.ValidRequestCount, stat.Address, stat.EpochRequest); err != nil {
+		return nil, err
+	}
+
+	if epoch.InvalidRequestCount, err = n.getRequestCount(ctx, model.InvalidRequestCount, stat.Address, stat.EpochInvalidRequest); err != nil {
+		return nil, err
+	}
+
+	epochs, err := n.databaseClient.FindEpochs(ctx, &schema.FindEpochsQuery{
+		Distinct: lo.ToPtr(true),
+		Limit:    lo.ToPtr(dashboardRewardEpochs + 1),
+	})
+	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+		return nil, fmt.Errorf("find epochs: %w", err)
+	}
+
+	// An Epoch is distributed in batches, the latest batch settles the Epoch.
+	epochs = lo.UniqBy(epochs, func(epoch *schema.Epoch) uint64 {
+		return epoch.ID
+	})
+
+	if len(epochs) == 0 {
+		return &epoch, nil
+	}
+
+	epoch.ID = epochs[0].ID + 1
+	epoch.StartedAt = epochs[0].BlockTimestamp
+
+	if len(epochs) > 1 {
+		duration := float64(epochs[0].BlockTimestamp-epochs[len(epochs)-1].BlockTimestamp) / float64(len(epochs)-1)
+
+		if duration > 0 {
+			epoch.Progress = min(float64(now.Unix()-epoch.StartedAt)/duration, 1)
+		}
+	}
+
+	return &epoch, nil
+}
+
+// getRequestCount returns the request count of a Node in the Epoch in progress,
+// which is counted in the cache by the enforcer, or falls back to the count in the stat if the cache is not initialized.
+func (n *NTA) getRequestCount(ctx context.Context, key string, address common.Address, statCount int64) (int64, error) {
+	var count int64
+
+	if err := n.cacheClient.Get(ctx, model.FormatNodeStatRedisKey(key, address.String()), &count); err != nil {
+		if errors.Is(err, redis.Nil) {
+			return statCount, nil
+		}
+
+		return 0, fmt.Errorf("get %s of %s: %w", key, address, err)
+	}
+
+	return count, nil
+}
+
+// getDashboardWorkers returns the Workers of a Node reported in the latest Epoch.
+func (n *NTA) getDashboardWorkers(ctx context.Context, address common.Address) ([]*nta.DashboardWorker, error) {
+	workers, err := n.databaseClient.FindNodeWorkers(ctx, &schema.WorkerQuery{
+		NodeAddresses: []common.Address{address},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find node workers: %w", err)
+	}
+
+	latestEpochID := lo.Max(lo.Map(workers, func(worker *schema.Worker, _ int) uint64 {
+		return worker.EpochID
+	}))
+
+	return nta.NewDashboardWorkers(lo.Filter(workers, func(worker *schema.Worker, _ int) bool {
+		return worker.EpochID == latestEpochID
+	})), nil
+}
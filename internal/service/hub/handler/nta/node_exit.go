@@ -0,0 +1,86 @@
+package nta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/lifecycle"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/schema"
+	"go.uber.org/zap"
+)
+
+// PostNodeExit announces the intention of a Node to leave the Network,
+// the Node is exiting until the exiter moves it to exited after the mandatory waiting period.
+func (n *NTA) PostNodeExit(c echo.Context) error {
+	var request nta.NodeExitRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	// The signed message expires, so that a leaked signature cannot announce the exit of the Node again once it re-registers.
+	if signedAt := time.Unix(request.Timestamp, 0); time.Since(signedAt).Abs() > n.exiterConfig.SignatureValidity {
+		return errorx.ValidationFailedError(c, fmt.Errorf("the message signed at %s has expired", signedAt.UTC().Format(time.RFC3339)))
+	}
+
+	message := fmt.Sprintf(exitMessage, strings.ToLower(request.NodeAddress.String()), request.Timestamp)
+
+	if err := n.checkSignature(c.Request().Context(), request.NodeAddress, message, request.Signature); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("check signature: %w", err))
+	}
+
+	used, err := n.cacheClient.Exists(c.Request().Context(), buildSignatureKey("exit", request.Signature))
+	if err != nil {
+		zap.L().Error("find used exit signature", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	if used > 0 {
+		return errorx.ValidationFailedError(c, fmt.Errorf("the signature has been used"))
+	}
+
+	node, err := n.databaseClient.FindNode(c.Request().Context(), request.NodeAddress)
+	if errors.Is(err, database.ErrorRowNotFound) {
+		return errorx.BadRequestError(c, fmt.Errorf("node %s not found", request.NodeAddress))
+	}
+
+	if err != nil {
+		zap.L().Error("find node", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	if !lifecycle.CanTransit(node.Status, schema.NodeStatusExiting) {
+		return errorx.BadRequestError(c, &nta.NodeStatusTransitionError{From: node.Status, To: schema.NodeStatusExiting})
+	}
+
+	if err := n.databaseClient.WithTransaction(c.Request().Context(), func(ctx context.Context, client database.Client) error {
+		_, err := lifecycle.Transit(ctx, client, []*schema.Node{node}, schema.NodeStatusExiting, schema.NodeStatusTransitionReasonExitAnnouncement)
+
+		return err
+	}); err != nil {
+		zap.L().Error("announce node exit", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	// The signature is kept as long as its message is valid.
+	if err := n.cacheClient.Set(c.Request().Context(), buildSignatureKey("exit", request.Signature), true, 2*n.exiterConfig.SignatureValidity); err != nil {
+		zap.L().Error("cache used exit signature", zap.Error(err))
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,94 @@
+package nta
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/creasty/defaults"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// GetInvalidResponses returns the invalid responses of all Nodes, which are the evidence of the penalties.
+func (n *NTA) GetInvalidResponses(c echo.Context) error {
+	var request nta.InvalidResponsesRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, fmt.Errorf("set default failed: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	return n.getInvalidResponses(c, nil, &request)
+}
+
+// GetNodeInvalidResponses returns the invalid responses of a Node.
+func (n *NTA) GetNodeInvalidResponses(c echo.Context) error {
+	var request nta.NodeInvalidResponsesRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, fmt.Errorf("set default failed: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	return n.getInvalidResponses(c, &request.NodeAddress, &request.InvalidResponsesRequest)
+}
+
+func (n *NTA) getInvalidResponses(c echo.Context, nodeAddress *common.Address, request *nta.InvalidResponsesRequest) error {
+	query := schema.NodeInvalidResponsesQuery{
+		Node:       nodeAddress,
+		EpochID:    request.EpochID,
+		Verifier:   request.Verifier,
+		BeforeDate: request.BeforeDate,
+		AfterDate:  request.AfterDate,
+		Cursor:     request.Cursor,
+		Limit:      lo.ToPtr(request.Limit),
+	}
+
+	if request.Type != nil {
+		responseType, err := schema.NodeInvalidResponseTypeString(*request.Type)
+		if err != nil {
+			return errorx.ValidationFailedError(c, fmt.Errorf("invalid type: %w", err))
+		}
+
+		query.Type = &responseType
+	}
+
+	responses, err := n.databaseClient.FindNodeInvalidResponses(c.Request().Context(), query)
+	if err != nil {
+		zap.L().Error("find node invalid responses", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	var cursor string
+
+	if len(responses) > 0 && len(responses) == request.Limit {
+		last, _ := lo.Last(responses)
+		cursor = strconv.FormatUint(last.ID, 10)
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data:   nta.NewNodeInvalidResponses(responses, request.Diff),
+		Cursor: cursor,
+	})
+}
@@ -19,6 +19,9 @@ import (
 	"github.com/redis/go-redis/v9"
 	"github.com/rss3-network/global-indexer/common/ethereum"
 	stakingv2 "github.com/rss3-network/global-indexer/contract/l2/staking/v2"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/lifecycle"
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
 	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
 	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
 	"github.com/rss3-network/global-indexer/schema"
@@ -162,9 +165,10 @@ func (n *NTA) register(ctx context.Context, request *nta.RegisterNodeRequest, re
 		}
 	}
 
-	err = nta.UpdateNodeStatus(node, schema.NodeStatusOnline)
-	if err != nil {
-		return fmt.Errorf("update node status: %w", err)
+	// An exited Node registers to the Network again before it goes online.
+	statuses := []schema.NodeStatus{schema.NodeStatusOnline}
+	if node.Status == schema.NodeStatusExited {
+		statuses = []schema.NodeStatus{schema.NodeStatusRegistered, schema.NodeStatusOnline}
 	}
 
 	node.Location, err = n.geoLite2.LookupNodeLocation(ctx, requestIP)
@@ -173,8 +177,20 @@ func (n *NTA) register(ctx context.Context, request *nta.RegisterNodeRequest, re
 	}
 
 	// Save Node to database.
-	if err = n.databaseClient.SaveNode(ctx, node); err != nil {
-		return fmt.Errorf("save Node: %s, %w", node.Address.String(), err)
+	if err = n.databaseClient.WithTransaction(ctx, func(ctx context.Context, client database.Client) error {
+		if err := client.SaveNode(ctx, node); err != nil {
+			return fmt.Errorf("save Node: %s, %w", node.Address.String(), err)
+		}
+
+		for _, status := range statuses {
+			if _, err := lifecycle.Transit(ctx, client, []*schema.Node{node}, status, schema.NodeStatusTransitionReasonRegistration); err != nil {
+				return fmt.Errorf("update node status: %w", err)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return err
 	}
 
 	if request.Type != "alpha" {
@@ -260,14 +276,46 @@ func (n *NTA) heartbeat(ctx context.Context, request *nta.NodeHeartbeatRequest,
 	}
 
 	node.LastHeartbeatTimestamp = time.Now().Unix()
-	err = nta.UpdateNodeStatus(node, schema.NodeStatusOnline)
+	status := n.heartbeatStatus(ctx, node)
 
-	if err != nil {
-		return fmt.Errorf("update node status: %w", err)
+	// Save Node to database.
+	return n.databaseClient.WithTransaction(ctx, func(ctx context.Context, client database.Client) error {
+		if err := client.SaveNode(ctx, node); err != nil {
+			return fmt.Errorf("save Node: %s, %w", node.Address.String(), err)
+		}
+
+		if _, err := lifecycle.Transit(ctx, client, []*schema.Node{node}, status, schema.NodeStatusTransitionReasonHeartbeat); err != nil {
+			return fmt.Errorf("update node status: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// heartbeatStatus returns the status of a Node that sent a heartbeat.
+// While the prober is running, it decides whether a probed Node is online, degraded or offline,
+// a heartbeat only proves that the Node is alive.
+// A heartbeat does not cancel an exit, exiting and exited Nodes keep their status.
+func (n *NTA) heartbeatStatus(ctx context.Context, node *schema.Node) schema.NodeStatus {
+	if node.Status == schema.NodeStatusExiting || node.Status == schema.NodeStatusExited {
+		return node.Status
 	}
 
-	// Save Node to database.
-	return n.databaseClient.SaveNode(ctx, node)
+	if !lo.Contains([]schema.NodeStatus{schema.NodeStatusOnline, schema.NodeStatusDegraded, schema.NodeStatusOffline}, node.Status) {
+		return schema.NodeStatusOnline
+	}
+
+	var status schema.NodeStatus
+
+	if err := n.cacheClient.Get(ctx, model.FormatNodeStatRedisKey(model.NodeProbeStatus, node.Address.String()), &status); err != nil {
+		if !errors.Is(err, redis.Nil) {
+			zap.L().Error("get probe status", zap.Error(err), zap.String("address", node.Address.String()))
+		}
+
+		return schema.NodeStatusOnline
+	}
+
+	return status
 }
 
 func (n *NTA) checkSignature(_ context.Context, address common.Address, message string, param string) error {
@@ -0,0 +1,59 @@
+package nta
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/creasty/defaults"
+	"github.com/labstack/echo/v4"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// GetNodeScore returns the breakdowns of the Reliability Score of a Node in the recent Epochs.
+func (n *NTA) GetNodeScore(c echo.Context) error {
+	var request nta.NodeScoreRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, fmt.Errorf("set default failed: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	scores, err := n.databaseClient.FindNodeScores(c.Request().Context(), schema.NodeScoresQuery{
+		NodeAddress: lo.ToPtr(request.NodeAddress),
+		Cursor:      request.Cursor,
+		Limit:       lo.ToPtr(request.Limit),
+	})
+	if err != nil {
+		zap.L().Error("get Node scores failed", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	if len(scores) == 0 && request.Cursor == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	var cursor string
+
+	if len(scores) > 0 && len(scores) == request.Limit {
+		last, _ := lo.Last(scores)
+		cursor = strconv.FormatUint(last.EpochID, 10)
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data:   nta.NodeScoresResponseData(scores),
+		Cursor: cursor,
+	})
+}
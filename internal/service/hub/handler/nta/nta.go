@@ -11,6 +11,7 @@ import (
 	"github.com/rss3-network/global-indexer/common/httputil"
 	stakingv2 "github.com/rss3-network/global-indexer/contract/l2/staking/v2"
 	"github.com/rss3-network/global-indexer/internal/cache"
+	"github.com/rss3-network/global-indexer/internal/config"
 	"github.com/rss3-network/global-indexer/internal/database"
 	"go.uber.org/zap"
 )
@@ -21,6 +22,9 @@ type NTA struct {
 	geoLite2        *geolite2.Client
 	cacheClient     cache.Client
 	httpClient      httputil.Client
+	taxerConfig     config.Taxer
+	webhookConfig   config.Webhook
+	exiterConfig    config.Exiter
 }
 
 var MinDeposit = new(big.Int).Mul(big.NewInt(10000), big.NewInt(1e18))
@@ -32,7 +36,7 @@ func (n *NTA) baseURL(c echo.Context) url.URL {
 	}
 }
 
-func NewNTA(_ context.Context, databaseClient database.Client, stakingContract *stakingv2.Staking, geoLite2 *geolite2.Client, cacheClient cache.Client, httpClient httputil.Client) *NTA {
+func NewNTA(_ context.Context, databaseClient database.Client, stakingContract *stakingv2.Staking, geoLite2 *geolite2.Client, cacheClient cache.Client, httpClient httputil.Client, config *config.File) *NTA {
 	minDeposit, err := stakingContract.MINDEPOSIT(&bind.CallOpts{})
 	if err != nil {
 		zap.L().Error("get min deposit", zap.Error(err))
@@ -46,5 +50,8 @@ func NewNTA(_ context.Context, databaseClient database.Client, stakingContract *
 		geoLite2:        geoLite2,
 		cacheClient:     cacheClient,
 		httpClient:      httpClient,
+		taxerConfig:     config.Taxer,
+		webhookConfig:   config.Webhook,
+		exiterConfig:    config.Exiter,
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/creasty/defaults"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/labstack/echo/v4"
 	"github.com/rss3-network/global-indexer/internal/database"
@@ -18,7 +19,29 @@ import (
 )
 
 func (n *NTA) GetNodeCountSnapshots(c echo.Context) error {
-	nodeSnapshots, err := n.databaseClient.FindNodeCountSnapshots(c.Request().Context())
+	var request nta.GetCountSnapshotsRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, fmt.Errorf("set default failed: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	if granularity, aggregated := request.Aggregated(); aggregated {
+		return n.getSnapshotPoints(c, request.Query(schema.SnapshotSeriesNodeCount, nil, granularity, lo.ToPtr(request.Limit)))
+	}
+
+	nodeSnapshots, err := n.databaseClient.FindNodeCountSnapshots(c.Request().Context(), schema.CountSnapshotsQuery{
+		Since: request.Since,
+		Until: request.Until,
+		Limit: lo.ToPtr(request.Limit),
+	})
 	if err != nil {
 		zap.L().Error("find Node snapshots", zap.Error(err))
 
@@ -39,9 +62,18 @@ func (n *NTA) GetNodeOperationProfitSnapshots(c echo.Context) error {
 		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
 	}
 
+	if err := defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, fmt.Errorf("set default failed: %w", err))
+	}
+
 	if err := c.Validate(&request); err != nil {
 		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
 	}
+
+	if granularity, aggregated := request.Aggregated(); aggregated {
+		return n.getSnapshotPoints(c, request.Query(schema.SnapshotSeriesOperationPool, lo.ToPtr(request.NodeAddress), granularity, request.Limit))
+	}
+
 	// FIXME: OperatorProfit -> NodeOperationProfit
 	query := schema.OperatorProfitSnapshotsQuery{
 		Operator:   lo.ToPtr(request.NodeAddress),
@@ -51,6 +83,15 @@ func (n *NTA) GetNodeOperationProfitSnapshots(c echo.Context) error {
 		AfterDate:  request.AfterDate,
 	}
 
+	// The inclusive range takes precedence over the exclusive dates.
+	if request.Since != nil {
+		query.AfterDate = lo.ToPtr(request.Since.Add(-time.Nanosecond))
+	}
+
+	if request.Until != nil {
+		query.BeforeDate = lo.ToPtr(request.Until.Add(time.Nanosecond))
+	}
+
 	operatorProfitSnapshots, err := n.databaseClient.FindOperatorProfitSnapshots(c.Request().Context(), query)
 	if err != nil {
 		zap.L().Error("find operator profit snapshots", zap.Error(err))
@@ -72,7 +113,29 @@ func (n *NTA) GetNodeOperationProfitSnapshots(c echo.Context) error {
 }
 
 func (n *NTA) GetEpochsAPYSnapshots(c echo.Context) error {
-	epochAPYSnapshots, err := n.databaseClient.FindEpochAPYSnapshots(c.Request().Context(), schema.EpochAPYSnapshotQuery{})
+	var request nta.GetEpochAPYSnapshotsRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, fmt.Errorf("set default failed: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	if granularity, aggregated := request.Aggregated(); aggregated {
+		return n.getSnapshotPoints(c, request.Query(schema.SnapshotSeriesEpochAPY, nil, granularity, request.Limit))
+	}
+
+	epochAPYSnapshots, err := n.databaseClient.FindEpochAPYSnapshots(c.Request().Context(), schema.EpochAPYSnapshotQuery{
+		Since: request.Since,
+		Until: request.Until,
+		Limit: request.Limit,
+	})
 	if err != nil {
 		zap.L().Error("find epoch APY snapshots", zap.Error(err))
 
@@ -84,6 +147,20 @@ func (n *NTA) GetEpochsAPYSnapshots(c echo.Context) error {
 	})
 }
 
+// getSnapshotPoints responds with the rolled up snapshots of a series.
+func (n *NTA) getSnapshotPoints(c echo.Context, query schema.SnapshotPointsQuery) error {
+	points, err := n.databaseClient.FindSnapshotPoints(c.Request().Context(), query)
+	if err != nil {
+		zap.L().Error("find snapshot points", zap.Error(err), zap.Any("query", query))
+
+		return errorx.InternalError(c)
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data: nta.GetSnapshotPointsResponseData(points),
+	})
+}
+
 func (n *NTA) findNodeOperationProfitSnapshots(ctx context.Context, operator common.Address, profit *nta.GetNodeOperationProfitResponse) ([]*nta.NodeProfitChangeDetail, error) {
 	if profit == nil {
 		return nil, nil
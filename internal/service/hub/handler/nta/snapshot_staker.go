@@ -3,7 +3,9 @@ package nta
 import (
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/creasty/defaults"
 	"github.com/labstack/echo/v4"
 	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
 	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
@@ -13,7 +15,29 @@ import (
 )
 
 func (n *NTA) GetStakerCountSnapshots(c echo.Context) error {
-	stakeSnapshots, err := n.databaseClient.FindStakerCountSnapshots(c.Request().Context())
+	var request nta.GetCountSnapshotsRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, fmt.Errorf("set default failed: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validate failed: %w", err))
+	}
+
+	if granularity, aggregated := request.Aggregated(); aggregated {
+		return n.getSnapshotPoints(c, request.Query(schema.SnapshotSeriesStakerCount, nil, granularity, lo.ToPtr(request.Limit)))
+	}
+
+	stakeSnapshots, err := n.databaseClient.FindStakerCountSnapshots(c.Request().Context(), schema.CountSnapshotsQuery{
+		Since: request.Since,
+		Until: request.Until,
+		Limit: lo.ToPtr(request.Limit),
+	})
 	if err != nil {
 		zap.L().Error("find staker_count snapshots", zap.Error(err))
 
@@ -34,10 +58,18 @@ func (n *NTA) GetStakerProfitSnapshots(c echo.Context) error {
 		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
 	}
 
+	if err := defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, fmt.Errorf("set default failed: %w", err))
+	}
+
 	if err := c.Validate(&request); err != nil {
 		return errorx.ValidationFailedError(c, fmt.Errorf("validate failed: %w", err))
 	}
 
+	if granularity, aggregated := request.Aggregated(); aggregated {
+		return n.getStakerProfitPoints(c, &request, granularity)
+	}
+
 	query := schema.StakerProfitSnapshotsQuery{
 		OwnerAddress: lo.ToPtr(request.StakerAddress),
 		Limit:        request.Limit,
@@ -46,6 +78,15 @@ func (n *NTA) GetStakerProfitSnapshots(c echo.Context) error {
 		AfterDate:    request.AfterDate,
 	}
 
+	// The inclusive range takes precedence over the exclusive dates.
+	if request.Since != nil {
+		query.AfterDate = lo.ToPtr(request.Since.Add(-time.Nanosecond))
+	}
+
+	if request.Until != nil {
+		query.BeforeDate = lo.ToPtr(request.Until.Add(time.Nanosecond))
+	}
+
 	stakerProfitSnapshots, err := n.databaseClient.FindStakerProfitSnapshots(c.Request().Context(), query)
 	if err != nil {
 		zap.L().Error("find staker profit snapshots", zap.Error(err))
@@ -65,3 +106,24 @@ func (n *NTA) GetStakerProfitSnapshots(c echo.Context) error {
 		Cursor: cursor,
 	})
 }
+
+// getStakerProfitPoints responds with the rolled up amounts and values of the Chips of a staker.
+func (n *NTA) getStakerProfitPoints(c echo.Context, request *nta.GetStakerProfitSnapshotsRequest, granularity schema.SnapshotGranularity) error {
+	amounts, err := n.databaseClient.FindSnapshotPoints(c.Request().Context(), request.Query(schema.SnapshotSeriesTotalChipAmounts, lo.ToPtr(request.StakerAddress), granularity, request.Limit))
+	if err != nil {
+		zap.L().Error("find staker chip amounts snapshot points", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	values, err := n.databaseClient.FindSnapshotPoints(c.Request().Context(), request.Query(schema.SnapshotSeriesTotalChipValues, lo.ToPtr(request.StakerAddress), granularity, request.Limit))
+	if err != nil {
+		zap.L().Error("find staker chip values snapshot points", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data: nta.NewStakerProfitPoints(amounts, values),
+	})
+}
@@ -0,0 +1,258 @@
+package nta
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/creasty/defaults"
+	"github.com/labstack/echo/v4"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/internal/webhook"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// PostSubscription subscribes a URL to the webhook events of a Node or a staker address,
+// the request is signed by the address and the secret to verify the deliveries is only returned once.
+func (n *NTA) PostSubscription(c echo.Context) error {
+	var request nta.CreateSubscriptionRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	eventTypes := lo.Map(request.EventTypes, func(eventType schema.WebhookEventType, _ int) string {
+		return eventType.String()
+	})
+
+	// The signed message expires, so that a leaked signature cannot subscribe the URL again later.
+	if signedAt := time.Unix(request.Timestamp, 0); time.Since(signedAt).Abs() > n.webhookConfig.SignatureValidity {
+		return errorx.ValidationFailedError(c, fmt.Errorf("the message signed at %s has expired", signedAt.UTC().Format(time.RFC3339)))
+	}
+
+	message := fmt.Sprintf(createSubscriptionMessage, strings.ToLower(request.Address.String()), request.Timestamp, request.URL, strings.Join(eventTypes, ","))
+
+	if err := n.checkSignature(c.Request().Context(), request.Address, message, request.Signature); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("check signature: %w", err))
+	}
+
+	used, err := n.cacheClient.Exists(c.Request().Context(), buildSignatureKey("subscription", request.Signature))
+	if err != nil {
+		zap.L().Error("find used subscription signature", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	if used > 0 {
+		return errorx.ValidationFailedError(c, fmt.Errorf("the signature has been used"))
+	}
+
+	subscriptions, err := n.databaseClient.FindWebhookSubscriptions(c.Request().Context(), schema.WebhookSubscriptionsQuery{
+		Address: lo.ToPtr(request.Address),
+		Limit:   lo.ToPtr(n.webhookConfig.MaxSubscriptions),
+	})
+	if err != nil {
+		zap.L().Error("find webhook subscriptions", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	if len(subscriptions) >= n.webhookConfig.MaxSubscriptions {
+		return errorx.BadRequestError(c, fmt.Errorf("address %s has reached the limit of %d subscriptions", request.Address, n.webhookConfig.MaxSubscriptions))
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		zap.L().Error("new webhook secret", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	subscription := &schema.WebhookSubscription{
+		Address:    request.Address,
+		URL:        request.URL,
+		EventTypes: request.EventTypes,
+		Secret:     secret,
+	}
+
+	if err := n.databaseClient.SaveWebhookSubscription(c.Request().Context(), subscription); err != nil {
+		zap.L().Error("save webhook subscription", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	// The signature is kept as long as its message is valid.
+	if err := n.cacheClient.Set(c.Request().Context(), buildSignatureKey("subscription", request.Signature), true, 2*n.webhookConfig.SignatureValidity); err != nil {
+		zap.L().Error("cache used subscription signature", zap.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data: nta.NewCreatedSubscription(subscription),
+	})
+}
+
+// GetSubscriptions returns the webhook subscriptions of an address.
+func (n *NTA) GetSubscriptions(c echo.Context) error {
+	var request nta.SubscriptionsRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, fmt.Errorf("set default failed: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	subscriptions, err := n.databaseClient.FindWebhookSubscriptions(c.Request().Context(), schema.WebhookSubscriptionsQuery{
+		Address: lo.ToPtr(request.Address),
+		Cursor:  request.Cursor,
+		Limit:   lo.ToPtr(request.Limit),
+	})
+	if err != nil {
+		zap.L().Error("find webhook subscriptions", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	var cursor string
+
+	if len(subscriptions) > 0 && len(subscriptions) == request.Limit {
+		last, _ := lo.Last(subscriptions)
+		cursor = strconv.FormatUint(last.ID, 10)
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data:   nta.NewSubscriptions(subscriptions),
+		Cursor: cursor,
+	})
+}
+
+// DeleteSubscription deletes a webhook subscription and its deliveries, the request is signed by the subscribed address.
+func (n *NTA) DeleteSubscription(c echo.Context) error {
+	var request nta.DeleteSubscriptionRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	subscription, err := n.databaseClient.FindWebhookSubscription(c.Request().Context(), request.ID)
+	if errors.Is(err, database.ErrorRowNotFound) {
+		return errorx.BadRequestError(c, fmt.Errorf("subscription %d not found", request.ID))
+	}
+
+	if err != nil {
+		zap.L().Error("find webhook subscription", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	message := fmt.Sprintf(deleteSubscriptionMessage, strings.ToLower(subscription.Address.String()), subscription.ID)
+
+	if err := n.checkSignature(c.Request().Context(), subscription.Address, message, request.Signature); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("check signature: %w", err))
+	}
+
+	if err := n.databaseClient.DeleteWebhookSubscription(c.Request().Context(), subscription.ID); err != nil {
+		zap.L().Error("delete webhook subscription", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// GetSubscriptionDeliveries returns the delivery log of a webhook subscription, the request is signed by the subscribed address.
+func (n *NTA) GetSubscriptionDeliveries(c echo.Context) error {
+	var request nta.SubscriptionDeliveriesRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, fmt.Errorf("set default failed: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	if signedAt := time.Unix(request.Timestamp, 0); time.Since(signedAt).Abs() > n.webhookConfig.SignatureValidity {
+		return errorx.ValidationFailedError(c, fmt.Errorf("the message signed at %s has expired", signedAt.UTC().Format(time.RFC3339)))
+	}
+
+	subscription, err := n.databaseClient.FindWebhookSubscription(c.Request().Context(), request.ID)
+	if errors.Is(err, database.ErrorRowNotFound) {
+		return errorx.BadRequestError(c, fmt.Errorf("subscription %d not found", request.ID))
+	}
+
+	if err != nil {
+		zap.L().Error("find webhook subscription", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	message := fmt.Sprintf(readDeliveriesMessage, strings.ToLower(subscription.Address.String()), request.Timestamp, subscription.ID)
+
+	if err := n.checkSignature(c.Request().Context(), subscription.Address, message, request.Signature); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("check signature: %w", err))
+	}
+
+	query := schema.WebhookDeliveriesQuery{
+		SubscriptionID: lo.ToPtr(request.ID),
+		Cursor:         request.Cursor,
+		Limit:          lo.ToPtr(request.Limit),
+	}
+
+	if request.Status != nil {
+		status, err := schema.WebhookDeliveryStatusString(*request.Status)
+		if err != nil {
+			return errorx.ValidationFailedError(c, fmt.Errorf("invalid status: %w", err))
+		}
+
+		query.Status = lo.ToPtr(status)
+	}
+
+	deliveries, err := n.databaseClient.FindWebhookDeliveries(c.Request().Context(), query)
+	if err != nil {
+		zap.L().Error("find webhook deliveries", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	var cursor string
+
+	if len(deliveries) > 0 && len(deliveries) == request.Limit {
+		last, _ := lo.Last(deliveries)
+		cursor = strconv.FormatUint(last.ID, 10)
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data:   nta.NewSubscriptionDeliveries(deliveries),
+		Cursor: cursor,
+	})
+}
+
+// buildSignatureKey returns the key of a used signature of the action, a signature is only accepted once.
+func buildSignatureKey(action, signature string) string {
+	return fmt.Sprintf("%s::%s::signature", action, strings.ToLower(signature))
+}
@@ -0,0 +1,129 @@
+package nta
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/creasty/defaults"
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/taxer"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+const (
+	// taxPreviewCacheKey is the cache key for the tax preview, it is cached as the Nodes are queried on the VSL.
+	taxPreviewCacheKey = "tax:preview"
+	taxPreviewCacheTTL = time.Minute
+)
+
+// GetTaxSubmissions returns the average tax rates submitted to the VSL by the taxer.
+func (n *NTA) GetTaxSubmissions(c echo.Context) error {
+	var request nta.TaxSubmissionsRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, fmt.Errorf("set default failed: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	submissions, err := n.databaseClient.FindAverageTaxSubmissions(c.Request().Context(), schema.AverageTaxRateSubmissionQuery{
+		Cursor: request.Cursor,
+		Limit:  lo.ToPtr(request.Limit),
+	})
+	if err != nil {
+		zap.L().Error("find average tax submissions", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	var cursor string
+
+	if len(submissions) > 0 && len(submissions) == request.Limit {
+		last, _ := lo.Last(submissions)
+		cursor = strconv.FormatUint(last.EpochID, 10)
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data:   nta.NewTaxSubmissions(submissions),
+		Cursor: cursor,
+	})
+}
+
+// GetTaxPreview returns the average tax rate that the next run of the taxer would submit.
+func (n *NTA) GetTaxPreview(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var preview nta.TaxPreview
+
+	if err := n.cacheClient.Get(ctx, taxPreviewCacheKey, &preview); err == nil {
+		return c.JSON(http.StatusOK, nta.Response{
+			Data: &preview,
+		})
+	} else if !errors.Is(err, redis.Nil) {
+		zap.L().Error("get tax preview from cache", zap.Error(err))
+	}
+
+	epochID, err := n.findNextTaxEpochID(c)
+	if err != nil {
+		zap.L().Error("find next tax epoch", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	inputs, err := taxer.FindTaxRateInputs(ctx, n.databaseClient, n.stakingContract)
+	if err != nil {
+		zap.L().Error("find tax rate inputs", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	data := nta.NewTaxPreview(epochID, taxer.CalculateAverageTaxRate(inputs, n.taxerConfig))
+
+	if err := n.cacheClient.Set(ctx, taxPreviewCacheKey, data, taxPreviewCacheTTL); err != nil {
+		zap.L().Error("set tax preview to cache", zap.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data: data,
+	})
+}
+
+// findNextTaxEpochID returns the epoch the next run of the taxer would submit for,
+// it is the latest epoch unless it has been submitted, then the taxer waits for the next epoch.
+func (n *NTA) findNextTaxEpochID(c echo.Context) (uint64, error) {
+	epochs, err := n.databaseClient.FindEpochs(c.Request().Context(), &schema.FindEpochsQuery{Limit: lo.ToPtr(1)})
+	if err != nil {
+		return 0, fmt.Errorf("find epochs: %w", err)
+	}
+
+	if len(epochs) == 0 {
+		return 0, nil
+	}
+
+	submissions, err := n.databaseClient.FindAverageTaxSubmissions(c.Request().Context(), schema.AverageTaxRateSubmissionQuery{
+		Limit: lo.ToPtr(1),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("find average tax submissions: %w", err)
+	}
+
+	if len(submissions) > 0 && submissions[0].EpochID >= epochs[0].ID {
+		return epochs[0].ID + 1, nil
+	}
+
+	return epochs[0].ID, nil
+}
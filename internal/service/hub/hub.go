@@ -6,13 +6,13 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
-	"github.com/redis/go-redis/v9"
 	"github.com/rss3-network/global-indexer/common/geolite2"
 	"github.com/rss3-network/global-indexer/common/httputil"
 	"github.com/rss3-network/global-indexer/contract/l2"
 	stakingv2 "github.com/rss3-network/global-indexer/contract/l2/staking/v2"
 	"github.com/rss3-network/global-indexer/internal/cache"
 	"github.com/rss3-network/global-indexer/internal/client/ethereum"
+	"github.com/rss3-network/global-indexer/internal/config"
 	"github.com/rss3-network/global-indexer/internal/config/flag"
 	"github.com/rss3-network/global-indexer/internal/database"
 	"github.com/rss3-network/global-indexer/internal/nameresolver"
@@ -40,7 +40,7 @@ func (v *Validator) Validate(i interface{}) error {
 	return v.validate.Struct(i)
 }
 
-func NewHub(ctx context.Context, databaseClient database.Client, redisClient *redis.Client, ethereumMultiChainClient *ethereum.MultiChainClient, geoLite2 *geolite2.Client, nameService *nameresolver.NameResolver, httpClient httputil.Client) (*Hub, error) {
+func NewHub(ctx context.Context, databaseClient database.Client, cacheClient cache.Client, ethereumMultiChainClient *ethereum.MultiChainClient, geoLite2 *geolite2.Client, nameService *nameresolver.NameResolver, httpClient httputil.Client, config *config.File) (*Hub, error) {
 	chainID := viper.GetUint64(flag.KeyChainIDL2)
 
 	ethereumClient, err := ethereumMultiChainClient.Get(chainID)
@@ -58,8 +58,6 @@ func NewHub(ctx context.Context, databaseClient database.Client, redisClient *re
 		return nil, fmt.Errorf("new staking contract: %w", err)
 	}
 
-	cacheClient := cache.New(redisClient)
-
 	dsl, err := dsl.NewDSL(ctx, databaseClient, cacheClient, nameService, stakingContract, httpClient)
 	if err != nil {
 		return nil, fmt.Errorf("new dsl: %w", err)
@@ -67,6 +65,6 @@ func NewHub(ctx context.Context, databaseClient database.Client, redisClient *re
 
 	return &Hub{
 		dsl: dsl,
-		nta: nta.NewNTA(ctx, databaseClient, stakingContract, geoLite2, cacheClient, httpClient),
+		nta: nta.NewNTA(ctx, databaseClient, stakingContract, geoLite2, cacheClient, httpClient, config),
 	}, nil
 }
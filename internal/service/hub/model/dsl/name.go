@@ -0,0 +1,19 @@
+package dsl
+
+import "github.com/ethereum/go-ethereum/common"
+
+// NamesRequest represents the request for the primary names of an address.
+type NamesRequest struct {
+	Address common.Address `param:"address" validate:"required"`
+}
+
+// ResolveNameRequest represents the request for the resolution of a name.
+type ResolveNameRequest struct {
+	Name string `param:"name" validate:"required"`
+}
+
+// ResolveNameResponse represents the EVM address a name resolves to.
+type ResolveNameResponse struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
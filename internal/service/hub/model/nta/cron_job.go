@@ -0,0 +1,95 @@
+package nta
+
+import (
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+)
+
+// CronJobOverdueGrace is the delay after the next slot of a cron job before it is overdue.
+const CronJobOverdueGrace = time.Minute
+
+type GetCronJobRunsRequest struct {
+	Name    string  `param:"name" validate:"required"`
+	Status  *string `query:"status" validate:"omitempty,oneof=running succeeded failed skipped"`
+	Trigger *string `query:"trigger" validate:"omitempty,oneof=schedule catch_up manual"`
+	Cursor  *uint64 `query:"cursor"`
+	Limit   int     `query:"limit" validate:"min=1,max=100" default:"20"`
+}
+
+type GetCronJobsResponseData []*CronJobStatus
+
+type GetCronJobRunsResponseData []*CronJobRun
+
+// CronJobStatus is a cron job with its last run and its last succeeded run.
+type CronJobStatus struct {
+	*schema.CronJob
+	// Overdue is true if no scheduler has reached the next slot of the job in time, such as when all of them are down.
+	Overdue          bool        `json:"overdue"`
+	LastRun          *CronJobRun `json:"last_run"`
+	LastSucceededRun *CronJobRun `json:"last_succeeded_run"`
+}
+
+func NewCronJobStatus(job *schema.CronJob, lastRun, lastSucceededRun *schema.CronJobRun, now time.Time) *CronJobStatus {
+	return &CronJobStatus{
+		CronJob:          job,
+		Overdue:          now.After(time.Unix(job.NextScheduledAt, 0).Add(CronJobOverdueGrace)),
+		LastRun:          NewCronJobRun(lastRun),
+		LastSucceededRun: NewCronJobRun(lastSucceededRun),
+	}
+}
+
+// CronJobRun is a run of a cron job without the scheduler holding the lock, the URLs in its error are redacted.
+type CronJobRun struct {
+	ID          uint64                   `json:"id"`
+	Job         string                   `json:"job"`
+	Trigger     schema.CronJobRunTrigger `json:"trigger"`
+	Status      schema.CronJobRunStatus  `json:"status"`
+	Error       string                   `json:"error,omitempty"`
+	ScheduledAt int64                    `json:"scheduled_at"`
+	StartedAt   int64                    `json:"started_at"`
+	FinishedAt  *int64                   `json:"finished_at,omitempty"`
+	Duration    int64                    `json:"duration"`
+}
+
+func NewCronJobRun(run *schema.CronJobRun) *CronJobRun {
+	if run == nil {
+		return nil
+	}
+
+	return &CronJobRun{
+		ID:          run.ID,
+		Job:         run.Job,
+		Trigger:     run.Trigger,
+		Status:      run.Status,
+		Error:       RedactURLs(run.Error),
+		ScheduledAt: run.ScheduledAt,
+		StartedAt:   run.StartedAt,
+		FinishedAt:  run.FinishedAt,
+		Duration:    run.Duration,
+	}
+}
+
+func NewCronJobRuns(runs []*schema.CronJobRun) GetCronJobRunsResponseData {
+	return lo.Map(runs, func(run *schema.CronJobRun, _ int) *CronJobRun {
+		return NewCronJobRun(run)
+	})
+}
+
+var urlPattern = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s"'<>]+`)
+
+// RedactURLs replaces the URLs in the message with their scheme and host,
+// the paths, the queries and the credentials of the RPC endpoints may contain API keys.
+func RedactURLs(message string) string {
+	return urlPattern.ReplaceAllStringFunc(message, func(match string) string {
+		u, err := url.Parse(match)
+		if err != nil || u.Host == "" {
+			return "[redacted]"
+		}
+
+		return u.Scheme + "://" + u.Hostname() + "/[redacted]"
+	})
+}
@@ -0,0 +1,20 @@
+package nta
+
+import (
+	"github.com/rss3-network/global-indexer/schema"
+)
+
+type GetEpochReconciliationsRequest struct {
+	// Inconsistent only returns the reports with mismatches.
+	Inconsistent *bool   `query:"inconsistent"`
+	Cursor       *uint64 `query:"cursor"`
+	Limit        int     `query:"limit" validate:"min=1,max=50" default:"10"`
+}
+
+type GetEpochReconciliationRequest struct {
+	EpochID uint64 `param:"epoch_id" validate:"required"`
+}
+
+type GetEpochReconciliationsResponseData []*schema.EpochReconciliation
+
+type GetEpochReconciliationResponseData *schema.EpochReconciliation
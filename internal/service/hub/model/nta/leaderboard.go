@@ -0,0 +1,89 @@
+package nta
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+type GetLeaderboardRequest struct {
+	Metric string `param:"metric" validate:"required,oneof=rewards apy reliability_score staking_growth uptime invalid_response_rate staker_count"`
+	// Epochs is the window of the recent Epochs the metric is aggregated over.
+	Epochs int `query:"epochs" validate:"oneof=1 7 30" default:"7"`
+	// EpochID defaults to the latest ranked Epoch.
+	EpochID *uint64 `query:"epoch_id"`
+	// Cursor is the Epoch and the rank of the last entry of the previous page, formatted as epoch_id:rank.
+	Cursor *string `query:"cursor"`
+	Limit  int     `query:"limit" validate:"min=1,max=100" default:"20"`
+}
+
+// ParseCursor returns the Epoch and the rank of the cursor, the pages after the first one are pinned to the Epoch of the cursor,
+// so that they are not mixed with the ranks of an Epoch ranked in the meantime.
+func (r *GetLeaderboardRequest) ParseCursor() (epochID uint64, rank int, err error) {
+	epoch, position, found := strings.Cut(*r.Cursor, ":")
+	if !found {
+		return 0, 0, fmt.Errorf("invalid cursor: %s", *r.Cursor)
+	}
+
+	if epochID, err = strconv.ParseUint(epoch, 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid epoch of cursor %s: %w", *r.Cursor, err)
+	}
+
+	if rank, err = strconv.Atoi(position); err != nil {
+		return 0, 0, fmt.Errorf("invalid rank of cursor %s: %w", *r.Cursor, err)
+	}
+
+	if r.EpochID != nil && *r.EpochID != epochID {
+		return 0, 0, fmt.Errorf("cursor %s is not of epoch %d", *r.Cursor, *r.EpochID)
+	}
+
+	return epochID, rank, nil
+}
+
+// NewLeaderboardCursor returns the cursor of the page ending with the rank.
+func NewLeaderboardCursor(rank *schema.NodeRank) string {
+	return fmt.Sprintf("%d:%d", rank.EpochID, rank.Rank)
+}
+
+// LeaderboardEntry is the rank of a Node on a leaderboard.
+type LeaderboardEntry struct {
+	Rank        int             `json:"rank"`
+	NodeAddress common.Address  `json:"node_address"`
+	Name        string          `json:"name"`
+	Value       decimal.Decimal `json:"value"`
+	EpochID     uint64          `json:"epoch_id"`
+	// PreviousRank and RankChange are nil if the Node was not ranked in the previous Epoch,
+	// a positive RankChange is the number of places the Node moved up.
+	PreviousRank *int `json:"previous_rank"`
+	RankChange   *int `json:"rank_change"`
+}
+
+type GetLeaderboardResponseData []*LeaderboardEntry
+
+func NewLeaderboard(ranks []*schema.NodeRank, nodes []*schema.Node) GetLeaderboardResponseData {
+	nodeMap := lo.SliceToMap(nodes, func(node *schema.Node) (common.Address, *schema.Node) {
+		return node.Address, node
+	})
+
+	return lo.Map(ranks, func(rank *schema.NodeRank, _ int) *LeaderboardEntry {
+		entry := LeaderboardEntry{
+			Rank:         rank.Rank,
+			NodeAddress:  rank.NodeAddress,
+			Value:        rank.Value,
+			EpochID:      rank.EpochID,
+			PreviousRank: rank.PreviousRank,
+			RankChange:   rank.RankChange(),
+		}
+
+		if node, exists := nodeMap[rank.NodeAddress]; exists {
+			entry.Name = node.Name
+		}
+
+		return &entry
+	})
+}
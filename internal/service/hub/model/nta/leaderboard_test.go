@@ -0,0 +1,33 @@
+package nta_test
+
+import (
+	"testing"
+
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLeaderboardRequestParseCursor(t *testing.T) {
+	t.Parallel()
+
+	request := nta.GetLeaderboardRequest{Cursor: lo.ToPtr(nta.NewLeaderboardCursor(&schema.NodeRank{EpochID: 42, Rank: 20}))}
+
+	epochID, rank, err := request.ParseCursor()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), epochID)
+	assert.Equal(t, 20, rank)
+
+	// The cursor of another Epoch than the requested one is rejected.
+	request.EpochID = lo.ToPtr(uint64(43))
+
+	_, _, err = request.ParseCursor()
+	require.Error(t, err)
+
+	for _, cursor := range []string{"20", "abc:20", "42:abc"} {
+		_, _, err := (&nta.GetLeaderboardRequest{Cursor: lo.ToPtr(cursor)}).ParseCursor()
+		require.Error(t, err, cursor)
+	}
+}
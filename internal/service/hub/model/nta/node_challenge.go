@@ -5,6 +5,8 @@ import "github.com/ethereum/go-ethereum/common"
 type NodeChallengeRequest struct {
 	NodeAddress common.Address `param:"node_address" validate:"required"`
 	Type        string         `query:"type"`
+	// Timestamp is the Unix time in seconds the exit announcement is signed at, it is required by the exit challenge.
+	Timestamp *int64 `query:"timestamp"`
 }
 
 type NodeChallengeResponseData string
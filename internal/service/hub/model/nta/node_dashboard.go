@@ -0,0 +1,113 @@
+package nta
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/shopspring/decimal"
+)
+
+type NodeDashboardRequest struct {
+	NodeAddress common.Address `param:"node_address" validate:"required"`
+}
+
+type NodeDashboardResponseData *NodeDashboard
+
+// NodeDashboard is the live state of a Node in the Epoch in progress, which is settled at the end of the Epoch.
+type NodeDashboard struct {
+	NodeAddress common.Address    `json:"node_address"`
+	Status      schema.NodeStatus `json:"status"`
+	Epoch       *DashboardEpoch   `json:"epoch"`
+	// Score is the current Reliability Score (σ) of the Node.
+	Score float64 `json:"score"`
+	// Ranks are the 1-based ranks of the Node in the qualified Node sets, omitted if the Node is not in a set.
+	Ranks            *DashboardRanks                  `json:"ranks"`
+	Workers          []*DashboardWorker               `json:"workers"`
+	InvalidResponses NodeInvalidResponsesResponseData `json:"invalid_responses"`
+	EstimatedRewards *EstimatedRewards                `json:"estimated_rewards"`
+}
+
+type DashboardEpoch struct {
+	ID uint64 `json:"id"`
+	// StartedAt is the Unix time the previous Epoch was settled at.
+	StartedAt int64 `json:"started_at"`
+	// Progress is the elapsed share of the average Epoch duration, it is capped at 1.
+	Progress            float64 `json:"progress"`
+	ValidRequestCount   int64   `json:"valid_request_count"`
+	InvalidRequestCount int64   `json:"invalid_request_count"`
+	// DemotionThreshold is the invalid request count at which the Node is demoted.
+	DemotionThreshold int `json:"demotion_threshold"`
+}
+
+type DashboardRanks struct {
+	Full *int `json:"full,omitempty"`
+	RSS  *int `json:"rss,omitempty"`
+}
+
+type DashboardWorker struct {
+	EpochID  uint64 `json:"epoch_id"`
+	Network  string `json:"network"`
+	Name     string `json:"name"`
+	IsActive bool   `json:"is_active"`
+}
+
+// EstimatedRewardsBasis is the basis of the estimated rewards.
+const EstimatedRewardsBasis = "recent_epochs_average"
+
+// EstimatedRewards is the estimate of the rewards of a Node for the Epoch in progress.
+type EstimatedRewards struct {
+	// Eligible is false if the Node would not be rewarded in its current status.
+	Eligible bool `json:"eligible"`
+	// Basis is how the rewards are estimated, see EstimateRewards.
+	Basis string `json:"basis"`
+	// BasisEpochs is the number of the recent Epochs of the Node the estimate is based on.
+	BasisEpochs      int             `json:"basis_epochs"`
+	OperationRewards decimal.Decimal `json:"operation_rewards"`
+	StakingRewards   decimal.Decimal `json:"staking_rewards"`
+	TaxCollected     decimal.Decimal `json:"tax_collected"`
+}
+
+func NewDashboardWorkers(workers []*schema.Worker) []*DashboardWorker {
+	result := make([]*DashboardWorker, len(workers))
+
+	for i, worker := range workers {
+		result[i] = &DashboardWorker{
+			EpochID:  worker.EpochID,
+			Network:  worker.Network,
+			Name:     worker.Name,
+			IsActive: worker.IsActive,
+		}
+	}
+
+	return result
+}
+
+// EstimateRewards estimates the rewards of a Node for the Epoch in progress by its average rewards in the recent Epochs.
+// The settler rewards the Nodes by their staking pools, the operation pools and the staker counts at the end of the Epoch,
+// not by the requests served, so the requests of the Epoch in progress do not change the estimate.
+func EstimateRewards(recentRewards []*schema.RewardedNode, eligible bool) *EstimatedRewards {
+	estimate := &EstimatedRewards{
+		Eligible:    eligible,
+		Basis:       EstimatedRewardsBasis,
+		BasisEpochs: len(recentRewards),
+	}
+
+	if !eligible || len(recentRewards) == 0 {
+		return estimate
+	}
+
+	var operationRewards, stakingRewards, taxCollected decimal.Decimal
+
+	for _, rewards := range recentRewards {
+		operationRewards = operationRewards.Add(rewards.OperationRewards)
+		stakingRewards = stakingRewards.Add(rewards.StakingRewards)
+		taxCollected = taxCollected.Add(rewards.TaxCollected)
+	}
+
+	epochs := decimal.NewFromInt(int64(len(recentRewards)))
+
+	estimate.OperationRewards = operationRewards.Div(epochs).Truncate(0)
+	estimate.StakingRewards = stakingRewards.Div(epochs).Truncate(0)
+	estimate.TaxCollected = taxCollected.Div(epochs).Truncate(0)
+
+	return estimate
+}
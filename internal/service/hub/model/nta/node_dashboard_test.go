@@ -0,0 +1,38 @@
+package nta_test
+
+import (
+	"testing"
+
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateRewards(t *testing.T) {
+	t.Parallel()
+
+	recentRewards := []*schema.RewardedNode{
+		{StakingRewards: decimal.NewFromInt(300), TaxCollected: decimal.NewFromInt(30), RequestCount: decimal.NewFromInt(100)},
+		{StakingRewards: decimal.NewFromInt(500), TaxCollected: decimal.NewFromInt(50), RequestCount: decimal.NewFromInt(300)},
+	}
+
+	// The estimate is the average rewards of the recent Epochs, regardless of their requests.
+	estimate := nta.EstimateRewards(recentRewards, true)
+	assert.True(t, estimate.Eligible)
+	assert.Equal(t, nta.EstimatedRewardsBasis, estimate.Basis)
+	assert.Equal(t, 2, estimate.BasisEpochs)
+	assert.Equal(t, "400", estimate.StakingRewards.String())
+	assert.Equal(t, "40", estimate.TaxCollected.String())
+	assert.Equal(t, "0", estimate.OperationRewards.String())
+
+	// A Node that is not settled is not rewarded.
+	estimate = nta.EstimateRewards(recentRewards, false)
+	assert.False(t, estimate.Eligible)
+	assert.True(t, estimate.StakingRewards.IsZero())
+
+	// A Node without rewards in the recent Epochs is estimated to be rewarded nothing.
+	estimate = nta.EstimateRewards(nil, true)
+	assert.Equal(t, 0, estimate.BasisEpochs)
+	assert.True(t, estimate.OperationRewards.IsZero())
+}
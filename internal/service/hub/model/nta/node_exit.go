@@ -0,0 +1,10 @@
+package nta
+
+import "github.com/ethereum/go-ethereum/common"
+
+type NodeExitRequest struct {
+	NodeAddress common.Address `param:"node_address" validate:"required"`
+	// Timestamp is the Unix time in seconds the message is signed at.
+	Timestamp int64  `json:"timestamp" validate:"required"`
+	Signature string `json:"signature" validate:"required"`
+}
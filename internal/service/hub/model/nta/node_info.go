@@ -58,10 +58,11 @@ func (err *NodeStatusTransitionError) Error() string {
 // for the state machine diagram.
 var transitions = map[schema.NodeStatus][]schema.NodeStatus{
 	schema.NodeStatusRegistered: {schema.NodeStatusRegistered, schema.NodeStatusOnline, schema.NodeStatusExited},
-	schema.NodeStatusOnline:     {schema.NodeStatusOnline, schema.NodeStatusExiting, schema.NodeStatusExited, schema.NodeStatusSlashed, schema.NodeStatusOffline},
+	schema.NodeStatusOnline:     {schema.NodeStatusOnline, schema.NodeStatusExiting, schema.NodeStatusExited, schema.NodeStatusSlashed, schema.NodeStatusOffline, schema.NodeStatusDegraded},
+	schema.NodeStatusDegraded:   {schema.NodeStatusDegraded, schema.NodeStatusOnline, schema.NodeStatusExiting, schema.NodeStatusExited, schema.NodeStatusSlashed, schema.NodeStatusOffline},
 	schema.NodeStatusExiting:    {schema.NodeStatusExiting, schema.NodeStatusExited},
 	schema.NodeStatusSlashed:    {schema.NodeStatusSlashed, schema.NodeStatusOnline, schema.NodeStatusOffline},
-	schema.NodeStatusOffline:    {schema.NodeStatusOffline, schema.NodeStatusOnline, schema.NodeStatusExited},
+	schema.NodeStatusOffline:    {schema.NodeStatusOffline, schema.NodeStatusOnline, schema.NodeStatusDegraded, schema.NodeStatusExited},
 	schema.NodeStatusExited:     {schema.NodeStatusExited, schema.NodeStatusRegistered},
 }
 
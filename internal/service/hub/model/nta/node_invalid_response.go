@@ -0,0 +1,173 @@
+package nta
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/rss3-network/global-indexer/schema"
+)
+
+const (
+	// diffContextLines is the number of the unchanged lines shown around the changes.
+	diffContextLines = 3
+	// maxDiffLines caps the lines of a response that are compared.
+	maxDiffLines = 5000
+)
+
+type InvalidResponsesRequest struct {
+	EpochID    *uint64         `query:"epoch_id"`
+	Type       *string         `query:"type" validate:"omitempty,oneof=inconsistent error"`
+	Verifier   *common.Address `query:"verifier"`
+	BeforeDate *time.Time      `query:"before_date"`
+	AfterDate  *time.Time      `query:"after_date"`
+	// Diff renders the difference between the verifier response and the Node response.
+	Diff bool `query:"diff"`
+	// Cursor is the ID of the last invalid response of the previous page.
+	Cursor *uint64 `query:"cursor"`
+	Limit  int     `query:"limit" validate:"min=1,max=100" default:"20"`
+}
+
+type NodeInvalidResponsesRequest struct {
+	NodeAddress common.Address `param:"node_address" validate:"required"`
+
+	InvalidResponsesRequest
+}
+
+type NodeInvalidResponsesResponseData []*NodeInvalidResponse
+
+type NodeInvalidResponse struct {
+	ID               uint64                         `json:"id"`
+	EpochID          uint64                         `json:"epoch_id"`
+	Type             schema.NodeInvalidResponseType `json:"type"`
+	Request          string                         `json:"request"`
+	Node             common.Address                 `json:"node"`
+	Response         json.RawMessage                `json:"response"`
+	VerifierNodes    []common.Address               `json:"verifier_nodes"`
+	VerifierResponse json.RawMessage                `json:"verifier_response"`
+	ItemDiff         json.RawMessage                `json:"item_diff,omitempty"`
+	Diff             *ResponseDiff                  `json:"diff,omitempty"`
+	CreatedAt        int64                          `json:"created_at"`
+}
+
+// ResponseDiff is a side-by-side line diff of the indented verifier response (left) and Node response (right).
+type ResponseDiff struct {
+	Hunks []*DiffHunk `json:"hunks"`
+	// Truncated is true if the responses are too long to be compared entirely.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// DiffHunk is a group of changes with their surrounding unchanged lines.
+type DiffHunk struct {
+	Lines []*DiffLine `json:"lines"`
+}
+
+// DiffLine is a row of the side-by-side diff, the line numbers start from 1 and are omitted for the blank side.
+type DiffLine struct {
+	// Op is one of equal, replace, delete and insert.
+	Op            string `json:"op"`
+	VerifierLine  int    `json:"verifier_line,omitempty"`
+	VerifierValue string `json:"verifier_value,omitempty"`
+	NodeLine      int    `json:"node_line,omitempty"`
+	NodeValue     string `json:"node_value,omitempty"`
+}
+
+func NewNodeInvalidResponse(response *schema.NodeInvalidResponse, diff bool) *NodeInvalidResponse {
+	result := &NodeInvalidResponse{
+		ID:               response.ID,
+		EpochID:          response.EpochID,
+		Type:             response.Type,
+		Request:          response.Request,
+		Node:             response.Node,
+		Response:         response.Response,
+		VerifierNodes:    response.VerifierNodes,
+		VerifierResponse: response.VerifierResponse,
+		ItemDiff:         response.ItemDiff,
+		CreatedAt:        response.CreatedAt,
+	}
+
+	if diff {
+		result.Diff = NewResponseDiff(response.VerifierResponse, response.Response)
+	}
+
+	return result
+}
+
+func NewNodeInvalidResponses(responses []*schema.NodeInvalidResponse, diff bool) NodeInvalidResponsesResponseData {
+	result := make([]*NodeInvalidResponse, len(responses))
+
+	for i, response := range responses {
+		result[i] = NewNodeInvalidResponse(response, diff)
+	}
+
+	return result
+}
+
+// NewResponseDiff compares the indented verifier response with the indented Node response line by line.
+func NewResponseDiff(verifierResponse, nodeResponse json.RawMessage) *ResponseDiff {
+	var (
+		diff          = &ResponseDiff{Hunks: make([]*DiffHunk, 0)}
+		verifierLines = splitResponseLines(verifierResponse)
+		nodeLines     = splitResponseLines(nodeResponse)
+	)
+
+	if len(verifierLines) > maxDiffLines || len(nodeLines) > maxDiffLines {
+		verifierLines, nodeLines = verifierLines[:min(len(verifierLines), maxDiffLines)], nodeLines[:min(len(nodeLines), maxDiffLines)]
+		diff.Truncated = true
+	}
+
+	matcher := difflib.NewMatcher(verifierLines, nodeLines)
+
+	for _, group := range matcher.GetGroupedOpCodes(diffContextLines) {
+		hunk := &DiffHunk{Lines: make([]*DiffLine, 0)}
+
+		for _, opCode := range group {
+			op := diffOps[opCode.Tag]
+
+			// The longer side of a change is padded with blank lines on the other side.
+			for k := 0; k < max(opCode.I2-opCode.I1, opCode.J2-opCode.J1); k++ {
+				line := &DiffLine{Op: op}
+
+				if i := opCode.I1 + k; i < opCode.I2 {
+					line.VerifierLine, line.VerifierValue = i+1, verifierLines[i]
+				}
+
+				if j := opCode.J1 + k; j < opCode.J2 {
+					line.NodeLine, line.NodeValue = j+1, nodeLines[j]
+				}
+
+				hunk.Lines = append(hunk.Lines, line)
+			}
+		}
+
+		diff.Hunks = append(diff.Hunks, hunk)
+	}
+
+	return diff
+}
+
+var diffOps = map[byte]string{
+	'e': "equal",
+	'r': "replace",
+	'd': "delete",
+	'i': "insert",
+}
+
+// splitResponseLines indents the response if it is JSON and splits it into lines.
+func splitResponseLines(response json.RawMessage) []string {
+	if len(response) == 0 {
+		return []string{}
+	}
+
+	var buffer bytes.Buffer
+
+	if err := json.Indent(&buffer, response, "", "  "); err != nil {
+		buffer.Reset()
+		buffer.Write(response)
+	}
+
+	return strings.Split(buffer.String(), "\n")
+}
@@ -0,0 +1,63 @@
+package nta_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResponseDiff(t *testing.T) {
+	t.Parallel()
+
+	diff := nta.NewResponseDiff(json.RawMessage(`{"id":"a","owner":"b","tag":"c"}`), json.RawMessage(`{"id":"a","owner":"x","tag":"c","type":"d"}`))
+
+	require.Len(t, diff.Hunks, 1)
+	assert.False(t, diff.Truncated)
+
+	changes := make([]*nta.DiffLine, 0)
+
+	for _, line := range diff.Hunks[0].Lines {
+		if line.Op != "equal" {
+			changes = append(changes, line)
+		}
+	}
+
+	require.Len(t, changes, 3)
+	assert.Equal(t, &nta.DiffLine{Op: "replace", VerifierLine: 3, VerifierValue: `  "owner": "b",`, NodeLine: 3, NodeValue: `  "owner": "x",`}, changes[0])
+	assert.Equal(t, "replace", changes[1].Op)
+	assert.Equal(t, `  "tag": "c"`, changes[1].VerifierValue)
+	assert.Equal(t, `  "tag": "c",`, changes[1].NodeValue)
+
+	// The shorter side of a change is padded with a blank line.
+	assert.Equal(t, &nta.DiffLine{Op: "replace", NodeLine: 5, NodeValue: `  "type": "d"`}, changes[2])
+
+	// The identical responses have no hunk.
+	assert.Empty(t, nta.NewResponseDiff(json.RawMessage(`{}`), json.RawMessage(`{}`)).Hunks)
+}
+
+func TestInvalidResponsesRequestCursor(t *testing.T) {
+	t.Parallel()
+
+	bind := func(cursor string) (*nta.InvalidResponsesRequest, error) {
+		var request nta.InvalidResponsesRequest
+
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/nta/invalid_responses?cursor="+cursor, nil), httptest.NewRecorder())
+
+		return &request, c.Bind(&request)
+	}
+
+	request, err := bind("42")
+	require.NoError(t, err)
+	require.NotNil(t, request.Cursor)
+	assert.Equal(t, uint64(42), *request.Cursor)
+
+	// A non-numeric cursor is a bad request instead of a database error.
+	_, err = bind("abc")
+	require.Error(t, err)
+}
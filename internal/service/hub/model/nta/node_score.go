@@ -0,0 +1,15 @@
+package nta
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+)
+
+type NodeScoreRequest struct {
+	NodeAddress common.Address `param:"node_address" validate:"required"`
+	Cursor      *uint64        `query:"cursor"`
+	Limit       int            `query:"limit" validate:"min=1,max=100" default:"20"`
+}
+
+// NodeScoresResponseData is the breakdowns of the Reliability Score of a Node, ordered from the latest Epoch.
+type NodeScoresResponseData []*schema.NodeScore
@@ -6,14 +6,34 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rss3-network/global-indexer/schema"
 	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
 )
 
+// SnapshotRangeRequest is the time range of the snapshots and how they are aggregated.
+type SnapshotRangeRequest struct {
+	// Since and Until are inclusive, an aggregated bucket is returned if it contains Since.
+	Since *time.Time `query:"since"`
+	Until *time.Time `query:"until"`
+	// Granularity aggregates the snapshots, they are not aggregated by default or by the epoch granularity.
+	Granularity *string `query:"granularity" validate:"omitempty,oneof=epoch day week month"`
+	// Aggregation is the value of a bucket of the granularity, it does not apply to the epoch granularity.
+	Aggregation string `query:"aggregation" validate:"oneof=last avg min max" default:"last"`
+}
+
+type GetCountSnapshotsRequest struct {
+	SnapshotRangeRequest
+
+	Limit int `query:"limit" validate:"min=1,max=1000" default:"100"`
+}
+
 type GetStakerProfitSnapshotsRequest struct {
 	StakerAddress common.Address `query:"staker_address" validate:"required"`
 	Limit         *int           `query:"limit"`
 	Cursor        *string        `query:"cursor"`
 	BeforeDate    *time.Time     `query:"before_date"`
 	AfterDate     *time.Time     `query:"after_date"`
+
+	SnapshotRangeRequest
 }
 
 type GetNodeOperationProfitSnapshotsRequest struct {
@@ -22,6 +42,43 @@ type GetNodeOperationProfitSnapshotsRequest struct {
 	Cursor      *string        `query:"cursor"`
 	BeforeDate  *time.Time     `query:"before_date"`
 	AfterDate   *time.Time     `query:"after_date"`
+
+	SnapshotRangeRequest
+}
+
+type GetEpochAPYSnapshotsRequest struct {
+	SnapshotRangeRequest
+
+	Limit *int `query:"limit" validate:"omitempty,min=1,max=1000"`
+}
+
+// Aggregated returns the granularity if the snapshots are aggregated by it.
+func (r *SnapshotRangeRequest) Aggregated() (schema.SnapshotGranularity, bool) {
+	if r.Granularity == nil {
+		return schema.SnapshotGranularityEpoch, false
+	}
+
+	value, err := schema.SnapshotGranularityString(*r.Granularity)
+	if err != nil || value == schema.SnapshotGranularityEpoch {
+		return schema.SnapshotGranularityEpoch, false
+	}
+
+	return value, true
+}
+
+// Query returns the query of the aggregated snapshots of the series.
+func (r *SnapshotRangeRequest) Query(series schema.SnapshotSeries, address *common.Address, granularity schema.SnapshotGranularity, limit *int) schema.SnapshotPointsQuery {
+	aggregation, _ := schema.SnapshotAggregationString(r.Aggregation)
+
+	return schema.SnapshotPointsQuery{
+		Series:      series,
+		Address:     address,
+		Granularity: granularity,
+		Aggregation: aggregation,
+		Since:       r.Since,
+		Until:       r.Until,
+		Limit:       limit,
+	}
 }
 
 type GetNodeCountSnapshotsResponseData []*CountSnapshot
@@ -30,6 +87,16 @@ type GetStakerCountSnapshotsResponseData []*CountSnapshot
 
 type GetOperatorProfitsSnapshotsResponseData []*schema.OperatorProfitSnapshot
 
+type GetSnapshotPointsResponseData []*schema.SnapshotPoint
+
+// StakerProfitPoint is the aggregated profit snapshots of a staker in a bucket.
+type StakerProfitPoint struct {
+	Date             time.Time       `json:"date"`
+	TotalChipAmounts decimal.Decimal `json:"total_chip_amounts"`
+	TotalChipValues  decimal.Decimal `json:"total_chip_values"`
+	Samples          int64           `json:"samples"`
+}
+
 type CountSnapshot struct {
 	Date  string `json:"date"`
 	Count uint64 `json:"count"`
@@ -52,3 +119,25 @@ func NewStakerCountSnapshots(stakeSnapshots []*schema.StakerCountSnapshot) GetSt
 		}
 	})
 }
+
+// NewStakerProfitPoints merges the aggregated amounts and values of the Chips of a staker by bucket,
+// the buckets of both series are rolled up from the same snapshots.
+func NewStakerProfitPoints(amounts, values []*schema.SnapshotPoint) []*StakerProfitPoint {
+	valueMap := lo.SliceToMap(values, func(point *schema.SnapshotPoint) (time.Time, *schema.SnapshotPoint) {
+		return point.Date, point
+	})
+
+	return lo.Map(amounts, func(amount *schema.SnapshotPoint, _ int) *StakerProfitPoint {
+		point := StakerProfitPoint{
+			Date:             amount.Date,
+			TotalChipAmounts: amount.Value,
+			Samples:          amount.Samples,
+		}
+
+		if value, exists := valueMap[amount.Date]; exists {
+			point.TotalChipValues = value.Value
+		}
+
+		return &point
+	})
+}
@@ -0,0 +1,67 @@
+package nta_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotRangeRequest(t *testing.T) {
+	t.Parallel()
+
+	var request nta.SnapshotRangeRequest
+
+	// The snapshots of each Epoch are not aggregated.
+	_, aggregated := request.Aggregated()
+	assert.False(t, aggregated)
+
+	request.Granularity = lo.ToPtr(schema.SnapshotGranularityEpoch.String())
+
+	_, aggregated = request.Aggregated()
+	assert.False(t, aggregated)
+
+	request.Granularity = lo.ToPtr("week")
+
+	granularity, aggregated := request.Aggregated()
+	assert.True(t, aggregated)
+	assert.Equal(t, schema.SnapshotGranularityWeek, granularity)
+
+	request.Aggregation = "max"
+
+	query := request.Query(schema.SnapshotSeriesNodeCount, nil, granularity, nil)
+	assert.Equal(t, schema.SnapshotAggregationMax, query.Aggregation)
+	assert.Equal(t, schema.SnapshotGranularityWeek, query.Granularity)
+}
+
+func TestNewStakerProfitPoints(t *testing.T) {
+	t.Parallel()
+
+	var (
+		june = time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+		july = time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	points := nta.NewStakerProfitPoints(
+		[]*schema.SnapshotPoint{
+			{Date: july, Value: decimal.NewFromInt(3), Samples: 2},
+			{Date: june, Value: decimal.NewFromInt(2), Samples: 30},
+		},
+		[]*schema.SnapshotPoint{
+			{Date: july, Value: decimal.NewFromInt(300), Samples: 2},
+		},
+	)
+
+	require.Len(t, points, 2)
+	assert.Equal(t, july, points[0].Date)
+	assert.Equal(t, "3", points[0].TotalChipAmounts.String())
+	assert.Equal(t, "300", points[0].TotalChipValues.String())
+	assert.Equal(t, int64(2), points[0].Samples)
+	assert.Equal(t, "2", points[1].TotalChipAmounts.String())
+	assert.True(t, points[1].TotalChipValues.IsZero())
+}
@@ -0,0 +1,122 @@
+package nta
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/webhook"
+	"github.com/rss3-network/global-indexer/schema"
+)
+
+type CreateSubscriptionRequest struct {
+	Address    common.Address            `json:"address" validate:"required"`
+	URL        string                    `json:"url" validate:"required,http_url,max=2048"`
+	EventTypes []schema.WebhookEventType `json:"event_types" validate:"required,min=1,unique"`
+	// Timestamp is the Unix time in seconds the message is signed at.
+	Timestamp int64  `json:"timestamp" validate:"required"`
+	Signature string `json:"signature" validate:"required"`
+}
+
+type DeleteSubscriptionRequest struct {
+	ID        uint64 `param:"id" validate:"required"`
+	Signature string `json:"signature" validate:"required"`
+}
+
+type SubscriptionsRequest struct {
+	Address common.Address `query:"address" validate:"required"`
+	Cursor  *uint64        `query:"cursor"`
+	Limit   int            `query:"limit" validate:"min=1,max=100" default:"20"`
+}
+
+type SubscriptionDeliveriesRequest struct {
+	ID     uint64  `param:"id" validate:"required"`
+	Status *string `query:"status" validate:"omitempty,oneof=pending succeeded failed"`
+	Cursor *uint64 `query:"cursor"`
+	Limit  int     `query:"limit" validate:"min=1,max=100" default:"20"`
+	// Timestamp is the Unix time in seconds the message is signed at, the signature is reused for the pages until it expires.
+	Timestamp int64  `query:"timestamp" validate:"required"`
+	Signature string `query:"signature" validate:"required"`
+}
+
+type CreateSubscriptionResponseData *Subscription
+
+type SubscriptionsResponseData []*Subscription
+
+type Subscription struct {
+	ID         uint64                    `json:"id"`
+	Address    common.Address            `json:"address"`
+	URL        string                    `json:"url"`
+	EventTypes []schema.WebhookEventType `json:"event_types"`
+	// Secret signs the deliveries, it is only returned when the subscription is created.
+	Secret    string `json:"secret,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// NewCreatedSubscription returns the subscription with its secret, which is never returned again.
+func NewCreatedSubscription(subscription *schema.WebhookSubscription) CreateSubscriptionResponseData {
+	result := newSubscription(subscription)
+	result.URL = subscription.URL
+	result.Secret = subscription.Secret
+
+	return result
+}
+
+func NewSubscriptions(subscriptions []*schema.WebhookSubscription) SubscriptionsResponseData {
+	result := make([]*Subscription, len(subscriptions))
+
+	for i, subscription := range subscriptions {
+		result[i] = newSubscription(subscription)
+	}
+
+	return result
+}
+
+// newSubscription returns the subscription with only the origin of its URL,
+// as the subscriptions are listed publicly and the path and the query may carry credentials.
+func newSubscription(subscription *schema.WebhookSubscription) *Subscription {
+	return &Subscription{
+		ID:         subscription.ID,
+		Address:    subscription.Address,
+		URL:        webhook.RedactURL(subscription.URL),
+		EventTypes: subscription.EventTypes,
+		CreatedAt:  subscription.CreatedAt,
+	}
+}
+
+type SubscriptionDeliveriesResponseData []*SubscriptionDelivery
+
+type SubscriptionDelivery struct {
+	ID             uint64                       `json:"id"`
+	EventID        uint64                       `json:"event_id"`
+	EventType      schema.WebhookEventType      `json:"event_type"`
+	Payload        json.RawMessage              `json:"payload"`
+	Status         schema.WebhookDeliveryStatus `json:"status"`
+	Attempts       int                          `json:"attempts"`
+	NextAttemptAt  int64                        `json:"next_attempt_at"`
+	ResponseStatus int                          `json:"response_status"`
+	Error          string                       `json:"error,omitempty"`
+	CreatedAt      int64                        `json:"created_at"`
+	UpdatedAt      int64                        `json:"updated_at"`
+}
+
+func NewSubscriptionDeliveries(deliveries []*schema.WebhookDelivery) SubscriptionDeliveriesResponseData {
+	result := make([]*SubscriptionDelivery, len(deliveries))
+
+	for i, delivery := range deliveries {
+		result[i] = &SubscriptionDelivery{
+			ID:             delivery.ID,
+			EventID:        delivery.EventID,
+			EventType:      delivery.EventType,
+			Payload:        delivery.Payload,
+			Status:         delivery.Status,
+			Attempts:       delivery.Attempts,
+			NextAttemptAt:  delivery.NextAttemptAt,
+			ResponseStatus: delivery.ResponseStatus,
+			Error:          delivery.Error,
+			CreatedAt:      delivery.CreatedAt,
+			UpdatedAt:      delivery.UpdatedAt,
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,76 @@
+package nta
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/shopspring/decimal"
+)
+
+type TaxSubmissionsRequest struct {
+	Cursor *uint64 `query:"cursor"`
+	Limit  int     `query:"limit" validate:"min=1,max=100" default:"20"`
+}
+
+type TaxSubmissionsResponseData []*TaxSubmission
+
+type TaxSubmission struct {
+	EpochID         uint64          `json:"epoch_id"`
+	AverageTaxRate  decimal.Decimal `json:"average_tax_rate"`
+	TransactionHash common.Hash     `json:"transaction_hash"`
+	SubmittedAt     int64           `json:"submitted_at"`
+}
+
+func NewTaxSubmissions(submissions []*schema.AverageTaxRateSubmission) TaxSubmissionsResponseData {
+	result := make([]*TaxSubmission, len(submissions))
+
+	for i, submission := range submissions {
+		result[i] = &TaxSubmission{
+			EpochID:         submission.EpochID,
+			AverageTaxRate:  submission.AverageTaxRate,
+			TransactionHash: submission.TransactionHash,
+			SubmittedAt:     submission.CreatedAt.Unix(),
+		}
+	}
+
+	return result
+}
+
+type TaxPreviewResponseData *TaxPreview
+
+// TaxPreview is the average tax rate that the next run of the taxer would submit, and the tax rates it is calculated from.
+type TaxPreview struct {
+	EpochID        uint64          `json:"epoch_id"`
+	Strategy       string          `json:"strategy"`
+	AverageTaxRate decimal.Decimal `json:"average_tax_rate"`
+	// SubmittedTaxRate is the average tax rate truncated to basis points, which is submitted to the VSL.
+	SubmittedTaxRate uint64          `json:"submitted_tax_rate"`
+	Nodes            []*TaxRateInput `json:"nodes"`
+}
+
+type TaxRateInput struct {
+	Address            common.Address  `json:"address"`
+	TaxRateBasisPoints uint64          `json:"tax_rate_basis_points"`
+	StakingPoolTokens  decimal.Decimal `json:"staking_pool_tokens"`
+	Excluded           bool            `json:"excluded"`
+}
+
+func NewTaxPreview(epochID uint64, averageTaxRate *schema.AverageTaxRate) TaxPreviewResponseData {
+	preview := &TaxPreview{
+		EpochID:          epochID,
+		Strategy:         averageTaxRate.Strategy,
+		AverageTaxRate:   averageTaxRate.AverageTaxRate,
+		SubmittedTaxRate: averageTaxRate.AverageTaxRate.BigInt().Uint64(),
+		Nodes:            make([]*TaxRateInput, len(averageTaxRate.Inputs)),
+	}
+
+	for i, input := range averageTaxRate.Inputs {
+		preview.Nodes[i] = &TaxRateInput{
+			Address:            input.Address,
+			TaxRateBasisPoints: input.TaxRateBasisPoints,
+			StakingPoolTokens:  input.StakingPoolTokens,
+			Excluded:           input.Excluded,
+		}
+	}
+
+	return preview
+}
@@ -8,11 +8,12 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
-	"github.com/redis/go-redis/v9"
 	"github.com/rss3-network/global-indexer/common/geolite2"
 	"github.com/rss3-network/global-indexer/common/httputil"
 	"github.com/rss3-network/global-indexer/docs"
+	"github.com/rss3-network/global-indexer/internal/cache"
 	"github.com/rss3-network/global-indexer/internal/client/ethereum"
+	"github.com/rss3-network/global-indexer/internal/config"
 	"github.com/rss3-network/global-indexer/internal/database"
 	"github.com/rss3-network/global-indexer/internal/nameresolver"
 	"github.com/rss3-network/global-indexer/internal/service"
@@ -41,8 +42,8 @@ func (s *Server) Run(_ context.Context) error {
 	return s.httpServer.Start(address)
 }
 
-func NewServer(databaseClient database.Client, redisClient *redis.Client, geoLite2 *geolite2.Client, ethereumMultiChainClient *ethereum.MultiChainClient, nameService *nameresolver.NameResolver, httpClient httputil.Client) (service.Server, error) {
-	hub, err := NewHub(context.Background(), databaseClient, redisClient, ethereumMultiChainClient, geoLite2, nameService, httpClient)
+func NewServer(databaseClient database.Client, cacheClient cache.Client, geoLite2 *geolite2.Client, ethereumMultiChainClient *ethereum.MultiChainClient, nameService *nameresolver.NameResolver, httpClient httputil.Client, config *config.File) (service.Server, error) {
+	hub, err := NewHub(context.Background(), databaseClient, cacheClient, ethereumMultiChainClient, geoLite2, nameService, httpClient, config)
 	if err != nil {
 		return nil, fmt.Errorf("new hub: %w", err)
 	}
@@ -75,6 +76,13 @@ func NewServer(databaseClient database.Client, redisClient *redis.Client, geoLit
 		nodes.POST("/heartbeat", instance.hub.nta.NodeHeartbeat)
 	}
 
+	jobs := instance.httpServer.Group("/jobs")
+	{
+		// Status and run history of the scheduler cron jobs
+		jobs.GET("", instance.hub.nta.GetCronJobs)
+		jobs.GET("/:name/runs", instance.hub.nta.GetCronJobRuns)
+	}
+
 	// nta is short for Network Transparency API
 	nta := instance.httpServer.Group("/nta")
 	{
@@ -98,6 +106,8 @@ func NewServer(databaseClient database.Client, redisClient *redis.Client, geoLit
 			epochs.GET("/:node_address/rewards", instance.hub.nta.GetEpochNodeRewards)
 			epochs.GET("/distributions/:transaction_hash", instance.hub.nta.GetEpochDistribution)
 			epochs.GET("/apy", instance.hub.nta.GetEpochsAPY)
+			epochs.GET("/reconciliations", instance.hub.nta.GetEpochReconciliations)
+			epochs.GET("/:epoch_id/reconciliation", instance.hub.nta.GetEpochReconciliation)
 		}
 
 		networks := nta.Group("/networks")
@@ -114,10 +124,31 @@ func NewServer(databaseClient database.Client, redisClient *redis.Client, geoLit
 			nodes.GET("/:node_address", instance.hub.nta.GetNode)
 			nodes.GET("/:node_address/avatar.svg", instance.hub.nta.GetNodeAvatar)
 			nodes.GET("/:node_address/challenge", instance.hub.nta.GetNodeChallenge)
+			nodes.GET("/:node_address/dashboard", instance.hub.nta.GetNodeDashboard)
 			nodes.GET("/:node_address/events", instance.hub.nta.GetNodeEvents)
+			nodes.GET("/:node_address/invalid_responses", instance.hub.nta.GetNodeInvalidResponses)
+			nodes.GET("/:node_address/score", instance.hub.nta.GetNodeScore)
 			nodes.GET("/:node_address/operation/profit", instance.hub.nta.GetNodeOperationProfit)
 
 			nodes.POST("/:node_address/hide_tax_rate", instance.hub.nta.PostNodeHideTaxRate)
+			nodes.POST("/:node_address/exit", instance.hub.nta.PostNodeExit)
+		}
+
+		nta.GET("/invalid_responses", instance.hub.nta.GetInvalidResponses)
+		nta.GET("/leaderboards/:metric", instance.hub.nta.GetLeaderboard)
+
+		subscriptions := nta.Group("/subscriptions")
+		{
+			subscriptions.GET("", instance.hub.nta.GetSubscriptions)
+			subscriptions.POST("", instance.hub.nta.PostSubscription)
+			subscriptions.DELETE("/:id", instance.hub.nta.DeleteSubscription)
+			subscriptions.GET("/:id/deliveries", instance.hub.nta.GetSubscriptionDeliveries)
+		}
+
+		tax := nta.Group("/tax")
+		{
+			tax.GET("/submissions", instance.hub.nta.GetTaxSubmissions)
+			tax.GET("/preview", instance.hub.nta.GetTaxPreview)
 		}
 
 		snapshots := nta.Group("/snapshots")
@@ -150,6 +181,9 @@ func NewServer(databaseClient database.Client, redisClient *redis.Client, geoLit
 		dsl.GET("/decentralized/platform/:platform", instance.hub.dsl.GetPlatformActivities)
 
 		dsl.POST("/decentralized/accounts", instance.hub.dsl.BatchGetAccountsActivities)
+
+		dsl.GET("/names/resolve/:name", instance.hub.dsl.ResolveName)
+		dsl.GET("/names/:address", instance.hub.dsl.GetNames)
 	}
 
 	return &instance, nil
@@ -7,6 +7,6 @@ import (
 
 var Module = fx.Options(
 	fx.Provide(provider.ProvideDatabaseClient),
-	fx.Provide(provider.ProvideRedisClient),
+	fx.Provide(provider.ProvideCacheClient),
 	fx.Provide(provider.ProvideEthereumMultiChainClient),
 )
@@ -15,6 +15,7 @@ import (
 	"github.com/rss3-network/global-indexer/internal/cache"
 	"github.com/rss3-network/global-indexer/internal/database"
 	"github.com/rss3-network/global-indexer/internal/service/indexer/internal"
+	"github.com/rss3-network/global-indexer/schema"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 )
@@ -188,6 +189,21 @@ func (h *handler) confirmPreviousBlocks(ctx context.Context, blockNumber uint64,
 	return err
 }
 
+// saveWebhookEvents saves the webhook events built by the function, they are only emitted by the finalized indexer,
+// so that the subscribers are not notified of the events of the blocks that may be reorganized.
+func (h *handler) saveWebhookEvents(ctx context.Context, databaseTransaction database.Client, newEvents func() ([]*schema.WebhookEvent, error)) error {
+	if !h.finalized {
+		return nil
+	}
+
+	events, err := newEvents()
+	if err != nil {
+		return err
+	}
+
+	return databaseTransaction.SaveWebhookEvents(ctx, events)
+}
+
 func NewHandler(chainID uint64, ethereumClient *ethclient.Client, cacheClient cache.Client, finalized bool) (internal.Handler, error) {
 	contractAddresses := l2.ContractMap[chainID]
 	if contractAddresses == nil {
@@ -7,6 +7,8 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/rss3-network/global-indexer/contract/l2"
 	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/webhook"
+	"github.com/rss3-network/global-indexer/schema"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 )
@@ -40,5 +42,21 @@ func (h *handler) indexChipsTransferLog(ctx context.Context, header *types.Heade
 		return fmt.Errorf("update stake chips owner: %w", err)
 	}
 
+	if err := h.saveWebhookEvents(ctx, databaseTransaction, func() ([]*schema.WebhookEvent, error) {
+		webhookEvent, err := webhook.NewChipTransferredEvent(&webhook.ChipTransfer{
+			ChipID:          event.TokenId,
+			From:            event.From,
+			To:              event.To,
+			TransactionHash: transaction.Hash(),
+		}, log.Index, int64(header.Time))
+		if err != nil {
+			return nil, err
+		}
+
+		return []*schema.WebhookEvent{webhookEvent}, nil
+	}); err != nil {
+		return fmt.Errorf("save chip transferred event: %w", err)
+	}
+
 	return nil
 }
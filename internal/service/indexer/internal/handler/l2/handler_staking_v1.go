@@ -17,6 +17,8 @@ import (
 	"github.com/rss3-network/global-indexer/common/ethereum"
 	"github.com/rss3-network/global-indexer/contract/l2"
 	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/lifecycle"
+	"github.com/rss3-network/global-indexer/internal/webhook"
 	"github.com/rss3-network/global-indexer/schema"
 	"github.com/samber/lo"
 	"github.com/shopspring/decimal"
@@ -46,6 +48,8 @@ func (h *handler) indexStakingV1Log(ctx context.Context, header *types.Header, t
 		return h.indexStakingV1NodeCreated(ctx, header, transaction, receipt, log, databaseTransaction)
 	case eventHash == l2.EventHashStakingV1NodeUpdated:
 		return h.indexStakingV1NodeUpdated(ctx, header, transaction, receipt, log, databaseTransaction)
+	case eventHash == l2.EventHashStakingV1NodeSlashed:
+		return h.indexStakingV1NodeSlashed(ctx, header, transaction, receipt, log, databaseTransaction)
 	default: // Discard all unsupported events.
 		return nil
 	}
@@ -378,6 +382,30 @@ func (h *handler) indexStakingV1UnstakeRequestedLog(ctx context.Context, header
 		return fmt.Errorf("save stake event: %w", err)
 	}
 
+	if err := h.saveWebhookEvents(ctx, databaseTransaction, func() ([]*schema.WebhookEvent, error) {
+		// The unstaked tokens are claimable after the unbonding period.
+		unbondingPeriod, err := h.contractStakingV1.STAKEUNBONDINGPERIOD(&bind.CallOpts{Context: ctx, BlockNumber: header.Number})
+		if err != nil {
+			return nil, fmt.Errorf("get stake unbonding period: %w", err)
+		}
+
+		webhookEvent, err := webhook.NewUnstakeClaimableEvent(&webhook.UnstakeClaimable{
+			RequestID:   stakeTransaction.ID,
+			Staker:      event.User,
+			NodeAddress: event.NodeAddr,
+			Amount:      event.UnstakeAmount,
+			Chips:       event.ChipsIds,
+			ClaimableAt: int64(header.Time) + unbondingPeriod.Int64(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return []*schema.WebhookEvent{webhookEvent}, nil
+	}); err != nil {
+		return fmt.Errorf("save unstake claimable event: %w", err)
+	}
+
 	return nil
 }
 
@@ -476,6 +504,12 @@ func (h *handler) indexStakingV1RewardDistributedLog(ctx context.Context, header
 		return fmt.Errorf("save epoch: %w", err)
 	}
 
+	if err := h.saveWebhookEvents(ctx, databaseTransaction, func() ([]*schema.WebhookEvent, error) {
+		return webhook.NewEpochRewardsDistributedEvents(&epoch)
+	}); err != nil {
+		return fmt.Errorf("save epoch rewards distributed events: %w", err)
+	}
+
 	// Skip if no Nodes were rewarded in this Epoch.
 	if epoch.TotalRewardedNodes == 0 {
 		return nil
@@ -656,6 +690,50 @@ func (h *handler) indexStakingV1NodeUpdated(ctx context.Context, header *types.H
 	return nil
 }
 
+func (h *handler) indexStakingV1NodeSlashed(ctx context.Context, header *types.Header, transaction *types.Transaction, _ *types.Receipt, log *types.Log, databaseTransaction database.Client) error {
+	ctx, span := otel.Tracer("").Start(ctx, "indexStakingV1NodeSlashed")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int64("block.number", header.Number.Int64()),
+		attribute.Stringer("block.hash", header.Hash()),
+		attribute.Stringer("transaction.hash", transaction.Hash()),
+		attribute.Int("log.index", int(log.Index)),
+	)
+
+	// Parse NodeSlashed event
+	event, err := h.contractStakingV1.ParseNodeSlashed(*log)
+	if err != nil {
+		return fmt.Errorf("parse NodeSlashed event: %w", err)
+	}
+
+	// Skip the status transition if the block is not finalized, because it cannot be rolled back.
+	if !h.finalized {
+		return nil
+	}
+
+	node, err := databaseTransaction.FindNode(ctx, event.NodeAddr)
+	if err != nil {
+		if errors.Is(err, database.ErrorRowNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("find Node: %w", err)
+	}
+
+	if !lifecycle.CanTransit(node.Status, schema.NodeStatusSlashed) {
+		zap.L().Warn("invalid node status transition", zap.String("address", node.Address.String()), zap.Stringer("from", node.Status), zap.Stringer("to", schema.NodeStatusSlashed))
+
+		return nil
+	}
+
+	if _, err := lifecycle.Transit(ctx, databaseTransaction, []*schema.Node{node}, schema.NodeStatusSlashed, schema.NodeStatusTransitionReasonSlash); err != nil {
+		return fmt.Errorf("transit Node to slashed: %w", err)
+	}
+
+	return nil
+}
+
 func (h *handler) buildNodeHideTaxRateKey(address common.Address) string {
 	return fmt.Sprintf("node::%s::hideTaxRate", strings.ToLower(address.String()))
 }
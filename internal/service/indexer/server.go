@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/redis/go-redis/v9"
 	"github.com/rss3-network/global-indexer/internal/cache"
 	"github.com/rss3-network/global-indexer/internal/client/ethereum"
 	"github.com/rss3-network/global-indexer/internal/config/flag"
@@ -130,10 +129,10 @@ func (s *Server) newL2Indexer(finalized bool) (internal.Indexer, error) {
 	return indexer, nil
 }
 
-func NewServer(databaseClient database.Client, redisClient *redis.Client, ethereumMultiChainClient *ethereum.MultiChainClient) (service.Server, error) {
+func NewServer(databaseClient database.Client, cacheClient cache.Client, ethereumMultiChainClient *ethereum.MultiChainClient) (service.Server, error) {
 	instance := Server{
 		databaseClient:           databaseClient,
-		cacheClient:              cache.New(redisClient),
+		cacheClient:              cacheClient,
 		ethereumMultiChainClient: ethereumMultiChainClient,
 	}
 
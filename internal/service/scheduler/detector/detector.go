@@ -2,23 +2,32 @@ package detector
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
-	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/internal/cache"
 	"github.com/rss3-network/global-indexer/internal/cronjob"
 	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/lifecycle"
 	"github.com/rss3-network/global-indexer/internal/service"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 )
 
-var _ service.Server = (*server)(nil)
+var (
+	_ service.Server = (*server)(nil)
+	_ cronjob.Job    = (*server)(nil)
+)
 
 var Name = "detector"
 
+const batchSize = 1000
+
 type server struct {
 	cronJob        *cronjob.CronJob
 	databaseClient database.Client
@@ -33,12 +42,7 @@ func (s *server) Spec() string {
 }
 
 func (s *server) Run(ctx context.Context) error {
-	err := s.cronJob.AddFunc(ctx, s.Spec(), func() {
-		if err := s.updateNodeActivity(ctx); err != nil {
-			zap.L().Error("detect node activity error", zap.Error(err))
-			return
-		}
-	})
+	err := s.cronJob.AddFunc(ctx, s.Spec(), s.updateNodeActivity)
 	if err != nil {
 		return fmt.Errorf("add detector cron job: %w", err)
 	}
@@ -54,22 +58,44 @@ func (s *server) Run(ctx context.Context) error {
 	return nil
 }
 
+func (s *server) RunOnce(ctx context.Context) error {
+	return s.cronJob.RunOnce(ctx, s.updateNodeActivity)
+}
+
+// updateNodeActivity transits the online and degraded Nodes that missed their heartbeats to offline.
 func (s *server) updateNodeActivity(ctx context.Context) error {
 	timeout := time.Now().Add(-5 * time.Minute)
 
-	if err := s.databaseClient.UpdateNodesStatusOffline(ctx, timeout.Unix()); err != nil {
-		zap.L().Error("update node activity error", zap.Error(err), zap.String("timeout", timeout.String()))
+	for {
+		nodes, err := s.databaseClient.FindNodes(ctx, schema.FindNodesQuery{
+			Statuses:            []schema.NodeStatus{schema.NodeStatusOnline, schema.NodeStatusDegraded},
+			LastHeartbeatBefore: lo.ToPtr(timeout.Unix()),
+			Limit:               lo.ToPtr(batchSize),
+		})
+		if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+			return fmt.Errorf("find inactive nodes: %w", err)
+		}
 
-		return fmt.Errorf("update node activity: %w", err)
-	}
+		if len(nodes) == 0 {
+			return nil
+		}
 
-	return nil
+		if err := s.databaseClient.WithTransaction(ctx, func(ctx context.Context, client database.Client) error {
+			_, err := lifecycle.Transit(ctx, client, nodes, schema.NodeStatusOffline, schema.NodeStatusTransitionReasonHeartbeatTimeout)
+
+			return err
+		}); err != nil {
+			zap.L().Error("update node activity error", zap.Error(err), zap.String("timeout", timeout.String()))
+
+			return fmt.Errorf("update node activity: %w", err)
+		}
+	}
 }
 
-func New(databaseClient database.Client, redis *redis.Client) (service.Server, error) {
+func New(databaseClient database.Client, cacheClient cache.Client) (service.Server, error) {
 	instance := server{
 		databaseClient: databaseClient,
-		cronJob:        cronjob.New(redis, Name, 10*time.Second),
+		cronJob:        cronjob.New(cacheClient, databaseClient, Name, 10*time.Second),
 	}
 
 	return &instance, nil
@@ -15,10 +15,11 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
-	"github.com/redis/go-redis/v9"
 	"github.com/rss3-network/global-indexer/contract/l2"
 	stakingv2 "github.com/rss3-network/global-indexer/contract/l2/staking/v2"
+	"github.com/rss3-network/global-indexer/internal/cache"
 	"github.com/rss3-network/global-indexer/internal/cronjob"
+	"github.com/rss3-network/global-indexer/internal/database"
 	"github.com/rss3-network/global-indexer/internal/service"
 	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/enforcer"
 	"go.uber.org/zap"
@@ -173,9 +174,9 @@ func (s *server) processLogs(ctx context.Context, logs []types.Log) error {
 	return nil
 }
 
-func New(redis *redis.Client, ethereumClient *ethclient.Client, blockNumber uint64, simpleEnforcer *enforcer.SimpleEnforcer, stakingContract *stakingv2.Staking, settlementContract *l2.Settlement, settlementContractAddress common.Address) service.Server {
+func New(databaseClient database.Client, cacheClient cache.Client, ethereumClient *ethclient.Client, blockNumber uint64, simpleEnforcer *enforcer.SimpleEnforcer, stakingContract *stakingv2.Staking, settlementContract *l2.Settlement, settlementContractAddress common.Address) service.Server {
 	return &server{
-		cronJob:                   cronjob.New(redis, Name, 1*time.Minute),
+		cronJob:                   cronjob.New(cacheClient, databaseClient, Name, 1*time.Minute),
 		blockNumber:               blockNumber,
 		settlementContract:        settlementContract,
 		stakingContract:           stakingContract,
@@ -8,14 +8,17 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/internal/cache"
 	"github.com/rss3-network/global-indexer/internal/cronjob"
+	"github.com/rss3-network/global-indexer/internal/database"
 	"github.com/rss3-network/global-indexer/internal/service"
 	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/enforcer"
-	"go.uber.org/zap"
 )
 
-var _ service.Server = (*server)(nil)
+var (
+	_ service.Server = (*server)(nil)
+	_ cronjob.Job    = (*server)(nil)
+)
 
 var Name = "reliability_score"
 
@@ -33,13 +36,7 @@ func (s *server) Spec() string {
 }
 
 func (s *server) Run(ctx context.Context) error {
-	err := s.cronJob.AddFunc(ctx, s.Spec(), func() {
-		if err := s.simpleEnforcer.MaintainReliabilityScore(ctx); err != nil {
-			zap.L().Error("maintain reliability_score error", zap.Error(err))
-			return
-		}
-	})
-
+	err := s.cronJob.AddFunc(ctx, s.Spec(), s.simpleEnforcer.MaintainReliabilityScore)
 	if err != nil {
 		return fmt.Errorf("add maintain reliability score cron job: %w", err)
 	}
@@ -55,9 +52,13 @@ func (s *server) Run(ctx context.Context) error {
 	return nil
 }
 
-func New(redis *redis.Client, simpleEnforcer *enforcer.SimpleEnforcer) service.Server {
+func (s *server) RunOnce(ctx context.Context) error {
+	return s.cronJob.RunOnce(ctx, s.simpleEnforcer.MaintainReliabilityScore)
+}
+
+func New(databaseClient database.Client, cacheClient cache.Client, simpleEnforcer *enforcer.SimpleEnforcer) service.Server {
 	return &server{
-		cronJob:        cronjob.New(redis, Name, 10*time.Second),
+		cronJob:        cronjob.New(cacheClient, databaseClient, Name, 10*time.Second),
 		simpleEnforcer: simpleEnforcer,
 	}
 }
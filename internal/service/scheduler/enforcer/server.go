@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/redis/go-redis/v9"
 	"github.com/rss3-network/global-indexer/common/httputil"
 	"github.com/rss3-network/global-indexer/contract/l2"
 	stakingv2 "github.com/rss3-network/global-indexer/contract/l2/staking/v2"
@@ -30,6 +29,11 @@ func (s *server) Name() string {
 	return Name
 }
 
+// Servers returns the servers of the enforcers.
+func (s *server) Servers() []service.Server {
+	return s.enforcers
+}
+
 func (s *server) Run(ctx context.Context) error {
 	errorPool := pool.New().WithContext(ctx).WithCancelOnError().WithFirstError()
 
@@ -44,7 +48,7 @@ func (s *server) Run(ctx context.Context) error {
 	return errorPool.Wait()
 }
 
-func New(databaseClient database.Client, redis *redis.Client, ethereumClient *ethclient.Client, httpClient httputil.Client) (service.Server, error) {
+func New(databaseClient database.Client, cacheClient cache.Client, ethereumClient *ethclient.Client, httpClient httputil.Client) (service.Server, error) {
 	chainID, err := ethereumClient.ChainID(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("get chain id: %w", err)
@@ -60,7 +64,7 @@ func New(databaseClient database.Client, redis *redis.Client, ethereumClient *et
 		return nil, fmt.Errorf("new staking contract: %w", err)
 	}
 
-	simpleEnforcer, err := enforcer.NewSimpleEnforcer(context.Background(), databaseClient, cache.New(redis), stakingContract, httpClient, false)
+	simpleEnforcer, err := enforcer.NewSimpleEnforcer(context.Background(), databaseClient, cacheClient, stakingContract, httpClient, false)
 
 	if err != nil {
 		return nil, fmt.Errorf("new simple enforcer: %w", err)
@@ -78,8 +82,8 @@ func New(databaseClient database.Client, redis *redis.Client, ethereumClient *et
 
 	return &server{
 		enforcers: []service.Server{
-			reliabilityscore.New(redis, simpleEnforcer),
-			epochfresher.New(redis, ethereumClient, checkpoint.BlockNumber, simpleEnforcer, stakingContract, settlementContract, contractAddresses.AddressStakingProxy),
+			reliabilityscore.New(databaseClient, cacheClient, simpleEnforcer),
+			epochfresher.New(databaseClient, cacheClient, ethereumClient, checkpoint.BlockNumber, simpleEnforcer, stakingContract, settlementContract, contractAddresses.AddressStakingProxy),
 		},
 	}, nil
 }
@@ -0,0 +1,187 @@
+package exiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/cache"
+	"github.com/rss3-network/global-indexer/internal/config"
+	"github.com/rss3-network/global-indexer/internal/cronjob"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/lifecycle"
+	"github.com/rss3-network/global-indexer/internal/service"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+var (
+	_ service.Server = (*server)(nil)
+	_ cronjob.Job    = (*server)(nil)
+)
+
+var Name = "exiter"
+
+const batchSize = 200
+
+type server struct {
+	cronJob        *cronjob.CronJob
+	databaseClient database.Client
+	config         config.Exiter
+}
+
+func (s *server) Name() string {
+	return Name
+}
+
+func (s *server) Spec() string {
+	return "0 */10 * * * *" // every 10 minutes
+}
+
+func (s *server) Run(ctx context.Context) error {
+	err := s.cronJob.AddFunc(ctx, s.Spec(), s.exitNodes)
+	if err != nil {
+		return fmt.Errorf("add exiter cron job: %w", err)
+	}
+
+	s.cronJob.Start()
+	defer s.cronJob.Stop()
+
+	stopchan := make(chan os.Signal, 1)
+
+	signal.Notify(stopchan, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
+	<-stopchan
+
+	return nil
+}
+
+func (s *server) RunOnce(ctx context.Context) error {
+	return s.cronJob.RunOnce(ctx, s.exitNodes)
+}
+
+// exitNodes transits the exiting Nodes that finished the waiting period and the long offline Nodes to exited.
+func (s *server) exitNodes(ctx context.Context) error {
+	now := time.Now()
+
+	if err := s.exitExitingNodes(ctx, now); err != nil {
+		return fmt.Errorf("exit exiting nodes: %w", err)
+	}
+
+	if err := s.exitOfflineNodes(ctx, now); err != nil {
+		return fmt.Errorf("exit offline nodes: %w", err)
+	}
+
+	return nil
+}
+
+// exitExitingNodes transits the exiting Nodes to exited once the waiting period since their exit announcement ends.
+func (s *server) exitExitingNodes(ctx context.Context, now time.Time) error {
+	var cursor *string
+
+	for {
+		nodes, err := s.databaseClient.FindNodes(ctx, schema.FindNodesQuery{
+			Status: lo.ToPtr(schema.NodeStatusExiting),
+			Cursor: cursor,
+			Limit:  lo.ToPtr(batchSize),
+		})
+		if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+			return fmt.Errorf("find exiting nodes: %w", err)
+		}
+
+		if len(nodes) == 0 {
+			return nil
+		}
+
+		cursor = lo.ToPtr(nodes[len(nodes)-1].Address.String())
+
+		transitions, err := s.databaseClient.FindNodeStatusTransitions(ctx, schema.NodeStatusTransitionsQuery{
+			NodeAddresses: lo.Map(nodes, func(node *schema.Node, _ int) common.Address { return node.Address }),
+			To:            lo.ToPtr(schema.NodeStatusExiting),
+		})
+		if err != nil {
+			return fmt.Errorf("find exit announcements: %w", err)
+		}
+
+		// The transitions are ordered from the latest, so the first one of a Node is its latest exit announcement.
+		announcements := make(map[common.Address]int64, len(nodes))
+
+		for _, transition := range transitions {
+			if _, exists := announcements[transition.NodeAddress]; !exists {
+				announcements[transition.NodeAddress] = transition.Timestamp
+			}
+		}
+
+		nodes = lo.Filter(nodes, func(node *schema.Node, _ int) bool {
+			announcedAt, exists := announcements[node.Address]
+			if !exists {
+				zap.L().Warn("exit announcement not found", zap.String("address", node.Address.String()))
+
+				return false
+			}
+
+			return now.Sub(time.Unix(announcedAt, 0)) >= s.config.WaitingPeriod
+		})
+
+		if err := s.transit(ctx, nodes, schema.NodeStatusTransitionReasonExitCompletion); err != nil {
+			return err
+		}
+	}
+}
+
+// exitOfflineNodes transits the Nodes that have been offline for longer than the offline period to exited.
+func (s *server) exitOfflineNodes(ctx context.Context, now time.Time) error {
+	for {
+		nodes, err := s.databaseClient.FindNodes(ctx, schema.FindNodesQuery{
+			Status:              lo.ToPtr(schema.NodeStatusOffline),
+			LastHeartbeatBefore: lo.ToPtr(now.Add(-s.config.OfflinePeriod).Unix()),
+			Limit:               lo.ToPtr(batchSize),
+		})
+		if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+			return fmt.Errorf("find long offline nodes: %w", err)
+		}
+
+		if len(nodes) == 0 {
+			return nil
+		}
+
+		if err := s.transit(ctx, nodes, schema.NodeStatusTransitionReasonLongOffline); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *server) transit(ctx context.Context, nodes []*schema.Node, reason schema.NodeStatusTransitionReason) error {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	if err := s.databaseClient.WithTransaction(ctx, func(ctx context.Context, client database.Client) error {
+		_, err := lifecycle.Transit(ctx, client, nodes, schema.NodeStatusExited, reason)
+
+		return err
+	}); err != nil {
+		return fmt.Errorf("transit nodes to exited: %w", err)
+	}
+
+	for _, node := range nodes {
+		zap.L().Info("node exited", zap.String("address", node.Address.String()), zap.Stringer("reason", reason))
+	}
+
+	return nil
+}
+
+func New(databaseClient database.Client, cacheClient cache.Client, config *config.File) (service.Server, error) {
+	instance := server{
+		databaseClient: databaseClient,
+		config:         config.Exiter,
+		cronJob:        cronjob.New(cacheClient, databaseClient, Name, 5*time.Minute),
+	}
+
+	return &instance, nil
+}
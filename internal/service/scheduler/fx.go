@@ -7,7 +7,7 @@ import (
 
 var Module = fx.Options(
 	fx.Provide(provider.ProvideDatabaseClient),
-	fx.Provide(provider.ProvideRedisClient),
+	fx.Provide(provider.ProvideCacheClient),
 	fx.Provide(provider.ProvideEthereumMultiChainClient),
 	fx.Provide(provider.ProvideHTTPClient),
 )
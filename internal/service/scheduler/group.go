@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rss3-network/global-indexer/internal/service"
+	"github.com/sourcegraph/conc/pool"
+	"go.uber.org/zap"
+)
+
+var _ service.Server = (*group)(nil)
+
+// group runs the servers concurrently in one process, a server that fails or panics is logged
+// and does not stop the others. It returns the errors of the servers once all of them have returned.
+type group struct {
+	name    string
+	servers []service.Server
+}
+
+func (g *group) Name() string {
+	return g.name
+}
+
+func (g *group) Run(ctx context.Context) error {
+	errorPool := pool.New().WithErrors()
+
+	for _, server := range g.servers {
+		server := server
+
+		errorPool.Go(func() error {
+			if err := run(ctx, server); err != nil {
+				zap.L().Error("scheduler server stopped", zap.String("server", server.Name()), zap.Error(err))
+
+				return fmt.Errorf("run %s: %w", server.Name(), err)
+			}
+
+			return nil
+		})
+	}
+
+	return errorPool.Wait()
+}
+
+// run runs the server, a panic of the server is returned as an error.
+func run(ctx context.Context, server service.Server) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+
+	return server.Run(ctx)
+}
+
+func newGroup(name string, servers []service.Server) *group {
+	return &group{
+		name:    name,
+		servers: servers,
+	}
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rss3-network/global-indexer/internal/service"
+	"github.com/stretchr/testify/assert"
+)
+
+type testServer struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+func (s *testServer) Name() string {
+	return s.name
+}
+
+func (s *testServer) Run(ctx context.Context) error {
+	return s.run(ctx)
+}
+
+func TestGroup(t *testing.T) {
+	t.Parallel()
+
+	var completed atomic.Bool
+
+	group := newGroup("scheduler", []service.Server{
+		&testServer{
+			name: "failing",
+			run: func(context.Context) error {
+				return errors.New("failed")
+			},
+		},
+		&testServer{
+			name: "panicking",
+			run: func(context.Context) error {
+				panic("boom")
+			},
+		},
+		&testServer{
+			name: "healthy",
+			run: func(ctx context.Context) error {
+				time.Sleep(10 * time.Millisecond)
+
+				// The healthy server is not canceled by the others.
+				completed.Store(ctx.Err() == nil)
+
+				return nil
+			},
+		},
+	})
+
+	err := group.Run(context.Background())
+	assert.ErrorContains(t, err, "run failing: failed")
+	assert.ErrorContains(t, err, "run panicking: panic: boom")
+	assert.True(t, completed.Load())
+}
+
+func TestParseServers(t *testing.T) {
+	t.Parallel()
+
+	names, err := parseServers(ServerAll)
+	assert.NoError(t, err)
+	assert.Equal(t, Servers(), names)
+
+	names, err = parseServers("detector, snapshot,detector")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"detector", "snapshot"}, names)
+
+	_, err = parseServers("detector,unknown")
+	assert.ErrorContains(t, err, "unknown scheduler server: unknown")
+
+	_, err = parseServers(" , ")
+	assert.Error(t, err)
+}
@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"fmt"
+
+	"github.com/rss3-network/global-indexer/common/httputil"
+	"github.com/rss3-network/global-indexer/internal/cache"
+	"github.com/rss3-network/global-indexer/internal/client/ethereum"
+	"github.com/rss3-network/global-indexer/internal/config"
+	"github.com/rss3-network/global-indexer/internal/cronjob"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/service"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/detector"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/enforcer"
+	reliabilityscore "github.com/rss3-network/global-indexer/internal/service/scheduler/enforcer/reliability_score"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/exiter"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/notifier"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/prober"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/reconciler"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/taxer"
+	"github.com/samber/lo"
+)
+
+// composite is a scheduler server running the servers of its jobs.
+type composite interface {
+	Servers() []service.Server
+}
+
+// Jobs returns the names of the cron jobs that can be run once.
+func Jobs() []string {
+	return append([]string{
+		detector.Name,
+		prober.Name,
+		exiter.Name,
+		taxer.Name,
+		notifier.Name,
+		reconciler.Name,
+		reliabilityscore.Name,
+	}, snapshot.Kinds()...)
+}
+
+// NewJob returns the cron job of the name, which is a scheduler server or a job of the enforcer and the snapshot servers.
+func NewJob(name string, databaseClient database.Client, cacheClient cache.Client, ethereumMultiChainClient *ethereum.MultiChainClient, httpClient httputil.Client, config *config.File) (cronjob.Job, error) {
+	if !lo.Contains(Jobs(), name) {
+		return nil, fmt.Errorf("unknown cron job %s, expected one of %v", name, Jobs())
+	}
+
+	server, err := newServer(serverOf(name), databaseClient, cacheClient, ethereumMultiChainClient, httpClient, config)
+	if err != nil {
+		return nil, err
+	}
+
+	job, found := findJob(server, name)
+	if !found {
+		return nil, fmt.Errorf("cron job %s not found in scheduler server %s", name, server.Name())
+	}
+
+	return job, nil
+}
+
+// serverOf returns the name of the scheduler server running the job.
+func serverOf(job string) string {
+	switch {
+	case job == reliabilityscore.Name:
+		return enforcer.Name
+	case lo.Contains(snapshot.Kinds(), job):
+		return snapshot.Name
+	default:
+		return job
+	}
+}
+
+func findJob(server service.Server, name string) (cronjob.Job, bool) {
+	if job, ok := server.(cronjob.Job); ok && job.Name() == name {
+		return job, true
+	}
+
+	if composite, ok := server.(composite); ok {
+		for _, server := range composite.Servers() {
+			if job, found := findJob(server, name); found {
+				return job, true
+			}
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,379 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/rss3-network/global-indexer/internal/cache"
+	"github.com/rss3-network/global-indexer/internal/config"
+	"github.com/rss3-network/global-indexer/internal/cronjob"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/service"
+	"github.com/rss3-network/global-indexer/internal/webhook"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/sourcegraph/conc/pool"
+	"go.uber.org/zap"
+)
+
+var (
+	_ service.Server = (*server)(nil)
+	_ cronjob.Job    = (*server)(nil)
+)
+
+var Name = "notifier"
+
+const (
+	batchSize = 200
+	// cleanupInterval is the interval at which the events and the deliveries past the retention are deleted.
+	cleanupInterval = time.Hour
+	// maxResponseSize caps the response of a subscriber that is read.
+	maxResponseSize = 4096
+	userAgent       = "RSS3-Webhook/1.0"
+)
+
+// server delivers the webhook events produced by the indexer and the enforcer to the subscriptions.
+// An event is first dispatched into a delivery for each matching subscription, then each delivery is sent
+// until the subscriber responds with a 2xx status code or the delivery runs out of attempts.
+type server struct {
+	cronJob        *cronjob.CronJob
+	databaseClient database.Client
+	httpClient     *http.Client
+	config         config.Webhook
+	lastCleanup    time.Time
+}
+
+func (s *server) Name() string {
+	return Name
+}
+
+func (s *server) Spec() string {
+	return "*/5 * * * * *" // every 5 seconds
+}
+
+func (s *server) Run(ctx context.Context) error {
+	err := s.cronJob.AddFunc(ctx, s.Spec(), s.run)
+	if err != nil {
+		return fmt.Errorf("add notifier cron job: %w", err)
+	}
+
+	s.cronJob.Start()
+	defer s.cronJob.Stop()
+
+	stopchan := make(chan os.Signal, 1)
+
+	signal.Notify(stopchan, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
+	<-stopchan
+
+	return nil
+}
+
+func (s *server) RunOnce(ctx context.Context) error {
+	return s.cronJob.RunOnce(ctx, s.run)
+}
+
+func (s *server) run(ctx context.Context) error {
+	if err := s.notify(ctx, time.Now()); err != nil {
+		return fmt.Errorf("notify webhook events: %w", err)
+	}
+
+	return nil
+}
+
+func (s *server) notify(ctx context.Context, now time.Time) error {
+	if err := s.dispatch(ctx, now); err != nil {
+		return fmt.Errorf("dispatch events: %w", err)
+	}
+
+	if err := s.deliver(ctx, now); err != nil {
+		return fmt.Errorf("deliver events: %w", err)
+	}
+
+	if now.Sub(s.lastCleanup) >= cleanupInterval {
+		if err := s.cleanup(ctx, now); err != nil {
+			return fmt.Errorf("clean up: %w", err)
+		}
+
+		s.lastCleanup = now
+	}
+
+	return nil
+}
+
+// dispatch creates a delivery of each due event for each subscription of its addresses and type.
+func (s *server) dispatch(ctx context.Context, now time.Time) error {
+	for {
+		events, err := s.databaseClient.FindWebhookEvents(ctx, schema.WebhookEventsQuery{
+			Dispatched:      lo.ToPtr(false),
+			ScheduledBefore: lo.ToPtr(now),
+			Limit:           lo.ToPtr(batchSize),
+		})
+		if err != nil {
+			return fmt.Errorf("find webhook events: %w", err)
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		deliveries := make([]*schema.WebhookDelivery, 0, len(events))
+
+		for _, event := range events {
+			subscriptions, err := s.databaseClient.FindWebhookSubscriptions(ctx, schema.WebhookSubscriptionsQuery{
+				Addresses: event.Addresses,
+				EventType: lo.ToPtr(event.Type),
+			})
+			if err != nil {
+				return fmt.Errorf("find subscriptions of event %d: %w", event.ID, err)
+			}
+
+			for _, subscription := range subscriptions {
+				payload, err := webhook.NewPayload(event, subscription)
+				if err != nil {
+					return fmt.Errorf("new payload of event %d: %w", event.ID, err)
+				}
+
+				deliveries = append(deliveries, &schema.WebhookDelivery{
+					SubscriptionID: subscription.ID,
+					EventID:        event.ID,
+					EventType:      event.Type,
+					Payload:        payload,
+					Status:         schema.WebhookDeliveryStatusPending,
+					NextAttemptAt:  now.Unix(),
+				})
+			}
+		}
+
+		if err := s.databaseClient.WithTransaction(ctx, func(ctx context.Context, client database.Client) error {
+			if err := client.SaveWebhookDeliveries(ctx, deliveries); err != nil {
+				return fmt.Errorf("save webhook deliveries: %w", err)
+			}
+
+			return client.UpdateWebhookEventsDispatched(ctx, lo.Map(events, func(event *schema.WebhookEvent, _ int) uint64 {
+				return event.ID
+			}))
+		}); err != nil {
+			return err
+		}
+
+		zap.L().Info("dispatch webhook events", zap.Int("events", len(events)), zap.Int("deliveries", len(deliveries)))
+
+		if len(events) < batchSize {
+			return nil
+		}
+	}
+}
+
+// deliver sends a batch of the due deliveries concurrently, a failed delivery is retried later with an exponential backoff.
+// The rest of the due deliveries are sent by the next runs.
+func (s *server) deliver(ctx context.Context, now time.Time) error {
+	deliveries, err := s.databaseClient.FindWebhookDeliveries(ctx, schema.WebhookDeliveriesQuery{
+		Status:            lo.ToPtr(schema.WebhookDeliveryStatusPending),
+		NextAttemptBefore: lo.ToPtr(now),
+		Limit:             lo.ToPtr(batchSize),
+	})
+	if err != nil {
+		return fmt.Errorf("find webhook deliveries: %w", err)
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	subscriptions, err := s.databaseClient.FindWebhookSubscriptions(ctx, schema.WebhookSubscriptionsQuery{
+		IDs: lo.Uniq(lo.Map(deliveries, func(delivery *schema.WebhookDelivery, _ int) uint64 {
+			return delivery.SubscriptionID
+		})),
+	})
+	if err != nil {
+		return fmt.Errorf("find subscriptions: %w", err)
+	}
+
+	subscriptionMap := lo.SliceToMap(subscriptions, func(subscription *schema.WebhookSubscription) (uint64, *schema.WebhookSubscription) {
+		return subscription.ID, subscription
+	})
+
+	deliveryPool := pool.New().WithContext(ctx).WithMaxGoroutines(s.config.Workers)
+
+	for _, delivery := range deliveries {
+		delivery := delivery
+
+		deliveryPool.Go(func(ctx context.Context) error {
+			s.attempt(ctx, delivery, subscriptionMap[delivery.SubscriptionID], now)
+
+			if err := s.databaseClient.UpdateWebhookDelivery(ctx, delivery); err != nil {
+				zap.L().Error("update webhook delivery", zap.Uint64("id", delivery.ID), zap.Error(err))
+			}
+
+			return nil
+		})
+	}
+
+	return deliveryPool.Wait()
+}
+
+// attempt sends the delivery to the subscription and records the result in the delivery.
+func (s *server) attempt(ctx context.Context, delivery *schema.WebhookDelivery, subscription *schema.WebhookSubscription, now time.Time) {
+	delivery.Attempts++
+
+	if subscription == nil {
+		delivery.Status = schema.WebhookDeliveryStatusFailed
+		delivery.Error = "subscription not found"
+
+		return
+	}
+
+	statusCode, err := s.send(ctx, delivery, subscription)
+
+	delivery.ResponseStatus = statusCode
+
+	if err == nil {
+		delivery.Status = schema.WebhookDeliveryStatusSucceeded
+		delivery.Error = ""
+
+		return
+	}
+
+	delivery.Error = err.Error()
+
+	if delivery.Attempts >= s.config.MaxAttempts {
+		delivery.Status = schema.WebhookDeliveryStatusFailed
+
+		zap.L().Warn("webhook delivery failed", zap.Uint64("id", delivery.ID), zap.Uint64("subscription", subscription.ID), zap.Int("attempts", delivery.Attempts), zap.Error(err))
+
+		return
+	}
+
+	delivery.NextAttemptAt = now.Add(s.backoff(delivery.Attempts)).Unix()
+}
+
+// send posts the signed payload to the URL of the subscription, it returns the status code of the response.
+func (s *server) send(ctx context.Context, delivery *schema.WebhookDelivery, subscription *schema.WebhookSubscription) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("new request: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", userAgent)
+	request.Header.Set(webhook.HeaderDelivery, strconv.FormatUint(delivery.ID, 10))
+	request.Header.Set(webhook.HeaderEvent, delivery.EventType.String())
+	request.Header.Set(webhook.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(webhook.HeaderSignature, webhook.Sign(subscription.Secret, timestamp, delivery.Payload))
+
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		// The error of the client quotes the URL, which may carry credentials in its path and query,
+		// the deliveries are read by the subscribed address, so only the origin is kept.
+		var urlError *url.Error
+		if errors.As(err, &urlError) {
+			return 0, fmt.Errorf("%s %s: %w", urlError.Op, webhook.RedactURL(urlError.URL), urlError.Err)
+		}
+
+		return 0, err
+	}
+
+	defer lo.Try(response.Body.Close)
+
+	// The response is drained to reuse the connection.
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, maxResponseSize))
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return response.StatusCode, fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
+
+// backoff returns the delay before the next attempt, it doubles with each attempt up to the max.
+func (s *server) backoff(attempts int) time.Duration {
+	delay := s.config.BackoffBase
+
+	for i := 1; i < attempts && delay < s.config.BackoffMax; i++ {
+		delay *= 2
+	}
+
+	return min(delay, s.config.BackoffMax)
+}
+
+// cleanup deletes the dispatched events and the finished deliveries past the retention.
+func (s *server) cleanup(ctx context.Context, now time.Time) error {
+	before := now.Add(-s.config.Retention)
+
+	if err := s.databaseClient.DeleteWebhookDeliveries(ctx, before); err != nil {
+		return fmt.Errorf("delete webhook deliveries: %w", err)
+	}
+
+	if err := s.databaseClient.DeleteWebhookEvents(ctx, before); err != nil {
+		return fmt.Errorf("delete webhook events: %w", err)
+	}
+
+	return nil
+}
+
+var errPrivateNetwork = errors.New("delivering to a private network address is not allowed")
+
+// newHTTPClient returns the client to send the deliveries, it does not follow redirects,
+// and refuses to connect to the loopback and private addresses unless they are allowed.
+func newHTTPClient(allowPrivateNetworks bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			if allowPrivateNetworks {
+				return nil
+			}
+
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			ip := net.ParseIP(host)
+			if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() {
+				return errPrivateNetwork
+			}
+
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			MaxIdleConnsPerHost: 2,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func newServer(databaseClient database.Client, cronJob *cronjob.CronJob, config config.Webhook) *server {
+	return &server{
+		cronJob:        cronJob,
+		databaseClient: databaseClient,
+		httpClient:     newHTTPClient(config.AllowPrivateNetworks),
+		config:         config,
+	}
+}
+
+func New(databaseClient database.Client, cacheClient cache.Client, config *config.File) (service.Server, error) {
+	return newServer(databaseClient, cronjob.New(cacheClient, databaseClient, Name, time.Minute), config.Webhook), nil
+}
@@ -0,0 +1,219 @@
+package notifier
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/config"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/webhook"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+type databaseClient struct {
+	database.Client
+
+	mu            sync.Mutex
+	subscriptions []*schema.WebhookSubscription
+	events        []*schema.WebhookEvent
+	deliveries    []*schema.WebhookDelivery
+}
+
+func (c *databaseClient) WithTransaction(ctx context.Context, transactionFunction func(ctx context.Context, client database.Client) error, _ ...*sql.TxOptions) error {
+	return transactionFunction(ctx, c)
+}
+
+func (c *databaseClient) FindWebhookSubscriptions(_ context.Context, query schema.WebhookSubscriptionsQuery) ([]*schema.WebhookSubscription, error) {
+	return lo.Filter(c.subscriptions, func(subscription *schema.WebhookSubscription, _ int) bool {
+		return (len(query.IDs) == 0 || lo.Contains(query.IDs, subscription.ID)) &&
+			(len(query.Addresses) == 0 || lo.Contains(query.Addresses, subscription.Address)) &&
+			(query.EventType == nil || lo.Contains(subscription.EventTypes, *query.EventType))
+	}), nil
+}
+
+func (c *databaseClient) FindWebhookEvents(_ context.Context, query schema.WebhookEventsQuery) ([]*schema.WebhookEvent, error) {
+	return lo.Filter(c.events, func(event *schema.WebhookEvent, _ int) bool {
+		return !event.Dispatched && event.ScheduledAt <= query.ScheduledBefore.Unix()
+	}), nil
+}
+
+func (c *databaseClient) UpdateWebhookEventsDispatched(_ context.Context, ids []uint64) error {
+	for _, event := range c.events {
+		if lo.Contains(ids, event.ID) {
+			event.Dispatched = true
+		}
+	}
+
+	return nil
+}
+
+func (c *databaseClient) SaveWebhookDeliveries(_ context.Context, deliveries []*schema.WebhookDelivery) error {
+	for _, delivery := range deliveries {
+		delivery.ID = uint64(len(c.deliveries) + 1)
+		c.deliveries = append(c.deliveries, delivery)
+	}
+
+	return nil
+}
+
+func (c *databaseClient) FindWebhookDeliveries(_ context.Context, query schema.WebhookDeliveriesQuery) ([]*schema.WebhookDelivery, error) {
+	return lo.FilterMap(c.deliveries, func(delivery *schema.WebhookDelivery, _ int) (*schema.WebhookDelivery, bool) {
+		// The deliveries are copied as they are updated concurrently.
+		copied := *delivery
+
+		return &copied, delivery.Status == *query.Status && delivery.NextAttemptAt <= query.NextAttemptBefore.Unix()
+	}), nil
+}
+
+func (c *databaseClient) UpdateWebhookDelivery(_ context.Context, delivery *schema.WebhookDelivery) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	*c.deliveries[delivery.ID-1] = *delivery
+
+	return nil
+}
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx     = context.Background()
+		now     = time.Unix(1725000000, 0)
+		node    = common.HexToAddress("0x01")
+		staker  = common.HexToAddress("0x02")
+		failing = true
+	)
+
+	subscriber := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, err := io.ReadAll(request.Body)
+		require.NoError(t, err)
+
+		timestamp, err := strconv.ParseInt(request.Header.Get(webhook.HeaderTimestamp), 10, 64)
+		require.NoError(t, err)
+		require.True(t, webhook.Verify("secret", timestamp, body, request.Header.Get(webhook.HeaderSignature)))
+
+		var payload webhook.Payload
+
+		require.NoError(t, json.Unmarshal(body, &payload))
+		require.Equal(t, schema.WebhookEventTypeNodeStatusChanged, payload.Type)
+		require.Equal(t, node, payload.Address)
+
+		writer.WriteHeader(lo.Ternary(failing, http.StatusServiceUnavailable, http.StatusNoContent))
+	}))
+	defer subscriber.Close()
+
+	client := &databaseClient{
+		subscriptions: []*schema.WebhookSubscription{
+			{ID: 1, Address: node, URL: subscriber.URL, EventTypes: []schema.WebhookEventType{schema.WebhookEventTypeNodeStatusChanged}, Secret: "secret"},
+			{ID: 2, Address: node, URL: subscriber.URL, EventTypes: []schema.WebhookEventType{schema.WebhookEventTypeNodeScoreDropped}, Secret: "secret"},
+		},
+		events: []*schema.WebhookEvent{
+			{ID: 1, Type: schema.WebhookEventTypeNodeStatusChanged, Addresses: []common.Address{node}, Data: json.RawMessage(`{}`), ScheduledAt: now.Unix()},
+			{ID: 2, Type: schema.WebhookEventTypeNodeStatusChanged, Addresses: []common.Address{staker}, Data: json.RawMessage(`{}`), ScheduledAt: now.Unix()},
+			// The event is not due yet.
+			{ID: 3, Type: schema.WebhookEventTypeNodeStatusChanged, Addresses: []common.Address{node}, Data: json.RawMessage(`{}`), ScheduledAt: now.Add(time.Hour).Unix()},
+		},
+	}
+
+	s := newServer(client, nil, config.Webhook{
+		Workers:              2,
+		Timeout:              time.Second,
+		MaxAttempts:          3,
+		BackoffBase:          time.Minute,
+		BackoffMax:           90 * time.Second,
+		AllowPrivateNetworks: true,
+	})
+
+	// The event of the node is delivered to the subscription of its type, the event of the staker has no subscription.
+	require.NoError(t, s.dispatch(ctx, now))
+	require.Len(t, client.deliveries, 1)
+	require.True(t, client.events[0].Dispatched)
+	require.True(t, client.events[1].Dispatched)
+	require.False(t, client.events[2].Dispatched)
+
+	delivery := client.deliveries[0]
+	require.Equal(t, uint64(1), delivery.SubscriptionID)
+
+	// The failed attempts are retried with the backoff.
+	require.NoError(t, s.deliver(ctx, now))
+	require.Equal(t, schema.WebhookDeliveryStatusPending, delivery.Status)
+	require.Equal(t, 1, delivery.Attempts)
+	require.Equal(t, http.StatusServiceUnavailable, delivery.ResponseStatus)
+	require.Equal(t, now.Add(time.Minute).Unix(), delivery.NextAttemptAt)
+
+	// The delivery is not due until the backoff ends.
+	require.NoError(t, s.deliver(ctx, now))
+	require.Equal(t, 1, delivery.Attempts)
+
+	now = now.Add(time.Minute)
+	require.NoError(t, s.deliver(ctx, now))
+	require.Equal(t, 2, delivery.Attempts)
+	require.Equal(t, now.Add(90*time.Second).Unix(), delivery.NextAttemptAt)
+
+	failing = false
+	now = now.Add(90 * time.Second)
+	require.NoError(t, s.deliver(ctx, now))
+	require.Equal(t, schema.WebhookDeliveryStatusSucceeded, delivery.Status)
+	require.Equal(t, 3, delivery.Attempts)
+	require.Equal(t, http.StatusNoContent, delivery.ResponseStatus)
+	require.Empty(t, delivery.Error)
+
+	// A delivery fails once it runs out of attempts.
+	failing = true
+	now = now.Add(time.Hour)
+	require.NoError(t, s.dispatch(ctx, now))
+	require.Len(t, client.deliveries, 2)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, s.deliver(ctx, now))
+		now = now.Add(time.Hour)
+	}
+
+	require.Equal(t, schema.WebhookDeliveryStatusFailed, client.deliveries[1].Status)
+	require.Equal(t, 3, client.deliveries[1].Attempts)
+	require.Equal(t, "unexpected status code 503", client.deliveries[1].Error)
+}
+
+func TestHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The loopback address is refused unless the private networks are allowed.
+	_, err := newHTTPClient(false).Get(server.URL)
+	require.ErrorIs(t, err, errPrivateNetwork)
+
+	response, err := newHTTPClient(true).Get(server.URL)
+	require.NoError(t, err)
+	require.NoError(t, response.Body.Close())
+}
+
+func TestSendRedactsURL(t *testing.T) {
+	t.Parallel()
+
+	s := newServer(&databaseClient{}, nil, config.Webhook{Timeout: time.Second})
+
+	// The loopback address is refused, the error keeps only the origin of the URL.
+	_, err := s.send(context.Background(), &schema.WebhookDelivery{Payload: json.RawMessage(`{}`)}, &schema.WebhookSubscription{
+		URL:    "http://127.0.0.1:1/hooks/credential?token=credential",
+		Secret: "secret",
+	})
+	require.ErrorIs(t, err, errPrivateNetwork)
+	require.Contains(t, err.Error(), "http://127.0.0.1:1")
+	require.NotContains(t, err.Error(), "credential")
+}
@@ -0,0 +1,84 @@
+package prober
+
+import (
+	"sort"
+	"time"
+
+	"github.com/rss3-network/global-indexer/internal/config"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+)
+
+// groupRounds groups the probes of a Node into rounds by their probe time, ordered from the latest round.
+func groupRounds(probes []*schema.NodeProbe) [][]*schema.NodeProbe {
+	rounds := lo.Values(lo.GroupBy(probes, func(probe *schema.NodeProbe) int64 {
+		return probe.ProbedAt.Unix()
+	}))
+
+	sort.Slice(rounds, func(i, j int) bool {
+		return rounds[i][0].ProbedAt.After(rounds[j][0].ProbedAt)
+	})
+
+	return rounds
+}
+
+// evaluate evaluates the status of a Node from its probe rounds, ordered from the latest round.
+//   - A Node is offline if all probes failed in the latest OfflineRounds rounds.
+//   - A Node is degraded if any probe of the latest round failed or was slow,
+//     its TLS certificate is about to expire, or too few of its Workers are ready.
+//     A Node whose probes only started failing stays degraded until it reaches OfflineRounds.
+//   - Otherwise the Node is online.
+func evaluate(current schema.NodeStatus, rounds [][]*schema.NodeProbe, conf config.Prober, now time.Time) schema.NodeStatus {
+	if len(rounds) == 0 {
+		return current
+	}
+
+	offlineRounds := max(conf.OfflineRounds, 1)
+
+	if len(rounds) >= offlineRounds && lo.EveryBy(rounds[:offlineRounds], allFailed) {
+		return schema.NodeStatusOffline
+	}
+
+	if allFailed(rounds[0]) && current == schema.NodeStatusOffline {
+		return schema.NodeStatusOffline
+	}
+
+	for _, probe := range rounds[0] {
+		switch {
+		case !probe.Succeeded(),
+			probe.Latency > conf.DegradedLatency,
+			probe.CertificateExpiresAt != nil && probe.CertificateExpiresAt.Before(now.Add(conf.CertificateExpiry)),
+			probe.Workers > 0 && float64(probe.ReadyWorkers)/float64(probe.Workers) < conf.MinReadyWorkerRatio:
+			return schema.NodeStatusDegraded
+		}
+	}
+
+	return schema.NodeStatusOnline
+}
+
+// failureRate returns the share of the failed probes in the rounds.
+func failureRate(rounds [][]*schema.NodeProbe) float64 {
+	var total, failed int
+
+	for _, round := range rounds {
+		for _, probe := range round {
+			total++
+
+			if !probe.Succeeded() {
+				failed++
+			}
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return float64(failed) / float64(total)
+}
+
+func allFailed(round []*schema.NodeProbe) bool {
+	return lo.NoneBy(round, func(probe *schema.NodeProbe) bool {
+		return probe.Succeeded()
+	})
+}
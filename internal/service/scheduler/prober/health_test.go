@@ -0,0 +1,121 @@
+package prober
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rss3-network/global-indexer/internal/config"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now  = time.Now()
+		conf = config.Prober{
+			DegradedLatency:     3 * time.Second,
+			CertificateExpiry:   7 * 24 * time.Hour,
+			MinReadyWorkerRatio: 0.8,
+			OfflineRounds:       3,
+		}
+	)
+
+	healthy := func() *schema.NodeProbe {
+		return &schema.NodeProbe{StatusCode: http.StatusOK, Latency: 100 * time.Millisecond, Workers: 10, ReadyWorkers: 10}
+	}
+
+	failed := func() *schema.NodeProbe {
+		return &schema.NodeProbe{Error: "do request: connection refused"}
+	}
+
+	tests := []struct {
+		name    string
+		current schema.NodeStatus
+		rounds  [][]*schema.NodeProbe
+		output  schema.NodeStatus
+	}{
+		{
+			name:    "no rounds",
+			current: schema.NodeStatusDegraded,
+			output:  schema.NodeStatusDegraded,
+		},
+		{
+			name:    "healthy",
+			current: schema.NodeStatusDegraded,
+			rounds:  [][]*schema.NodeProbe{{healthy(), healthy()}},
+			output:  schema.NodeStatusOnline,
+		},
+		{
+			name:    "server error",
+			current: schema.NodeStatusOnline,
+			rounds:  [][]*schema.NodeProbe{{healthy(), {StatusCode: http.StatusInternalServerError}}},
+			output:  schema.NodeStatusDegraded,
+		},
+		{
+			name:    "slow",
+			current: schema.NodeStatusOnline,
+			rounds:  [][]*schema.NodeProbe{{healthy(), {StatusCode: http.StatusOK, Latency: 5 * time.Second}}},
+			output:  schema.NodeStatusDegraded,
+		},
+		{
+			name:    "certificate expiring",
+			current: schema.NodeStatusOnline,
+			rounds:  [][]*schema.NodeProbe{{{StatusCode: http.StatusOK, CertificateExpiresAt: lo.ToPtr(now.Add(24 * time.Hour))}}},
+			output:  schema.NodeStatusDegraded,
+		},
+		{
+			name:    "stale workers",
+			current: schema.NodeStatusOnline,
+			rounds:  [][]*schema.NodeProbe{{{StatusCode: http.StatusOK, Workers: 10, ReadyWorkers: 5}}},
+			output:  schema.NodeStatusDegraded,
+		},
+		{
+			name:    "failing below offline rounds",
+			current: schema.NodeStatusOnline,
+			rounds:  [][]*schema.NodeProbe{{failed(), failed()}, {failed(), failed()}, {healthy(), healthy()}},
+			output:  schema.NodeStatusDegraded,
+		},
+		{
+			name:    "failing for offline rounds",
+			current: schema.NodeStatusDegraded,
+			rounds:  [][]*schema.NodeProbe{{failed(), failed()}, {failed(), failed()}, {failed(), failed()}},
+			output:  schema.NodeStatusOffline,
+		},
+		{
+			name:    "offline still failing",
+			current: schema.NodeStatusOffline,
+			rounds:  [][]*schema.NodeProbe{{failed(), failed()}, {healthy(), healthy()}},
+			output:  schema.NodeStatusOffline,
+		},
+		{
+			name:    "offline recovered",
+			current: schema.NodeStatusOffline,
+			rounds:  [][]*schema.NodeProbe{{healthy(), healthy()}, {failed(), failed()}, {failed(), failed()}},
+			output:  schema.NodeStatusOnline,
+		},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.output, evaluate(tt.current, tt.rounds, conf, now), tt.name)
+	}
+}
+
+func TestFailureRate(t *testing.T) {
+	t.Parallel()
+
+	rounds := groupRounds([]*schema.NodeProbe{
+		{StatusCode: http.StatusOK, ProbedAt: time.Unix(60, 0)},
+		{StatusCode: http.StatusBadGateway, ProbedAt: time.Unix(120, 0)},
+		{StatusCode: http.StatusOK, ProbedAt: time.Unix(120, 0)},
+		{Error: "timeout", ProbedAt: time.Unix(180, 0)},
+	})
+
+	require.Len(t, rounds, 3)
+	require.Equal(t, time.Unix(180, 0), rounds[0][0].ProbedAt)
+	require.Equal(t, 0.5, failureRate(rounds))
+	require.Zero(t, failureRate(nil))
+}
@@ -0,0 +1,136 @@
+package prober
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/rss3-network/node/schema/worker"
+)
+
+type workersStatusResponse struct {
+	Data struct {
+		Decentralized []*workerStatus `json:"decentralized"`
+		RSS           *workerStatus   `json:"rss"`
+		Federated     []*workerStatus `json:"federated"`
+	} `json:"data"`
+}
+
+type workerStatus struct {
+	Status worker.Status `json:"status"`
+}
+
+type canaryResponse struct {
+	Data json.RawMessage `json:"data"`
+}
+
+// probeNode probes the root, the Workers status and the canary transaction of a Node.
+func (s *server) probeNode(ctx context.Context, node *schema.Node, probedAt time.Time) []*schema.NodeProbe {
+	probes := []*schema.NodeProbe{
+		s.probe(ctx, node, schema.NodeProbeTargetRoot, "", nil),
+		s.probe(ctx, node, schema.NodeProbeTargetWorkersStatus, "/workers_status", decodeWorkersStatus),
+	}
+
+	if s.config.CanaryTransaction != "" {
+		probes = append(probes, s.probe(ctx, node, schema.NodeProbeTargetCanary, "/decentralized/tx/"+url.PathEscape(s.config.CanaryTransaction), decodeCanary))
+	}
+
+	for _, probe := range probes {
+		probe.NodeAddress = node.Address
+		probe.ProbedAt = probedAt
+	}
+
+	return probes
+}
+
+// probe requests the path of the Node endpoint and records the response, the decode function checks the response body.
+func (s *server) probe(ctx context.Context, node *schema.Node, target schema.NodeProbeTarget, path string, decode func(io.Reader, *schema.NodeProbe) error) *schema.NodeProbe {
+	probe := schema.NodeProbe{Target: target}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, node.Endpoint+path, nil)
+	if err != nil {
+		probe.Error = fmt.Sprintf("create request: %s", err)
+
+		return &probe
+	}
+
+	if node.AccessToken != "" {
+		request.Header.Set("Authorization", node.AccessToken)
+	}
+
+	startedAt := time.Now()
+
+	response, err := s.httpClient.Do(request)
+
+	probe.Latency = time.Since(startedAt)
+
+	if err != nil {
+		probe.Error = fmt.Sprintf("do request: %s", err)
+
+		return &probe
+	}
+
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	probe.StatusCode = response.StatusCode
+
+	if response.TLS != nil && len(response.TLS.PeerCertificates) > 0 {
+		probe.CertificateExpiresAt = &response.TLS.PeerCertificates[0].NotAfter
+	}
+
+	if !probe.Succeeded() || decode == nil {
+		return &probe
+	}
+
+	if err := decode(response.Body, &probe); err != nil {
+		probe.Error = err.Error()
+	}
+
+	return &probe
+}
+
+// decodeWorkersStatus records the data freshness of a Node as the share of its Workers that are ready.
+func decodeWorkersStatus(body io.Reader, probe *schema.NodeProbe) error {
+	var response workersStatusResponse
+
+	if err := json.NewDecoder(body).Decode(&response); err != nil {
+		return fmt.Errorf("decode workers status: %w", err)
+	}
+
+	workers := append(response.Data.Decentralized, response.Data.Federated...)
+
+	if response.Data.RSS != nil {
+		workers = append(workers, response.Data.RSS)
+	}
+
+	for _, w := range workers {
+		probe.Workers++
+
+		if w.Status == worker.StatusReady {
+			probe.ReadyWorkers++
+		}
+	}
+
+	return nil
+}
+
+func decodeCanary(body io.Reader, _ *schema.NodeProbe) error {
+	var response canaryResponse
+
+	if err := json.NewDecoder(body).Decode(&response); err != nil {
+		return fmt.Errorf("decode canary transaction: %w", err)
+	}
+
+	if len(response.Data) == 0 || string(response.Data) == "null" {
+		return fmt.Errorf("canary transaction not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package prober
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/cache"
+	"github.com/rss3-network/global-indexer/internal/config"
+	"github.com/rss3-network/global-indexer/internal/cronjob"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/lifecycle"
+	"github.com/rss3-network/global-indexer/internal/service"
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/sourcegraph/conc/pool"
+	"go.uber.org/zap"
+)
+
+var (
+	_ service.Server = (*server)(nil)
+	_ cronjob.Job    = (*server)(nil)
+)
+
+var Name = "prober"
+
+const (
+	// probeInterval is the interval between two probe rounds, it matches the spec of the server.
+	probeInterval = time.Minute
+	// failureRateWindow is the window of the probe rounds that the failure rate is calculated from.
+	failureRateWindow = time.Hour
+	// heartbeatTimeout is the timeout of the heartbeat after which the detector marks a Node offline,
+	// such a Node is left to the detector until it sends a heartbeat again.
+	heartbeatTimeout = 5 * time.Minute
+	// probeConcurrency is the maximum number of Nodes probed concurrently.
+	probeConcurrency = 20
+	batchSize        = 200
+)
+
+type server struct {
+	cronJob        *cronjob.CronJob
+	databaseClient database.Client
+	cacheClient    cache.Client
+	httpClient     *http.Client
+	config         config.Prober
+}
+
+func (s *server) Name() string {
+	return Name
+}
+
+func (s *server) Spec() string {
+	return "0 * * * * *" // every minute
+}
+
+func (s *server) Run(ctx context.Context) error {
+	err := s.cronJob.AddFunc(ctx, s.Spec(), s.probeNodes)
+	if err != nil {
+		return fmt.Errorf("add prober cron job: %w", err)
+	}
+
+	s.cronJob.Start()
+	defer s.cronJob.Stop()
+
+	stopchan := make(chan os.Signal, 1)
+
+	signal.Notify(stopchan, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
+	<-stopchan
+
+	return nil
+}
+
+func (s *server) RunOnce(ctx context.Context) error {
+	return s.cronJob.RunOnce(ctx, s.probeNodes)
+}
+
+// probeNodes runs a probe round against all online, degraded and offline Nodes,
+// then transitions the Nodes to the status evaluated from their recent rounds.
+func (s *server) probeNodes(ctx context.Context) error {
+	probedAt := time.Now().Truncate(time.Second)
+
+	var cursor *string
+
+	for {
+		nodes, err := s.databaseClient.FindNodes(ctx, schema.FindNodesQuery{
+			Statuses: []schema.NodeStatus{schema.NodeStatusOnline, schema.NodeStatusDegraded, schema.NodeStatusOffline},
+			Cursor:   cursor,
+			Limit:    lo.ToPtr(batchSize),
+		})
+		if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+			return fmt.Errorf("find nodes: %w", err)
+		}
+
+		if len(nodes) == 0 {
+			break
+		}
+
+		if err := s.probeBatch(ctx, nodes, probedAt); err != nil {
+			return err
+		}
+
+		cursor = lo.ToPtr(nodes[len(nodes)-1].Address.String())
+	}
+
+	if err := s.databaseClient.DeleteNodeProbes(ctx, probedAt.Add(-s.config.Retention)); err != nil {
+		return fmt.Errorf("delete expired node probes: %w", err)
+	}
+
+	return nil
+}
+
+func (s *server) probeBatch(ctx context.Context, nodes []*schema.Node, probedAt time.Time) error {
+	nodes = lo.Filter(nodes, func(node *schema.Node, _ int) bool {
+		if node.Endpoint == "" {
+			return false
+		}
+
+		return node.Status != schema.NodeStatusOffline || time.Since(time.Unix(node.LastHeartbeatTimestamp, 0)) < heartbeatTimeout
+	})
+
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	var (
+		locker sync.Mutex
+		probes = make([]*schema.NodeProbe, 0, len(nodes)*3)
+	)
+
+	probePool := pool.New().WithMaxGoroutines(probeConcurrency)
+
+	for _, node := range nodes {
+		node := node
+
+		probePool.Go(func() {
+			results := s.probeNode(ctx, node, probedAt)
+
+			locker.Lock()
+			defer locker.Unlock()
+
+			probes = append(probes, results...)
+		})
+	}
+
+	probePool.Wait()
+
+	if err := s.databaseClient.SaveNodeProbes(ctx, probes); err != nil {
+		return fmt.Errorf("save node probes: %w", err)
+	}
+
+	recentProbes, err := s.databaseClient.FindNodeProbes(ctx, schema.NodeProbeQuery{
+		NodeAddresses: lo.Map(nodes, func(node *schema.Node, _ int) common.Address { return node.Address }),
+		Since:         lo.ToPtr(probedAt.Add(-failureRateWindow)),
+	})
+	if err != nil {
+		return fmt.Errorf("find recent node probes: %w", err)
+	}
+
+	probesByNode := lo.GroupBy(recentProbes, func(probe *schema.NodeProbe) common.Address {
+		return probe.NodeAddress
+	})
+
+	transitions := make(map[schema.NodeStatus][]*schema.Node)
+
+	for _, node := range nodes {
+		rounds := groupRounds(probesByNode[node.Address])
+
+		status := evaluate(node.Status, rounds, s.config, probedAt)
+
+		s.setCache(ctx, model.NodeProbeStatus, node.Address, status, 3*probeInterval)
+		s.setCache(ctx, model.NodeProbeFailureRate, node.Address, failureRate(rounds), 2*failureRateWindow)
+
+		if status == node.Status {
+			continue
+		}
+
+		if !lifecycle.CanTransit(node.Status, status) {
+			zap.L().Warn("invalid node status transition", zap.String("address", node.Address.String()), zap.Stringer("from", node.Status), zap.Stringer("to", status))
+
+			continue
+		}
+
+		zap.L().Info("transition node status", zap.String("address", node.Address.String()), zap.Stringer("from", node.Status), zap.Stringer("to", status))
+
+		transitions[status] = append(transitions[status], node)
+	}
+
+	if len(transitions) == 0 {
+		return nil
+	}
+
+	return s.databaseClient.WithTransaction(ctx, func(ctx context.Context, client database.Client) error {
+		for status, nodes := range transitions {
+			if _, err := lifecycle.Transit(ctx, client, nodes, status, schema.NodeStatusTransitionReasonProbe); err != nil {
+				return fmt.Errorf("transit nodes to %s: %w", status, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *server) setCache(ctx context.Context, prefix string, address common.Address, value any, expiration time.Duration) {
+	if err := s.cacheClient.Set(ctx, model.FormatNodeStatRedisKey(prefix, address.String()), value, expiration); err != nil {
+		zap.L().Error("set probe cache", zap.Error(err), zap.String("prefix", prefix), zap.String("address", address.String()))
+	}
+}
+
+func New(databaseClient database.Client, cacheClient cache.Client, config *config.File) (service.Server, error) {
+	instance := server{
+		databaseClient: databaseClient,
+		cacheClient:    cacheClient,
+		httpClient:     &http.Client{Timeout: config.Prober.Timeout},
+		config:         config.Prober,
+		cronJob:        cronjob.New(cacheClient, databaseClient, Name, probeInterval),
+	}
+
+	return &instance, nil
+}
@@ -0,0 +1,43 @@
+package reconciler
+
+import (
+	"context"
+
+	"github.com/rss3-network/global-indexer/schema"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// metrics reports the mismatches found by the reconciliation, an alert is expected to fire on any increase.
+type metrics struct {
+	mismatches      metric.Int64Counter
+	reconciledEpoch metric.Int64Gauge
+}
+
+// recordReconciliation records the mismatches of a reconciled Epoch by type.
+func (m *metrics) recordReconciliation(ctx context.Context, reconciliation *schema.EpochReconciliation) {
+	for _, mismatch := range reconciliation.Mismatches {
+		m.mismatches.Add(ctx, 1, metric.WithAttributes(attribute.String("type", mismatch.Type.String())))
+	}
+
+	m.reconciledEpoch.Record(ctx, int64(reconciliation.EpochID))
+}
+
+func newMetrics() (*metrics, error) {
+	var (
+		meter = otel.Meter("github.com/rss3-network/global-indexer/internal/service/scheduler/reconciler")
+		m     metrics
+		err   error
+	)
+
+	if m.mismatches, err = meter.Int64Counter("reconciler.mismatches", metric.WithDescription("The number of the mismatches found between the settled and the distributed rewards by type.")); err != nil {
+		return nil, err
+	}
+
+	if m.reconciledEpoch, err = meter.Int64Gauge("reconciler.epoch", metric.WithDescription("The latest reconciled Epoch.")); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
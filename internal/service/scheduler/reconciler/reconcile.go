@@ -0,0 +1,231 @@
+package reconciler
+
+import (
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+// submission is a batch submitted by the settler, recorded by an Epoch trigger or a confirmed batch of the settlement plan.
+type submission struct {
+	transactionHash common.Hash
+	data            *schema.SettlementData
+}
+
+// newSubmissions merges the triggers and the confirmed batches of the plan by their transactions,
+// the triggers are preferred as they record what was confirmed on chain.
+func newSubmissions(triggers []*schema.EpochTrigger, plan *schema.SettlementPlan) []*submission {
+	submissions := make([]*submission, 0, len(triggers))
+
+	for _, trigger := range triggers {
+		submissions = append(submissions, &submission{
+			transactionHash: trigger.TransactionHash,
+			data:            lo.ToPtr(trigger.Data),
+		})
+	}
+
+	if plan == nil {
+		return submissions
+	}
+
+	for _, batch := range plan.Batches {
+		if batch.Status != schema.SettlementBatchStatusConfirmed || batch.TransactionHash == nil || batch.Data == nil {
+			continue
+		}
+
+		if !lo.ContainsBy(submissions, func(submission *submission) bool { return submission.transactionHash == *batch.TransactionHash }) {
+			submissions = append(submissions, &submission{
+				transactionHash: *batch.TransactionHash,
+				data:            batch.Data,
+			})
+		}
+	}
+
+	return submissions
+}
+
+// reconcile compares the batches of an Epoch submitted by the settler with the indexed distributions,
+// the planned Nodes and the request counts of the Node stats, it returns the mismatches found.
+func reconcile(epochID uint64, submissions []*submission, distributions []*schema.Epoch, rewardedNodes []*schema.RewardedNode, plan *schema.SettlementPlan, stats map[common.Address]*schema.Stat) []*schema.EpochReconciliationMismatch {
+	r := reconciliation{epochID: epochID}
+
+	// The distributions are ordered from the earliest, so that the later ones are reported as the duplicates.
+	distributions = slices.Clone(distributions)
+	slices.SortStableFunc(distributions, func(a, b *schema.Epoch) int {
+		return a.BlockNumber.Cmp(b.BlockNumber)
+	})
+
+	distributed := lo.GroupBy(rewardedNodes, func(rewardedNode *schema.RewardedNode) common.Hash {
+		return rewardedNode.TransactionHash
+	})
+
+	submitted := make(map[common.Hash]struct{}, len(submissions))
+	submittedNodes := make(map[common.Address]struct{})
+
+	for _, submission := range submissions {
+		submitted[submission.transactionHash] = struct{}{}
+
+		for _, nodeAddress := range submission.data.NodeAddress {
+			submittedNodes[nodeAddress] = struct{}{}
+		}
+
+		if !lo.ContainsBy(distributions, func(distribution *schema.Epoch) bool {
+			return distribution.TransactionHash == submission.transactionHash
+		}) {
+			r.add(schema.EpochReconciliationMismatchTypeMissingDistribution, &submission.transactionHash, nil, strconv.Itoa(len(submission.data.NodeAddress)), "")
+
+			continue
+		}
+
+		r.compareBatch(submission, distributed[submission.transactionHash])
+	}
+
+	for _, distribution := range distributions {
+		if _, exists := submitted[distribution.TransactionHash]; !exists {
+			r.add(schema.EpochReconciliationMismatchTypeUnexpectedDistribution, &distribution.TransactionHash, nil, "", strconv.Itoa(len(distributed[distribution.TransactionHash])))
+		}
+	}
+
+	r.findDuplicates(distributions, distributed)
+
+	// The planned Nodes that were submitted are compared by their batches above.
+	if plan != nil {
+		rewarded := lo.SliceToMap(rewardedNodes, func(rewardedNode *schema.RewardedNode) (common.Address, struct{}) {
+			return rewardedNode.NodeAddress, struct{}{}
+		})
+
+		for _, nodeAddress := range plan.NodeAddresses() {
+			_, isSubmitted := submittedNodes[nodeAddress]
+			_, isRewarded := rewarded[nodeAddress]
+
+			if !isSubmitted && !isRewarded {
+				r.add(schema.EpochReconciliationMismatchTypeMissingNode, nil, lo.ToPtr(nodeAddress), "planned", "")
+			}
+		}
+	}
+
+	for _, rewardedNode := range rewardedNodes {
+		stat, exists := stats[rewardedNode.NodeAddress]
+		if !exists {
+			continue
+		}
+
+		// The Node stat keeps the request count of the latest settled Epoch, which is the one rewarded.
+		if uint64(stat.Epoch) == epochID {
+			if !rewardedNode.RequestCount.Equal(decimal.NewFromInt(stat.LastEpochRequest)) {
+				r.add(schema.EpochReconciliationMismatchTypeRequestCountStat, &rewardedNode.TransactionHash, lo.ToPtr(rewardedNode.NodeAddress), strconv.FormatInt(stat.LastEpochRequest, 10), rewardedNode.RequestCount.String())
+			}
+
+			continue
+		}
+
+		// The request count of an earlier Epoch is only counted in the total request count of the Node stat, which can only be greater.
+		if rewardedNode.RequestCount.GreaterThan(decimal.NewFromInt(stat.TotalRequest)) {
+			r.add(schema.EpochReconciliationMismatchTypeRequestCountExceedsTotal, &rewardedNode.TransactionHash, lo.ToPtr(rewardedNode.NodeAddress), strconv.FormatInt(stat.TotalRequest, 10), rewardedNode.RequestCount.String())
+		}
+	}
+
+	return r.mismatches
+}
+
+type reconciliation struct {
+	epochID    uint64
+	mismatches []*schema.EpochReconciliationMismatch
+}
+
+func (r *reconciliation) add(mismatchType schema.EpochReconciliationMismatchType, transactionHash *common.Hash, nodeAddress *common.Address, expected, actual string) {
+	r.mismatches = append(r.mismatches, &schema.EpochReconciliationMismatch{
+		EpochID:         r.epochID,
+		Type:            mismatchType,
+		TransactionHash: transactionHash,
+		NodeAddress:     nodeAddress,
+		Expected:        expected,
+		Actual:          actual,
+	})
+}
+
+// compareBatch compares the Nodes, the request counts and the operation rewards of a submitted batch with its distribution.
+func (r *reconciliation) compareBatch(submission *submission, rewardedNodes []*schema.RewardedNode) {
+	indexed := lo.SliceToMap(rewardedNodes, func(rewardedNode *schema.RewardedNode) (common.Address, *schema.RewardedNode) {
+		return rewardedNode.NodeAddress, rewardedNode
+	})
+
+	transactionHash := &submission.transactionHash
+
+	for i, nodeAddress := range submission.data.NodeAddress {
+		rewardedNode, exists := indexed[nodeAddress]
+		if !exists {
+			r.add(schema.EpochReconciliationMismatchTypeMissingNode, transactionHash, lo.ToPtr(nodeAddress), "submitted", "")
+
+			continue
+		}
+
+		delete(indexed, nodeAddress)
+
+		if requestCount := valueAt(submission.data.RequestCount, i); !requestCount.Equal(rewardedNode.RequestCount) {
+			r.add(schema.EpochReconciliationMismatchTypeRequestCount, transactionHash, lo.ToPtr(nodeAddress), requestCount.String(), rewardedNode.RequestCount.String())
+		}
+
+		if operationRewards := valueAt(submission.data.OperationRewards, i); !operationRewards.Equal(rewardedNode.OperationRewards) {
+			r.add(schema.EpochReconciliationMismatchTypeOperationRewards, transactionHash, lo.ToPtr(nodeAddress), operationRewards.String(), rewardedNode.OperationRewards.String())
+		}
+	}
+
+	// The rest of the indexed Nodes were not submitted, they are reported in the order of the distribution.
+	for _, rewardedNode := range rewardedNodes {
+		if _, exists := indexed[rewardedNode.NodeAddress]; exists {
+			r.add(schema.EpochReconciliationMismatchTypeUnexpectedNode, transactionHash, lo.ToPtr(rewardedNode.NodeAddress), "", "rewarded")
+		}
+	}
+}
+
+// findDuplicates reports the distributions repeating the Nodes of an earlier distribution as the duplicate batches,
+// and the Nodes rewarded more than once across the other distributions as the duplicate Nodes.
+func (r *reconciliation) findDuplicates(distributions []*schema.Epoch, distributed map[common.Hash][]*schema.RewardedNode) {
+	batches := make(map[string]common.Hash, len(distributions))
+	rewardedTimes := make(map[common.Address]int)
+	duplicateNodes := make([]common.Address, 0)
+
+	for _, distribution := range distributions {
+		nodeAddresses := lo.Map(distributed[distribution.TransactionHash], func(rewardedNode *schema.RewardedNode, _ int) string {
+			return rewardedNode.NodeAddress.Hex()
+		})
+
+		slices.Sort(nodeAddresses)
+
+		key := strings.Join(nodeAddresses, ",")
+
+		if first, exists := batches[key]; exists && len(nodeAddresses) > 0 {
+			r.add(schema.EpochReconciliationMismatchTypeDuplicateBatch, &distribution.TransactionHash, nil, first.Hex(), distribution.TransactionHash.Hex())
+
+			continue
+		}
+
+		batches[key] = distribution.TransactionHash
+
+		for _, rewardedNode := range distributed[distribution.TransactionHash] {
+			if rewardedTimes[rewardedNode.NodeAddress]++; rewardedTimes[rewardedNode.NodeAddress] == 2 {
+				duplicateNodes = append(duplicateNodes, rewardedNode.NodeAddress)
+			}
+		}
+	}
+
+	for _, nodeAddress := range duplicateNodes {
+		r.add(schema.EpochReconciliationMismatchTypeDuplicateNode, nil, lo.ToPtr(nodeAddress), "1", strconv.Itoa(rewardedTimes[nodeAddress]))
+	}
+}
+
+// valueAt returns the value of a submitted array, or zero if the array is shorter.
+func valueAt(values []*big.Int, index int) decimal.Decimal {
+	if index >= len(values) || values[index] == nil {
+		return decimal.Zero
+	}
+
+	return decimal.NewFromBigInt(values[index], 0)
+}
@@ -0,0 +1,206 @@
+package reconciler
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcile(t *testing.T) {
+	t.Parallel()
+
+	var (
+		nodeA = common.HexToAddress("0x0a")
+		nodeB = common.HexToAddress("0x0b")
+		nodeC = common.HexToAddress("0x0c")
+		nodeD = common.HexToAddress("0x0d")
+		txA   = common.HexToHash("0x01")
+		txB   = common.HexToHash("0x02")
+		txC   = common.HexToHash("0x03")
+	)
+
+	newData := func(nodeAddresses ...common.Address) schema.SettlementData {
+		return schema.SettlementData{
+			Epoch:            big.NewInt(1),
+			NodeAddress:      nodeAddresses,
+			OperationRewards: lo.Map(nodeAddresses, func(_ common.Address, _ int) *big.Int { return big.NewInt(100) }),
+			RequestCount:     lo.Map(nodeAddresses, func(_ common.Address, _ int) *big.Int { return big.NewInt(10) }),
+		}
+	}
+
+	newRewardedNode := func(transactionHash common.Hash, nodeAddress common.Address, operationRewards, requestCount int64) *schema.RewardedNode {
+		return &schema.RewardedNode{
+			EpochID:          1,
+			TransactionHash:  transactionHash,
+			NodeAddress:      nodeAddress,
+			OperationRewards: decimal.NewFromInt(operationRewards),
+			RequestCount:     decimal.NewFromInt(requestCount),
+		}
+	}
+
+	newDistribution := func(transactionHash common.Hash, blockNumber int64) *schema.Epoch {
+		return &schema.Epoch{ID: 1, TransactionHash: transactionHash, BlockNumber: big.NewInt(blockNumber), Finalized: true}
+	}
+
+	stats := map[common.Address]*schema.Stat{
+		nodeA: {Address: nodeA, TotalRequest: 100},
+		nodeB: {Address: nodeB, TotalRequest: 100},
+		nodeC: {Address: nodeC, TotalRequest: 5},
+		// The stat of nodeD is of the reconciled Epoch, which counted more requests than submitted.
+		nodeD: {Address: nodeD, Epoch: 1, TotalRequest: 100, LastEpochRequest: 12},
+	}
+
+	testcases := []struct {
+		name          string
+		triggers      []*schema.EpochTrigger
+		plan          *schema.SettlementPlan
+		distributions []*schema.Epoch
+		rewardedNodes []*schema.RewardedNode
+		want          []schema.EpochReconciliationMismatchType
+	}{
+		{
+			name:          "consistent",
+			triggers:      []*schema.EpochTrigger{{TransactionHash: txA, EpochID: 1, Data: newData(nodeA, nodeB)}},
+			plan:          schema.NewSettlementPlan(1, []common.Address{nodeA, nodeB}, 0),
+			distributions: []*schema.Epoch{newDistribution(txA, 1)},
+			rewardedNodes: []*schema.RewardedNode{newRewardedNode(txA, nodeA, 100, 10), newRewardedNode(txA, nodeB, 100, 10)},
+		},
+		{
+			name:          "differing amounts",
+			triggers:      []*schema.EpochTrigger{{TransactionHash: txA, EpochID: 1, Data: newData(nodeA, nodeB)}},
+			distributions: []*schema.Epoch{newDistribution(txA, 1)},
+			rewardedNodes: []*schema.RewardedNode{newRewardedNode(txA, nodeA, 90, 10), newRewardedNode(txA, nodeB, 100, 11)},
+			want: []schema.EpochReconciliationMismatchType{
+				schema.EpochReconciliationMismatchTypeOperationRewards,
+				schema.EpochReconciliationMismatchTypeRequestCount,
+			},
+		},
+		{
+			name:          "missing and unexpected nodes",
+			triggers:      []*schema.EpochTrigger{{TransactionHash: txA, EpochID: 1, Data: newData(nodeA, nodeB)}},
+			plan:          schema.NewSettlementPlan(1, []common.Address{nodeA, nodeB, nodeC}, 0),
+			distributions: []*schema.Epoch{newDistribution(txA, 1)},
+			rewardedNodes: []*schema.RewardedNode{newRewardedNode(txA, nodeA, 100, 10), newRewardedNode(txA, nodeC, 100, 1)},
+			want: []schema.EpochReconciliationMismatchType{
+				schema.EpochReconciliationMismatchTypeMissingNode,
+				schema.EpochReconciliationMismatchTypeUnexpectedNode,
+			},
+		},
+		{
+			name: "missing and unexpected distributions",
+			triggers: []*schema.EpochTrigger{
+				{TransactionHash: txA, EpochID: 1, Data: newData(nodeA)},
+				{TransactionHash: txB, EpochID: 1, Data: newData(nodeB)},
+			},
+			distributions: []*schema.Epoch{newDistribution(txA, 1), newDistribution(txC, 2)},
+			rewardedNodes: []*schema.RewardedNode{newRewardedNode(txA, nodeA, 100, 10), newRewardedNode(txC, nodeC, 100, 1)},
+			want: []schema.EpochReconciliationMismatchType{
+				schema.EpochReconciliationMismatchTypeMissingDistribution,
+				schema.EpochReconciliationMismatchTypeUnexpectedDistribution,
+			},
+		},
+		{
+			name: "duplicate batch",
+			triggers: []*schema.EpochTrigger{
+				{TransactionHash: txA, EpochID: 1, Data: newData(nodeA, nodeB)},
+				{TransactionHash: txB, EpochID: 1, Data: newData(nodeA, nodeB)},
+			},
+			distributions: []*schema.Epoch{newDistribution(txB, 2), newDistribution(txA, 1)},
+			rewardedNodes: []*schema.RewardedNode{
+				newRewardedNode(txA, nodeA, 100, 10), newRewardedNode(txA, nodeB, 100, 10),
+				newRewardedNode(txB, nodeB, 100, 10), newRewardedNode(txB, nodeA, 100, 10),
+			},
+			want: []schema.EpochReconciliationMismatchType{
+				schema.EpochReconciliationMismatchTypeDuplicateBatch,
+			},
+		},
+		{
+			name: "duplicate node",
+			triggers: []*schema.EpochTrigger{
+				{TransactionHash: txA, EpochID: 1, Data: newData(nodeA, nodeB)},
+				{TransactionHash: txB, EpochID: 1, Data: newData(nodeB)},
+			},
+			distributions: []*schema.Epoch{newDistribution(txA, 1), newDistribution(txB, 2)},
+			rewardedNodes: []*schema.RewardedNode{
+				newRewardedNode(txA, nodeA, 100, 10), newRewardedNode(txA, nodeB, 100, 10),
+				newRewardedNode(txB, nodeB, 100, 10),
+			},
+			want: []schema.EpochReconciliationMismatchType{
+				schema.EpochReconciliationMismatchTypeDuplicateNode,
+			},
+		},
+		{
+			name:          "request count of the stat",
+			triggers:      []*schema.EpochTrigger{{TransactionHash: txA, EpochID: 1, Data: newData(nodeA, nodeD)}},
+			distributions: []*schema.Epoch{newDistribution(txA, 1)},
+			rewardedNodes: []*schema.RewardedNode{newRewardedNode(txA, nodeA, 100, 10), newRewardedNode(txA, nodeD, 100, 10)},
+			want: []schema.EpochReconciliationMismatchType{
+				schema.EpochReconciliationMismatchTypeRequestCountStat,
+			},
+		},
+		{
+			name: "batch of the plan",
+			plan: func() *schema.SettlementPlan {
+				plan := schema.NewSettlementPlan(1, []common.Address{nodeC}, 0)
+
+				plan.Batches[0].Status = schema.SettlementBatchStatusConfirmed
+				plan.Batches[0].TransactionHash = lo.ToPtr(txC)
+				plan.Batches[0].Data = lo.ToPtr(newData(nodeC))
+
+				return plan
+			}(),
+			distributions: []*schema.Epoch{newDistribution(txC, 1)},
+			rewardedNodes: []*schema.RewardedNode{newRewardedNode(txC, nodeC, 100, 10)},
+			want: []schema.EpochReconciliationMismatchType{
+				schema.EpochReconciliationMismatchTypeRequestCountExceedsTotal,
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mismatches := reconcile(1, newSubmissions(testcase.triggers, testcase.plan), testcase.distributions, testcase.rewardedNodes, testcase.plan, stats)
+
+			require.ElementsMatch(t, testcase.want, lo.Map(mismatches, func(mismatch *schema.EpochReconciliationMismatch, _ int) schema.EpochReconciliationMismatchType {
+				return mismatch.Type
+			}))
+		})
+	}
+}
+
+func TestSettled(t *testing.T) {
+	t.Parallel()
+
+	var (
+		finalized   = []*schema.Epoch{{ID: 2, Finalized: true}}
+		unfinalized = []*schema.Epoch{{ID: 2, Finalized: true}, {ID: 2}}
+		plan        = schema.NewSettlementPlan(2, []common.Address{common.HexToAddress("0x0a")}, 0)
+	)
+
+	// An Epoch before the latest one is settled once its distributions are finalized.
+	require.True(t, settled(2, 3, finalized, plan))
+	require.False(t, settled(2, 3, unfinalized, nil))
+
+	// The latest Epoch is settled once its settlement plan is completed.
+	require.False(t, settled(2, 2, finalized, plan))
+
+	for _, batch := range plan.Batches {
+		batch.Status = schema.SettlementBatchStatusConfirmed
+	}
+
+	require.True(t, settled(2, 2, finalized, plan))
+
+	// The latest Epoch settled without a plan is settled once its distributions are finalized.
+	require.True(t, settled(2, 2, finalized, nil))
+	require.False(t, settled(2, 2, unfinalized, nil))
+	require.False(t, settled(2, 2, nil, nil))
+}
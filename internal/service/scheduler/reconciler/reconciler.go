@@ -0,0 +1,210 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/cache"
+	"github.com/rss3-network/global-indexer/internal/cronjob"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/service"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+var (
+	_ service.Server = (*server)(nil)
+	_ cronjob.Job    = (*server)(nil)
+)
+
+var Name = "reconciler"
+
+// maxEpochs caps the Epochs reconciled by a run, the rest are reconciled by the next runs.
+const maxEpochs = 100
+
+// server reconciles each settled Epoch once, comparing the batches submitted by the settler
+// with the distributions indexed from the chain, and saves a report of the mismatches found.
+type server struct {
+	cronJob        *cronjob.CronJob
+	databaseClient database.Client
+	metrics        *metrics
+}
+
+func (s *server) Name() string {
+	return Name
+}
+
+func (s *server) Spec() string {
+	return "0 */10 * * * *" // every 10 minutes
+}
+
+func (s *server) Run(ctx context.Context) error {
+	err := s.cronJob.AddFunc(ctx, s.Spec(), s.reconcileEpochs)
+	if err != nil {
+		return fmt.Errorf("add reconciler cron job: %w", err)
+	}
+
+	s.cronJob.Start()
+	defer s.cronJob.Stop()
+
+	stopchan := make(chan os.Signal, 1)
+
+	signal.Notify(stopchan, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
+	<-stopchan
+
+	return nil
+}
+
+func (s *server) RunOnce(ctx context.Context) error {
+	return s.cronJob.RunOnce(ctx, s.reconcileEpochs)
+}
+
+// reconcileEpochs reconciles the Epochs after the latest reconciled one in order, until an Epoch is not settled yet.
+// The first run starts from the latest Epoch.
+func (s *server) reconcileEpochs(ctx context.Context) error {
+	reconciliations, err := s.databaseClient.FindEpochReconciliations(ctx, schema.EpochReconciliationsQuery{Limit: lo.ToPtr(1)})
+	if err != nil {
+		return fmt.Errorf("find latest reconciliation: %w", err)
+	}
+
+	epochs, err := s.databaseClient.FindEpochs(ctx, &schema.FindEpochsQuery{Limit: lo.ToPtr(1)})
+	if err != nil {
+		return fmt.Errorf("find latest epoch: %w", err)
+	}
+
+	if len(epochs) == 0 {
+		return nil
+	}
+
+	// Without a report, the earlier Epochs are not reconciled, so that the history is not reported at once.
+	epochID, latestID := epochs[0].ID, epochs[0].ID
+
+	if len(reconciliations) > 0 {
+		epochID = reconciliations[0].EpochID + 1
+	}
+
+	for end := epochID + maxEpochs; epochID <= latestID && epochID < end; epochID++ {
+		reconciliation, err := s.reconcileEpoch(ctx, epochID, latestID)
+		if err != nil {
+			return fmt.Errorf("reconcile epoch %d: %w", epochID, err)
+		}
+
+		// The Epochs are reconciled in order, so that the latest report marks the progress.
+		if reconciliation == nil {
+			return nil
+		}
+
+		if err := s.databaseClient.SaveEpochReconciliation(ctx, reconciliation); err != nil {
+			return fmt.Errorf("save reconciliation of epoch %d: %w", epochID, err)
+		}
+
+		s.metrics.recordReconciliation(ctx, reconciliation)
+
+		if len(reconciliation.Mismatches) > 0 {
+			zap.L().Warn("epoch reconciliation mismatches", zap.Uint64("epoch", epochID), zap.Int("mismatches", len(reconciliation.Mismatches)))
+		}
+	}
+
+	return nil
+}
+
+// reconcileEpoch returns the reconciliation of the Epoch, or nil if the Epoch is not settled or its distributions are not finalized yet.
+func (s *server) reconcileEpoch(ctx context.Context, epochID, latestID uint64) (*schema.EpochReconciliation, error) {
+	distributions, err := s.databaseClient.FindEpochs(ctx, &schema.FindEpochsQuery{EpochID: lo.ToPtr(epochID)})
+	if err != nil {
+		return nil, fmt.Errorf("find distributions: %w", err)
+	}
+
+	plan, err := s.databaseClient.FindSettlementPlan(ctx, epochID)
+	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+		return nil, fmt.Errorf("find settlement plan: %w", err)
+	}
+
+	if !settled(epochID, latestID, distributions, plan) {
+		return nil, nil
+	}
+
+	triggers, err := s.databaseClient.FindEpochTriggers(ctx, epochID)
+	if err != nil {
+		return nil, fmt.Errorf("find epoch triggers: %w", err)
+	}
+
+	rewardedNodes, err := s.databaseClient.FindEpochRewardedNodes(ctx, epochID)
+	if err != nil {
+		return nil, fmt.Errorf("find rewarded nodes: %w", err)
+	}
+
+	stats := make(map[common.Address]*schema.Stat)
+
+	if len(rewardedNodes) > 0 {
+		result, err := s.databaseClient.FindNodeStats(ctx, &schema.StatQuery{
+			Addresses: lo.Uniq(lo.Map(rewardedNodes, func(rewardedNode *schema.RewardedNode, _ int) common.Address {
+				return rewardedNode.NodeAddress
+			})),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("find node stats: %w", err)
+		}
+
+		stats = lo.SliceToMap(result, func(stat *schema.Stat) (common.Address, *schema.Stat) {
+			return stat.Address, stat
+		})
+	}
+
+	mismatches := reconcile(epochID, newSubmissions(triggers, plan), distributions, rewardedNodes, plan, stats)
+
+	return &schema.EpochReconciliation{
+		EpochID:       epochID,
+		Distributions: len(distributions),
+		RewardedNodes: len(rewardedNodes),
+		MismatchCount: len(mismatches),
+		ReconciledAt:  time.Now().Unix(),
+		Mismatches:    mismatches,
+	}, nil
+}
+
+// settled returns whether the Epoch is settled and its distributions are finalized.
+// An Epoch before the latest indexed one is settled, the latest one is settled once its settlement plan is completed.
+// The latest Epoch settled without a plan, by a settler from before the settlement plans or by the settler tx command,
+// is settled once its indexed distributions are finalized, so a batch of it indexed later is not reconciled.
+func settled(epochID, latestID uint64, distributions []*schema.Epoch, plan *schema.SettlementPlan) bool {
+	if lo.ContainsBy(distributions, func(distribution *schema.Epoch) bool { return !distribution.Finalized }) {
+		return false
+	}
+
+	if epochID < latestID {
+		return true
+	}
+
+	if plan == nil {
+		return len(distributions) > 0
+	}
+
+	return plan.Completed()
+}
+
+func newServer(databaseClient database.Client, cronJob *cronjob.CronJob) (*server, error) {
+	s := &server{
+		cronJob:        cronJob,
+		databaseClient: databaseClient,
+	}
+
+	var err error
+
+	if s.metrics, err = newMetrics(); err != nil {
+		return nil, fmt.Errorf("new metrics: %w", err)
+	}
+
+	return s, nil
+}
+
+func New(databaseClient database.Client, cacheClient cache.Client) (service.Server, error) {
+	return newServer(databaseClient, cronjob.New(cacheClient, databaseClient, Name, 5*time.Minute))
+}
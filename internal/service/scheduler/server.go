@@ -2,37 +2,181 @@ package scheduler
 
 import (
 	"fmt"
+	"strings"
 
-	"github.com/redis/go-redis/v9"
 	"github.com/rss3-network/global-indexer/common/httputil"
+	"github.com/rss3-network/global-indexer/internal/cache"
 	"github.com/rss3-network/global-indexer/internal/client/ethereum"
 	"github.com/rss3-network/global-indexer/internal/config"
 	"github.com/rss3-network/global-indexer/internal/config/flag"
+	"github.com/rss3-network/global-indexer/internal/cronjob"
 	"github.com/rss3-network/global-indexer/internal/database"
 	"github.com/rss3-network/global-indexer/internal/service"
 	"github.com/rss3-network/global-indexer/internal/service/scheduler/detector"
 	"github.com/rss3-network/global-indexer/internal/service/scheduler/enforcer"
+	epochfresher "github.com/rss3-network/global-indexer/internal/service/scheduler/enforcer/epoch_fresher"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/exiter"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/notifier"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/prober"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/reconciler"
 	"github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot"
 	"github.com/rss3-network/global-indexer/internal/service/scheduler/taxer"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/verifier"
+	"github.com/samber/lo"
 	"github.com/spf13/viper"
+	"go.uber.org/zap"
 )
 
-// NewServer creates a new scheduler server that executes cron jobs.
-func NewServer(databaseClient database.Client, redis *redis.Client, ethereumMultiChainClient *ethereum.MultiChainClient, httpClient httputil.Client, config *config.File) (service.Server, error) {
+// ServerAll selects all the scheduler servers.
+const ServerAll = "all"
+
+// Servers returns the names of the scheduler servers selected by ServerAll.
+func Servers() []string {
+	return []string{
+		detector.Name,
+		prober.Name,
+		exiter.Name,
+		enforcer.Name,
+		snapshot.Name,
+		taxer.Name,
+		verifier.Name,
+		notifier.Name,
+		reconciler.Name,
+	}
+}
+
+// NewServer creates a new scheduler server that executes the cron jobs of the servers selected by the server flag,
+// which is a server name, a comma separated list of them, or all. The servers share the clients and run in one process,
+// except the jobs disabled by the config.
+func NewServer(databaseClient database.Client, cacheClient cache.Client, ethereumMultiChainClient *ethereum.MultiChainClient, httpClient httputil.Client, config *config.File) (service.Server, error) {
+	names, err := parseServers(viper.GetString(flag.KeyServer))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := configureJobs(config.Scheduler); err != nil {
+		return nil, fmt.Errorf("configure jobs: %w", err)
+	}
+
+	var servers []service.Server
+
+	for _, name := range names {
+		if config.Scheduler.Jobs[name].Disabled {
+			zap.L().Info("scheduler server disabled", zap.String("server", name))
+
+			continue
+		}
+
+		server, err := newServer(name, databaseClient, cacheClient, ethereumMultiChainClient, httpClient, config)
+		if err != nil {
+			return nil, fmt.Errorf("new %s server: %w", name, err)
+		}
+
+		for _, job := range flatten(server) {
+			if config.Scheduler.Jobs[job.Name()].Disabled {
+				zap.L().Info("scheduler job disabled", zap.String("job", job.Name()))
+
+				continue
+			}
+
+			servers = append(servers, job)
+		}
+	}
+
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("all jobs of %s are disabled", strings.Join(names, ", "))
+	}
+
+	name := names[0]
+	if len(names) > 1 {
+		name = "scheduler"
+	}
+
+	return newGroup(name, servers), nil
+}
+
+// parseServers returns the names of the servers selected by the value of the server flag.
+func parseServers(value string) ([]string, error) {
+	if value == ServerAll {
+		return Servers(), nil
+	}
+
+	names := lo.Uniq(lo.Compact(lo.Map(strings.Split(value, ","), func(name string, _ int) string {
+		return strings.TrimSpace(name)
+	})))
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no scheduler server selected, expected %s or some of %v", ServerAll, Servers())
+	}
+
+	for _, name := range names {
+		if !lo.Contains(Servers(), name) {
+			return nil, fmt.Errorf("unknown scheduler server: %s", name)
+		}
+	}
+
+	return names, nil
+}
+
+// configureJobs checks the names in the config and overrides the specs of the cron jobs.
+func configureJobs(config config.Scheduler) error {
+	for name, job := range config.Jobs {
+		if !lo.Contains(Servers(), name) && !lo.Contains(Jobs(), name) && name != epochfresher.Name {
+			return fmt.Errorf("unknown scheduler job: %s", name)
+		}
+
+		if job.Spec == "" {
+			continue
+		}
+
+		if !lo.Contains(Jobs(), name) {
+			return fmt.Errorf("%s is not a cron job, its spec cannot be overridden", name)
+		}
+
+		if err := cronjob.Override(name, job.Spec); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// flatten returns the servers of the jobs run by the server, which is the server itself unless it is composite.
+func flatten(server service.Server) []service.Server {
+	if composite, ok := server.(composite); ok {
+		return lo.FlatMap(composite.Servers(), func(server service.Server, _ int) []service.Server {
+			return flatten(server)
+		})
+	}
+
+	return []service.Server{server}
+}
+
+func newServer(server string, databaseClient database.Client, cacheClient cache.Client, ethereumMultiChainClient *ethereum.MultiChainClient, httpClient httputil.Client, config *config.File) (service.Server, error) {
 	ethereumClient, err := ethereumMultiChainClient.Get(viper.GetUint64(flag.KeyChainIDL2))
 	if err != nil {
 		return nil, fmt.Errorf("get ethereum client: %w", err)
 	}
 
-	switch server := viper.GetString(flag.KeyServer); server {
+	switch server {
 	case detector.Name:
-		return detector.New(databaseClient, redis)
+		return detector.New(databaseClient, cacheClient)
+	case prober.Name:
+		return prober.New(databaseClient, cacheClient, config)
+	case exiter.Name:
+		return exiter.New(databaseClient, cacheClient, config)
 	case enforcer.Name:
-		return enforcer.New(databaseClient, redis, ethereumClient, httpClient)
+		return enforcer.New(databaseClient, cacheClient, ethereumClient, httpClient)
 	case snapshot.Name:
-		return snapshot.New(databaseClient, redis, ethereumClient)
+		return snapshot.New(databaseClient, cacheClient, ethereumClient)
 	case taxer.Name:
-		return taxer.New(databaseClient, redis, ethereumClient, config)
+		return taxer.New(databaseClient, cacheClient, ethereumClient, config)
+	case verifier.Name:
+		return verifier.New(databaseClient, cacheClient, ethereumClient, httpClient)
+	case notifier.Name:
+		return notifier.New(databaseClient, cacheClient, config)
+	case reconciler.Name:
+		return reconciler.New(databaseClient, cacheClient)
 	default:
 		return nil, fmt.Errorf("unknown scheduler server: %s", server)
 	}
@@ -11,7 +11,6 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/redis/go-redis/v9"
 	stakingv2 "github.com/rss3-network/global-indexer/contract/l2/staking/v2"
 	"github.com/rss3-network/global-indexer/internal/cache"
 	"github.com/rss3-network/global-indexer/internal/cronjob"
@@ -29,7 +28,10 @@ var (
 	CacheKeyEpochAverageAPY = "epoch_average_apy"
 )
 
-var _ service.Server = (*server)(nil)
+var (
+	_ service.Server = (*server)(nil)
+	_ cronjob.Job    = (*server)(nil)
+)
 
 type server struct {
 	cronJob         *cronjob.CronJob
@@ -47,38 +49,7 @@ func (s *server) Spec() string {
 }
 
 func (s *server) Run(ctx context.Context) error {
-	err := s.cronJob.AddFunc(ctx, s.Spec(), func() {
-		// Query the latest of the epoch apy snapshots.
-		snapshots, err := s.databaseClient.FindEpochAPYSnapshots(ctx, schema.EpochAPYSnapshotQuery{Limit: lo.ToPtr(1)})
-		if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
-			zap.L().Error("find epoch APY snapshots", zap.Error(err))
-
-			return
-		}
-
-		// Query the latest epoch of the epoch events.
-		epochEvents, err := s.databaseClient.FindEpochs(ctx, &schema.FindEpochsQuery{Limit: lo.ToPtr(1)})
-		if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
-			zap.L().Error("find epochs", zap.Error(err))
-
-			return
-		}
-
-		var latestSnapshotEpochID uint64
-
-		if len(snapshots) > 0 {
-			latestSnapshotEpochID = snapshots[0].EpochID
-		}
-
-		// Save the minTokensToStake snapshots.
-		if latestSnapshotEpochID < epochEvents[0].ID {
-			if err := s.saveAPYToSnapshots(ctx, latestSnapshotEpochID, epochEvents[0]); err != nil {
-				zap.L().Error("save APY to snapshots", zap.Error(err))
-
-				return
-			}
-		}
-	})
+	err := s.cronJob.AddFunc(ctx, s.Spec(), s.snapshotEpochs)
 	if err != nil {
 		return fmt.Errorf("add apy cron job: %w", err)
 	}
@@ -94,80 +65,151 @@ func (s *server) Run(ctx context.Context) error {
 	return nil
 }
 
+func (s *server) RunOnce(ctx context.Context) error {
+	return s.cronJob.RunOnce(ctx, s.snapshotEpochs)
+}
+
+// snapshotEpochs saves the APY snapshots of the Epochs after the latest snapshot one.
+func (s *server) snapshotEpochs(ctx context.Context) error {
+	// Query the latest of the epoch apy snapshots.
+	snapshots, err := s.databaseClient.FindEpochAPYSnapshots(ctx, schema.EpochAPYSnapshotQuery{Limit: lo.ToPtr(1)})
+	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+		return fmt.Errorf("find epoch APY snapshots: %w", err)
+	}
+
+	// Query the latest epoch of the epoch events.
+	epochEvents, err := s.databaseClient.FindEpochs(ctx, &schema.FindEpochsQuery{Limit: lo.ToPtr(1)})
+	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+		return fmt.Errorf("find epochs: %w", err)
+	}
+
+	if len(epochEvents) == 0 {
+		return nil
+	}
+
+	var latestSnapshotEpochID uint64
+
+	if len(snapshots) > 0 {
+		latestSnapshotEpochID = snapshots[0].EpochID
+	}
+
+	// Save the minTokensToStake snapshots.
+	if latestSnapshotEpochID < epochEvents[0].ID {
+		if err := s.saveAPYToSnapshots(ctx, latestSnapshotEpochID, epochEvents[0]); err != nil {
+			return fmt.Errorf("save APY to snapshots: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func (s *server) saveAPYToSnapshots(ctx context.Context, latestEpochSnapshot uint64, latestEpochEvent *schema.Epoch) error {
 	for id := latestEpochSnapshot + 1; id <= latestEpochEvent.ID; id++ {
-		// Query the epoch transactions by the epoch id.
-		transactions, err := s.databaseClient.FindEpochTransactions(ctx, id, latestEpochEvent.TotalRewardedNodes, nil)
-		if err != nil {
-			return fmt.Errorf("find epoch transactions: %w", err)
+		if err := s.saveEpochAPYSnapshots(ctx, id); err != nil {
+			return err
 		}
+	}
 
-		if len(transactions) == 0 {
-			continue
-		}
+	return s.saveAverageAPY(ctx)
+}
+
+// Backfill recomputes the APY snapshots of the Epoch at the block heights of its distributions, and the average APY.
+func (s *server) Backfill(ctx context.Context, epochID uint64) error {
+	if err := s.saveEpochAPYSnapshots(ctx, epochID); err != nil {
+		return err
+	}
+
+	return s.saveAverageAPY(ctx)
+}
+
+// saveEpochAPYSnapshots saves the APY of each rewarded Node of the Epoch, and the average of them as the APY of the Epoch.
+func (s *server) saveEpochAPYSnapshots(ctx context.Context, id uint64) error {
+	// Query the epoch transactions by the epoch id.
+	transactions, err := s.databaseClient.FindEpochs(ctx, &schema.FindEpochsQuery{EpochID: lo.ToPtr(id)})
+	if err != nil {
+		return fmt.Errorf("find epoch transactions: %w", err)
+	}
+
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	rewardedNodes, err := s.databaseClient.FindEpochRewardedNodes(ctx, id)
+	if err != nil {
+		return fmt.Errorf("find epoch rewarded nodes: %w", err)
+	}
+
+	var (
+		nodeAPYSnapshots = make([]*schema.NodeAPYSnapshot, 0, len(rewardedNodes))
+		sum              = decimal.NewFromInt(0)
+	)
 
-		var (
-			nodeAPYSnapshots = make([]*schema.NodeAPYSnapshot, 0)
-			sum              = decimal.NewFromInt(0)
-		)
-
-		for _, transaction := range transactions {
-			for _, item := range transaction.RewardedNodes {
-				node, err := s.stakingContract.GetNode(&bind.CallOpts{BlockNumber: transaction.BlockNumber}, item.NodeAddress)
-				if err != nil {
-					zap.L().Error("get node from rpc", zap.Error(err), zap.String("nodeAddress", item.NodeAddress.String()), zap.Any("blockNumber", transaction.BlockNumber))
-
-					return fmt.Errorf("get node from rpc: %w", err)
-				}
-
-				// Calculate the APY.
-				// APY = (operationRewards + stakingRewards) / (stakingPoolTokens) * (1 - tax) * number of epochs in a year
-				// number of epochs in a year = 365 * 24 / 18 = 486.6666666666667
-				if node.StakingPoolTokens.Cmp(big.NewInt(0)) > 0 {
-					tax := 1 - float64(node.TaxRateBasisPoints)/10000
-
-					apy := item.OperationRewards.Add(item.StakingRewards).
-						Div(decimal.NewFromBigInt(node.StakingPoolTokens, 0)).
-						Mul(decimal.NewFromFloat(tax)).
-						Mul(decimal.NewFromFloat(486.6666666666667))
-
-					nodeAPYSnapshots = append(nodeAPYSnapshots, &schema.NodeAPYSnapshot{
-						Date:        time.Unix(transaction.EndTimestamp, 0),
-						EpochID:     id,
-						NodeAddress: item.NodeAddress,
-						APY:         apy,
-					})
-
-					sum = sum.Add(apy)
-				}
+	for _, transaction := range transactions {
+		for _, item := range rewardedNodes {
+			if item.TransactionHash != transaction.TransactionHash {
+				continue
+			}
+
+			node, err := s.stakingContract.GetNode(&bind.CallOpts{Context: ctx, BlockNumber: transaction.BlockNumber}, item.NodeAddress)
+			if err != nil {
+				zap.L().Error("get node from rpc", zap.Error(err), zap.String("nodeAddress", item.NodeAddress.String()), zap.Any("blockNumber", transaction.BlockNumber))
+
+				return fmt.Errorf("get node from rpc: %w", err)
+			}
+
+			// Calculate the APY.
+			// APY = (operationRewards + stakingRewards) / (stakingPoolTokens) * (1 - tax) * number of epochs in a year
+			// number of epochs in a year = 365 * 24 / 18 = 486.6666666666667
+			if node.StakingPoolTokens.Cmp(big.NewInt(0)) > 0 {
+				tax := 1 - float64(node.TaxRateBasisPoints)/10000
+
+				apy := item.OperationRewards.Add(item.StakingRewards).
+					Div(decimal.NewFromBigInt(node.StakingPoolTokens, 0)).
+					Mul(decimal.NewFromFloat(tax)).
+					Mul(decimal.NewFromFloat(486.6666666666667))
+
+				nodeAPYSnapshots = append(nodeAPYSnapshots, &schema.NodeAPYSnapshot{
+					Date:        time.Unix(transaction.EndTimestamp, 0),
+					EpochID:     id,
+					NodeAddress: item.NodeAddress,
+					APY:         apy,
+				})
+
+				sum = sum.Add(apy)
 			}
 		}
+	}
 
-		zap.L().Info("save APY to snapshots", zap.Uint64("epochID", id), zap.Int("nodeAPYSnapshots", len(nodeAPYSnapshots)))
+	zap.L().Info("save APY to snapshots", zap.Uint64("epochID", id), zap.Int("nodeAPYSnapshots", len(nodeAPYSnapshots)))
 
-		// Save the node APY snapshots.
+	// Save the node APY snapshots.
+	if len(nodeAPYSnapshots) > 0 {
 		if err := s.databaseClient.SaveNodeAPYSnapshots(ctx, nodeAPYSnapshots); err != nil {
 			return fmt.Errorf("save node APY snapshots: %w", err)
 		}
+	}
 
-		var apy decimal.Decimal
-		if len(nodeAPYSnapshots) > 0 {
-			apy = sum.Div(decimal.NewFromInt(int64(len(nodeAPYSnapshots))))
-		}
+	var apy decimal.Decimal
+	if len(nodeAPYSnapshots) > 0 {
+		apy = sum.Div(decimal.NewFromInt(int64(len(nodeAPYSnapshots))))
+	}
 
-		epochAPYSnapshot := schema.EpochAPYSnapshot{
-			Date:    time.Unix(transactions[0].EndTimestamp, 0),
-			EpochID: id,
-			APY:     apy,
-		}
+	epochAPYSnapshot := schema.EpochAPYSnapshot{
+		Date:    time.Unix(transactions[0].EndTimestamp, 0),
+		EpochID: id,
+		APY:     apy,
+	}
 
-		// Save the epoch APY snapshot.
-		if err := s.databaseClient.SaveEpochAPYSnapshot(ctx, &epochAPYSnapshot); err != nil {
-			return fmt.Errorf("save epoch APY snapshot: %w", err)
-		}
+	// Save the epoch APY snapshot.
+	if err := s.databaseClient.SaveEpochAPYSnapshot(ctx, &epochAPYSnapshot); err != nil {
+		return fmt.Errorf("save epoch APY snapshot: %w", err)
 	}
 
-	// Save the epoch average APY to cache.
+	return nil
+}
+
+// saveAverageAPY saves the average APY of the Epochs to cache.
+func (s *server) saveAverageAPY(ctx context.Context) error {
 	apy, err := s.databaseClient.FindEpochAPYSnapshotsAverage(ctx)
 	if err != nil {
 		return fmt.Errorf("find epoch APY snapshots average: %w", err)
@@ -180,10 +222,10 @@ func (s *server) saveAPYToSnapshots(ctx context.Context, latestEpochSnapshot uin
 	return nil
 }
 
-func New(databaseClient database.Client, redisClient *redis.Client, stakingContract *stakingv2.Staking) service.Server {
+func New(databaseClient database.Client, cacheClient cache.Client, stakingContract *stakingv2.Staking) service.Server {
 	return &server{
-		cronJob:         cronjob.New(redisClient, Name, Timeout),
-		cacheClient:     cache.New(redisClient),
+		cronJob:         cronjob.New(cacheClient, databaseClient, Name, Timeout),
+		cacheClient:     cacheClient,
 		databaseClient:  databaseClient,
 		stakingContract: stakingContract,
 	}
@@ -0,0 +1,99 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rss3-network/global-indexer/internal/cache"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/service"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot/apy"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot/leaderboard"
+	nodecount "github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot/node_count"
+	operatorprofit "github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot/operator_profit"
+	stakercount "github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot/staker_count"
+	stakerprofit "github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot/staker_profit"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// Backfiller recomputes the snapshots of an Epoch at the block height of its distribution,
+// the contract calls are made at the historical block, which requires an archive node.
+// The snapshots are upserted, so that an Epoch can be backfilled any number of times.
+type Backfiller interface {
+	Name() string
+	Backfill(ctx context.Context, epochID uint64) error
+}
+
+// Kinds returns the kinds of the snapshots that can be backfilled.
+func Kinds() []string {
+	return []string{
+		nodecount.Name,
+		stakercount.Name,
+		stakerprofit.Name,
+		operatorprofit.Name,
+		apy.Name,
+		leaderboard.Name,
+	}
+}
+
+// NewBackfiller returns the Backfiller of the kind of snapshots, which is the name of its scheduler.
+func NewBackfiller(kind string, databaseClient database.Client, cacheClient cache.Client, ethereumClient *ethclient.Client) (Backfiller, error) {
+	snapshots, err := newSnapshots(databaseClient, cacheClient, ethereumClient)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, found := lo.Find(snapshots, func(snapshot service.Server) bool {
+		return snapshot.Name() == kind
+	})
+	if !found {
+		return nil, fmt.Errorf("unknown snapshot kind %s, expected one of %v", kind, Kinds())
+	}
+
+	backfiller, ok := snapshot.(Backfiller)
+	if !ok {
+		return nil, fmt.Errorf("snapshot %s does not support backfill", kind)
+	}
+
+	return backfiller, nil
+}
+
+// Backfill recomputes the snapshots of the Epochs from fromEpoch to toEpoch inclusive in order,
+// toEpoch defaults to the latest indexed Epoch if it is zero. It stops at the first failed Epoch,
+// which is safe to backfill again.
+func Backfill(ctx context.Context, databaseClient database.Client, backfiller Backfiller, fromEpoch, toEpoch uint64) error {
+	if toEpoch == 0 {
+		epochs, err := databaseClient.FindEpochs(ctx, &schema.FindEpochsQuery{Limit: lo.ToPtr(1)})
+		if err != nil {
+			return fmt.Errorf("find latest epoch: %w", err)
+		}
+
+		if len(epochs) == 0 {
+			return errors.New("no epoch has been indexed")
+		}
+
+		toEpoch = epochs[0].ID
+	}
+
+	if fromEpoch == 0 || fromEpoch > toEpoch {
+		return fmt.Errorf("invalid epoch range %d to %d", fromEpoch, toEpoch)
+	}
+
+	for epochID := fromEpoch; epochID <= toEpoch; epochID++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := backfiller.Backfill(ctx, epochID); err != nil {
+			return fmt.Errorf("backfill %s snapshots of epoch %d: %w", backfiller.Name(), epochID, err)
+		}
+
+		zap.L().Info("backfilled snapshots", zap.String("kind", backfiller.Name()), zap.Uint64("epoch", epochID))
+	}
+
+	return nil
+}
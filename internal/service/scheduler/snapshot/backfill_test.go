@@ -0,0 +1,61 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/stretchr/testify/require"
+)
+
+type databaseClient struct {
+	database.Client
+}
+
+func (c *databaseClient) FindEpochs(_ context.Context, _ *schema.FindEpochsQuery) ([]*schema.Epoch, error) {
+	return []*schema.Epoch{{ID: 5}}, nil
+}
+
+type backfiller struct {
+	epochs []uint64
+	failed uint64
+}
+
+func (b *backfiller) Name() string {
+	return "test"
+}
+
+func (b *backfiller) Backfill(_ context.Context, epochID uint64) error {
+	if epochID == b.failed {
+		return errors.New("rpc error")
+	}
+
+	b.epochs = append(b.epochs, epochID)
+
+	return nil
+}
+
+func TestBackfill(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// The range ends at the latest indexed Epoch by default.
+	b := &backfiller{}
+	require.NoError(t, Backfill(ctx, &databaseClient{}, b, 3, 0))
+	require.Equal(t, []uint64{3, 4, 5}, b.epochs)
+
+	b = &backfiller{}
+	require.NoError(t, Backfill(ctx, &databaseClient{}, b, 1, 2))
+	require.Equal(t, []uint64{1, 2}, b.epochs)
+
+	// The backfill stops at the first failed Epoch.
+	b = &backfiller{failed: 2}
+	require.ErrorContains(t, Backfill(ctx, &databaseClient{}, b, 1, 3), "backfill test snapshots of epoch 2")
+	require.Equal(t, []uint64{1}, b.epochs)
+
+	require.Error(t, Backfill(ctx, &databaseClient{}, &backfiller{}, 0, 3))
+	require.Error(t, Backfill(ctx, &databaseClient{}, &backfiller{}, 4, 3))
+}
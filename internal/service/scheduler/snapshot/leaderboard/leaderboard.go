@@ -0,0 +1,488 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/common/ethereum"
+	stakingv2 "github.com/rss3-network/global-indexer/contract/l2/staking/v2"
+	"github.com/rss3-network/global-indexer/internal/cache"
+	"github.com/rss3-network/global-indexer/internal/cronjob"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/service"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"github.com/sourcegraph/conc/pool"
+	"go.uber.org/zap"
+)
+
+var (
+	Name    = "leaderboard"
+	Timeout = 10 * time.Minute
+)
+
+var (
+	_ service.Server = (*server)(nil)
+	_ cronjob.Job    = (*server)(nil)
+)
+
+// server ranks the Nodes on the leaderboards of every metric and window once an Epoch is distributed.
+// An Epoch is ranked after its APY snapshots are saved, so that all the metrics of the Epoch are available.
+type server struct {
+	cronJob         *cronjob.CronJob
+	databaseClient  database.Client
+	stakingContract *stakingv2.Staking
+}
+
+func (s *server) Name() string {
+	return Name
+}
+
+func (s *server) Spec() string {
+	return "0 */5 * * * *" // every 5 minutes
+}
+
+func (s *server) Run(ctx context.Context) error {
+	err := s.cronJob.AddFunc(ctx, s.Spec(), s.rankEpochs)
+	if err != nil {
+		return fmt.Errorf("add leaderboard cron job: %w", err)
+	}
+
+	s.cronJob.Start()
+	defer s.cronJob.Stop()
+
+	stopchan := make(chan os.Signal, 1)
+
+	signal.Notify(stopchan, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
+	<-stopchan
+
+	return nil
+}
+
+func (s *server) RunOnce(ctx context.Context) error {
+	return s.cronJob.RunOnce(ctx, s.rankEpochs)
+}
+
+// rankEpochs ranks the Epochs after the latest ranked one in order, the first run only ranks the latest Epoch,
+// the earlier Epochs can be ranked by the snapshot backfill command.
+func (s *server) rankEpochs(ctx context.Context) error {
+	epochs, err := s.databaseClient.FindEpochs(ctx, &schema.FindEpochsQuery{Limit: lo.ToPtr(1)})
+	if err != nil {
+		return fmt.Errorf("find latest epoch: %w", err)
+	}
+
+	if len(epochs) == 0 {
+		return nil
+	}
+
+	latestRanks, err := s.databaseClient.FindNodeRanks(ctx, schema.NodeRanksQuery{Limit: lo.ToPtr(1)})
+	if err != nil {
+		return fmt.Errorf("find latest node ranks: %w", err)
+	}
+
+	fromEpochID := epochs[0].ID
+
+	if len(latestRanks) > 0 {
+		fromEpochID = latestRanks[0].EpochID + 1
+	}
+
+	for epochID := fromEpochID; epochID <= epochs[0].ID; epochID++ {
+		snapshots, err := s.databaseClient.FindEpochAPYSnapshots(ctx, schema.EpochAPYSnapshotQuery{EpochID: lo.ToPtr(epochID)})
+		if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+			return fmt.Errorf("find epoch APY snapshots: %w", err)
+		}
+
+		if len(snapshots) == 0 {
+			return nil
+		}
+
+		if err := s.rankEpoch(ctx, epochID); err != nil {
+			return fmt.Errorf("rank epoch %d: %w", epochID, err)
+		}
+	}
+
+	return nil
+}
+
+// Backfill ranks the Nodes of the Epoch again, the staking pools and the staker counts are queried at the block height of its distribution.
+func (s *server) Backfill(ctx context.Context, epochID uint64) error {
+	return s.rankEpoch(ctx, epochID)
+}
+
+// rankEpoch ranks the Nodes registered by the end of the Epoch on the leaderboards of every metric and window.
+func (s *server) rankEpoch(ctx context.Context, epochID uint64) error {
+	epoch, err := s.findEpoch(ctx, epochID)
+	if err != nil {
+		return err
+	}
+
+	if epoch == nil {
+		return nil
+	}
+
+	nodes, err := s.databaseClient.FindNodes(ctx, schema.FindNodesQuery{})
+	if err != nil {
+		return fmt.Errorf("find nodes: %w", err)
+	}
+
+	stakes, err := s.findStakingPools(ctx, nodes, epoch.blockNumber)
+	if err != nil {
+		return err
+	}
+
+	// The Nodes not registered by the end of the Epoch are not ranked.
+	nodes = lo.Filter(nodes, func(node *schema.Node, _ int) bool {
+		_, exists := stakes[node.Address]
+
+		return exists
+	})
+
+	addresses := lo.Map(nodes, func(node *schema.Node, _ int) common.Address {
+		return node.Address
+	})
+
+	windows, err := s.newWindows(ctx, epoch)
+	if err != nil {
+		return err
+	}
+
+	metrics, err := s.collectMetrics(ctx, epoch, nodes, windows)
+	if err != nil {
+		return err
+	}
+
+	previousRanks, err := s.findPreviousRanks(ctx, epochID)
+	if err != nil {
+		return err
+	}
+
+	ranks := make([]*schema.NodeRank, 0, len(schema.LeaderboardWindows)*len(schema.LeaderboardMetricValues())*len(nodes))
+
+	for _, window := range windows {
+		rewardedNodes := metrics.rewardedNodes(window.epochIDs)
+
+		stakingGrowths, err := s.findStakingGrowths(ctx, stakes, epoch, window, rewardedNodes)
+		if err != nil {
+			return err
+		}
+
+		values := map[schema.LeaderboardMetric]map[common.Address]decimal.Decimal{
+			schema.LeaderboardMetricRewards:             sumRewards(addresses, rewardedNodes),
+			schema.LeaderboardMetricAPY:                 average(addresses, metrics.apySamples(window.epochIDs)),
+			schema.LeaderboardMetricReliabilityScore:    average(addresses, metrics.scoreSamples(window.epochIDs)),
+			schema.LeaderboardMetricStakingGrowth:       stakingGrowths,
+			schema.LeaderboardMetricUptime:              metrics.uptimes(nodes, window.start, epoch.endTimestamp),
+			schema.LeaderboardMetricInvalidResponseRate: invalidResponseRates(addresses, rewardedNodes, metrics.invalidResponseCounts[window.epochs]),
+			schema.LeaderboardMetricStakerCount:         metrics.stakerCounts,
+		}
+
+		for _, metric := range schema.LeaderboardMetricValues() {
+			key := rankKey{metric: metric, epochs: window.epochs}
+
+			ranks = append(ranks, rank(metric, window.epochs, epochID, values[metric], stakes, previousRanks[key])...)
+		}
+	}
+
+	if err := s.databaseClient.SaveNodeRanks(ctx, epochID, ranks); err != nil {
+		return fmt.Errorf("save node ranks: %w", err)
+	}
+
+	zap.L().Info("rank nodes", zap.Uint64("epoch", epochID), zap.Int("nodes", len(nodes)), zap.Int("ranks", len(ranks)))
+
+	return nil
+}
+
+// epoch is the distribution of an Epoch, an Epoch may be distributed by more than one transaction.
+type epoch struct {
+	id             uint64
+	startTimestamp int64
+	endTimestamp   int64
+	// blockNumber is the block of the last distribution of the Epoch.
+	blockNumber *big.Int
+}
+
+// findEpoch returns the distribution of the Epoch, nil if it has not been indexed.
+func (s *server) findEpoch(ctx context.Context, epochID uint64) (*epoch, error) {
+	transactions, err := s.databaseClient.FindEpochs(ctx, &schema.FindEpochsQuery{EpochID: lo.ToPtr(epochID)})
+	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+		return nil, fmt.Errorf("find epoch %d: %w", epochID, err)
+	}
+
+	if len(transactions) == 0 {
+		return nil, nil
+	}
+
+	transaction := lo.MaxBy(transactions, func(a, b *schema.Epoch) bool {
+		return a.BlockNumber.Cmp(b.BlockNumber) > 0
+	})
+
+	return &epoch{
+		id:             epochID,
+		startTimestamp: transaction.StartTimestamp,
+		endTimestamp:   transaction.EndTimestamp,
+		blockNumber:    transaction.BlockNumber,
+	}, nil
+}
+
+// window is the range of the recent Epochs a leaderboard is aggregated over.
+type window struct {
+	epochs   int
+	epochIDs []uint64
+	// start is the start of the first Epoch of the window.
+	start int64
+	// base is the Epoch before the window, the staking growth is measured from its distribution, nil if it has not been indexed.
+	base *epoch
+}
+
+func (s *server) newWindows(ctx context.Context, last *epoch) ([]*window, error) {
+	windows := make([]*window, 0, len(schema.LeaderboardWindows))
+
+	for _, epochs := range schema.LeaderboardWindows {
+		w := window{
+			epochs: epochs,
+			// The start is estimated by the duration of the last Epoch if the first Epoch has not been indexed.
+			start: last.endTimestamp - int64(epochs)*(last.endTimestamp-last.startTimestamp),
+		}
+
+		for epochID := last.id; epochID > 0 && len(w.epochIDs) < epochs; epochID-- {
+			w.epochIDs = append(w.epochIDs, epochID)
+		}
+
+		first, err := s.findEpoch(ctx, lo.Min(w.epochIDs))
+		if err != nil {
+			return nil, err
+		}
+
+		if first != nil {
+			w.start = first.startTimestamp
+		}
+
+		if last.id > uint64(epochs) {
+			if w.base, err = s.findEpoch(ctx, last.id-uint64(epochs)); err != nil {
+				return nil, err
+			}
+		}
+
+		windows = append(windows, &w)
+	}
+
+	return windows, nil
+}
+
+// metrics are the values of the Nodes in the Epochs of the largest window.
+type metrics struct {
+	rewardedNodesByEpoch map[uint64][]*schema.RewardedNode
+	apySnapshots         []*schema.NodeAPYSnapshot
+	scores               []*schema.NodeScore
+	// invalidResponseCounts are the counts of each window.
+	invalidResponseCounts map[int]map[common.Address]int64
+	transitions           map[common.Address][]*schema.NodeStatusTransition
+	stakerCounts          map[common.Address]decimal.Decimal
+}
+
+func (s *server) collectMetrics(ctx context.Context, last *epoch, nodes []*schema.Node, windows []*window) (*metrics, error) {
+	largest := lo.MaxBy(windows, func(a, b *window) bool {
+		return a.epochs > b.epochs
+	})
+
+	m := metrics{
+		rewardedNodesByEpoch:  make(map[uint64][]*schema.RewardedNode, len(largest.epochIDs)),
+		invalidResponseCounts: make(map[int]map[common.Address]int64, len(windows)),
+	}
+
+	for _, epochID := range largest.epochIDs {
+		rewardedNodes, err := s.databaseClient.FindEpochRewardedNodes(ctx, epochID)
+		if err != nil {
+			return nil, fmt.Errorf("find rewarded nodes of epoch %d: %w", epochID, err)
+		}
+
+		m.rewardedNodesByEpoch[epochID] = rewardedNodes
+	}
+
+	var err error
+
+	if m.apySnapshots, err = s.databaseClient.FindNodeAPYSnapshots(ctx, schema.NodeAPYSnapshotQuery{EpochIDs: largest.epochIDs}); err != nil {
+		return nil, fmt.Errorf("find node APY snapshots: %w", err)
+	}
+
+	if m.scores, err = s.databaseClient.FindNodeScores(ctx, schema.NodeScoresQuery{EpochIDs: largest.epochIDs}); err != nil {
+		return nil, fmt.Errorf("find node scores: %w", err)
+	}
+
+	for _, window := range windows {
+		if m.invalidResponseCounts[window.epochs], err = s.databaseClient.FindNodeInvalidResponseCounts(ctx, window.epochIDs); err != nil {
+			return nil, fmt.Errorf("find node invalid response counts: %w", err)
+		}
+	}
+
+	transitions, err := s.databaseClient.FindNodeStatusTransitions(ctx, schema.NodeStatusTransitionsQuery{
+		NodeAddresses: lo.Map(nodes, func(node *schema.Node, _ int) common.Address {
+			return node.Address
+		}),
+		Until: lo.ToPtr(last.endTimestamp),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find node status transitions: %w", err)
+	}
+
+	sort.SliceStable(transitions, func(i, j int) bool {
+		return transitions[i].ID < transitions[j].ID
+	})
+
+	m.transitions = lo.GroupBy(transitions, func(transition *schema.NodeStatusTransition) common.Address {
+		return transition.NodeAddress
+	})
+
+	if m.stakerCounts, err = s.findStakerCounts(ctx, nodes, last.blockNumber); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (m *metrics) rewardedNodes(epochIDs []uint64) []*schema.RewardedNode {
+	return lo.FlatMap(epochIDs, func(epochID uint64, _ int) []*schema.RewardedNode {
+		return m.rewardedNodesByEpoch[epochID]
+	})
+}
+
+func (m *metrics) apySamples(epochIDs []uint64) []sample {
+	return lo.FilterMap(m.apySnapshots, func(snapshot *schema.NodeAPYSnapshot, _ int) (sample, bool) {
+		return sample{nodeAddress: snapshot.NodeAddress, value: snapshot.APY}, lo.Contains(epochIDs, snapshot.EpochID)
+	})
+}
+
+func (m *metrics) scoreSamples(epochIDs []uint64) []sample {
+	return lo.FilterMap(m.scores, func(score *schema.NodeScore, _ int) (sample, bool) {
+		return sample{nodeAddress: score.NodeAddress, value: decimal.NewFromFloat(score.Score)}, lo.Contains(epochIDs, score.EpochID)
+	})
+}
+
+func (m *metrics) uptimes(nodes []*schema.Node, start, end int64) map[common.Address]decimal.Decimal {
+	return lo.SliceToMap(nodes, func(node *schema.Node) (common.Address, decimal.Decimal) {
+		return node.Address, uptime(node.Status, m.transitions[node.Address], start, end)
+	})
+}
+
+// findStakingPools returns the staking pool tokens of the Nodes registered at the block.
+func (s *server) findStakingPools(ctx context.Context, nodes []*schema.Node, blockNumber *big.Int) (map[common.Address]decimal.Decimal, error) {
+	var (
+		mutex        sync.Mutex
+		errorPool    = pool.New().WithContext(ctx).WithMaxGoroutines(30).WithCancelOnError().WithFirstError()
+		stakingPools = make(map[common.Address]decimal.Decimal, len(nodes))
+	)
+
+	for _, node := range nodes {
+		node := node
+
+		errorPool.Go(func(ctx context.Context) error {
+			nodeInfo, err := s.stakingContract.GetNode(&bind.CallOpts{Context: ctx, BlockNumber: blockNumber}, node.Address)
+			if err != nil {
+				return fmt.Errorf("get node %s from rpc: %w", node.Address, err)
+			}
+
+			if nodeInfo.Account == ethereum.AddressGenesis {
+				return nil
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			stakingPools[node.Address] = decimal.NewFromBigInt(nodeInfo.StakingPoolTokens, 0)
+
+			return nil
+		})
+	}
+
+	if err := errorPool.Wait(); err != nil {
+		return nil, err
+	}
+
+	return stakingPools, nil
+}
+
+// findStakingGrowths returns the growth of the staking pool tokens of the Nodes since the base Epoch, which is the values
+// staked minus the values unstaked after the base Epoch plus the staking rewards of the window. The slashed tokens are not indexed,
+// so they are not deducted. The staking pools are empty before the first indexed Epoch.
+func (s *server) findStakingGrowths(ctx context.Context, stakes map[common.Address]decimal.Decimal, last *epoch, window *window, rewardedNodes []*schema.RewardedNode) (map[common.Address]decimal.Decimal, error) {
+	if window.base == nil {
+		return stakes, nil
+	}
+
+	stakingGrowths, err := s.databaseClient.FindNodeStakedValues(ctx, window.base.blockNumber, last.blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("find node staked values since epoch %d: %w", window.base.id, err)
+	}
+
+	for _, rewardedNode := range rewardedNodes {
+		stakingGrowths[rewardedNode.NodeAddress] = stakingGrowths[rewardedNode.NodeAddress].Add(rewardedNode.StakingRewards)
+	}
+
+	return lo.MapValues(stakes, func(_ decimal.Decimal, address common.Address) decimal.Decimal {
+		return stakingGrowths[address]
+	}), nil
+}
+
+// findStakerCounts returns the number of the stakers of the Nodes at the block.
+func (s *server) findStakerCounts(ctx context.Context, nodes []*schema.Node, blockNumber *big.Int) (map[common.Address]decimal.Decimal, error) {
+	stakerCounts := make(map[common.Address]decimal.Decimal, len(nodes))
+
+	for _, node := range nodes {
+		count, err := s.databaseClient.FindStakerCount(ctx, schema.StakeChipsQuery{
+			Node:        lo.ToPtr(node.Address),
+			BlockNumber: blockNumber,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("find staker count of node %s: %w", node.Address, err)
+		}
+
+		stakerCounts[node.Address] = decimal.NewFromInt(count)
+	}
+
+	return stakerCounts, nil
+}
+
+// findPreviousRanks returns the ranks of the Nodes of the previous Epoch on each leaderboard.
+func (s *server) findPreviousRanks(ctx context.Context, epochID uint64) (map[rankKey]map[common.Address]int, error) {
+	previousRanks := make(map[rankKey]map[common.Address]int)
+
+	if epochID <= 1 {
+		return previousRanks, nil
+	}
+
+	ranks, err := s.databaseClient.FindNodeRanks(ctx, schema.NodeRanksQuery{EpochID: lo.ToPtr(epochID - 1)})
+	if err != nil {
+		return nil, fmt.Errorf("find node ranks of epoch %d: %w", epochID-1, err)
+	}
+
+	for _, nodeRank := range ranks {
+		key := rankKey{metric: nodeRank.Metric, epochs: nodeRank.Epochs}
+
+		if previousRanks[key] == nil {
+			previousRanks[key] = make(map[common.Address]int)
+		}
+
+		previousRanks[key][nodeRank.NodeAddress] = nodeRank.Rank
+	}
+
+	return previousRanks, nil
+}
+
+func New(databaseClient database.Client, cacheClient cache.Client, stakingContract *stakingv2.Staking) service.Server {
+	return &server{
+		cronJob:         cronjob.New(cacheClient, databaseClient, Name, Timeout),
+		databaseClient:  databaseClient,
+		stakingContract: stakingContract,
+	}
+}
@@ -0,0 +1,170 @@
+package leaderboard
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/shopspring/decimal"
+)
+
+// rankKey identifies the leaderboard of a metric over a window.
+type rankKey struct {
+	metric schema.LeaderboardMetric
+	epochs int
+}
+
+// rank ranks the Nodes by their values of the metric, a tie is broken by the staking pool tokens of the Nodes,
+// then by their addresses, so that every Node has a distinct rank.
+func rank(metric schema.LeaderboardMetric, epochs int, epochID uint64, values map[common.Address]decimal.Decimal, stakes map[common.Address]decimal.Decimal, previousRanks map[common.Address]int) []*schema.NodeRank {
+	ranks := make([]*schema.NodeRank, 0, len(values))
+
+	for address, value := range values {
+		ranks = append(ranks, &schema.NodeRank{
+			Metric:      metric,
+			Epochs:      epochs,
+			EpochID:     epochID,
+			NodeAddress: address,
+			Value:       value,
+		})
+	}
+
+	sort.Slice(ranks, func(i, j int) bool {
+		if comparison := ranks[i].Value.Cmp(ranks[j].Value); comparison != 0 {
+			return (comparison < 0) == metric.Ascending()
+		}
+
+		if comparison := stakes[ranks[i].NodeAddress].Cmp(stakes[ranks[j].NodeAddress]); comparison != 0 {
+			return comparison > 0
+		}
+
+		return bytes.Compare(ranks[i].NodeAddress.Bytes(), ranks[j].NodeAddress.Bytes()) < 0
+	})
+
+	for index, nodeRank := range ranks {
+		nodeRank.Rank = index + 1
+
+		if previousRank, exists := previousRanks[nodeRank.NodeAddress]; exists {
+			previousRank := previousRank
+			nodeRank.PreviousRank = &previousRank
+		}
+	}
+
+	return ranks
+}
+
+// sumRewards returns the sum of the operation and the staking rewards of each Node.
+func sumRewards(nodes []common.Address, rewardedNodes []*schema.RewardedNode) map[common.Address]decimal.Decimal {
+	values := make(map[common.Address]decimal.Decimal, len(nodes))
+
+	for _, node := range nodes {
+		values[node] = decimal.Zero
+	}
+
+	for _, rewardedNode := range rewardedNodes {
+		if value, exists := values[rewardedNode.NodeAddress]; exists {
+			values[rewardedNode.NodeAddress] = value.Add(rewardedNode.OperationRewards).Add(rewardedNode.StakingRewards)
+		}
+	}
+
+	return values
+}
+
+// sample is a value of a Node in an Epoch.
+type sample struct {
+	nodeAddress common.Address
+	value       decimal.Decimal
+}
+
+// average returns the average of the samples of each Node, the Nodes without samples are not ranked.
+func average(nodes []common.Address, samples []sample) map[common.Address]decimal.Decimal {
+	var (
+		sums   = make(map[common.Address]decimal.Decimal, len(nodes))
+		counts = make(map[common.Address]int64, len(nodes))
+	)
+
+	for _, node := range nodes {
+		sums[node] = decimal.Zero
+	}
+
+	for _, sample := range samples {
+		if sum, exists := sums[sample.nodeAddress]; exists {
+			sums[sample.nodeAddress] = sum.Add(sample.value)
+			counts[sample.nodeAddress]++
+		}
+	}
+
+	values := make(map[common.Address]decimal.Decimal, len(counts))
+
+	for node, count := range counts {
+		values[node] = sums[node].Div(decimal.NewFromInt(count))
+	}
+
+	return values
+}
+
+// invalidResponseRates returns the number of the invalid responses per request served of each Node,
+// the Nodes that served no request are not ranked.
+func invalidResponseRates(nodes []common.Address, rewardedNodes []*schema.RewardedNode, invalidResponseCounts map[common.Address]int64) map[common.Address]decimal.Decimal {
+	requestCounts := sumRequestCounts(rewardedNodes)
+	values := make(map[common.Address]decimal.Decimal, len(nodes))
+
+	for _, node := range nodes {
+		requestCount := requestCounts[node]
+
+		if !requestCount.IsPositive() {
+			continue
+		}
+
+		values[node] = decimal.NewFromInt(invalidResponseCounts[node]).Div(requestCount)
+	}
+
+	return values
+}
+
+func sumRequestCounts(rewardedNodes []*schema.RewardedNode) map[common.Address]decimal.Decimal {
+	requestCounts := make(map[common.Address]decimal.Decimal)
+
+	for _, rewardedNode := range rewardedNodes {
+		requestCounts[rewardedNode.NodeAddress] = requestCounts[rewardedNode.NodeAddress].Add(rewardedNode.RequestCount)
+	}
+
+	return requestCounts
+}
+
+// uptime returns the ratio of the time the Node was online from the start to the end,
+// the transitions of the Node must be ordered by time and include the ones before the start.
+// The status before the first transition is the from status of it, or the current status without any transition.
+func uptime(status schema.NodeStatus, transitions []*schema.NodeStatusTransition, start, end int64) decimal.Decimal {
+	if end <= start {
+		return decimal.Zero
+	}
+
+	if len(transitions) > 0 {
+		status = transitions[0].From
+	}
+
+	var (
+		online int64
+		since  = start
+	)
+
+	for _, transition := range transitions {
+		if transition.Timestamp > end {
+			break
+		}
+
+		if transition.Timestamp > start && status == schema.NodeStatusOnline {
+			online += transition.Timestamp - since
+		}
+
+		status, since = transition.To, max(transition.Timestamp, start)
+	}
+
+	if status == schema.NodeStatusOnline {
+		online += end - since
+	}
+
+	return decimal.NewFromInt(online).Div(decimal.NewFromInt(end - start))
+}
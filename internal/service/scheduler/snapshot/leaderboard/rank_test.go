@@ -0,0 +1,111 @@
+package leaderboard
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRank(t *testing.T) {
+	t.Parallel()
+
+	var (
+		nodeA = common.HexToAddress("0x0a")
+		nodeB = common.HexToAddress("0x0b")
+		nodeC = common.HexToAddress("0x0c")
+		nodeD = common.HexToAddress("0x0d")
+	)
+
+	values := map[common.Address]decimal.Decimal{
+		nodeA: decimal.NewFromInt(10),
+		nodeB: decimal.NewFromInt(20),
+		nodeC: decimal.NewFromInt(10),
+		nodeD: decimal.NewFromInt(10),
+	}
+
+	// The tie of A, C and D is broken by the staking pools, then by the addresses.
+	stakes := map[common.Address]decimal.Decimal{
+		nodeC: decimal.NewFromInt(100),
+	}
+
+	ranks := rank(schema.LeaderboardMetricRewards, 7, 10, values, stakes, map[common.Address]int{nodeA: 1, nodeB: 3})
+	require.Len(t, ranks, 4)
+
+	assert.Equal(t, []common.Address{nodeB, nodeC, nodeA, nodeD}, lo.Map(ranks, func(nodeRank *schema.NodeRank, _ int) common.Address {
+		return nodeRank.NodeAddress
+	}))
+	assert.Equal(t, []int{1, 2, 3, 4}, lo.Map(ranks, func(nodeRank *schema.NodeRank, _ int) int {
+		return nodeRank.Rank
+	}))
+
+	assert.Equal(t, 2, *ranks[0].RankChange())
+	assert.Nil(t, ranks[1].RankChange())
+	assert.Equal(t, -2, *ranks[2].RankChange())
+
+	// A lower invalid response rate ranks higher.
+	ranks = rank(schema.LeaderboardMetricInvalidResponseRate, 7, 10, values, stakes, nil)
+	assert.Equal(t, nodeC, ranks[0].NodeAddress)
+	assert.Equal(t, nodeB, ranks[3].NodeAddress)
+}
+
+func TestAggregations(t *testing.T) {
+	t.Parallel()
+
+	var (
+		nodeA = common.HexToAddress("0x0a")
+		nodeB = common.HexToAddress("0x0b")
+		nodes = []common.Address{nodeA, nodeB}
+	)
+
+	rewardedNodes := []*schema.RewardedNode{
+		{EpochID: 1, NodeAddress: nodeA, OperationRewards: decimal.NewFromInt(1), StakingRewards: decimal.NewFromInt(2), RequestCount: decimal.NewFromInt(100)},
+		{EpochID: 2, NodeAddress: nodeA, OperationRewards: decimal.NewFromInt(3), StakingRewards: decimal.NewFromInt(4), RequestCount: decimal.NewFromInt(300)},
+		// The Node is not ranked.
+		{EpochID: 2, NodeAddress: common.HexToAddress("0x0c"), OperationRewards: decimal.NewFromInt(5)},
+	}
+
+	rewards := sumRewards(nodes, rewardedNodes)
+	assert.Len(t, rewards, 2)
+	assert.Equal(t, "10", rewards[nodeA].String())
+	assert.True(t, rewards[nodeB].IsZero())
+
+	// The Node without samples is not ranked.
+	averages := average(nodes, []sample{
+		{nodeAddress: nodeA, value: decimal.NewFromInt(1)},
+		{nodeAddress: nodeA, value: decimal.NewFromInt(2)},
+	})
+	assert.Len(t, averages, 1)
+	assert.Equal(t, "1.5", averages[nodeA].String())
+
+	// The Node that served no request is not ranked.
+	rates := invalidResponseRates(nodes, rewardedNodes, map[common.Address]int64{nodeA: 8, nodeB: 1})
+	assert.Len(t, rates, 1)
+	assert.Equal(t, "0.02", rates[nodeA].String())
+}
+
+func TestUptime(t *testing.T) {
+	t.Parallel()
+
+	transitions := []*schema.NodeStatusTransition{
+		{From: schema.NodeStatusRegistered, To: schema.NodeStatusOnline, Timestamp: 50},
+		{From: schema.NodeStatusOnline, To: schema.NodeStatusOffline, Timestamp: 120},
+		{From: schema.NodeStatusOffline, To: schema.NodeStatusOnline, Timestamp: 170},
+		// The transition after the end is ignored.
+		{From: schema.NodeStatusOnline, To: schema.NodeStatusOffline, Timestamp: 300},
+	}
+
+	// Online from 100 to 120 and from 170 to 200.
+	assert.Equal(t, "0.5", uptime(schema.NodeStatusOffline, transitions, 100, 200).String())
+
+	// The Node registered in the window is offline before its registration.
+	assert.Equal(t, "0.5", uptime(schema.NodeStatusOffline, transitions[:2], 0, 100).String())
+
+	// The current status is used without any transition.
+	assert.Equal(t, "1", uptime(schema.NodeStatusOnline, nil, 100, 200).String())
+	assert.True(t, uptime(schema.NodeStatusOffline, nil, 100, 200).IsZero())
+}
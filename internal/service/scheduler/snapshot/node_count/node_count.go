@@ -3,17 +3,17 @@ package nodecount
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
-	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/internal/cache"
 	"github.com/rss3-network/global-indexer/internal/cronjob"
 	"github.com/rss3-network/global-indexer/internal/database"
 	"github.com/rss3-network/global-indexer/internal/service"
 	"github.com/rss3-network/global-indexer/schema"
-	"go.uber.org/zap"
 )
 
 var (
@@ -21,12 +21,14 @@ var (
 	Timeout = 10 * time.Second
 )
 
-var _ service.Server = (*server)(nil)
+var (
+	_ service.Server = (*server)(nil)
+	_ cronjob.Job    = (*server)(nil)
+)
 
 type server struct {
 	cronJob        *cronjob.CronJob
 	databaseClient database.Client
-	redisClient    *redis.Client
 }
 
 func (s *server) Name() string {
@@ -38,20 +40,7 @@ func (s *server) Spec() string {
 }
 
 func (s *server) Run(ctx context.Context) error {
-	err := s.cronJob.AddFunc(ctx, s.Spec(), func() {
-		year, month, day := time.Now().UTC().Date()
-		date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
-
-		nodeSnapshot := schema.NodeSnapshot{
-			Date: date,
-		}
-
-		if err := s.databaseClient.SaveNodeCountSnapshot(ctx, &nodeSnapshot); err != nil {
-			zap.L().Error("save Node count snapshot error", zap.Error(err))
-
-			return
-		}
-	})
+	err := s.cronJob.AddFunc(ctx, s.Spec(), s.snapshotToday)
 	if err != nil {
 		return fmt.Errorf("add node count cron job: %w", err)
 	}
@@ -67,10 +56,55 @@ func (s *server) Run(ctx context.Context) error {
 	return nil
 }
 
-func New(databaseClient database.Client, redis *redis.Client) service.Server {
+func (s *server) RunOnce(ctx context.Context) error {
+	return s.cronJob.RunOnce(ctx, s.snapshotToday)
+}
+
+// snapshotToday saves the snapshot of the current date.
+func (s *server) snapshotToday(ctx context.Context) error {
+	year, month, day := time.Now().UTC().Date()
+
+	if err := s.saveSnapshot(ctx, time.Date(year, month, day, 0, 0, 0, 0, time.UTC), nil); err != nil {
+		return fmt.Errorf("save Node count snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// Backfill saves the node count snapshot at the block height of the Epoch distribution, dated by the day of the distribution.
+func (s *server) Backfill(ctx context.Context, epochID uint64) error {
+	epochItems, err := s.databaseClient.FindEpochTransactions(ctx, epochID, 1, nil)
+	if err != nil {
+		return fmt.Errorf("find epoch transactions: %w", err)
+	}
+
+	if len(epochItems) == 0 {
+		return nil
+	}
+
+	year, month, day := time.Unix(epochItems[0].BlockTimestamp, 0).UTC().Date()
+
+	return s.saveSnapshot(ctx, time.Date(year, month, day, 0, 0, 0, 0, time.UTC), epochItems[0].BlockNumber)
+}
+
+// saveSnapshot saves the node count at the block height, or the current node count if the block number is nil.
+func (s *server) saveSnapshot(ctx context.Context, date time.Time, blockNumber *big.Int) error {
+	count, err := s.databaseClient.FindNodeCount(ctx, blockNumber)
+	if err != nil {
+		return fmt.Errorf("find node count: %w", err)
+	}
+
+	nodeSnapshot := schema.NodeSnapshot{
+		Date:  date,
+		Count: count,
+	}
+
+	return s.databaseClient.SaveNodeCountSnapshot(ctx, &nodeSnapshot)
+}
+
+func New(databaseClient database.Client, cacheClient cache.Client) service.Server {
 	return &server{
-		cronJob:        cronjob.New(redis, Name, Timeout),
+		cronJob:        cronjob.New(cacheClient, databaseClient, Name, Timeout),
 		databaseClient: databaseClient,
-		redisClient:    redis,
 	}
 }
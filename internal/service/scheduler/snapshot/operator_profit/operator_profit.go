@@ -11,9 +11,9 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/redis/go-redis/v9"
 	"github.com/rss3-network/global-indexer/common/ethereum"
 	stakingv2 "github.com/rss3-network/global-indexer/contract/l2/staking/v2"
+	"github.com/rss3-network/global-indexer/internal/cache"
 	"github.com/rss3-network/global-indexer/internal/cronjob"
 	"github.com/rss3-network/global-indexer/internal/database"
 	"github.com/rss3-network/global-indexer/internal/service"
@@ -29,12 +29,14 @@ var (
 	Timeout = 3 * time.Minute
 )
 
-var _ service.Server = (*server)(nil)
+var (
+	_ service.Server = (*server)(nil)
+	_ cronjob.Job    = (*server)(nil)
+)
 
 type server struct {
 	cronJob         *cronjob.CronJob
 	databaseClient  database.Client
-	redisClient     *redis.Client
 	stakingContract *stakingv2.Staking
 }
 
@@ -47,54 +49,56 @@ func (s *server) Spec() string {
 }
 
 func (s *server) Run(ctx context.Context) error {
-	err := s.cronJob.AddFunc(ctx, s.Spec(), func() {
-		// Query the latest epoch of the staker profit snapshots.
-		snapshot, err := s.databaseClient.FindOperatorProfitSnapshots(ctx, schema.OperatorProfitSnapshotsQuery{Limit: lo.ToPtr(1)})
-		if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
-			zap.L().Error("find staker profit snapshots", zap.Error(err))
+	err := s.cronJob.AddFunc(ctx, s.Spec(), s.snapshotEpochs)
+	if err != nil {
+		return fmt.Errorf("add staker profit cron job: %w", err)
+	}
 
-			return
-		}
+	s.cronJob.Start()
+	defer s.cronJob.Stop()
 
-		// Query the latest epoch of the epoch events.
-		epochEvents, err := s.databaseClient.FindEpochs(ctx, &schema.FindEpochsQuery{Limit: lo.ToPtr(1)})
-		if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
-			zap.L().Error("find epochs", zap.Error(err))
+	stopchan := make(chan os.Signal, 1)
 
-			return
-		}
+	signal.Notify(stopchan, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
+	<-stopchan
 
-		var latestEpochSnapshot, latestEpochEvent uint64
+	return nil
+}
 
-		if len(snapshot) > 0 {
-			latestEpochSnapshot = snapshot[0].EpochID
-		}
+func (s *server) RunOnce(ctx context.Context) error {
+	return s.cronJob.RunOnce(ctx, s.snapshotEpochs)
+}
 
-		if len(epochEvents) > 0 {
-			latestEpochEvent = epochEvents[0].ID
-		}
+// snapshotEpochs saves the operator profit snapshots of the Epochs after the latest snapshot one.
+func (s *server) snapshotEpochs(ctx context.Context) error {
+	// Query the latest epoch of the staker profit snapshots.
+	snapshot, err := s.databaseClient.FindOperatorProfitSnapshots(ctx, schema.OperatorProfitSnapshotsQuery{Limit: lo.ToPtr(1)})
+	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+		return fmt.Errorf("find operator profit snapshots: %w", err)
+	}
 
-		// Save the staker profit snapshots.
-		if latestEpochSnapshot < latestEpochEvent {
-			if err := s.saveOperatorProfitSnapshots(ctx, latestEpochSnapshot, latestEpochEvent); err != nil {
-				zap.L().Error("save staker profit snapshots", zap.Error(err))
+	// Query the latest epoch of the epoch events.
+	epochEvents, err := s.databaseClient.FindEpochs(ctx, &schema.FindEpochsQuery{Limit: lo.ToPtr(1)})
+	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+		return fmt.Errorf("find epochs: %w", err)
+	}
 
-				return
-			}
-		}
-	})
+	var latestEpochSnapshot, latestEpochEvent uint64
 
-	if err != nil {
-		return fmt.Errorf("add staker profit cron job: %w", err)
+	if len(snapshot) > 0 {
+		latestEpochSnapshot = snapshot[0].EpochID
 	}
 
-	s.cronJob.Start()
-	defer s.cronJob.Stop()
-
-	stopchan := make(chan os.Signal, 1)
+	if len(epochEvents) > 0 {
+		latestEpochEvent = epochEvents[0].ID
+	}
 
-	signal.Notify(stopchan, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
-	<-stopchan
+	// Save the staker profit snapshots.
+	if latestEpochSnapshot < latestEpochEvent {
+		if err := s.saveOperatorProfitSnapshots(ctx, latestEpochSnapshot, latestEpochEvent); err != nil {
+			return fmt.Errorf("save operator profit snapshots: %w", err)
+		}
+	}
 
 	return nil
 }
@@ -109,75 +113,92 @@ func (s *server) saveOperatorProfitSnapshots(ctx context.Context, latestEpochSna
 	}
 
 	for epochID := latestEpochSnapshot + 1; epochID <= latestEpochEvent; epochID++ {
-		// Fetch the epoch items by the epoch id.
-		epochItems, err := s.databaseClient.FindEpochTransactions(ctx, epochID, 1, nil)
-		if err != nil {
-			zap.L().Error("find epoch transactions", zap.Error(err))
-
-			continue
+		if err := s.saveOperatorProfitSnapshotsByEpochID(ctx, epochID, nodes); err != nil {
+			return err
 		}
+	}
 
-		if len(epochItems) == 0 {
-			continue
-		}
+	return nil
+}
 
-		var (
-			mutex     sync.Mutex
-			errorPool = pool.New().WithContext(ctx).WithMaxGoroutines(30).WithCancelOnError().WithFirstError()
-			data      = make([]*schema.OperatorProfitSnapshot, 0, len(nodes))
-		)
+// Backfill recomputes the operator profit snapshots of the Epoch at the block height of its distribution.
+func (s *server) Backfill(ctx context.Context, epochID uint64) error {
+	nodes, err := s.databaseClient.FindNodes(ctx, schema.FindNodesQuery{})
+	if err != nil {
+		return fmt.Errorf("find Nodes: %w", err)
+	}
 
-		for _, node := range nodes {
-			node := node
+	return s.saveOperatorProfitSnapshotsByEpochID(ctx, epochID, nodes)
+}
 
-			if node.Address == ethereum.AddressGenesis {
-				continue
-			}
+// saveOperatorProfitSnapshotsByEpochID saves the operation pools of the Nodes at the block height of the Epoch distribution,
+// the Nodes not registered by then are skipped.
+func (s *server) saveOperatorProfitSnapshotsByEpochID(ctx context.Context, epochID uint64, nodes []*schema.Node) error {
+	// Fetch the epoch items by the epoch id.
+	epochItems, err := s.databaseClient.FindEpochTransactions(ctx, epochID, 1, nil)
+	if err != nil {
+		return fmt.Errorf("find epoch transactions: %w", err)
+	}
+
+	if len(epochItems) == 0 {
+		return nil
+	}
 
-			errorPool.Go(func(ctx context.Context) error {
-				// Query the Node info from the staking contract.
-				nodeInfo, err := s.stakingContract.GetNode(&bind.CallOpts{Context: ctx, BlockNumber: epochItems[0].BlockNumber}, node.Address)
-				if err != nil {
-					zap.L().Error("get Node from rpc", zap.Error(err))
+	var (
+		mutex     sync.Mutex
+		errorPool = pool.New().WithContext(ctx).WithMaxGoroutines(30).WithCancelOnError().WithFirstError()
+		data      = make([]*schema.OperatorProfitSnapshot, 0, len(nodes))
+	)
 
-					return fmt.Errorf("get Node from rpc: %w", err)
-				}
+	for _, node := range nodes {
+		node := node
 
-				if nodeInfo.Account == ethereum.AddressGenesis {
-					return nil
-				}
+		if node.Address == ethereum.AddressGenesis {
+			continue
+		}
 
-				mutex.Lock()
-				defer mutex.Unlock()
+		errorPool.Go(func(ctx context.Context) error {
+			// Query the Node info from the staking contract.
+			nodeInfo, err := s.stakingContract.GetNode(&bind.CallOpts{Context: ctx, BlockNumber: epochItems[0].BlockNumber}, node.Address)
+			if err != nil {
+				zap.L().Error("get Node from rpc", zap.Error(err))
 
-				data = append(data, &schema.OperatorProfitSnapshot{
-					Date:          time.Unix(epochItems[0].BlockTimestamp, 0),
-					EpochID:       epochID,
-					Operator:      nodeInfo.Account,
-					OperationPool: decimal.NewFromBigInt(nodeInfo.OperationPoolTokens, 0),
-				})
+				return fmt.Errorf("get Node from rpc: %w", err)
+			}
 
+			if nodeInfo.Account == ethereum.AddressGenesis {
 				return nil
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			data = append(data, &schema.OperatorProfitSnapshot{
+				Date:          time.Unix(epochItems[0].BlockTimestamp, 0),
+				EpochID:       epochID,
+				Operator:      nodeInfo.Account,
+				OperationPool: decimal.NewFromBigInt(nodeInfo.OperationPoolTokens, 0),
 			})
-		}
 
-		if err := errorPool.Wait(); err != nil {
-			return fmt.Errorf("fetch operator profit: %w", err)
-		}
+			return nil
+		})
+	}
 
-		if err := s.databaseClient.SaveOperatorProfitSnapshots(ctx, data); err != nil {
-			return fmt.Errorf("save Node min tokens to stake snapshots: %w", err)
-		}
+	if err := errorPool.Wait(); err != nil {
+		return fmt.Errorf("fetch operator profit: %w", err)
+	}
+
+	if err := s.databaseClient.SaveOperatorProfitSnapshots(ctx, data); err != nil {
+		return fmt.Errorf("save Node min tokens to stake snapshots: %w", err)
 	}
 
 	return nil
 }
 
-func New(databaseClient database.Client, redisClient *redis.Client, stakingContract *stakingv2.Staking) service.Server {
+func New(databaseClient database.Client, cacheClient cache.Client, stakingContract *stakingv2.Staking) service.Server {
 	return &server{
-		cronJob:         cronjob.New(redisClient, Name, Timeout),
+		cronJob:         cronjob.New(cacheClient, databaseClient, Name, Timeout),
 		databaseClient:  databaseClient,
-		redisClient:     redisClient,
 		stakingContract: stakingContract,
 	}
 }
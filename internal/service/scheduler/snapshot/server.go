@@ -5,12 +5,13 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/redis/go-redis/v9"
 	"github.com/rss3-network/global-indexer/contract/l2"
 	stakingv2 "github.com/rss3-network/global-indexer/contract/l2/staking/v2"
+	"github.com/rss3-network/global-indexer/internal/cache"
 	"github.com/rss3-network/global-indexer/internal/database"
 	"github.com/rss3-network/global-indexer/internal/service"
 	"github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot/apy"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot/leaderboard"
 	nodecount "github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot/node_count"
 	operatorprofit "github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot/operator_profit"
 	stakercount "github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot/staker_count"
@@ -30,6 +31,11 @@ func (s *server) Name() string {
 	return Name
 }
 
+// Servers returns the servers of the snapshots.
+func (s *server) Servers() []service.Server {
+	return s.snapshots
+}
+
 func (s *server) Run(ctx context.Context) error {
 	errorPool := pool.New().WithContext(ctx).WithCancelOnError().WithFirstError()
 
@@ -44,7 +50,18 @@ func (s *server) Run(ctx context.Context) error {
 	return errorPool.Wait()
 }
 
-func New(databaseClient database.Client, redis *redis.Client, ethereumClient *ethclient.Client) (service.Server, error) {
+func New(databaseClient database.Client, cacheClient cache.Client, ethereumClient *ethclient.Client) (service.Server, error) {
+	snapshots, err := newSnapshots(databaseClient, cacheClient, ethereumClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &server{
+		snapshots: snapshots,
+	}, nil
+}
+
+func newSnapshots(databaseClient database.Client, cacheClient cache.Client, ethereumClient *ethclient.Client) ([]service.Server, error) {
 	chainID, err := ethereumClient.ChainID(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("get chain id: %w", err)
@@ -60,13 +77,12 @@ func New(databaseClient database.Client, redis *redis.Client, ethereumClient *et
 		return nil, fmt.Errorf("new staking contract: %w", err)
 	}
 
-	return &server{
-		snapshots: []service.Server{
-			nodecount.New(databaseClient, redis),
-			stakercount.New(databaseClient, redis),
-			stakerprofit.New(databaseClient, redis, stakingContract),
-			operatorprofit.New(databaseClient, redis, stakingContract),
-			apy.New(databaseClient, redis, stakingContract),
-		},
+	return []service.Server{
+		nodecount.New(databaseClient, cacheClient),
+		stakercount.New(databaseClient, cacheClient),
+		stakerprofit.New(databaseClient, cacheClient, stakingContract),
+		operatorprofit.New(databaseClient, cacheClient, stakingContract),
+		apy.New(databaseClient, cacheClient, stakingContract),
+		leaderboard.New(databaseClient, cacheClient, stakingContract),
 	}, nil
 }
@@ -13,9 +13,9 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/redis/go-redis/v9"
 	"github.com/rss3-network/global-indexer/common/ethereum"
 	stakingv2 "github.com/rss3-network/global-indexer/contract/l2/staking/v2"
+	"github.com/rss3-network/global-indexer/internal/cache"
 	"github.com/rss3-network/global-indexer/internal/cronjob"
 	"github.com/rss3-network/global-indexer/internal/database"
 	"github.com/rss3-network/global-indexer/internal/service"
@@ -31,12 +31,14 @@ var (
 	Timeout = 3 * time.Minute
 )
 
-var _ service.Server = (*server)(nil)
+var (
+	_ service.Server = (*server)(nil)
+	_ cronjob.Job    = (*server)(nil)
+)
 
 type server struct {
 	cronJob         *cronjob.CronJob
 	databaseClient  database.Client
-	redisClient     *redis.Client
 	stakingContract *stakingv2.Staking
 }
 
@@ -49,81 +51,79 @@ func (s *server) Spec() string {
 }
 
 func (s *server) Run(ctx context.Context) error {
-	err := s.cronJob.AddFunc(ctx, s.Spec(), func() {
-		// Query the latest epoch of the epoch events.
-		epochEvents, err := s.databaseClient.FindEpochs(ctx, &schema.FindEpochsQuery{Limit: lo.ToPtr(1)})
-		if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
-			zap.L().Error("find epochs", zap.Error(err))
-
-			return
-		}
-
-		if len(epochEvents) == 0 {
-			return
-		}
-
-		var latestEpochSnapshot uint64
+	err := s.cronJob.AddFunc(ctx, s.Spec(), s.snapshotEpochs)
+	if err != nil {
+		return fmt.Errorf("add staker profit cron job: %w", err)
+	}
 
-		// Check the latest epoch of the staker profit snapshots.
-		for epochID := epochEvents[0].ID; epochID > 0; epochID-- {
-			// Query the epoch of the staker profit snapshots.
-			snapshots, err := s.databaseClient.FindStakerProfitSnapshots(ctx, schema.StakerProfitSnapshotsQuery{EpochID: lo.ToPtr(epochID)})
-			if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
-				zap.L().Error("find staker profit snapshots", zap.Error(err))
+	s.cronJob.Start()
+	defer s.cronJob.Stop()
 
-				return
-			}
+	stopchan := make(chan os.Signal, 1)
 
-			if len(snapshots) == 0 {
-				continue
-			}
+	signal.Notify(stopchan, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
+	<-stopchan
 
-			epochItems, err := s.databaseClient.FindEpochTransactions(ctx, epochID, 1, nil)
-			if err != nil {
-				zap.L().Error("find epoch transactions", zap.Error(err))
+	return nil
+}
 
-				return
-			}
+func (s *server) RunOnce(ctx context.Context) error {
+	return s.cronJob.RunOnce(ctx, s.snapshotEpochs)
+}
 
-			stakerCount, err := s.databaseClient.FindStakerCount(ctx, schema.StakeChipsQuery{
-				BlockNumber: epochItems[0].BlockNumber,
-			})
-			if err != nil {
-				zap.L().Error("find staker count", zap.Error(err))
+// snapshotEpochs saves the staker profit snapshots of the Epochs after the latest complete snapshot one.
+func (s *server) snapshotEpochs(ctx context.Context) error {
+	// Query the latest epoch of the epoch events.
+	epochEvents, err := s.databaseClient.FindEpochs(ctx, &schema.FindEpochsQuery{Limit: lo.ToPtr(1)})
+	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+		return fmt.Errorf("find epochs: %w", err)
+	}
 
-				return
-			}
+	if len(epochEvents) == 0 {
+		return nil
+	}
 
-			if int64(len(snapshots)) < stakerCount {
-				continue
-			}
+	var latestEpochSnapshot uint64
 
-			latestEpochSnapshot = epochID
+	// Check the latest epoch of the staker profit snapshots.
+	for epochID := epochEvents[0].ID; epochID > 0; epochID-- {
+		// Query the epoch of the staker profit snapshots.
+		snapshots, err := s.databaseClient.FindStakerProfitSnapshots(ctx, schema.StakerProfitSnapshotsQuery{EpochID: lo.ToPtr(epochID)})
+		if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+			return fmt.Errorf("find staker profit snapshots: %w", err)
+		}
 
-			break
+		if len(snapshots) == 0 {
+			continue
 		}
 
-		// Save the staker profit snapshots.
-		if latestEpochSnapshot < epochEvents[0].ID {
-			if err := s.saveStakerProfitSnapshots(ctx, latestEpochSnapshot, epochEvents[0].ID); err != nil {
-				zap.L().Error("save staker profit snapshots", zap.Error(err))
+		epochItems, err := s.databaseClient.FindEpochTransactions(ctx, epochID, 1, nil)
+		if err != nil {
+			return fmt.Errorf("find epoch transactions: %w", err)
+		}
 
-				return
-			}
+		stakerCount, err := s.databaseClient.FindStakerCount(ctx, schema.StakeChipsQuery{
+			BlockNumber: epochItems[0].BlockNumber,
+		})
+		if err != nil {
+			return fmt.Errorf("find staker count: %w", err)
 		}
-	})
 
-	if err != nil {
-		return fmt.Errorf("add staker profit cron job: %w", err)
-	}
+		if int64(len(snapshots)) < stakerCount {
+			continue
+		}
 
-	s.cronJob.Start()
-	defer s.cronJob.Stop()
+		latestEpochSnapshot = epochID
 
-	stopchan := make(chan os.Signal, 1)
+		break
+	}
 
-	signal.Notify(stopchan, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
-	<-stopchan
+	// Save the staker profit snapshots.
+	if latestEpochSnapshot < epochEvents[0].ID {
+		if err := s.saveStakerProfitSnapshots(ctx, latestEpochSnapshot, epochEvents[0].ID); err != nil {
+			return fmt.Errorf("save staker profit snapshots: %w", err)
+		}
+	}
 
 	return nil
 }
@@ -131,7 +131,7 @@ func (s *server) Run(ctx context.Context) error {
 func (s *server) saveStakerProfitSnapshots(ctx context.Context, latestEpochSnapshot, latestEpochEvent uint64) error {
 	// Iterate the epoch id from the latest epoch snapshot to the latest epoch event.
 	for epochID := latestEpochSnapshot + 1; epochID <= latestEpochEvent; epochID++ {
-		if err := s.saveStakerProfitSnapshotsByEpochID(ctx, epochID); err != nil {
+		if err := s.saveStakerProfitSnapshotsByEpochID(ctx, epochID, false); err != nil {
 			return fmt.Errorf("save staker profit snapshots by epoch id: %w", err)
 		}
 	}
@@ -139,7 +139,15 @@ func (s *server) saveStakerProfitSnapshots(ctx context.Context, latestEpochSnaps
 	return nil
 }
 
-func (s *server) saveStakerProfitSnapshotsByEpochID(ctx context.Context, epochID uint64) error {
+// Backfill recomputes the staker profit snapshots of the Epoch at the block height of its distribution,
+// overwriting the saved ones.
+func (s *server) Backfill(ctx context.Context, epochID uint64) error {
+	return s.saveStakerProfitSnapshotsByEpochID(ctx, epochID, true)
+}
+
+// saveStakerProfitSnapshotsByEpochID saves the profit snapshots of the stakers of the Epoch,
+// the stakers with a saved snapshot are skipped unless recompute is set.
+func (s *server) saveStakerProfitSnapshotsByEpochID(ctx context.Context, epochID uint64, recompute bool) error {
 	// Fetch the epoch items by the epoch id.
 	epochItems, err := s.databaseClient.FindEpochTransactions(ctx, epochID, 1, nil)
 	if err != nil {
@@ -180,14 +188,16 @@ func (s *server) saveStakerProfitSnapshotsByEpochID(ctx context.Context, epochID
 				continue
 			}
 
-			// Query the staker profit snapshots by the owner address and the epoch id.
-			exist, _ := s.databaseClient.FindStakerProfitSnapshots(ctx, schema.StakerProfitSnapshotsQuery{
-				OwnerAddress: lo.ToPtr(staker.Owner),
-				EpochID:      lo.ToPtr(epochID),
-				Limit:        lo.ToPtr(1),
-			})
-			if len(exist) > 0 {
-				continue
+			if !recompute {
+				// Query the staker profit snapshots by the owner address and the epoch id.
+				exist, _ := s.databaseClient.FindStakerProfitSnapshots(ctx, schema.StakerProfitSnapshotsQuery{
+					OwnerAddress: lo.ToPtr(staker.Owner),
+					EpochID:      lo.ToPtr(epochID),
+					Limit:        lo.ToPtr(1),
+				})
+				if len(exist) > 0 {
+					continue
+				}
 			}
 
 			data, err := s.buildStakerProfitSnapshots(ctx, epochItems[0], staker.Owner)
@@ -277,11 +287,10 @@ func (s *server) buildStakerProfitSnapshots(ctx context.Context, currentEpoch *s
 	return profit, nil
 }
 
-func New(databaseClient database.Client, redisClient *redis.Client, stakingContract *stakingv2.Staking) service.Server {
+func New(databaseClient database.Client, cacheClient cache.Client, stakingContract *stakingv2.Staking) service.Server {
 	return &server{
-		cronJob:         cronjob.New(redisClient, Name, Timeout),
+		cronJob:         cronjob.New(cacheClient, databaseClient, Name, Timeout),
 		databaseClient:  databaseClient,
-		redisClient:     redisClient,
 		stakingContract: stakingContract,
 	}
 }
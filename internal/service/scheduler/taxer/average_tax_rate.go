@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"math/big"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/rss3-network/global-indexer/common/txmgr"
@@ -85,62 +84,16 @@ func (s *Server) submitAverageTaxRate(ctx context.Context, epochID uint64) error
 
 // calculateAverageTaxRate calculates the average tax rate based on all non-public good nodes.
 func (s *Server) calculateAverageTaxRate(ctx context.Context) (*decimal.Decimal, error) {
-	var (
-		sumTaxRate, nodeCount decimal.Decimal
-		cursor                *string
-	)
-
-	for {
-		// Find nodes from the database.
-		nodes, err := s.databaseClient.FindNodes(ctx, schema.FindNodesQuery{
-			Cursor: cursor,
-			Limit:  lo.ToPtr(100),
-		})
-		if err != nil {
-			zap.L().Error("find Nodes", zap.Error(err))
-
-			return nil, err
-		}
-
-		if len(nodes) == 0 {
-			break
-		}
-
-		// Exclude public good nodes
-		var nodeAddresses []common.Address
-
-		for _, node := range nodes {
-			if node.IsPublicGood {
-				continue
-			}
-
-			nodeAddresses = append(nodeAddresses, node.Address)
-		}
-
-		// Query the VSL to complement the Node info.
-		nodeInfo, err := s.stakingContract.GetNodes(&bind.CallOpts{Context: ctx}, nodeAddresses)
-		if err != nil {
-			zap.L().Error("get Nodes on the VSL by staking contract", zap.Error(err))
-
-			return nil, err
-		}
-
-		// Accumulate the tax and number of all nodes.
-		for _, node := range nodeInfo {
-			sumTaxRate = sumTaxRate.Add(decimal.NewFromInt(int64(node.TaxRateBasisPoints)))
-			nodeCount = nodeCount.Add(decimal.NewFromInt(1))
-		}
-
-		cursor = lo.ToPtr(nodes[len(nodes)-1].Address.String())
+	inputs, err := FindTaxRateInputs(ctx, s.databaseClient, s.stakingContract)
+	if err != nil {
+		return nil, err
 	}
 
-	// If there are no Nodes, return 0.
-	if nodeCount.IsZero() {
-		return lo.ToPtr(decimal.Zero), nil
-	}
+	averageTaxRate := CalculateAverageTaxRate(inputs, s.taxerConfig)
+
+	zap.L().Info("calculate average tax rate", zap.String("strategy", averageTaxRate.Strategy), zap.Int("nodes", len(inputs)), zap.Stringer("averageTaxRate", averageTaxRate.AverageTaxRate))
 
-	// Calculate and return the average tax rate.
-	return lo.ToPtr(sumTaxRate.Div(nodeCount)), nil
+	return &averageTaxRate.AverageTaxRate, nil
 }
 
 // invokeSettlementContract invokes the settlement contract to submit the average tax.
@@ -187,10 +140,9 @@ func (s *Server) sendTransaction(ctx context.Context, input []byte) (*common.Has
 	if receipt.Status != types.ReceiptStatusSuccessful {
 		zap.L().Error("received an invalid transaction receipt", zap.String("tx", receipt.TxHash.String()))
 
-		// select {} purposely block the process as it is a critical error and meaningless to continue
-		// if panic() is called, the process will be restarted by the supervisor
-		// we do not want that as it will be stuck in the same state
-		select {}
+		// It is a critical error and meaningless to continue,
+		// the transaction manager is halted until the transaction is resolved by an operator
+		return nil, fmt.Errorf("%w: received an invalid transaction receipt %s", txmgr.ErrHalted, receipt.TxHash)
 	}
 
 	return lo.ToPtr(receipt.TxHash), nil
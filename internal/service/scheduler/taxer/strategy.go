@@ -0,0 +1,175 @@
+package taxer
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	stakingv2 "github.com/rss3-network/global-indexer/contract/l2/staking/v2"
+	"github.com/rss3-network/global-indexer/internal/config"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// FindTaxRateInputs finds the tax rates of all non-public good Nodes on the VSL.
+func FindTaxRateInputs(ctx context.Context, databaseClient database.Client, stakingContract *stakingv2.Staking) ([]*schema.TaxRateInput, error) {
+	var (
+		inputs []*schema.TaxRateInput
+		cursor *string
+	)
+
+	for {
+		// Find nodes from the database.
+		nodes, err := databaseClient.FindNodes(ctx, schema.FindNodesQuery{
+			Cursor: cursor,
+			Limit:  lo.ToPtr(100),
+		})
+		if err != nil {
+			zap.L().Error("find Nodes", zap.Error(err))
+
+			return nil, err
+		}
+
+		if len(nodes) == 0 {
+			break
+		}
+
+		cursor = lo.ToPtr(nodes[len(nodes)-1].Address.String())
+
+		// Exclude public good nodes
+		var nodeAddresses []common.Address
+
+		for _, node := range nodes {
+			if node.IsPublicGood {
+				continue
+			}
+
+			nodeAddresses = append(nodeAddresses, node.Address)
+		}
+
+		if len(nodeAddresses) == 0 {
+			continue
+		}
+
+		// Query the VSL to complement the Node info.
+		nodeInfo, err := stakingContract.GetNodes(&bind.CallOpts{Context: ctx}, nodeAddresses)
+		if err != nil {
+			zap.L().Error("get Nodes on the VSL by staking contract", zap.Error(err))
+
+			return nil, err
+		}
+
+		for _, node := range nodeInfo {
+			input := &schema.TaxRateInput{
+				Address:            node.Account,
+				TaxRateBasisPoints: node.TaxRateBasisPoints,
+				StakingPoolTokens:  decimal.Zero,
+			}
+
+			if node.StakingPoolTokens != nil {
+				input.StakingPoolTokens = decimal.NewFromBigInt(node.StakingPoolTokens, 0)
+			}
+
+			inputs = append(inputs, input)
+		}
+	}
+
+	return inputs, nil
+}
+
+// CalculateAverageTaxRate averages the tax rates with the strategy of the config.
+func CalculateAverageTaxRate(inputs []*schema.TaxRateInput, taxerConfig config.Taxer) *schema.AverageTaxRate {
+	result := &schema.AverageTaxRate{
+		Strategy:       taxerConfig.Strategy,
+		AverageTaxRate: decimal.Zero,
+		Inputs:         inputs,
+	}
+
+	// If there are no Nodes, the average tax rate is 0.
+	if len(inputs) == 0 {
+		return result
+	}
+
+	switch taxerConfig.Strategy {
+	case schema.TaxStrategyStakeWeighted:
+		result.AverageTaxRate = stakeWeightedMean(inputs)
+	case schema.TaxStrategyMedian:
+		result.AverageTaxRate = median(inputs)
+	case schema.TaxStrategyTrimmedMean:
+		result.AverageTaxRate = trimmedMean(inputs, taxerConfig.TrimRatio)
+	default:
+		result.AverageTaxRate = mean(inputs)
+	}
+
+	return result
+}
+
+func mean(inputs []*schema.TaxRateInput) decimal.Decimal {
+	var sumTaxRate decimal.Decimal
+
+	for _, input := range inputs {
+		sumTaxRate = sumTaxRate.Add(decimal.NewFromInt(int64(input.TaxRateBasisPoints)))
+	}
+
+	return sumTaxRate.Div(decimal.NewFromInt(int64(len(inputs))))
+}
+
+// stakeWeightedMean weights the tax rates by the staking pool tokens, it falls back to the mean if no Node has a stake.
+func stakeWeightedMean(inputs []*schema.TaxRateInput) decimal.Decimal {
+	var sumWeightedTaxRate, sumStakingPoolTokens decimal.Decimal
+
+	for _, input := range inputs {
+		sumWeightedTaxRate = sumWeightedTaxRate.Add(decimal.NewFromInt(int64(input.TaxRateBasisPoints)).Mul(input.StakingPoolTokens))
+		sumStakingPoolTokens = sumStakingPoolTokens.Add(input.StakingPoolTokens)
+	}
+
+	if sumStakingPoolTokens.IsZero() {
+		return mean(inputs)
+	}
+
+	return sumWeightedTaxRate.Div(sumStakingPoolTokens)
+}
+
+func median(inputs []*schema.TaxRateInput) decimal.Decimal {
+	sorted := sortByTaxRate(inputs)
+	middle := len(sorted) / 2
+
+	if len(sorted)%2 == 1 {
+		return decimal.NewFromInt(int64(sorted[middle].TaxRateBasisPoints))
+	}
+
+	return mean(sorted[middle-1 : middle+1])
+}
+
+// trimmedMean excludes the lowest and the highest tax rates by the trim ratio, then averages the rest.
+func trimmedMean(inputs []*schema.TaxRateInput, trimRatio float64) decimal.Decimal {
+	var (
+		sorted  = sortByTaxRate(inputs)
+		trimmed = int(float64(len(sorted)) * trimRatio)
+	)
+
+	for _, input := range sorted[:trimmed] {
+		input.Excluded = true
+	}
+
+	for _, input := range sorted[len(sorted)-trimmed:] {
+		input.Excluded = true
+	}
+
+	return mean(sorted[trimmed : len(sorted)-trimmed])
+}
+
+// sortByTaxRate returns a copy of the inputs sorted by the tax rate.
+func sortByTaxRate(inputs []*schema.TaxRateInput) []*schema.TaxRateInput {
+	sorted := append([]*schema.TaxRateInput(nil), inputs...)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].TaxRateBasisPoints < sorted[j].TaxRateBasisPoints
+	})
+
+	return sorted
+}
@@ -0,0 +1,88 @@
+package taxer_test
+
+import (
+	"testing"
+
+	"github.com/rss3-network/global-indexer/internal/config"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/taxer"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateAverageTaxRate(t *testing.T) {
+	t.Parallel()
+
+	newInputs := func() []*schema.TaxRateInput {
+		return []*schema.TaxRateInput{
+			{TaxRateBasisPoints: 1000, StakingPoolTokens: decimal.NewFromInt(1)},
+			{TaxRateBasisPoints: 9000, StakingPoolTokens: decimal.NewFromInt(1)},
+			{TaxRateBasisPoints: 2000, StakingPoolTokens: decimal.NewFromInt(3)},
+			{TaxRateBasisPoints: 3000, StakingPoolTokens: decimal.NewFromInt(0)},
+			{TaxRateBasisPoints: 0, StakingPoolTokens: decimal.NewFromInt(5)},
+		}
+	}
+
+	testcases := []struct {
+		name     string
+		config   config.Taxer
+		expected decimal.Decimal
+	}{
+		{
+			name:     "mean",
+			config:   config.Taxer{Strategy: schema.TaxStrategyMean},
+			expected: decimal.NewFromInt(3000),
+		},
+		{
+			name:     "stake weighted",
+			config:   config.Taxer{Strategy: schema.TaxStrategyStakeWeighted},
+			expected: decimal.NewFromInt(1600),
+		},
+		{
+			name:     "median",
+			config:   config.Taxer{Strategy: schema.TaxStrategyMedian},
+			expected: decimal.NewFromInt(2000),
+		},
+		{
+			name:     "trimmed mean",
+			config:   config.Taxer{Strategy: schema.TaxStrategyTrimmedMean, TrimRatio: 0.2},
+			expected: decimal.NewFromInt(2000),
+		},
+	}
+
+	for _, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := taxer.CalculateAverageTaxRate(newInputs(), testcase.config)
+
+			assert.Equal(t, testcase.config.Strategy, result.Strategy)
+			assert.True(t, testcase.expected.Equal(result.AverageTaxRate), "expected %s, got %s", testcase.expected, result.AverageTaxRate)
+		})
+	}
+
+	t.Run("trimmed mean excludes the extremes", func(t *testing.T) {
+		t.Parallel()
+
+		result := taxer.CalculateAverageTaxRate(newInputs(), config.Taxer{Strategy: schema.TaxStrategyTrimmedMean, TrimRatio: 0.2})
+
+		excluded := make([]uint64, 0)
+
+		for _, input := range result.Inputs {
+			if input.Excluded {
+				excluded = append(excluded, input.TaxRateBasisPoints)
+			}
+		}
+
+		require.ElementsMatch(t, []uint64{0, 9000}, excluded)
+	})
+
+	t.Run("no nodes", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, taxer.CalculateAverageTaxRate(nil, config.Taxer{Strategy: schema.TaxStrategyMedian}).AverageTaxRate.IsZero())
+	})
+}
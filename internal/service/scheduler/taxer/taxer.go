@@ -10,19 +10,21 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/redis/go-redis/v9"
-	gicrypto "github.com/rss3-network/global-indexer/common/crypto"
 	"github.com/rss3-network/global-indexer/common/txmgr"
 	"github.com/rss3-network/global-indexer/contract/l2"
 	stakingv2 "github.com/rss3-network/global-indexer/contract/l2/staking/v2"
+	"github.com/rss3-network/global-indexer/internal/cache"
 	"github.com/rss3-network/global-indexer/internal/config"
 	"github.com/rss3-network/global-indexer/internal/cronjob"
 	"github.com/rss3-network/global-indexer/internal/database"
 	"github.com/rss3-network/global-indexer/internal/service"
-	"go.uber.org/zap"
+	"github.com/rss3-network/global-indexer/internal/service/settler"
 )
 
-var _ service.Server = (*Server)(nil)
+var (
+	_ service.Server = (*Server)(nil)
+	_ cronjob.Job    = (*Server)(nil)
+)
 
 var (
 	Name    = "taxer"
@@ -35,6 +37,7 @@ type Server struct {
 	chainID         *big.Int
 	stakingContract *stakingv2.Staking
 	settlerConfig   *config.Settler
+	taxerConfig     config.Taxer
 	txManager       txmgr.TxManager
 }
 
@@ -47,14 +50,7 @@ func (s *Server) Spec() string {
 }
 
 func (s *Server) Run(ctx context.Context) error {
-	err := s.cronJob.AddFunc(ctx, s.Spec(), func() {
-		if err := s.checkAndSubmitAverageTaxRate(ctx); err != nil {
-			zap.L().Error("submit average tax rate error", zap.Error(err))
-
-			return
-		}
-	})
-
+	err := s.cronJob.AddFunc(ctx, s.Spec(), s.checkAndSubmitAverageTaxRate)
 	if err != nil {
 		return fmt.Errorf("add cron job error: %w", err)
 	}
@@ -70,7 +66,11 @@ func (s *Server) Run(ctx context.Context) error {
 	return nil
 }
 
-func New(databaseClient database.Client, redisClient *redis.Client, ethereumClient *ethclient.Client, config *config.File) (*Server, error) {
+func (s *Server) RunOnce(ctx context.Context) error {
+	return s.cronJob.RunOnce(ctx, s.checkAndSubmitAverageTaxRate)
+}
+
+func New(databaseClient database.Client, cacheClient cache.Client, ethereumClient *ethclient.Client, config *config.File) (*Server, error) {
 	chainID, err := ethereumClient.ChainID(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("get chain ID: %w", err)
@@ -86,33 +86,19 @@ func New(databaseClient database.Client, redisClient *redis.Client, ethereumClie
 		return nil, fmt.Errorf("new staking contract: %w", err)
 	}
 
-	signerFactory, from, err := gicrypto.NewSignerFactory(config.Settler.PrivateKey, config.Settler.SignerEndpoint, config.Settler.WalletAddress)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create signer")
-	}
-
-	defaultTxConfig := txmgr.Config{
-		ResubmissionTimeout:       20 * time.Second,
-		FeeLimitMultiplier:        5,
-		TxSendTimeout:             5 * time.Minute,
-		TxNotInMempoolTimeout:     1 * time.Hour,
-		NetworkTimeout:            5 * time.Minute,
-		ReceiptQueryInterval:      500 * time.Millisecond,
-		NumConfirmations:          5,
-		SafeAbortNonceTooLowCount: 3,
-	}
-
-	txManager, err := txmgr.NewSimpleTxManager(defaultTxConfig, chainID, nil, ethereumClient, from, signerFactory(chainID))
+	// The transaction manager shares the settler wallet and its nonce locker with the settler.
+	txManager, err := settler.NewTxManager(Name, databaseClient, cacheClient, ethereumClient, chainID, config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create tx manager")
+		return nil, err
 	}
 
 	server := &Server{
-		cronJob:         cronjob.New(redisClient, Name, Timeout),
+		cronJob:         cronjob.New(cacheClient, databaseClient, Name, Timeout),
 		databaseClient:  databaseClient,
 		chainID:         chainID,
 		stakingContract: stakingContract,
 		settlerConfig:   config.Settler,
+		taxerConfig:     config.Taxer,
 		txManager:       txManager,
 	}
 
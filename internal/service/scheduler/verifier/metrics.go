@@ -0,0 +1,87 @@
+package verifier
+
+import (
+	"context"
+	"time"
+
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+const (
+	resultProcessed    = "processed"
+	resultRetried      = "retried"
+	resultDeadLettered = "dead_lettered"
+	resultDuplicate    = "duplicate"
+)
+
+// metrics reports the throughput and the backlog of the queue, which tell if the verifiers keep up with the hubs.
+type metrics struct {
+	tasks metric.Int64Counter
+	lag   metric.Float64Histogram
+}
+
+// recordTask records the result of a task, and its lag from being enqueued to being handled.
+func (m *metrics) recordTask(ctx context.Context, result string, task *model.VerificationTask) {
+	attributes := metric.WithAttributes(attribute.String("result", result), attribute.String("type", task.Type))
+
+	m.tasks.Add(ctx, 1, attributes)
+
+	if task.EnqueuedAt > 0 {
+		m.lag.Record(ctx, time.Since(time.Unix(task.EnqueuedAt, 0)).Seconds(), attributes)
+	}
+}
+
+func newMetrics(stats func(ctx context.Context) (*queueStats, error)) (*metrics, error) {
+	var (
+		meter = otel.Meter("github.com/rss3-network/global-indexer/internal/service/scheduler/verifier")
+		m     metrics
+		err   error
+	)
+
+	if m.tasks, err = meter.Int64Counter("verifier.tasks", metric.WithDescription("The number of the handled verification tasks by result.")); err != nil {
+		return nil, err
+	}
+
+	if m.lag, err = meter.Float64Histogram("verifier.task.lag", metric.WithDescription("The time from a verification task being enqueued to being handled."), metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+
+	length, err := meter.Int64ObservableGauge("verifier.queue.length", metric.WithDescription("The number of the verification tasks in the stream."))
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := meter.Int64ObservableGauge("verifier.queue.pending", metric.WithDescription("The number of the verification tasks delivered but not acknowledged."))
+	if err != nil {
+		return nil, err
+	}
+
+	deadLetter, err := meter.Int64ObservableGauge("verifier.queue.dead_letter", metric.WithDescription("The number of the verification tasks in the dead-letter stream."))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = meter.RegisterCallback(func(ctx context.Context, observer metric.Observer) error {
+		result, err := stats(ctx)
+		if err != nil {
+			zap.L().Error("get verification queue stats", zap.Error(err))
+
+			return nil
+		}
+
+		observer.ObserveInt64(length, result.length)
+		observer.ObserveInt64(pending, result.pending)
+		observer.ObserveInt64(deadLetter, result.deadLetter)
+
+		return nil
+	}, length, pending, deadLetter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
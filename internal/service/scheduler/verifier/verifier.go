@@ -0,0 +1,403 @@
+package verifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/go-redsync/redsync/v4"
+	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/common/httputil"
+	"github.com/rss3-network/global-indexer/contract/l2"
+	stakingv2 "github.com/rss3-network/global-indexer/contract/l2/staking/v2"
+	"github.com/rss3-network/global-indexer/internal/cache"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/service"
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/distributor"
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/sourcegraph/conc/pool"
+	"go.uber.org/zap"
+)
+
+var _ service.Server = (*server)(nil)
+
+var Name = "verifier"
+
+const (
+	// readCount is the number of the tasks a worker reads at a time.
+	readCount = 1
+	// readBlock is how long a worker waits for a new task.
+	readBlock = 5 * time.Second
+	// readRetryInterval is how long a worker waits after it fails to read the stream.
+	readRetryInterval = time.Second
+	// claimCount is the number of the idle tasks claimed at a time.
+	claimCount = 100
+	// statsInterval is the interval at which the stats of the queue are logged.
+	statsInterval = time.Minute
+)
+
+// processor processes a verification task, it is satisfied by *distributor.Distributor.
+type processor interface {
+	ProcessVerificationTask(ctx context.Context, task *model.VerificationTask) error
+}
+
+// server consumes the verification tasks enqueued by the hubs, see model.VerificationTask.
+// A task is delivered at least once: it is acknowledged after it has been processed, and a task left
+// unacknowledged by a crashed verifier is claimed by another one after model.VerificationQueueConfig.ClaimIdle.
+// The ID of a processed task is remembered to skip its redeliveries, a task is processed by one verifier at a time,
+// and the processor resumes a retried task from the step it failed at.
+type server struct {
+	cacheClient cache.Client
+	processor   processor
+	config      *model.VerificationQueueConfig
+	consumer    string
+	metrics     *metrics
+}
+
+func (s *server) Name() string {
+	return Name
+}
+
+func (s *server) Run(ctx context.Context) error {
+	if !s.config.Enabled {
+		zap.L().Warn("verification queue is not enabled, the hubs verify the responses in-process")
+	}
+
+	if err := s.cacheClient.XGroupCreate(ctx, model.VerificationStreamKey, model.VerificationGroup); err != nil {
+		return fmt.Errorf("create verification group: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workerPool := pool.New().WithContext(ctx)
+
+	for i := 0; i < s.config.Workers; i++ {
+		consumer := fmt.Sprintf("%s-%d", s.consumer, i)
+
+		workerPool.Go(func(ctx context.Context) error {
+			s.consume(ctx, consumer)
+
+			return nil
+		})
+	}
+
+	workerPool.Go(func(ctx context.Context) error {
+		s.claim(ctx)
+
+		return nil
+	})
+
+	workerPool.Go(func(ctx context.Context) error {
+		s.logStats(ctx)
+
+		return nil
+	})
+
+	zap.L().Info("verifier started", zap.String("consumer", s.consumer), zap.Int("workers", s.config.Workers))
+
+	stopchan := make(chan os.Signal, 1)
+
+	signal.Notify(stopchan, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
+
+	select {
+	case <-stopchan:
+	case <-ctx.Done():
+	}
+
+	// The tasks being processed are finished before exiting.
+	cancel()
+
+	return workerPool.Wait()
+}
+
+// consume reads and processes the new tasks until the context is canceled.
+func (s *server) consume(ctx context.Context, consumer string) {
+	for ctx.Err() == nil {
+		messages, err := s.cacheClient.XReadGroup(ctx, model.VerificationStreamKey, model.VerificationGroup, consumer, readCount, readBlock)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			zap.L().Error("read verification tasks", zap.String("consumer", consumer), zap.Error(err))
+
+			time.Sleep(readRetryInterval)
+
+			continue
+		}
+
+		for _, message := range messages {
+			// The task is processed with a context that is not canceled on shutdown.
+			s.handle(context.WithoutCancel(ctx), message)
+		}
+	}
+}
+
+// claim processes the tasks left unacknowledged by the crashed verifiers.
+func (s *server) claim(ctx context.Context) {
+	consumer := fmt.Sprintf("%s-claimer", s.consumer)
+
+	ticker := time.NewTicker(s.config.ClaimIdle / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		messages, err := s.cacheClient.XAutoClaim(ctx, model.VerificationStreamKey, model.VerificationGroup, consumer, s.config.ClaimIdle, claimCount)
+		if err != nil {
+			zap.L().Error("claim idle verification tasks", zap.Error(err))
+
+			continue
+		}
+
+		if len(messages) > 0 {
+			zap.L().Info("claim idle verification tasks", zap.Int("tasks", len(messages)))
+		}
+
+		for _, message := range messages {
+			s.handle(context.WithoutCancel(ctx), message)
+		}
+	}
+}
+
+// handle processes a task, a failed task is enqueued again until it runs out of retries,
+// then it is moved to the dead-letter stream.
+func (s *server) handle(ctx context.Context, message redis.XMessage) {
+	task, err := decodeTask(message)
+	if err != nil {
+		zap.L().Error("decode verification task", zap.String("message", message.ID), zap.Error(err))
+
+		s.deadLetter(ctx, message, &model.VerificationTask{}, err)
+
+		return
+	}
+
+	processedKey := fmt.Sprintf("%s:%s", model.VerificationProcessedKey, task.ID)
+
+	// The task has been processed before it was redelivered.
+	if exists, err := s.cacheClient.Exists(ctx, processedKey); err == nil && exists > 0 {
+		s.metrics.recordTask(ctx, resultDuplicate, task)
+		s.ack(ctx, message)
+
+		return
+	}
+
+	// A slow task claimed from another verifier is left to it, the task is claimed again if that verifier crashed.
+	mutex := s.cacheClient.NewMutex(fmt.Sprintf("%s:%s", model.VerificationLockKey, task.ID), redsync.WithExpiry(s.config.ClaimIdle), redsync.WithTries(1))
+
+	if err := mutex.LockContext(ctx); err != nil {
+		zap.L().Warn("verification task is being processed by another verifier", zap.String("id", task.ID), zap.Error(err))
+
+		return
+	}
+
+	defer func() {
+		if _, err := mutex.UnlockContext(ctx); err != nil {
+			zap.L().Error("unlock verification task", zap.String("id", task.ID), zap.Error(err))
+		}
+	}()
+
+	if err := s.process(ctx, task); err != nil {
+		s.retry(ctx, message, task, err)
+
+		return
+	}
+
+	if err := s.cacheClient.Set(ctx, processedKey, true, s.config.IdempotencyTTL); err != nil {
+		zap.L().Error("mark verification task processed", zap.String("id", task.ID), zap.Error(err))
+	}
+
+	s.metrics.recordTask(ctx, resultProcessed, task)
+	s.ack(ctx, message)
+}
+
+// process processes the task, a panic is recovered as an error.
+func (s *server) process(ctx context.Context, task *model.VerificationTask) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return s.processor.ProcessVerificationTask(ctx, task)
+}
+
+func (s *server) retry(ctx context.Context, message redis.XMessage, task *model.VerificationTask, cause error) {
+	task.Attempt++
+
+	if task.Attempt > s.config.MaxRetries {
+		s.deadLetter(ctx, message, task, cause)
+
+		return
+	}
+
+	zap.L().Warn("retry verification task", zap.String("id", task.ID), zap.Int("attempt", task.Attempt), zap.Error(cause))
+
+	data, err := json.Marshal(task)
+	if err != nil {
+		zap.L().Error("marshal verification task", zap.String("id", task.ID), zap.Error(err))
+
+		return
+	}
+
+	// The task is left unacknowledged to be claimed later if it cannot be enqueued again.
+	if _, err := s.cacheClient.XAdd(ctx, model.VerificationStreamKey, s.config.MaxLength, map[string]interface{}{"task": data}); err != nil {
+		zap.L().Error("enqueue verification task again", zap.String("id", task.ID), zap.Error(err))
+
+		return
+	}
+
+	s.metrics.recordTask(ctx, resultRetried, task)
+	s.ack(ctx, message)
+}
+
+func (s *server) deadLetter(ctx context.Context, message redis.XMessage, task *model.VerificationTask, cause error) {
+	zap.L().Error("move verification task to dead-letter stream", zap.String("id", task.ID), zap.Int("attempt", task.Attempt), zap.Error(cause))
+
+	values := map[string]interface{}{
+		"error":   cause.Error(),
+		"message": message.ID,
+	}
+
+	// The message is kept as is if it cannot be decoded into a task.
+	if data, err := json.Marshal(task); err == nil && task.ID != "" {
+		values["task"] = data
+	} else if data, ok := message.Values["task"].(string); ok {
+		values["task"] = data
+	}
+
+	if _, err := s.cacheClient.XAdd(ctx, model.VerificationDeadLetterStreamKey, s.config.MaxLength, values); err != nil {
+		zap.L().Error("add verification task to dead-letter stream", zap.String("id", task.ID), zap.Error(err))
+
+		return
+	}
+
+	s.metrics.recordTask(ctx, resultDeadLettered, task)
+	s.ack(ctx, message)
+}
+
+func (s *server) ack(ctx context.Context, message redis.XMessage) {
+	if err := s.cacheClient.XAck(ctx, model.VerificationStreamKey, model.VerificationGroup, message.ID); err != nil {
+		zap.L().Error("acknowledge verification task", zap.String("message", message.ID), zap.Error(err))
+	}
+}
+
+// logStats logs the backlog of the queue periodically.
+func (s *server) logStats(ctx context.Context) {
+	ticker := time.NewTicker(statsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		stats, err := s.stats(ctx)
+		if err != nil {
+			zap.L().Error("get verification queue stats", zap.Error(err))
+
+			continue
+		}
+
+		zap.L().Info("verification queue stats", zap.Int64("length", stats.length), zap.Int64("pending", stats.pending), zap.Int64("dead_letter", stats.deadLetter))
+	}
+}
+
+type queueStats struct {
+	length     int64
+	pending    int64
+	deadLetter int64
+}
+
+func (s *server) stats(ctx context.Context) (*queueStats, error) {
+	var (
+		stats queueStats
+		err   error
+	)
+
+	if stats.length, err = s.cacheClient.XLen(ctx, model.VerificationStreamKey); err != nil {
+		return nil, fmt.Errorf("get stream length: %w", err)
+	}
+
+	if stats.pending, err = s.cacheClient.XPendingCount(ctx, model.VerificationStreamKey, model.VerificationGroup); err != nil {
+		return nil, fmt.Errorf("get pending count: %w", err)
+	}
+
+	if stats.deadLetter, err = s.cacheClient.XLen(ctx, model.VerificationDeadLetterStreamKey); err != nil {
+		return nil, fmt.Errorf("get dead-letter stream length: %w", err)
+	}
+
+	return &stats, nil
+}
+
+func decodeTask(message redis.XMessage) (*model.VerificationTask, error) {
+	data, ok := message.Values["task"].(string)
+	if !ok {
+		return nil, errors.New("missing task")
+	}
+
+	var task model.VerificationTask
+
+	if err := json.Unmarshal([]byte(data), &task); err != nil {
+		return nil, fmt.Errorf("unmarshal task: %w", err)
+	}
+
+	return &task, nil
+}
+
+func newServer(cacheClient cache.Client, processor processor, config *model.VerificationQueueConfig) (*server, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("get hostname: %w", err)
+	}
+
+	s := &server{
+		cacheClient: cacheClient,
+		processor:   processor,
+		config:      config,
+		consumer:    fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+	}
+
+	if s.metrics, err = newMetrics(s.stats); err != nil {
+		return nil, fmt.Errorf("new metrics: %w", err)
+	}
+
+	return s, nil
+}
+
+func New(databaseClient database.Client, cacheClient cache.Client, ethereumClient *ethclient.Client, httpClient httputil.Client) (service.Server, error) {
+	chainID, err := ethereumClient.ChainID(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("get chain id: %w", err)
+	}
+
+	contractAddresses := l2.ContractMap[chainID.Uint64()]
+	if contractAddresses == nil {
+		return nil, fmt.Errorf("contract address not found for chain id: %d", chainID.Uint64())
+	}
+
+	stakingContract, err := stakingv2.NewStaking(contractAddresses.AddressStakingProxy, ethereumClient)
+	if err != nil {
+		return nil, fmt.Errorf("new staking contract: %w", err)
+	}
+
+	dataDistributor, err := distributor.NewDistributor(context.Background(), databaseClient, cacheClient, httpClient, stakingContract)
+	if err != nil {
+		return nil, fmt.Errorf("new distributor: %w", err)
+	}
+
+	return newServer(cacheClient, dataDistributor, model.VerificationQueue)
+}
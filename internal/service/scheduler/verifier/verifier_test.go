@@ -0,0 +1,155 @@
+package verifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-redsync/redsync/v4"
+	"github.com/rss3-network/global-indexer/internal/cache"
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/stretchr/testify/require"
+)
+
+type mockProcessor struct {
+	mu       sync.Mutex
+	attempts map[string]int
+	// failures is the number of the attempts that fail for each task.
+	failures map[string]int
+}
+
+func (p *mockProcessor) ProcessVerificationTask(_ context.Context, task *model.VerificationTask) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.attempts[task.ID]++
+
+	if p.attempts[task.ID] <= p.failures[task.ID] {
+		return errors.New("process failed")
+	}
+
+	return nil
+}
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx         = context.Background()
+		cacheClient = cache.NewMemory()
+		processor   = &mockProcessor{
+			attempts: make(map[string]int),
+			failures: map[string]int{"flaky": 2, "broken": 10},
+		}
+	)
+
+	s, err := newServer(cacheClient, processor, &model.VerificationQueueConfig{
+		Workers:        1,
+		MaxRetries:     2,
+		MaxLength:      100,
+		ClaimIdle:      time.Minute,
+		IdempotencyTTL: time.Hour,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, cacheClient.XGroupCreate(ctx, model.VerificationStreamKey, model.VerificationGroup))
+
+	for _, id := range []string{"ok", "flaky", "broken", "ok"} {
+		data, err := json.Marshal(model.NewVerificationTask(id, model.VerificationTaskTypeActivity, nil))
+		require.NoError(t, err)
+
+		_, err = cacheClient.XAdd(ctx, model.VerificationStreamKey, 0, map[string]interface{}{"task": data})
+		require.NoError(t, err)
+	}
+
+	// Drain the stream, the failed tasks are enqueued again.
+	for {
+		messages, err := cacheClient.XReadGroup(ctx, model.VerificationStreamKey, model.VerificationGroup, "test", 10, 0)
+		require.NoError(t, err)
+
+		if len(messages) == 0 {
+			break
+		}
+
+		for _, message := range messages {
+			s.handle(ctx, message)
+		}
+	}
+
+	// The duplicate task is processed once.
+	require.Equal(t, map[string]int{"ok": 1, "flaky": 3, "broken": 3}, processor.attempts)
+
+	pending, err := cacheClient.XPendingCount(ctx, model.VerificationStreamKey, model.VerificationGroup)
+	require.NoError(t, err)
+	require.Zero(t, pending)
+
+	stats, err := s.stats(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), stats.deadLetter)
+
+	require.NoError(t, cacheClient.XGroupCreate(ctx, model.VerificationDeadLetterStreamKey, "test"))
+
+	messages, err := cacheClient.XReadGroup(ctx, model.VerificationDeadLetterStreamKey, "test", "test", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	require.Equal(t, "process failed", messages[0].Values["error"])
+
+	task, err := decodeTask(messages[0])
+	require.NoError(t, err)
+	require.Equal(t, "broken", task.ID)
+	require.Equal(t, 3, task.Attempt)
+}
+
+func TestServerLockedTask(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx         = context.Background()
+		cacheClient = cache.NewMemory()
+		processor   = &mockProcessor{attempts: make(map[string]int)}
+	)
+
+	s, err := newServer(cacheClient, processor, &model.VerificationQueueConfig{
+		Workers:        1,
+		MaxRetries:     2,
+		MaxLength:      100,
+		ClaimIdle:      time.Minute,
+		IdempotencyTTL: time.Hour,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, cacheClient.XGroupCreate(ctx, model.VerificationStreamKey, model.VerificationGroup))
+
+	data, err := json.Marshal(model.NewVerificationTask("slow", model.VerificationTaskTypeActivity, nil))
+	require.NoError(t, err)
+
+	_, err = cacheClient.XAdd(ctx, model.VerificationStreamKey, 0, map[string]interface{}{"task": data})
+	require.NoError(t, err)
+
+	// Another verifier is processing the task.
+	mutex := cacheClient.NewMutex(model.VerificationLockKey+":slow", redsync.WithExpiry(time.Minute))
+	require.NoError(t, mutex.LockContext(ctx))
+
+	messages, err := cacheClient.XReadGroup(ctx, model.VerificationStreamKey, model.VerificationGroup, "test", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+
+	s.handle(ctx, messages[0])
+
+	// The task is left unacknowledged to the verifier processing it.
+	require.Zero(t, processor.attempts["slow"])
+
+	pending, err := cacheClient.XPendingCount(ctx, model.VerificationStreamKey, model.VerificationGroup)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), pending)
+
+	_, err = mutex.UnlockContext(ctx)
+	require.NoError(t, err)
+
+	s.handle(ctx, messages[0])
+
+	require.Equal(t, 1, processor.attempts["slow"])
+}
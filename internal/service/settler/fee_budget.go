@@ -0,0 +1,58 @@
+package settler
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/rss3-network/global-indexer/common/txmgr"
+	"github.com/rss3-network/global-indexer/schema"
+	"go.uber.org/zap"
+)
+
+// epochFeeBudget returns the remaining fee budget of the epoch,
+// which is the configured budget minus the fees paid by the confirmed batches.
+// nil means the budget is unlimited.
+func (s *Server) epochFeeBudget(ctx context.Context, plan *schema.SettlementPlan) (*big.Int, error) {
+	if s.settlerConfig.MaxFeePerEpochInGwei == 0 {
+		return nil, nil
+	}
+
+	budget := txmgr.GweiToWei(s.settlerConfig.MaxFeePerEpochInGwei)
+
+	for _, batch := range plan.Batches {
+		if batch.Status != schema.SettlementBatchStatusConfirmed || batch.TransactionHash == nil {
+			continue
+		}
+
+		receipt, err := s.ethereumClient.TransactionReceipt(ctx, *batch.TransactionHash)
+		if err != nil {
+			return nil, fmt.Errorf("get transaction receipt %s: %w", batch.TransactionHash, err)
+		}
+
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+		budget.Sub(budget, fee)
+	}
+
+	if budget.Sign() < 0 {
+		budget.SetInt64(0)
+	}
+
+	return budget, nil
+}
+
+// alertFeeBudgetExceeded records the exceeded fee budget on the batch, so that it can be alerted on.
+func (s *Server) alertFeeBudgetExceeded(ctx context.Context, batch *schema.SettlementBatch, err error) {
+	zap.L().Error("settlement fee budget exceeded", zap.Uint64("epoch", batch.EpochID), zap.Int("index", batch.Index), zap.Error(err))
+
+	s.recordBatchError(ctx, batch, err)
+}
+
+// recordBatchError records the condition preventing the batch from being submitted.
+func (s *Server) recordBatchError(ctx context.Context, batch *schema.SettlementBatch, err error) {
+	batch.Error = err.Error()
+
+	if err := s.databaseClient.UpdateSettlementBatch(ctx, batch); err != nil {
+		zap.L().Error("update settlement batch", zap.Uint64("epoch", batch.EpochID), zap.Int("index", batch.Index), zap.Error(err))
+	}
+}
@@ -8,15 +8,15 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/go-redsync/redsync/v4"
-	"github.com/go-redsync/redsync/v4/redis/goredis/v9"
-	"github.com/redis/go-redis/v9"
 	gicrypto "github.com/rss3-network/global-indexer/common/crypto"
 	"github.com/rss3-network/global-indexer/common/txmgr"
 	"github.com/rss3-network/global-indexer/contract/l2"
 	stakingv2 "github.com/rss3-network/global-indexer/contract/l2/staking/v2"
+	"github.com/rss3-network/global-indexer/internal/cache"
 	"github.com/rss3-network/global-indexer/internal/client/ethereum"
 	"github.com/rss3-network/global-indexer/internal/config"
 	"github.com/rss3-network/global-indexer/internal/config/flag"
@@ -180,6 +180,10 @@ func (s *Server) listenEpochEvent(ctx context.Context) error {
 				if err := s.submitEpochProof(ctx, s.currentEpoch+1); err != nil {
 					zap.L().Error("trigger new epoch", zap.Error(err))
 
+					if s.waitIfBlocked(err, timer) {
+						continue
+					}
+
 					return err
 				}
 			} else if timeSinceLastTrigger < epochInterval {
@@ -206,6 +210,10 @@ func (s *Server) listenEpochEvent(ctx context.Context) error {
 						if err := s.retryEpochProof(ctx, lastEpochTrigger.EpochID); err != nil {
 							zap.L().Error("retry epoch trigger", zap.Error(err))
 
+							if s.waitIfBlocked(err, timer) {
+								continue
+							}
+
 							return err
 						}
 					}
@@ -220,12 +228,32 @@ func (s *Server) listenEpochEvent(ctx context.Context) error {
 
 			if err := s.submitEpochProof(ctx, s.currentEpoch+1); err != nil {
 				zap.L().Error("submitEpochProof new epoch", zap.Error(err))
+
+				if s.waitIfBlocked(err, timer) {
+					continue
+				}
+
 				return err
 			}
 		}
 	}
 }
 
+// waitIfBlocked waits for a while if the transaction manager is halted, awaiting offline signature or the fee budget is exceeded,
+// the settler stays alive and retries after an operator intervenes or the fees drop.
+func (s *Server) waitIfBlocked(err error, timer *time.Timer) bool {
+	if isRetryable(err) {
+		return false
+	}
+
+	zap.L().Error("settler is blocked, waiting before retrying", zap.Error(err))
+
+	timer.Reset(time.Minute)
+	<-timer.C
+
+	return true
+}
+
 func (s *Server) loadCheckpoint(ctx context.Context) (uint64, uint64, error) {
 	// Load checkpoint from database.
 	// A checkpoint is basically the last indexed block
@@ -247,10 +275,7 @@ func (s *Server) loadCheckpoint(ctx context.Context) (uint64, uint64, error) {
 	return indexedBlock.BlockNumber, latestFinalizedBlock.NumberU64(), nil
 }
 
-func NewServer(databaseClient database.Client, redisClient *redis.Client, ethereumMultiChainClient *ethereum.MultiChainClient, config *config.File) (service.Server, error) {
-	redisPool := goredis.NewPool(redisClient)
-	rs := redsync.New(redisPool)
-
+func NewServer(databaseClient database.Client, cacheClient cache.Client, ethereumMultiChainClient *ethereum.MultiChainClient, config *config.File) (service.Server, error) {
 	chainID := new(big.Int).SetUint64(viper.GetUint64(flag.KeyChainIDL2))
 
 	ethereumClient, err := ethereumMultiChainClient.Get(chainID.Uint64())
@@ -273,32 +298,14 @@ func NewServer(databaseClient database.Client, redisClient *redis.Client, ethere
 		return nil, fmt.Errorf("new settlement contract: %w", err)
 	}
 
-	signerFactory, from, err := gicrypto.NewSignerFactory(config.Settler.PrivateKey, config.Settler.SignerEndpoint, config.Settler.WalletAddress)
-
+	txManager, err := NewTxManager(Name, databaseClient, cacheClient, ethereumClient, chainID, config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create signer")
-	}
-
-	defaultTxConfig := txmgr.Config{
-		ResubmissionTimeout:       20 * time.Second,
-		FeeLimitMultiplier:        5,
-		TxSendTimeout:             5 * time.Minute,
-		TxNotInMempoolTimeout:     1 * time.Hour,
-		NetworkTimeout:            5 * time.Minute,
-		ReceiptQueryInterval:      500 * time.Millisecond,
-		NumConfirmations:          5,
-		SafeAbortNonceTooLowCount: 3,
-	}
-
-	txManager, err := txmgr.NewSimpleTxManager(defaultTxConfig, chainID, nil, ethereumClient, from, signerFactory(chainID))
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to create tx manager")
+		return nil, err
 	}
 
 	server := &Server{
 		chainID:            chainID,
-		mutex:              rs.NewMutex(Name, redsync.WithExpiry(5*time.Minute)),
+		mutex:              cacheClient.NewMutex(Name, redsync.WithExpiry(5*time.Minute)),
 		settlerConfig:      config.Settler,
 		ethereumClient:     ethereumClient,
 		databaseClient:     databaseClient,
@@ -310,3 +317,64 @@ func NewServer(databaseClient database.Client, redisClient *redis.Client, ethere
 
 	return server, nil
 }
+
+// NewTxManager creates the transaction manager of the owner on the settler wallet,
+// which is signed by the private key, the keystore, the offline mode or the remote signer in the settler config.
+func NewTxManager(owner string, databaseClient database.Client, cacheClient cache.Client, ethereumClient *ethclient.Client, chainID *big.Int, config *config.File) (*txmgr.SimpleTxManager, error) {
+	signerFactory, from, err := newSignerFactory(config.Settler)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signer: %w", err)
+	}
+
+	// The nonce locker is shared by all owners of the settler wallet.
+	nonceLocker := cacheClient.NewMutex(fmt.Sprintf(txmgr.NonceLockKey, chainID.Uint64(), from), redsync.WithExpiry(time.Minute))
+
+	txManager, err := txmgr.NewSimpleTxManager(NewTxConfig(owner, config.Settler), chainID, databaseClient, nonceLocker, ethereumClient, from, signerFactory(chainID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tx manager: %w", err)
+	}
+
+	return txManager, nil
+}
+
+// NewTxConfig creates the config of the transaction manager of the owner from the settler config,
+// which is shared by all owners of the settler wallet.
+func NewTxConfig(owner string, settler *config.Settler) txmgr.Config {
+	conf := txmgr.NewConfig(owner, settler.ResubmissionTimeout, settler.FeeLimitMultiplier, settler.NumConfirmations)
+
+	conf.FeeOracle = txmgr.FeeOracleConfig{
+		Mode:       txmgr.FeeOracleMode(settler.FeeOracle.Mode),
+		Percentile: settler.FeeOracle.Percentile,
+		Blocks:     settler.FeeOracle.Blocks,
+	}
+
+	if settler.MaxFeePerTransactionInGwei > 0 {
+		conf.MaxFeePerTransaction = txmgr.GweiToWei(settler.MaxFeePerTransactionInGwei)
+	}
+
+	if settler.FeeOracle.GasTipCapInGwei > 0 {
+		conf.FeeOracle.GasTipCap = txmgr.GweiToWei(settler.FeeOracle.GasTipCapInGwei)
+	}
+
+	return conf
+}
+
+// newSignerFactory creates the signer of the settler wallet,
+// in the order of the remote signer, the keystore, the offline mode and the plaintext private key.
+func newSignerFactory(settler *config.Settler) (gicrypto.SignerFactory, common.Address, error) {
+	switch {
+	case settler.SignerEndpoint != "" && settler.WalletAddress != "":
+		return gicrypto.NewSignerFactory("", settler.SignerEndpoint, settler.WalletAddress)
+	case settler.Keystore != nil:
+		passphrase, err := gicrypto.ReadPassphrase(settler.Keystore.PassphraseFile, settler.Keystore.PassphraseEnv)
+		if err != nil {
+			return nil, common.Address{}, err
+		}
+
+		return gicrypto.NewKeystoreSignerFactory(settler.Keystore.Path, passphrase)
+	case settler.Offline != nil:
+		return gicrypto.NewOfflineSignerFactory(settler.Offline.Directory, settler.WalletAddress)
+	default:
+		return gicrypto.NewSignerFactory(settler.PrivateKey, "", "")
+	}
+}
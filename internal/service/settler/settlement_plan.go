@@ -0,0 +1,150 @@
+package settler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// loadSettlementPlan loads the persisted settlement plan of the epoch,
+// or creates one by freezing the current set of online Nodes into batches.
+func (s *Server) loadSettlementPlan(ctx context.Context, epoch uint64) (*schema.SettlementPlan, error) {
+	plan, err := s.databaseClient.FindSettlementPlan(ctx, epoch)
+	if err == nil {
+		zap.L().Info("resume settlement plan", zap.Uint64("epoch", epoch), zap.Int("batches", len(plan.Batches)))
+
+		return plan, nil
+	}
+
+	if !errors.Is(err, database.ErrorRowNotFound) {
+		return nil, fmt.Errorf("find settlement plan: %w", err)
+	}
+
+	nodeAddresses, err := s.findSettlementNodeAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan = schema.NewSettlementPlan(epoch, nodeAddresses, s.settlerConfig.BatchSize)
+
+	if err := s.databaseClient.SaveSettlementPlan(ctx, plan); err != nil {
+		return nil, fmt.Errorf("save settlement plan: %w", err)
+	}
+
+	zap.L().Info("create settlement plan", zap.Uint64("epoch", epoch), zap.Int("nodes", len(nodeAddresses)), zap.Int("batches", len(plan.Batches)))
+
+	// Reload the plan, the persisted one is authoritative.
+	if plan, err = s.databaseClient.FindSettlementPlan(ctx, epoch); err != nil {
+		return nil, fmt.Errorf("reload settlement plan: %w", err)
+	}
+
+	return plan, nil
+}
+
+// findSettlementNodeAddresses finds the addresses of all online Nodes, including the degraded ones.
+func (s *Server) findSettlementNodeAddresses(ctx context.Context) ([]common.Address, error) {
+	var (
+		cursor        *string
+		nodeAddresses = make([]common.Address, 0)
+	)
+
+	for {
+		nodes, err := s.databaseClient.FindNodes(ctx, schema.FindNodesQuery{
+			Statuses: []schema.NodeStatus{schema.NodeStatusOnline, schema.NodeStatusDegraded},
+			Cursor:   cursor,
+			Limit:    lo.ToPtr(s.settlerConfig.BatchSize),
+		})
+		if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+			return nil, fmt.Errorf("find online nodes: %w", err)
+		}
+
+		if len(nodes) == 0 {
+			return nodeAddresses, nil
+		}
+
+		for _, node := range nodes {
+			nodeAddresses = append(nodeAddresses, node.Address)
+		}
+
+		cursor = lo.ToPtr(nodes[len(nodes)-1].Address.String())
+	}
+}
+
+// recoverSettlementBatch checks whether a batch left in the submitting state has already landed on chain.
+// It returns the receipt of the landed transaction, or nil if the batch must be submitted again.
+func (s *Server) recoverSettlementBatch(ctx context.Context, batch *schema.SettlementBatch) (*types.Receipt, error) {
+	transactionHash := batch.TransactionHash
+
+	// The transaction hash is unknown if the process died while the transaction was in flight,
+	// look it up from the indexed reward distributions of the epoch instead.
+	if transactionHash == nil {
+		var err error
+
+		if transactionHash, err = s.findSettlementBatchTransaction(ctx, batch); err != nil {
+			return nil, err
+		}
+	}
+
+	if transactionHash == nil {
+		return nil, nil
+	}
+
+	receipt, err := s.ethereumClient.TransactionReceipt(ctx, *transactionHash)
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("get transaction receipt %s: %w", transactionHash, err)
+	}
+
+	return receipt, nil
+}
+
+// findSettlementBatchTransaction finds the hash of the indexed transaction that settled the batch.
+func (s *Server) findSettlementBatchTransaction(ctx context.Context, batch *schema.SettlementBatch) (*common.Hash, error) {
+	if len(batch.NodeAddresses) > 0 {
+		epochs, err := s.databaseClient.FindEpochNodeRewards(ctx, batch.NodeAddresses[0], 1, nil)
+		if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+			return nil, fmt.Errorf("find epoch node rewards: %w", err)
+		}
+
+		if len(epochs) > 0 && epochs[0].ID == batch.EpochID {
+			return lo.ToPtr(epochs[0].TransactionHash), nil
+		}
+	} else {
+		epochs, err := s.databaseClient.FindEpochs(ctx, &schema.FindEpochsQuery{EpochID: lo.ToPtr(batch.EpochID)})
+		if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+			return nil, fmt.Errorf("find epochs: %w", err)
+		}
+
+		for _, epoch := range epochs {
+			if epoch.TotalRewardedNodes == 0 {
+				return lo.ToPtr(epoch.TransactionHash), nil
+			}
+		}
+	}
+
+	// The final batch advances the epoch of the Settlement contract, which is visible before the indexer catches up.
+	if batch.IsFinal {
+		currentEpoch, err := s.settlementContract.CurrentEpoch(&bind.CallOpts{Context: ctx})
+		if err != nil {
+			return nil, fmt.Errorf("get current epoch from chain: %w", err)
+		}
+
+		if currentEpoch.Uint64() >= batch.EpochID {
+			return nil, fmt.Errorf("final batch of epoch %d landed on chain but its transaction is not indexed yet", batch.EpochID)
+		}
+	}
+
+	return nil, nil
+}
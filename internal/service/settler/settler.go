@@ -23,6 +23,7 @@ import (
 // which calculates the Operation Rewards for the Nodes
 // formats the data and invokes the contract
 // a retry logic is implemented to handle possible failures
+// The batches are driven by a persisted settlement plan, a restarted settler resumes at the first unconfirmed batch
 func (s *Server) submitEpochProof(ctx context.Context, epoch uint64) error {
 	if err := s.mutex.Lock(); err != nil {
 		zap.L().Error("lock error", zap.String("key", s.mutex.Name()), zap.Error(err))
@@ -36,67 +37,128 @@ func (s *Server) submitEpochProof(ctx context.Context, epoch uint64) error {
 		}
 	}()
 
+	plan, err := s.loadSettlementPlan(ctx, epoch)
+	if err != nil {
+		zap.L().Error("load settlement plan", zap.Uint64("epoch", epoch), zap.Error(err))
+
+		return err
+	}
+
+	for batch := plan.NextBatch(); batch != nil; batch = plan.NextBatch() {
+		feeBudget, err := s.epochFeeBudget(ctx, plan)
+		if err != nil {
+			return err
+		}
+
+		if err := s.submitSettlementBatch(ctx, batch, feeBudget); err != nil {
+			zap.L().Error("submit settlement batch", zap.Uint64("epoch", epoch), zap.Int("index", batch.Index), zap.Error(err))
+
+			return err
+		}
+	}
+
+	zap.L().Info("Epoch Proof submitted successfully", zap.Uint64("settler", epoch))
+
+	return nil
+}
+
+// submitSettlementBatch submits a single batch of the settlement plan and records its progress
+// feeBudget is the remaining fee budget of the epoch, nil means unlimited
+func (s *Server) submitSettlementBatch(ctx context.Context, batch *schema.SettlementBatch, feeBudget *big.Int) error {
 	var (
-		cursor      *string
-		firstInvoke = true
+		nodes  []*schema.Node
+		scores []*big.Float
 	)
 
-	for {
+	// The process died during the previous submission of this batch, check whether it has landed on chain
+	if batch.Status == schema.SettlementBatchStatusSubmitting {
+		receipt, err := s.recoverSettlementBatch(ctx, batch)
+		if err != nil {
+			return fmt.Errorf("recover settlement batch: %w", err)
+		}
+
+		if receipt != nil && receipt.Status == types.ReceiptStatusSuccessful {
+			zap.L().Info("settlement batch already landed on chain", zap.Uint64("epoch", batch.EpochID), zap.Int("index", batch.Index), zap.String("tx", receipt.TxHash.String()))
+
+			return s.confirmSettlementBatch(ctx, batch, receipt)
+		}
+	}
+
+	// Freeze the data of the batch before the first submission, so that retries send identical data
+	if batch.Data == nil {
 		msg := "construct Settlement data"
 		// Construct transactionData as required by the Settlement contract
-		transactionData, nodes, scores, err := s.constructSettlementData(ctx, epoch, cursor)
+		transactionData, batchNodes, batchScores, err := s.constructSettlementData(ctx, batch)
 		if err != nil {
 			zap.L().Error(msg, zap.Error(err))
 
 			return fmt.Errorf("%s: %w", msg, err)
 		}
 
-		// Finish processing when conditions are met
-		if len(transactionData.NodeAddress) == 0 && !firstInvoke {
-			zap.L().Info("finished processing transactionData.")
+		zap.L().Info(msg, zap.Any("transactionData", transactionData))
 
-			break
-		}
+		batch.Data, nodes, scores = transactionData, batchNodes, batchScores
+	}
 
-		zap.L().Info(msg, zap.Any("transactionData", transactionData))
+	batch.Status = schema.SettlementBatchStatusSubmitting
 
-		// Invoke the Settlement contract
-		receipt, err := retry.DoWithData(
-			func() (*types.Receipt, error) {
-				return s.invokeSettlementContract(ctx, *transactionData)
-			},
-			retry.Delay(time.Second),
-			retry.Attempts(5),
-		)
-		if err != nil {
-			zap.L().Error("retry submitEpochProof invokeSettlementContract", zap.Error(err))
+	if err := s.databaseClient.UpdateSettlementBatch(ctx, batch); err != nil {
+		return fmt.Errorf("update settlement batch: %w", err)
+	}
 
-			return err
-		}
+	// Invoke the Settlement contract
+	receipt, err := retry.DoWithData(
+		func() (*types.Receipt, error) {
+			return s.invokeSettlementContract(ctx, *batch.Data, feeBudget)
+		},
+		retry.Delay(time.Second),
+		retry.Attempts(5),
+		retry.RetryIf(isRetryable),
+	)
+	if err != nil {
+		zap.L().Error("retry submitEpochProof invokeSettlementContract", zap.Error(err))
 
-		// Save the Settlement to the database, as the reference point for the next Epoch
-		if err := s.saveSettlement(ctx, receipt, *transactionData); err != nil {
-			return err
+		switch {
+		case errors.Is(err, txmgr.ErrFeeBudgetExceeded):
+			s.alertFeeBudgetExceeded(ctx, batch, err)
+		case errors.Is(err, txmgr.ErrAwaitingSignature):
+			s.recordBatchError(ctx, batch, err)
 		}
 
-		zap.L().Info("Settlement contracted invoked successfully", zap.String("tx", receipt.TxHash.String()), zap.Any("data", *transactionData))
+		return err
+	}
 
-		firstInvoke = false
+	zap.L().Info("Settlement contracted invoked successfully", zap.String("tx", receipt.TxHash.String()), zap.Any("data", *batch.Data))
 
-		// Update the Node scores
-		if len(nodes) > 0 {
-			err = s.updateNodesScore(ctx, scores, nodes)
-			if err != nil {
-				zap.L().Error("failed to update node scores", zap.Error(err))
-			}
-		}
+	if err := s.confirmSettlementBatch(ctx, batch, receipt); err != nil {
+		return err
+	}
 
-		if len(transactionData.NodeAddress) > 0 {
-			cursor = lo.ToPtr(transactionData.NodeAddress[len(transactionData.NodeAddress)-1].String())
+	// Update the Node scores, which are only known when the data was constructed by this process
+	if len(nodes) > 0 {
+		if err := s.updateNodesScore(ctx, scores, nodes); err != nil {
+			zap.L().Error("failed to update node scores", zap.Error(err))
 		}
 	}
 
-	zap.L().Info("Epoch Proof submitted successfully", zap.Uint64("settler", epoch))
+	return nil
+}
+
+// confirmSettlementBatch records the receipt of a batch and saves the Settlement
+func (s *Server) confirmSettlementBatch(ctx context.Context, batch *schema.SettlementBatch, receipt *types.Receipt) error {
+	// Save the Settlement to the database, as the reference point for the next Epoch
+	if err := s.saveSettlement(ctx, receipt, *batch.Data); err != nil {
+		return err
+	}
+
+	batch.TransactionHash = lo.ToPtr(receipt.TxHash)
+	batch.ReceiptStatus = lo.ToPtr(receipt.Status)
+	batch.Status = schema.SettlementBatchStatusConfirmed
+	batch.Error = ""
+
+	if err := s.databaseClient.UpdateSettlementBatch(ctx, batch); err != nil {
+		return fmt.Errorf("update settlement batch: %w", err)
+	}
 
 	return nil
 }
@@ -127,8 +189,9 @@ func (s *Server) retryEpochProof(ctx context.Context, epochID uint64) error {
 	for _, trigger := range epochTriggers {
 		// Invoke the Settlement contract
 		receipt, err := retry.DoWithData(func() (*types.Receipt, error) {
-			return s.invokeSettlementContract(ctx, trigger.Data)
-		}, retry.Delay(time.Second), retry.Attempts(5))
+			// The epoch proof must be resubmitted after a reorg regardless of the epoch fee budget
+			return s.invokeSettlementContract(ctx, trigger.Data, nil)
+		}, retry.Delay(time.Second), retry.Attempts(5), retry.RetryIf(isRetryable))
 
 		if err != nil {
 			zap.L().Error("retry submitEpochProof invokeSettlementContract", zap.Error(err))
@@ -144,39 +207,38 @@ func (s *Server) retryEpochProof(ctx context.Context, epochID uint64) error {
 }
 
 // constructSettlementData constructs Settlement data as required by the Settlement contract
-func (s *Server) constructSettlementData(ctx context.Context, epoch uint64, cursor *string) (*schema.SettlementData, []*schema.Node, []*big.Float, error) {
-	// batchSize is the number of Nodes to process in each batch.
-	// This is to prevent the contract call from running out of gas.
-	batchSize := s.settlerConfig.BatchSize
-
-	// Find qualified Nodes from the database
-	nodes, err := s.databaseClient.FindNodes(ctx, schema.FindNodesQuery{
-		Status: lo.ToPtr(schema.NodeStatusOnline),
-		Cursor: cursor,
-		Limit:  lo.ToPtr(batchSize + 1),
-	})
-	if err != nil {
-		// No qualified Nodes found in the database
-		if errors.Is(err, database.ErrorRowNotFound) {
-			return nil, nil, nil, nil
+// for the Nodes frozen in the batch of the settlement plan
+func (s *Server) constructSettlementData(ctx context.Context, batch *schema.SettlementBatch) (*schema.SettlementData, []*schema.Node, []*big.Float, error) {
+	nodes := make([]*schema.Node, 0, len(batch.NodeAddresses))
+
+	// Find the Nodes of the batch from the database
+	if len(batch.NodeAddresses) > 0 {
+		foundNodes, err := s.databaseClient.FindNodes(ctx, schema.FindNodesQuery{
+			NodeAddresses: batch.NodeAddresses,
+		})
+		if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+			zap.L().Error("find Nodes of the settlement batch", zap.Error(err), zap.Uint64("epoch", batch.EpochID), zap.Int("index", batch.Index))
+
+			return nil, nil, nil, err
 		}
 
-		zap.L().Error("No qualified Nodes found", zap.Error(err), zap.Any("cursor", cursor))
+		nodeMap := lo.SliceToMap(foundNodes, func(node *schema.Node) (common.Address, *schema.Node) {
+			return node.Address, node
+		})
 
-		return nil, nil, nil, err
-	}
+		// Keep the order and membership of the batch as planned
+		for _, address := range batch.NodeAddresses {
+			node, exists := nodeMap[address]
+			if !exists {
+				return nil, nil, nil, fmt.Errorf("node %s of the settlement batch not found", address)
+			}
 
-	// isFinal is true if it's the last batch of Nodes
-	isFinal := len(nodes) <= batchSize
-	if !isFinal {
-		nodes = nodes[:batchSize]
+			nodes = append(nodes, node)
+		}
 	}
 
 	// nodeAddresses is a slice of Node addresses
-	nodeAddresses := make([]common.Address, 0, len(nodes))
-	for _, node := range nodes {
-		nodeAddresses = append(nodeAddresses, node.Address)
-	}
+	nodeAddresses := batch.NodeAddresses
 
 	// Update the Node staking data from the VSL.
 	if err := s.fetchNodePoolSizes(nodeAddresses, nodes); err != nil {
@@ -207,11 +269,11 @@ func (s *Server) constructSettlementData(ctx context.Context, epoch uint64, curs
 	}
 
 	return &schema.SettlementData{
-		Epoch:            big.NewInt(int64(epoch)),
+		Epoch:            big.NewInt(int64(batch.EpochID)),
 		NodeAddress:      nodeAddresses,
 		OperationRewards: operationRewards,
 		RequestCount:     requestCount,
-		IsFinal:          isFinal,
+		IsFinal:          batch.IsFinal,
 	}, nodes, scores, nil
 }
 
@@ -247,13 +309,13 @@ func parseScores(scores []*big.Float) ([]decimal.Decimal, error) {
 
 // invokeSettlementContract invokes the Settlement contract with prepared data
 // and saves the Settlement to the database
-func (s *Server) invokeSettlementContract(ctx context.Context, data schema.SettlementData) (*types.Receipt, error) {
+func (s *Server) invokeSettlementContract(ctx context.Context, data schema.SettlementData, feeBudget *big.Int) (*types.Receipt, error) {
 	input, err := s.prepareInputData(data)
 	if err != nil {
 		return nil, err
 	}
 
-	receipt, err := s.sendTransaction(ctx, input)
+	receipt, err := s.sendTransaction(ctx, input, feeBudget)
 	if err != nil {
 		return nil, err
 	}
@@ -262,12 +324,13 @@ func (s *Server) invokeSettlementContract(ctx context.Context, data schema.Settl
 }
 
 // sendTransaction sends the transaction and returns the receipt if successful
-func (s *Server) sendTransaction(ctx context.Context, input []byte) (*types.Receipt, error) {
+func (s *Server) sendTransaction(ctx context.Context, input []byte, feeBudget *big.Int) (*types.Receipt, error) {
 	txCandidate := txmgr.TxCandidate{
-		TxData:   input,
-		To:       lo.ToPtr(l2.ContractMap[s.chainID.Uint64()].AddressSettlementProxy),
-		GasLimit: s.settlerConfig.GasLimit,
-		Value:    big.NewInt(0),
+		TxData:    input,
+		To:        lo.ToPtr(l2.ContractMap[s.chainID.Uint64()].AddressSettlementProxy),
+		GasLimit:  s.settlerConfig.GasLimit,
+		Value:     big.NewInt(0),
+		FeeBudget: feeBudget,
 	}
 
 	receipt, err := s.txManager.Send(ctx, txCandidate)
@@ -278,16 +341,21 @@ func (s *Server) sendTransaction(ctx context.Context, input []byte) (*types.Rece
 	if receipt.Status != types.ReceiptStatusSuccessful {
 		zap.L().Error("received an invalid transaction receipt", zap.String("tx", receipt.TxHash.String()))
 
-		// select {} purposely block the process as it is a critical error and meaningless to continue
-		// if panic() is called, the process will be restarted by the supervisor
-		// we do not want that as it will be stuck in the same state
-		select {}
+		// It is a critical error and meaningless to continue,
+		// the transaction manager is halted until the transaction is resolved by an operator
+		return nil, fmt.Errorf("%w: received an invalid transaction receipt %s", txmgr.ErrHalted, receipt.TxHash)
 	}
 
 	// return the receipt if the transaction is successful
 	return receipt, nil
 }
 
+// isRetryable returns false if the transaction manager is halted, awaiting offline signature or the fee budget is exceeded,
+// as retrying cannot succeed without an operator
+func isRetryable(err error) bool {
+	return !errors.Is(err, txmgr.ErrHalted) && !errors.Is(err, txmgr.ErrFeeBudgetExceeded) && !errors.Is(err, txmgr.ErrAwaitingSignature)
+}
+
 // saveSettlement saves the Settlement data to the database
 func (s *Server) saveSettlement(ctx context.Context, receipt *types.Receipt, data schema.SettlementData) error {
 	if err := s.databaseClient.SaveEpochTrigger(ctx, &schema.EpochTrigger{
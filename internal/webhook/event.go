@@ -0,0 +1,183 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/rss3-network/global-indexer/schema"
+)
+
+// NodeScoreDrop is the data of a node_score_dropped event.
+type NodeScoreDrop struct {
+	NodeAddress   common.Address `json:"node_address"`
+	EpochID       uint64         `json:"epoch_id"`
+	PreviousScore float64        `json:"previous_score"`
+	Score         float64        `json:"score"`
+}
+
+// NodeInvalidResponse is the data of a node_invalid_response event, the evidence is queried by the invalid responses API.
+type NodeInvalidResponse struct {
+	NodeAddress   common.Address                 `json:"node_address"`
+	EpochID       uint64                         `json:"epoch_id"`
+	Type          schema.NodeInvalidResponseType `json:"type"`
+	Request       string                         `json:"request"`
+	VerifierNodes []common.Address               `json:"verifier_nodes"`
+}
+
+// ChipTransfer is the data of a chip_transferred event.
+type ChipTransfer struct {
+	ChipID          *big.Int       `json:"chip_id"`
+	From            common.Address `json:"from"`
+	To              common.Address `json:"to"`
+	TransactionHash common.Hash    `json:"transaction_hash"`
+}
+
+// UnstakeClaimable is the data of an unstake_claimable event.
+type UnstakeClaimable struct {
+	RequestID   common.Hash    `json:"request_id"`
+	Staker      common.Address `json:"staker"`
+	NodeAddress common.Address `json:"node_address"`
+	Amount      *big.Int       `json:"amount"`
+	Chips       []*big.Int     `json:"chips"`
+	ClaimableAt int64          `json:"claimable_at"`
+}
+
+// NewNodeStatusChangedEvent returns the event of a status transition, the transition must have been saved.
+func NewNodeStatusChangedEvent(transition *schema.NodeStatusTransition) (*schema.WebhookEvent, error) {
+	return newEvent(
+		fmt.Sprintf("%s:%d", schema.WebhookEventTypeNodeStatusChanged, transition.ID),
+		schema.WebhookEventTypeNodeStatusChanged,
+		[]common.Address{transition.NodeAddress},
+		transition,
+		transition.Timestamp,
+	)
+}
+
+// NewNodeScoreDroppedEvent returns the event of the reliability score of a Node dropping from the previous score.
+func NewNodeScoreDroppedEvent(score *schema.NodeScore, previousScore float64) (*schema.WebhookEvent, error) {
+	return newEvent(
+		fmt.Sprintf("%s:%s:%d:%d", schema.WebhookEventTypeNodeScoreDropped, lowerHex(score.NodeAddress), score.EpochID, score.UpdatedAt),
+		schema.WebhookEventTypeNodeScoreDropped,
+		[]common.Address{score.NodeAddress},
+		&NodeScoreDrop{
+			NodeAddress:   score.NodeAddress,
+			EpochID:       score.EpochID,
+			PreviousScore: previousScore,
+			Score:         score.Score,
+		},
+		score.UpdatedAt,
+	)
+}
+
+// NewNodeInvalidResponseEvents returns the events of the invalid responses, each invalid response is recorded once.
+func NewNodeInvalidResponseEvents(responses []*schema.NodeInvalidResponse) ([]*schema.WebhookEvent, error) {
+	events := make([]*schema.WebhookEvent, 0, len(responses))
+
+	for _, response := range responses {
+		event, err := newEvent(
+			fmt.Sprintf("%s:%s", schema.WebhookEventTypeNodeInvalidResponse, uuid.NewString()),
+			schema.WebhookEventTypeNodeInvalidResponse,
+			[]common.Address{response.Node},
+			&NodeInvalidResponse{
+				NodeAddress:   response.Node,
+				EpochID:       response.EpochID,
+				Type:          response.Type,
+				Request:       response.Request,
+				VerifierNodes: response.VerifierNodes,
+			},
+			time.Now().Unix(),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// NewEpochRewardsDistributedEvents returns an event for each Node rewarded in the Epoch.
+func NewEpochRewardsDistributedEvents(epoch *schema.Epoch) ([]*schema.WebhookEvent, error) {
+	events := make([]*schema.WebhookEvent, 0, len(epoch.RewardedNodes))
+
+	for _, rewardedNode := range epoch.RewardedNodes {
+		event, err := newEvent(
+			fmt.Sprintf("%s:%d:%s", schema.WebhookEventTypeEpochRewardsDistributed, epoch.ID, lowerHex(rewardedNode.NodeAddress)),
+			schema.WebhookEventTypeEpochRewardsDistributed,
+			[]common.Address{rewardedNode.NodeAddress},
+			rewardedNode,
+			epoch.BlockTimestamp,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// NewChipTransferredEvent returns the event of a Chip transfer, it concerns both the sender and the recipient.
+func NewChipTransferredEvent(transfer *ChipTransfer, logIndex uint, timestamp int64) (*schema.WebhookEvent, error) {
+	// The zero address is the sender of a minted Chip and the recipient of a burned one.
+	addresses := make([]common.Address, 0, 2)
+
+	for _, address := range []common.Address{transfer.From, transfer.To} {
+		if address != (common.Address{}) {
+			addresses = append(addresses, address)
+		}
+	}
+
+	return newEvent(
+		fmt.Sprintf("%s:%s:%d", schema.WebhookEventTypeChipTransferred, transfer.TransactionHash.Hex(), logIndex),
+		schema.WebhookEventTypeChipTransferred,
+		addresses,
+		transfer,
+		timestamp,
+	)
+}
+
+// NewUnstakeClaimableEvent returns the event of an unstake request, it is delivered once the request becomes claimable.
+func NewUnstakeClaimableEvent(unstake *UnstakeClaimable) (*schema.WebhookEvent, error) {
+	event, err := newEvent(
+		fmt.Sprintf("%s:%s", schema.WebhookEventTypeUnstakeClaimable, unstake.RequestID.Hex()),
+		schema.WebhookEventTypeUnstakeClaimable,
+		[]common.Address{unstake.Staker},
+		unstake,
+		unstake.ClaimableAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	event.ScheduledAt = unstake.ClaimableAt
+
+	return event, nil
+}
+
+func newEvent(key string, eventType schema.WebhookEventType, addresses []common.Address, data any, timestamp int64) (*schema.WebhookEvent, error) {
+	value, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s event: %w", eventType, err)
+	}
+
+	return &schema.WebhookEvent{
+		Key:         key,
+		Type:        eventType,
+		Addresses:   addresses,
+		Data:        value,
+		Timestamp:   timestamp,
+		ScheduledAt: time.Now().Unix(),
+	}, nil
+}
+
+func lowerHex(address common.Address) string {
+	return strings.ToLower(address.Hex())
+}
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+)
+
+const (
+	// HeaderDelivery is the ID of the delivery, it is the same for the retries of a delivery.
+	HeaderDelivery = "X-RSS3-Webhook-Delivery"
+	// HeaderEvent is the type of the delivered event.
+	HeaderEvent = "X-RSS3-Webhook-Event"
+	// HeaderTimestamp is the Unix time of the attempt, the subscribers should reject the stale ones.
+	HeaderTimestamp = "X-RSS3-Webhook-Timestamp"
+	// HeaderSignature is the HMAC-SHA256 of the timestamp and the body, see Sign.
+	HeaderSignature = "X-RSS3-Webhook-Signature"
+
+	signaturePrefix = "sha256="
+	secretLength    = 32
+)
+
+// Payload is the body of a delivery.
+type Payload struct {
+	EventID uint64                  `json:"event_id"`
+	Type    schema.WebhookEventType `json:"type"`
+	// Address is the subscribed address the event is delivered for.
+	Address   common.Address  `json:"address"`
+	Timestamp int64           `json:"timestamp"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// NewPayload returns the body of the delivery of the event to the subscription.
+func NewPayload(event *schema.WebhookEvent, subscription *schema.WebhookSubscription) (json.RawMessage, error) {
+	return json.Marshal(&Payload{
+		EventID:   event.ID,
+		Type:      event.Type,
+		Address:   subscription.Address,
+		Timestamp: event.Timestamp,
+		Data:      event.Data,
+	})
+}
+
+// NewSecret returns a random secret to sign the deliveries of a subscription.
+func NewSecret() (string, error) {
+	secret := make([]byte, secretLength)
+
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("read random bytes: %w", err)
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
+// Sign returns the signature of a delivery, which is sha256= followed by
+// the hex encoded HMAC-SHA256 of "{timestamp}.{body}" keyed by the secret of the subscription.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify returns true if the signature of a delivery is signed by the secret.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
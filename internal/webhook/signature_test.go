@@ -0,0 +1,27 @@
+package webhook_test
+
+import (
+	"testing"
+
+	"github.com/rss3-network/global-indexer/internal/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"event_id":1}`)
+
+	// echo -n '1725000000.{"event_id":1}' | openssl dgst -sha256 -hmac secret
+	signature := webhook.Sign("secret", 1725000000, body)
+	assert.Equal(t, "sha256=54e2fd3299b305092788c22804068e645339fa4788d92d4fd7e36e6e280017db", signature)
+
+	assert.True(t, webhook.Verify("secret", 1725000000, body, signature))
+	assert.False(t, webhook.Verify("secret", 1725000001, body, signature))
+	assert.False(t, webhook.Verify("other", 1725000000, body, signature))
+
+	secret, err := webhook.NewSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 64)
+}
@@ -0,0 +1,13 @@
+package webhook
+
+import "net/url"
+
+// RedactURL returns only the origin of the URL of a subscription, the path and the query may carry credentials.
+func RedactURL(value string) string {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return ""
+	}
+
+	return (&url.URL{Scheme: parsed.Scheme, Host: parsed.Host}).String()
+}
@@ -0,0 +1,30 @@
+package schema
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+)
+
+// The strategies to average the tax rates of the non-public good Nodes.
+const (
+	TaxStrategyMean          = "mean"
+	TaxStrategyStakeWeighted = "stake_weighted"
+	TaxStrategyMedian        = "median"
+	TaxStrategyTrimmedMean   = "trimmed_mean"
+)
+
+// TaxRateInput is the tax rate of a non-public good Node that the average tax rate is calculated from.
+type TaxRateInput struct {
+	Address            common.Address
+	TaxRateBasisPoints uint64
+	StakingPoolTokens  decimal.Decimal
+	// Excluded is true if the tax rate is trimmed by the trimmed_mean strategy.
+	Excluded bool
+}
+
+// AverageTaxRate is the average tax rate calculated from the tax rates of the non-public good Nodes.
+type AverageTaxRate struct {
+	Strategy       string
+	AverageTaxRate decimal.Decimal
+	Inputs         []*TaxRateInput
+}
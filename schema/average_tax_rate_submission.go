@@ -18,5 +18,7 @@ type AverageTaxRateSubmission struct {
 
 type AverageTaxRateSubmissionQuery struct {
 	EpochID *uint64 `json:"epoch_id"`
-	Limit   *int    `json:"limit"`
+	// Cursor is the epoch ID of the last submission of the previous page.
+	Cursor *uint64 `json:"cursor"`
+	Limit  *int    `json:"limit"`
 }
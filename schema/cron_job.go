@@ -0,0 +1,65 @@
+package schema
+
+// CronJob is a job run by the scheduler on a cron schedule.
+type CronJob struct {
+	Name string `json:"name"`
+	Spec string `json:"spec"`
+	// Timeout is the expiry of the lock of the job in seconds, which is renewed while the job is running.
+	Timeout int64 `json:"timeout"`
+	// NextScheduledAt is the next slot of the schedule, it is updated by the scheduler at every slot,
+	// or at the slot after every sample interval for a job scheduled more often than every minute.
+	NextScheduledAt int64 `json:"next_scheduled_at"`
+	UpdatedAt       int64 `json:"updated_at"`
+}
+
+// CronJobRun is a run of a CronJob, it is saved when the run starts and updated when the run finishes.
+// A run is skipped if the lock of the job is held by another scheduler.
+type CronJobRun struct {
+	ID      uint64            `json:"id"`
+	Job     string            `json:"job"`
+	Trigger CronJobRunTrigger `json:"trigger"`
+	Status  CronJobRunStatus  `json:"status"`
+	Error   string            `json:"error,omitempty"`
+	// Holder is the scheduler holding the lock of the job, which is another scheduler if the run is skipped.
+	Holder string `json:"holder"`
+	// ScheduledAt is the slot of the schedule the run is for, or the time the run is triggered manually.
+	ScheduledAt int64  `json:"scheduled_at"`
+	StartedAt   int64  `json:"started_at"`
+	FinishedAt  *int64 `json:"finished_at,omitempty"`
+	// Duration is the duration of the run in milliseconds.
+	Duration int64 `json:"duration"`
+}
+
+//go:generate go run --mod=mod github.com/dmarkham/enumer@v1.5.9 --values --type=CronJobRunTrigger --linecomment --output cron_job_run_trigger_string.go --json --yaml --sql
+type CronJobRunTrigger int64
+
+const (
+	// CronJobRunTriggerSchedule the run is for a slot of the schedule.
+	CronJobRunTriggerSchedule CronJobRunTrigger = iota // schedule
+	// CronJobRunTriggerCatchUp the run is for the latest slot missed while no scheduler was running the job.
+	CronJobRunTriggerCatchUp // catch_up
+	// CronJobRunTriggerManual the run is triggered by the run-once command.
+	CronJobRunTriggerManual // manual
+)
+
+//go:generate go run --mod=mod github.com/dmarkham/enumer@v1.5.9 --values --type=CronJobRunStatus --linecomment --output cron_job_run_status_string.go --json --yaml --sql
+type CronJobRunStatus int64
+
+const (
+	// CronJobRunStatusRunning the run has started.
+	CronJobRunStatusRunning CronJobRunStatus = iota // running
+	// CronJobRunStatusSucceeded the run has finished without error.
+	CronJobRunStatusSucceeded // succeeded
+	// CronJobRunStatusFailed the run has finished with an error.
+	CronJobRunStatusFailed // failed
+	// CronJobRunStatusSkipped the lock of the job could not be acquired.
+	CronJobRunStatusSkipped // skipped
+)
+
+type CronJobRunsQuery struct {
+	Job      *string
+	Status   *CronJobRunStatus
+	Triggers []CronJobRunTrigger
+	Cursor   *uint64
+	Limit    *int
+}
@@ -0,0 +1,156 @@
+// Code generated by "enumer --values --type=CronJobRunStatus --linecomment --output cron_job_run_status_string.go --json --yaml --sql"; DO NOT EDIT.
+
+package schema
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _CronJobRunStatusName = "runningsucceededfailedskipped"
+
+var _CronJobRunStatusIndex = [...]uint8{0, 7, 16, 22, 29}
+
+const _CronJobRunStatusLowerName = "runningsucceededfailedskipped"
+
+func (i CronJobRunStatus) String() string {
+	if i < 0 || i >= CronJobRunStatus(len(_CronJobRunStatusIndex)-1) {
+		return fmt.Sprintf("CronJobRunStatus(%d)", i)
+	}
+	return _CronJobRunStatusName[_CronJobRunStatusIndex[i]:_CronJobRunStatusIndex[i+1]]
+}
+
+func (CronJobRunStatus) Values() []string {
+	return CronJobRunStatusStrings()
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _CronJobRunStatusNoOp() {
+	var x [1]struct{}
+	_ = x[CronJobRunStatusRunning-(0)]
+	_ = x[CronJobRunStatusSucceeded-(1)]
+	_ = x[CronJobRunStatusFailed-(2)]
+	_ = x[CronJobRunStatusSkipped-(3)]
+}
+
+var _CronJobRunStatusValues = []CronJobRunStatus{CronJobRunStatusRunning, CronJobRunStatusSucceeded, CronJobRunStatusFailed, CronJobRunStatusSkipped}
+
+var _CronJobRunStatusNameToValueMap = map[string]CronJobRunStatus{
+	_CronJobRunStatusName[0:7]:        CronJobRunStatusRunning,
+	_CronJobRunStatusLowerName[0:7]:   CronJobRunStatusRunning,
+	_CronJobRunStatusName[7:16]:       CronJobRunStatusSucceeded,
+	_CronJobRunStatusLowerName[7:16]:  CronJobRunStatusSucceeded,
+	_CronJobRunStatusName[16:22]:      CronJobRunStatusFailed,
+	_CronJobRunStatusLowerName[16:22]: CronJobRunStatusFailed,
+	_CronJobRunStatusName[22:29]:      CronJobRunStatusSkipped,
+	_CronJobRunStatusLowerName[22:29]: CronJobRunStatusSkipped,
+}
+
+var _CronJobRunStatusNames = []string{
+	_CronJobRunStatusName[0:7],
+	_CronJobRunStatusName[7:16],
+	_CronJobRunStatusName[16:22],
+	_CronJobRunStatusName[22:29],
+}
+
+// CronJobRunStatusString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func CronJobRunStatusString(s string) (CronJobRunStatus, error) {
+	if val, ok := _CronJobRunStatusNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _CronJobRunStatusNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to CronJobRunStatus values", s)
+}
+
+// CronJobRunStatusValues returns all values of the enum
+func CronJobRunStatusValues() []CronJobRunStatus {
+	return _CronJobRunStatusValues
+}
+
+// CronJobRunStatusStrings returns a slice of all String values of the enum
+func CronJobRunStatusStrings() []string {
+	strs := make([]string, len(_CronJobRunStatusNames))
+	copy(strs, _CronJobRunStatusNames)
+	return strs
+}
+
+// IsACronJobRunStatus returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i CronJobRunStatus) IsACronJobRunStatus() bool {
+	for _, v := range _CronJobRunStatusValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for CronJobRunStatus
+func (i CronJobRunStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for CronJobRunStatus
+func (i *CronJobRunStatus) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("CronJobRunStatus should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = CronJobRunStatusString(s)
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for CronJobRunStatus
+func (i CronJobRunStatus) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for CronJobRunStatus
+func (i *CronJobRunStatus) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = CronJobRunStatusString(s)
+	return err
+}
+
+func (i CronJobRunStatus) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *CronJobRunStatus) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of CronJobRunStatus: %[1]T(%[1]v)", value)
+	}
+
+	val, err := CronJobRunStatusString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
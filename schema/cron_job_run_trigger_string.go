@@ -0,0 +1,152 @@
+// Code generated by "enumer --values --type=CronJobRunTrigger --linecomment --output cron_job_run_trigger_string.go --json --yaml --sql"; DO NOT EDIT.
+
+package schema
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _CronJobRunTriggerName = "schedulecatch_upmanual"
+
+var _CronJobRunTriggerIndex = [...]uint8{0, 8, 16, 22}
+
+const _CronJobRunTriggerLowerName = "schedulecatch_upmanual"
+
+func (i CronJobRunTrigger) String() string {
+	if i < 0 || i >= CronJobRunTrigger(len(_CronJobRunTriggerIndex)-1) {
+		return fmt.Sprintf("CronJobRunTrigger(%d)", i)
+	}
+	return _CronJobRunTriggerName[_CronJobRunTriggerIndex[i]:_CronJobRunTriggerIndex[i+1]]
+}
+
+func (CronJobRunTrigger) Values() []string {
+	return CronJobRunTriggerStrings()
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _CronJobRunTriggerNoOp() {
+	var x [1]struct{}
+	_ = x[CronJobRunTriggerSchedule-(0)]
+	_ = x[CronJobRunTriggerCatchUp-(1)]
+	_ = x[CronJobRunTriggerManual-(2)]
+}
+
+var _CronJobRunTriggerValues = []CronJobRunTrigger{CronJobRunTriggerSchedule, CronJobRunTriggerCatchUp, CronJobRunTriggerManual}
+
+var _CronJobRunTriggerNameToValueMap = map[string]CronJobRunTrigger{
+	_CronJobRunTriggerName[0:8]:        CronJobRunTriggerSchedule,
+	_CronJobRunTriggerLowerName[0:8]:   CronJobRunTriggerSchedule,
+	_CronJobRunTriggerName[8:16]:       CronJobRunTriggerCatchUp,
+	_CronJobRunTriggerLowerName[8:16]:  CronJobRunTriggerCatchUp,
+	_CronJobRunTriggerName[16:22]:      CronJobRunTriggerManual,
+	_CronJobRunTriggerLowerName[16:22]: CronJobRunTriggerManual,
+}
+
+var _CronJobRunTriggerNames = []string{
+	_CronJobRunTriggerName[0:8],
+	_CronJobRunTriggerName[8:16],
+	_CronJobRunTriggerName[16:22],
+}
+
+// CronJobRunTriggerString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func CronJobRunTriggerString(s string) (CronJobRunTrigger, error) {
+	if val, ok := _CronJobRunTriggerNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _CronJobRunTriggerNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to CronJobRunTrigger values", s)
+}
+
+// CronJobRunTriggerValues returns all values of the enum
+func CronJobRunTriggerValues() []CronJobRunTrigger {
+	return _CronJobRunTriggerValues
+}
+
+// CronJobRunTriggerStrings returns a slice of all String values of the enum
+func CronJobRunTriggerStrings() []string {
+	strs := make([]string, len(_CronJobRunTriggerNames))
+	copy(strs, _CronJobRunTriggerNames)
+	return strs
+}
+
+// IsACronJobRunTrigger returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i CronJobRunTrigger) IsACronJobRunTrigger() bool {
+	for _, v := range _CronJobRunTriggerValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for CronJobRunTrigger
+func (i CronJobRunTrigger) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for CronJobRunTrigger
+func (i *CronJobRunTrigger) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("CronJobRunTrigger should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = CronJobRunTriggerString(s)
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for CronJobRunTrigger
+func (i CronJobRunTrigger) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for CronJobRunTrigger
+func (i *CronJobRunTrigger) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = CronJobRunTriggerString(s)
+	return err
+}
+
+func (i CronJobRunTrigger) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *CronJobRunTrigger) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of CronJobRunTrigger: %[1]T(%[1]v)", value)
+	}
+
+	val, err := CronJobRunTriggerString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
@@ -16,5 +16,7 @@ type EpochAPYSnapshot struct {
 
 type EpochAPYSnapshotQuery struct {
 	EpochID *uint64
+	Since   *time.Time
+	Until   *time.Time
 	Limit   *int
 }
@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// EpochReconciliation is the report of reconciling the settlement of a finalized Epoch,
+// the batches submitted by the settler are checked against the indexed RewardDistributed events and the Node stats.
+type EpochReconciliation struct {
+	EpochID uint64 `json:"epoch_id"`
+	// Distributions is the number of the indexed RewardDistributed transactions of the Epoch.
+	Distributions int `json:"distributions"`
+	// RewardedNodes is the number of the indexed rewarded Node records of the Epoch.
+	RewardedNodes int   `json:"rewarded_nodes"`
+	MismatchCount int   `json:"mismatch_count"`
+	ReconciledAt  int64 `json:"reconciled_at"`
+	// Mismatches are only loaded when a single report is found.
+	Mismatches []*EpochReconciliationMismatch `json:"mismatches,omitempty"`
+}
+
+// EpochReconciliationMismatch is a difference found by reconciling an Epoch,
+// the transaction hash and the Node address are set if the mismatch concerns them.
+type EpochReconciliationMismatch struct {
+	EpochID         uint64                          `json:"epoch_id"`
+	Type            EpochReconciliationMismatchType `json:"type"`
+	TransactionHash *common.Hash                    `json:"transaction_hash,omitempty"`
+	NodeAddress     *common.Address                 `json:"node_address,omitempty"`
+	// Expected is the value submitted by the settler, Actual is the value found in the indexed events or the stats.
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+//go:generate go run --mod=mod github.com/dmarkham/enumer@v1.5.9 --values --type=EpochReconciliationMismatchType --linecomment --output epoch_reconciliation_mismatch_type_string.go --json --yaml --sql
+type EpochReconciliationMismatchType int64
+
+const (
+	// EpochReconciliationMismatchTypeMissingDistribution a submitted batch has no indexed RewardDistributed event.
+	EpochReconciliationMismatchTypeMissingDistribution EpochReconciliationMismatchType = iota // missing_distribution
+	// EpochReconciliationMismatchTypeUnexpectedDistribution an indexed RewardDistributed event was not submitted by the settler.
+	EpochReconciliationMismatchTypeUnexpectedDistribution // unexpected_distribution
+	// EpochReconciliationMismatchTypeDuplicateBatch a batch was distributed more than once with the same Nodes.
+	EpochReconciliationMismatchTypeDuplicateBatch // duplicate_batch
+	// EpochReconciliationMismatchTypeDuplicateNode a Node was rewarded in more than one batch.
+	EpochReconciliationMismatchTypeDuplicateNode // duplicate_node
+	// EpochReconciliationMismatchTypeMissingNode a submitted or planned Node was not rewarded.
+	EpochReconciliationMismatchTypeMissingNode // missing_node
+	// EpochReconciliationMismatchTypeUnexpectedNode a Node was rewarded without being submitted.
+	EpochReconciliationMismatchTypeUnexpectedNode // unexpected_node
+	// EpochReconciliationMismatchTypeRequestCount the rewarded request count differs from the submitted one.
+	EpochReconciliationMismatchTypeRequestCount // request_count
+	// EpochReconciliationMismatchTypeOperationRewards the rewarded operation rewards differ from the submitted ones.
+	EpochReconciliationMismatchTypeOperationRewards // operation_rewards
+	// EpochReconciliationMismatchTypeRequestCountExceedsTotal the rewarded request count exceeds the total request count of the Node stat.
+	EpochReconciliationMismatchTypeRequestCountExceedsTotal // request_count_exceeds_total
+	// EpochReconciliationMismatchTypeRequestCountStat the rewarded request count differs from the request count of the Epoch in the Node stat.
+	EpochReconciliationMismatchTypeRequestCountStat // request_count_stat
+)
+
+type EpochReconciliationsQuery struct {
+	// Inconsistent only matches the reports with mismatches.
+	Inconsistent *bool
+	Cursor       *uint64
+	Limit        *int
+}
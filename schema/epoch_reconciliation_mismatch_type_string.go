@@ -0,0 +1,180 @@
+// Code generated by "enumer --values --type=EpochReconciliationMismatchType --linecomment --output epoch_reconciliation_mismatch_type_string.go --json --yaml --sql"; DO NOT EDIT.
+
+package schema
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _EpochReconciliationMismatchTypeName = "missing_distributionunexpected_distributionduplicate_batchduplicate_nodemissing_nodeunexpected_noderequest_countoperation_rewardsrequest_count_exceeds_totalrequest_count_stat"
+
+var _EpochReconciliationMismatchTypeIndex = [...]uint8{0, 20, 43, 58, 72, 84, 99, 112, 129, 156, 174}
+
+const _EpochReconciliationMismatchTypeLowerName = "missing_distributionunexpected_distributionduplicate_batchduplicate_nodemissing_nodeunexpected_noderequest_countoperation_rewardsrequest_count_exceeds_totalrequest_count_stat"
+
+func (i EpochReconciliationMismatchType) String() string {
+	if i < 0 || i >= EpochReconciliationMismatchType(len(_EpochReconciliationMismatchTypeIndex)-1) {
+		return fmt.Sprintf("EpochReconciliationMismatchType(%d)", i)
+	}
+	return _EpochReconciliationMismatchTypeName[_EpochReconciliationMismatchTypeIndex[i]:_EpochReconciliationMismatchTypeIndex[i+1]]
+}
+
+func (EpochReconciliationMismatchType) Values() []string {
+	return EpochReconciliationMismatchTypeStrings()
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _EpochReconciliationMismatchTypeNoOp() {
+	var x [1]struct{}
+	_ = x[EpochReconciliationMismatchTypeMissingDistribution-(0)]
+	_ = x[EpochReconciliationMismatchTypeUnexpectedDistribution-(1)]
+	_ = x[EpochReconciliationMismatchTypeDuplicateBatch-(2)]
+	_ = x[EpochReconciliationMismatchTypeDuplicateNode-(3)]
+	_ = x[EpochReconciliationMismatchTypeMissingNode-(4)]
+	_ = x[EpochReconciliationMismatchTypeUnexpectedNode-(5)]
+	_ = x[EpochReconciliationMismatchTypeRequestCount-(6)]
+	_ = x[EpochReconciliationMismatchTypeOperationRewards-(7)]
+	_ = x[EpochReconciliationMismatchTypeRequestCountExceedsTotal-(8)]
+	_ = x[EpochReconciliationMismatchTypeRequestCountStat-(9)]
+}
+
+var _EpochReconciliationMismatchTypeValues = []EpochReconciliationMismatchType{EpochReconciliationMismatchTypeMissingDistribution, EpochReconciliationMismatchTypeUnexpectedDistribution, EpochReconciliationMismatchTypeDuplicateBatch, EpochReconciliationMismatchTypeDuplicateNode, EpochReconciliationMismatchTypeMissingNode, EpochReconciliationMismatchTypeUnexpectedNode, EpochReconciliationMismatchTypeRequestCount, EpochReconciliationMismatchTypeOperationRewards, EpochReconciliationMismatchTypeRequestCountExceedsTotal, EpochReconciliationMismatchTypeRequestCountStat}
+
+var _EpochReconciliationMismatchTypeNameToValueMap = map[string]EpochReconciliationMismatchType{
+	_EpochReconciliationMismatchTypeName[0:20]:         EpochReconciliationMismatchTypeMissingDistribution,
+	_EpochReconciliationMismatchTypeLowerName[0:20]:    EpochReconciliationMismatchTypeMissingDistribution,
+	_EpochReconciliationMismatchTypeName[20:43]:        EpochReconciliationMismatchTypeUnexpectedDistribution,
+	_EpochReconciliationMismatchTypeLowerName[20:43]:   EpochReconciliationMismatchTypeUnexpectedDistribution,
+	_EpochReconciliationMismatchTypeName[43:58]:        EpochReconciliationMismatchTypeDuplicateBatch,
+	_EpochReconciliationMismatchTypeLowerName[43:58]:   EpochReconciliationMismatchTypeDuplicateBatch,
+	_EpochReconciliationMismatchTypeName[58:72]:        EpochReconciliationMismatchTypeDuplicateNode,
+	_EpochReconciliationMismatchTypeLowerName[58:72]:   EpochReconciliationMismatchTypeDuplicateNode,
+	_EpochReconciliationMismatchTypeName[72:84]:        EpochReconciliationMismatchTypeMissingNode,
+	_EpochReconciliationMismatchTypeLowerName[72:84]:   EpochReconciliationMismatchTypeMissingNode,
+	_EpochReconciliationMismatchTypeName[84:99]:        EpochReconciliationMismatchTypeUnexpectedNode,
+	_EpochReconciliationMismatchTypeLowerName[84:99]:   EpochReconciliationMismatchTypeUnexpectedNode,
+	_EpochReconciliationMismatchTypeName[99:112]:       EpochReconciliationMismatchTypeRequestCount,
+	_EpochReconciliationMismatchTypeLowerName[99:112]:  EpochReconciliationMismatchTypeRequestCount,
+	_EpochReconciliationMismatchTypeName[112:129]:      EpochReconciliationMismatchTypeOperationRewards,
+	_EpochReconciliationMismatchTypeLowerName[112:129]: EpochReconciliationMismatchTypeOperationRewards,
+	_EpochReconciliationMismatchTypeName[129:156]:      EpochReconciliationMismatchTypeRequestCountExceedsTotal,
+	_EpochReconciliationMismatchTypeLowerName[129:156]: EpochReconciliationMismatchTypeRequestCountExceedsTotal,
+	_EpochReconciliationMismatchTypeName[156:174]:      EpochReconciliationMismatchTypeRequestCountStat,
+	_EpochReconciliationMismatchTypeLowerName[156:174]: EpochReconciliationMismatchTypeRequestCountStat,
+}
+
+var _EpochReconciliationMismatchTypeNames = []string{
+	_EpochReconciliationMismatchTypeName[0:20],
+	_EpochReconciliationMismatchTypeName[20:43],
+	_EpochReconciliationMismatchTypeName[43:58],
+	_EpochReconciliationMismatchTypeName[58:72],
+	_EpochReconciliationMismatchTypeName[72:84],
+	_EpochReconciliationMismatchTypeName[84:99],
+	_EpochReconciliationMismatchTypeName[99:112],
+	_EpochReconciliationMismatchTypeName[112:129],
+	_EpochReconciliationMismatchTypeName[129:156],
+	_EpochReconciliationMismatchTypeName[156:174],
+}
+
+// EpochReconciliationMismatchTypeString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func EpochReconciliationMismatchTypeString(s string) (EpochReconciliationMismatchType, error) {
+	if val, ok := _EpochReconciliationMismatchTypeNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _EpochReconciliationMismatchTypeNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to EpochReconciliationMismatchType values", s)
+}
+
+// EpochReconciliationMismatchTypeValues returns all values of the enum
+func EpochReconciliationMismatchTypeValues() []EpochReconciliationMismatchType {
+	return _EpochReconciliationMismatchTypeValues
+}
+
+// EpochReconciliationMismatchTypeStrings returns a slice of all String values of the enum
+func EpochReconciliationMismatchTypeStrings() []string {
+	strs := make([]string, len(_EpochReconciliationMismatchTypeNames))
+	copy(strs, _EpochReconciliationMismatchTypeNames)
+	return strs
+}
+
+// IsAEpochReconciliationMismatchType returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i EpochReconciliationMismatchType) IsAEpochReconciliationMismatchType() bool {
+	for _, v := range _EpochReconciliationMismatchTypeValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for EpochReconciliationMismatchType
+func (i EpochReconciliationMismatchType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for EpochReconciliationMismatchType
+func (i *EpochReconciliationMismatchType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("EpochReconciliationMismatchType should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = EpochReconciliationMismatchTypeString(s)
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for EpochReconciliationMismatchType
+func (i EpochReconciliationMismatchType) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for EpochReconciliationMismatchType
+func (i *EpochReconciliationMismatchType) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = EpochReconciliationMismatchTypeString(s)
+	return err
+}
+
+func (i EpochReconciliationMismatchType) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *EpochReconciliationMismatchType) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of EpochReconciliationMismatchType: %[1]T(%[1]v)", value)
+	}
+
+	val, err := EpochReconciliationMismatchTypeString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
@@ -0,0 +1,168 @@
+// Code generated by "enumer --values --type=LeaderboardMetric --linecomment --output leaderboard_metric_string.go --json --yaml --sql"; DO NOT EDIT.
+
+package schema
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _LeaderboardMetricName = "rewardsapyreliability_scorestaking_growthuptimeinvalid_response_ratestaker_count"
+
+var _LeaderboardMetricIndex = [...]uint8{0, 7, 10, 27, 41, 47, 68, 80}
+
+const _LeaderboardMetricLowerName = "rewardsapyreliability_scorestaking_growthuptimeinvalid_response_ratestaker_count"
+
+func (i LeaderboardMetric) String() string {
+	if i < 0 || i >= LeaderboardMetric(len(_LeaderboardMetricIndex)-1) {
+		return fmt.Sprintf("LeaderboardMetric(%d)", i)
+	}
+	return _LeaderboardMetricName[_LeaderboardMetricIndex[i]:_LeaderboardMetricIndex[i+1]]
+}
+
+func (LeaderboardMetric) Values() []string {
+	return LeaderboardMetricStrings()
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _LeaderboardMetricNoOp() {
+	var x [1]struct{}
+	_ = x[LeaderboardMetricRewards-(0)]
+	_ = x[LeaderboardMetricAPY-(1)]
+	_ = x[LeaderboardMetricReliabilityScore-(2)]
+	_ = x[LeaderboardMetricStakingGrowth-(3)]
+	_ = x[LeaderboardMetricUptime-(4)]
+	_ = x[LeaderboardMetricInvalidResponseRate-(5)]
+	_ = x[LeaderboardMetricStakerCount-(6)]
+}
+
+var _LeaderboardMetricValues = []LeaderboardMetric{LeaderboardMetricRewards, LeaderboardMetricAPY, LeaderboardMetricReliabilityScore, LeaderboardMetricStakingGrowth, LeaderboardMetricUptime, LeaderboardMetricInvalidResponseRate, LeaderboardMetricStakerCount}
+
+var _LeaderboardMetricNameToValueMap = map[string]LeaderboardMetric{
+	_LeaderboardMetricName[0:7]:        LeaderboardMetricRewards,
+	_LeaderboardMetricLowerName[0:7]:   LeaderboardMetricRewards,
+	_LeaderboardMetricName[7:10]:       LeaderboardMetricAPY,
+	_LeaderboardMetricLowerName[7:10]:  LeaderboardMetricAPY,
+	_LeaderboardMetricName[10:27]:      LeaderboardMetricReliabilityScore,
+	_LeaderboardMetricLowerName[10:27]: LeaderboardMetricReliabilityScore,
+	_LeaderboardMetricName[27:41]:      LeaderboardMetricStakingGrowth,
+	_LeaderboardMetricLowerName[27:41]: LeaderboardMetricStakingGrowth,
+	_LeaderboardMetricName[41:47]:      LeaderboardMetricUptime,
+	_LeaderboardMetricLowerName[41:47]: LeaderboardMetricUptime,
+	_LeaderboardMetricName[47:68]:      LeaderboardMetricInvalidResponseRate,
+	_LeaderboardMetricLowerName[47:68]: LeaderboardMetricInvalidResponseRate,
+	_LeaderboardMetricName[68:80]:      LeaderboardMetricStakerCount,
+	_LeaderboardMetricLowerName[68:80]: LeaderboardMetricStakerCount,
+}
+
+var _LeaderboardMetricNames = []string{
+	_LeaderboardMetricName[0:7],
+	_LeaderboardMetricName[7:10],
+	_LeaderboardMetricName[10:27],
+	_LeaderboardMetricName[27:41],
+	_LeaderboardMetricName[41:47],
+	_LeaderboardMetricName[47:68],
+	_LeaderboardMetricName[68:80],
+}
+
+// LeaderboardMetricString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func LeaderboardMetricString(s string) (LeaderboardMetric, error) {
+	if val, ok := _LeaderboardMetricNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _LeaderboardMetricNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to LeaderboardMetric values", s)
+}
+
+// LeaderboardMetricValues returns all values of the enum
+func LeaderboardMetricValues() []LeaderboardMetric {
+	return _LeaderboardMetricValues
+}
+
+// LeaderboardMetricStrings returns a slice of all String values of the enum
+func LeaderboardMetricStrings() []string {
+	strs := make([]string, len(_LeaderboardMetricNames))
+	copy(strs, _LeaderboardMetricNames)
+	return strs
+}
+
+// IsALeaderboardMetric returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i LeaderboardMetric) IsALeaderboardMetric() bool {
+	for _, v := range _LeaderboardMetricValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for LeaderboardMetric
+func (i LeaderboardMetric) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for LeaderboardMetric
+func (i *LeaderboardMetric) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("LeaderboardMetric should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = LeaderboardMetricString(s)
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for LeaderboardMetric
+func (i LeaderboardMetric) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for LeaderboardMetric
+func (i *LeaderboardMetric) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = LeaderboardMetricString(s)
+	return err
+}
+
+func (i LeaderboardMetric) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *LeaderboardMetric) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of LeaderboardMetric: %[1]T(%[1]v)", value)
+	}
+
+	val, err := LeaderboardMetricString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
@@ -0,0 +1,72 @@
+package schema
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ManagedTransaction is a journal entry of a transaction sent by the transaction manager.
+// A journal entry is identified by the chain, the sender and the nonce,
+// every fee bump replaces the signed transaction but keeps the nonce.
+type ManagedTransaction struct {
+	ChainID uint64         `json:"chain_id"`
+	From    common.Address `json:"from"`
+	Nonce   uint64         `json:"nonce"`
+	// Owner is the name of the service that sent the transaction.
+	Owner string `json:"owner"`
+	// The candidate of the transaction.
+	To       *common.Address `json:"to,omitempty"`
+	Data     []byte          `json:"data"`
+	Value    *big.Int        `json:"value"`
+	GasLimit uint64          `json:"gas_limit"`
+	// The latest signed transaction.
+	GasTipCap       *big.Int    `json:"gas_tip_cap"`
+	GasFeeCap       *big.Int    `json:"gas_fee_cap"`
+	RawTransaction  []byte      `json:"raw_transaction"`
+	TransactionHash common.Hash `json:"transaction_hash"`
+	// TransactionHashes are the hashes of all signed transactions of the nonce, including fee bumps.
+	TransactionHashes []common.Hash           `json:"transaction_hashes"`
+	FeeBumps          int                     `json:"fee_bumps"`
+	State             ManagedTransactionState `json:"state"`
+	Receipt           *types.Receipt          `json:"receipt,omitempty"`
+	Error             string                  `json:"error,omitempty"`
+	// Version is incremented by every save of the entry, a save of a stale version is rejected by the journal.
+	Version   uint64    `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+//go:generate go run --mod=mod github.com/dmarkham/enumer@v1.5.9 --values --type=ManagedTransactionState --linecomment --output managed_transaction_state_string.go --json --yaml --sql
+type ManagedTransactionState int64
+
+const (
+	// ManagedTransactionStatePending the transaction is signed and may have been published, no confirmed receipt yet.
+	ManagedTransactionStatePending ManagedTransactionState = iota // pending
+	// ManagedTransactionStateConfirmed the transaction has been confirmed with a successful receipt.
+	ManagedTransactionStateConfirmed // confirmed
+	// ManagedTransactionStateHalted the transaction has been confirmed with a failed receipt.
+	// No further transactions are sent from the wallet until the halted transaction is resolved by an operator.
+	ManagedTransactionStateHalted // halted
+	// ManagedTransactionStateDropped the transaction was given up without a receipt,
+	// e.g. its nonce has been consumed by another transaction.
+	ManagedTransactionStateDropped // dropped
+	// ManagedTransactionStateCancelled the transaction has been replaced by a zero-value self-transfer by an operator.
+	ManagedTransactionStateCancelled // cancelled
+	// ManagedTransactionStateResolved the halted transaction has been resolved by an operator.
+	ManagedTransactionStateResolved // resolved
+	// ManagedTransactionStateUnsigned the transaction has been exported for offline signing,
+	// it becomes pending once the signed transaction is imported.
+	ManagedTransactionStateUnsigned // unsigned
+)
+
+type ManagedTransactionQuery struct {
+	ChainID *uint64
+	From    *common.Address
+	Owner   *string
+	Nonce   *uint64
+	States  []ManagedTransactionState
+	Limit   *int
+}
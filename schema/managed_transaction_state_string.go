@@ -0,0 +1,168 @@
+// Code generated by "enumer --values --type=ManagedTransactionState --linecomment --output managed_transaction_state_string.go --json --yaml --sql"; DO NOT EDIT.
+
+package schema
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _ManagedTransactionStateName = "pendingconfirmedhalteddroppedcancelledresolvedunsigned"
+
+var _ManagedTransactionStateIndex = [...]uint8{0, 7, 16, 22, 29, 38, 46, 54}
+
+const _ManagedTransactionStateLowerName = "pendingconfirmedhalteddroppedcancelledresolvedunsigned"
+
+func (i ManagedTransactionState) String() string {
+	if i < 0 || i >= ManagedTransactionState(len(_ManagedTransactionStateIndex)-1) {
+		return fmt.Sprintf("ManagedTransactionState(%d)", i)
+	}
+	return _ManagedTransactionStateName[_ManagedTransactionStateIndex[i]:_ManagedTransactionStateIndex[i+1]]
+}
+
+func (ManagedTransactionState) Values() []string {
+	return ManagedTransactionStateStrings()
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _ManagedTransactionStateNoOp() {
+	var x [1]struct{}
+	_ = x[ManagedTransactionStatePending-(0)]
+	_ = x[ManagedTransactionStateConfirmed-(1)]
+	_ = x[ManagedTransactionStateHalted-(2)]
+	_ = x[ManagedTransactionStateDropped-(3)]
+	_ = x[ManagedTransactionStateCancelled-(4)]
+	_ = x[ManagedTransactionStateResolved-(5)]
+	_ = x[ManagedTransactionStateUnsigned-(6)]
+}
+
+var _ManagedTransactionStateValues = []ManagedTransactionState{ManagedTransactionStatePending, ManagedTransactionStateConfirmed, ManagedTransactionStateHalted, ManagedTransactionStateDropped, ManagedTransactionStateCancelled, ManagedTransactionStateResolved, ManagedTransactionStateUnsigned}
+
+var _ManagedTransactionStateNameToValueMap = map[string]ManagedTransactionState{
+	_ManagedTransactionStateName[0:7]:        ManagedTransactionStatePending,
+	_ManagedTransactionStateLowerName[0:7]:   ManagedTransactionStatePending,
+	_ManagedTransactionStateName[7:16]:       ManagedTransactionStateConfirmed,
+	_ManagedTransactionStateLowerName[7:16]:  ManagedTransactionStateConfirmed,
+	_ManagedTransactionStateName[16:22]:      ManagedTransactionStateHalted,
+	_ManagedTransactionStateLowerName[16:22]: ManagedTransactionStateHalted,
+	_ManagedTransactionStateName[22:29]:      ManagedTransactionStateDropped,
+	_ManagedTransactionStateLowerName[22:29]: ManagedTransactionStateDropped,
+	_ManagedTransactionStateName[29:38]:      ManagedTransactionStateCancelled,
+	_ManagedTransactionStateLowerName[29:38]: ManagedTransactionStateCancelled,
+	_ManagedTransactionStateName[38:46]:      ManagedTransactionStateResolved,
+	_ManagedTransactionStateLowerName[38:46]: ManagedTransactionStateResolved,
+	_ManagedTransactionStateName[46:54]:      ManagedTransactionStateUnsigned,
+	_ManagedTransactionStateLowerName[46:54]: ManagedTransactionStateUnsigned,
+}
+
+var _ManagedTransactionStateNames = []string{
+	_ManagedTransactionStateName[0:7],
+	_ManagedTransactionStateName[7:16],
+	_ManagedTransactionStateName[16:22],
+	_ManagedTransactionStateName[22:29],
+	_ManagedTransactionStateName[29:38],
+	_ManagedTransactionStateName[38:46],
+	_ManagedTransactionStateName[46:54],
+}
+
+// ManagedTransactionStateString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func ManagedTransactionStateString(s string) (ManagedTransactionState, error) {
+	if val, ok := _ManagedTransactionStateNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _ManagedTransactionStateNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to ManagedTransactionState values", s)
+}
+
+// ManagedTransactionStateValues returns all values of the enum
+func ManagedTransactionStateValues() []ManagedTransactionState {
+	return _ManagedTransactionStateValues
+}
+
+// ManagedTransactionStateStrings returns a slice of all String values of the enum
+func ManagedTransactionStateStrings() []string {
+	strs := make([]string, len(_ManagedTransactionStateNames))
+	copy(strs, _ManagedTransactionStateNames)
+	return strs
+}
+
+// IsAManagedTransactionState returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i ManagedTransactionState) IsAManagedTransactionState() bool {
+	for _, v := range _ManagedTransactionStateValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for ManagedTransactionState
+func (i ManagedTransactionState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for ManagedTransactionState
+func (i *ManagedTransactionState) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("ManagedTransactionState should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = ManagedTransactionStateString(s)
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for ManagedTransactionState
+func (i ManagedTransactionState) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for ManagedTransactionState
+func (i *ManagedTransactionState) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = ManagedTransactionStateString(s)
+	return err
+}
+
+func (i ManagedTransactionState) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *ManagedTransactionState) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of ManagedTransactionState: %[1]T(%[1]v)", value)
+	}
+
+	val, err := ManagedTransactionStateString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
@@ -82,6 +82,14 @@ const (
 	// NodeStatusExiting
 	// Node announced its intention to leave the Network, and is now in the mandatory waiting period.
 	NodeStatusExiting // exiting
+
+	// NodeStatusDegraded
+	// Node is online but does not serve requests reliably, it is still distributed requests with a lower reliability score.
+	// Possible reasons:
+	// - Node responds slowly or with errors to the active health probes.
+	// - Node's TLS certificate is about to expire.
+	// - Node's Workers have fallen behind their Networks.
+	NodeStatusDegraded // degraded
 )
 
 type BatchUpdateNode struct {
@@ -92,7 +100,10 @@ type BatchUpdateNode struct {
 type FindNodesQuery struct {
 	NodeAddresses []common.Address
 	Status        *NodeStatus
-	Cursor        *string
-	Limit         *int
-	OrderByScore  bool
+	Statuses      []NodeStatus
+	// LastHeartbeatBefore only matches the Nodes whose last heartbeat is before the unix timestamp.
+	LastHeartbeatBefore *int64
+	Cursor              *string
+	Limit               *int
+	OrderByScore        bool
 }
@@ -19,4 +19,5 @@ type NodeAPYSnapshot struct {
 
 type NodeAPYSnapshotQuery struct {
 	NodeAddress *common.Address
+	EpochIDs    []uint64
 }
@@ -11,6 +11,9 @@ type NodeEventType string
 const (
 	NodeEventNodeCreated NodeEventType = "nodeCreated"
 	NodeEventNodeUpdated NodeEventType = "nodeUpdated"
+	// NodeEventNodeStatusChanged is emitted by the Global Indexer rather than the VSL,
+	// its transaction hash identifies the status transition and its block number is 0.
+	NodeEventNodeStatusChanged NodeEventType = "nodeStatusChanged"
 )
 
 type NodeEvent struct {
@@ -33,6 +36,7 @@ type NodeEventMetadata struct {
 	NodeCreatedMetadata            *NodeCreatedMetadata            `json:"node_created,omitempty"`
 	NodeUpdatedMetadata            *NodeUpdatedMetadata            `json:"node_updated,omitempty"`
 	NodeUpdated2PublicGoodMetadata *NodeUpdated2PublicGoodMetadata `json:"node_updated_to_public_good,omitempty"`
+	NodeStatusChangedMetadata      *NodeStatusChangedMetadata      `json:"node_status_changed,omitempty"`
 }
 
 type NodeCreatedMetadata struct {
@@ -55,6 +59,13 @@ type NodeUpdated2PublicGoodMetadata struct {
 	PublicGood bool           `json:"public_good"`
 }
 
+type NodeStatusChangedMetadata struct {
+	Address common.Address             `json:"address"`
+	From    NodeStatus                 `json:"from"`
+	To      NodeStatus                 `json:"to"`
+	Reason  NodeStatusTransitionReason `json:"reason"`
+}
+
 type NodeEventsQuery struct {
 	NodeAddress *common.Address
 	Cursor      *string
@@ -2,6 +2,7 @@ package schema
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -18,6 +19,7 @@ type NodeInvalidResponse struct {
 	VerifierResponse json.RawMessage         `json:"verifier_response"`
 	Node             common.Address          `json:"node"`
 	Response         json.RawMessage         `json:"response"`
+	ItemDiff         json.RawMessage         `json:"item_diff,omitempty"`
 	CreatedAt        int64                   `json:"created_at"`
 }
 
@@ -30,3 +32,14 @@ const (
 	// NodeInvalidResponseTypeError when the Node returns an error
 	NodeInvalidResponseTypeError // error
 )
+
+type NodeInvalidResponsesQuery struct {
+	Node       *common.Address
+	EpochID    *uint64
+	Type       *NodeInvalidResponseType
+	Verifier   *common.Address
+	BeforeDate *time.Time
+	AfterDate  *time.Time
+	Cursor     *uint64
+	Limit      *int
+}
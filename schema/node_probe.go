@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// NodeProbe is the result of an active health probe of a Node endpoint, all probes of a round share the same ProbedAt.
+type NodeProbe struct {
+	NodeAddress common.Address  `json:"node_address"`
+	Target      NodeProbeTarget `json:"target"`
+	// StatusCode is 0 if no response was received.
+	StatusCode int           `json:"status_code"`
+	Latency    time.Duration `json:"latency"`
+	Error      string        `json:"error,omitempty"`
+	// CertificateExpiresAt is the expiry of the TLS certificate of the endpoint, nil for plain HTTP.
+	CertificateExpiresAt *time.Time `json:"certificate_expires_at,omitempty"`
+	// Workers and ReadyWorkers measure the data freshness of the Node, they are only set by the workers_status probe.
+	Workers      int       `json:"workers,omitempty"`
+	ReadyWorkers int       `json:"ready_workers,omitempty"`
+	ProbedAt     time.Time `json:"probed_at"`
+}
+
+// Succeeded returns true if the probe received a successful response.
+func (p *NodeProbe) Succeeded() bool {
+	return p.Error == "" && p.StatusCode >= 200 && p.StatusCode < 300
+}
+
+//go:generate go run --mod=mod github.com/dmarkham/enumer@v1.5.9 --values --type=NodeProbeTarget --linecomment --output node_probe_target_string.go --json --yaml --sql
+type NodeProbeTarget int64
+
+const (
+	// NodeProbeTargetRoot the root of the Node endpoint.
+	NodeProbeTargetRoot NodeProbeTarget = iota // root
+	// NodeProbeTargetWorkersStatus the status of the Node Workers.
+	NodeProbeTargetWorkersStatus // workers_status
+	// NodeProbeTargetCanary a known transaction queried from the Node.
+	NodeProbeTargetCanary // canary
+)
+
+type NodeProbeQuery struct {
+	NodeAddresses []common.Address
+	// Since only matches the probes at or after the time.
+	Since *time.Time
+	Limit *int
+}
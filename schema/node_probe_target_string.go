@@ -0,0 +1,152 @@
+// Code generated by "enumer --values --type=NodeProbeTarget --linecomment --output node_probe_target_string.go --json --yaml --sql"; DO NOT EDIT.
+
+package schema
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _NodeProbeTargetName = "rootworkers_statuscanary"
+
+var _NodeProbeTargetIndex = [...]uint8{0, 4, 18, 24}
+
+const _NodeProbeTargetLowerName = "rootworkers_statuscanary"
+
+func (i NodeProbeTarget) String() string {
+	if i < 0 || i >= NodeProbeTarget(len(_NodeProbeTargetIndex)-1) {
+		return fmt.Sprintf("NodeProbeTarget(%d)", i)
+	}
+	return _NodeProbeTargetName[_NodeProbeTargetIndex[i]:_NodeProbeTargetIndex[i+1]]
+}
+
+func (NodeProbeTarget) Values() []string {
+	return NodeProbeTargetStrings()
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _NodeProbeTargetNoOp() {
+	var x [1]struct{}
+	_ = x[NodeProbeTargetRoot-(0)]
+	_ = x[NodeProbeTargetWorkersStatus-(1)]
+	_ = x[NodeProbeTargetCanary-(2)]
+}
+
+var _NodeProbeTargetValues = []NodeProbeTarget{NodeProbeTargetRoot, NodeProbeTargetWorkersStatus, NodeProbeTargetCanary}
+
+var _NodeProbeTargetNameToValueMap = map[string]NodeProbeTarget{
+	_NodeProbeTargetName[0:4]:        NodeProbeTargetRoot,
+	_NodeProbeTargetLowerName[0:4]:   NodeProbeTargetRoot,
+	_NodeProbeTargetName[4:18]:       NodeProbeTargetWorkersStatus,
+	_NodeProbeTargetLowerName[4:18]:  NodeProbeTargetWorkersStatus,
+	_NodeProbeTargetName[18:24]:      NodeProbeTargetCanary,
+	_NodeProbeTargetLowerName[18:24]: NodeProbeTargetCanary,
+}
+
+var _NodeProbeTargetNames = []string{
+	_NodeProbeTargetName[0:4],
+	_NodeProbeTargetName[4:18],
+	_NodeProbeTargetName[18:24],
+}
+
+// NodeProbeTargetString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func NodeProbeTargetString(s string) (NodeProbeTarget, error) {
+	if val, ok := _NodeProbeTargetNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _NodeProbeTargetNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to NodeProbeTarget values", s)
+}
+
+// NodeProbeTargetValues returns all values of the enum
+func NodeProbeTargetValues() []NodeProbeTarget {
+	return _NodeProbeTargetValues
+}
+
+// NodeProbeTargetStrings returns a slice of all String values of the enum
+func NodeProbeTargetStrings() []string {
+	strs := make([]string, len(_NodeProbeTargetNames))
+	copy(strs, _NodeProbeTargetNames)
+	return strs
+}
+
+// IsANodeProbeTarget returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i NodeProbeTarget) IsANodeProbeTarget() bool {
+	for _, v := range _NodeProbeTargetValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for NodeProbeTarget
+func (i NodeProbeTarget) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for NodeProbeTarget
+func (i *NodeProbeTarget) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("NodeProbeTarget should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = NodeProbeTargetString(s)
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for NodeProbeTarget
+func (i NodeProbeTarget) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for NodeProbeTarget
+func (i *NodeProbeTarget) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = NodeProbeTargetString(s)
+	return err
+}
+
+func (i NodeProbeTarget) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *NodeProbeTarget) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of NodeProbeTarget: %[1]T(%[1]v)", value)
+	}
+
+	val, err := NodeProbeTargetString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
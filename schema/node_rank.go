@@ -0,0 +1,69 @@
+package schema
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+)
+
+// LeaderboardWindows are the numbers of the recent Epochs the metrics of the leaderboards are aggregated over.
+var LeaderboardWindows = []int{1, 7, 30}
+
+// NodeRank is the rank of a Node on the leaderboard of a metric aggregated over the window of Epochs ending with the Epoch.
+type NodeRank struct {
+	Metric LeaderboardMetric `json:"metric"`
+	// Epochs is the size of the window.
+	Epochs      int             `json:"epochs"`
+	EpochID     uint64          `json:"epoch_id"`
+	NodeAddress common.Address  `json:"node_address"`
+	Value       decimal.Decimal `json:"value"`
+	Rank        int             `json:"rank"`
+	// PreviousRank is the rank of the Node on the leaderboard of the previous Epoch, nil if it was not ranked.
+	PreviousRank *int `json:"previous_rank"`
+}
+
+// RankChange returns the number of places the Node moved up since the previous Epoch, negative if it moved down.
+func (r *NodeRank) RankChange() *int {
+	if r.PreviousRank == nil {
+		return nil
+	}
+
+	change := *r.PreviousRank - r.Rank
+
+	return &change
+}
+
+//go:generate go run --mod=mod github.com/dmarkham/enumer@v1.5.9 --values --type=LeaderboardMetric --linecomment --output leaderboard_metric_string.go --json --yaml --sql
+type LeaderboardMetric int64
+
+const (
+	// LeaderboardMetricRewards the sum of the operation and the staking rewards.
+	LeaderboardMetricRewards LeaderboardMetric = iota // rewards
+	// LeaderboardMetricAPY the average APY of the Epochs.
+	LeaderboardMetricAPY // apy
+	// LeaderboardMetricReliabilityScore the average Reliability Score σ of the Epochs.
+	LeaderboardMetricReliabilityScore // reliability_score
+	// LeaderboardMetricStakingGrowth the growth of the staking pool tokens.
+	LeaderboardMetricStakingGrowth // staking_growth
+	// LeaderboardMetricUptime the ratio of the time the Node was online.
+	LeaderboardMetricUptime // uptime
+	// LeaderboardMetricInvalidResponseRate the number of the invalid responses per request served.
+	LeaderboardMetricInvalidResponseRate // invalid_response_rate
+	// LeaderboardMetricStakerCount the number of the stakers at the end of the window.
+	LeaderboardMetricStakerCount // staker_count
+)
+
+// Ascending returns true if a lower value of the metric ranks higher.
+func (m LeaderboardMetric) Ascending() bool {
+	return m == LeaderboardMetricInvalidResponseRate
+}
+
+type NodeRanksQuery struct {
+	Metric *LeaderboardMetric
+	Epochs *int
+	// EpochID defaults to the latest ranked Epoch.
+	EpochID     *uint64
+	NodeAddress *common.Address
+	// Cursor only matches the ranks after the rank.
+	Cursor *int
+	Limit  *int
+}
@@ -0,0 +1,30 @@
+package schema
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// NodeScore is the breakdown of the Reliability Score σ of a Node in an Epoch.
+type NodeScore struct {
+	NodeAddress common.Address `json:"node_address"`
+	EpochID     uint64         `json:"epoch_id"`
+	// ModelVersion is the version of the reliability score model that calculated the score.
+	ModelVersion string                `json:"model_version"`
+	Score        float64               `json:"score"`
+	Components   []*NodeScoreComponent `json:"components"`
+	UpdatedAt    int64                 `json:"updated_at"`
+}
+
+// NodeScoreComponent is the score of a component of the Reliability Score σ, calculated from the value of the Node.
+type NodeScoreComponent struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+	Score float64 `json:"score"`
+}
+
+type NodeScoresQuery struct {
+	NodeAddress *common.Address
+	EpochIDs    []uint64
+	Cursor      *uint64
+	Limit       *int
+}
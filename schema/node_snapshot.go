@@ -19,3 +19,10 @@ type NodeSnapshot struct {
 	Date  time.Time `json:"date"`
 	Count int64     `json:"count"`
 }
+
+// CountSnapshotsQuery queries the Node and the staker count snapshots, Since and Until are inclusive.
+type CountSnapshotsQuery struct {
+	Since *time.Time
+	Until *time.Time
+	Limit *int
+}
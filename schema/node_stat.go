@@ -18,11 +18,13 @@ type Stat struct {
 	Epoch                int64          `json:"epoch"`
 	TotalRequest         int64          `json:"total_request"`
 	EpochRequest         int64          `json:"epoch_request"`
+	LastEpochRequest     int64          `json:"last_epoch_request"`
 	EpochInvalidRequest  int64          `json:"epoch_invalid_request"`
 	DecentralizedNetwork int            `json:"decentralized_network"`
 	FederatedNetwork     int            `json:"federated_network"`
 	Indexer              int            `json:"indexer"`
 	ResetAt              time.Time      `json:"reset_at"`
+	ProbeFailureRate     float64        `json:"probe_failure_rate"`
 }
 
 type StatQuery struct {
@@ -9,11 +9,11 @@ import (
 	"strings"
 )
 
-const _NodeStatusName = "registeredonlineofflineexitedslashedexiting"
+const _NodeStatusName = "registeredonlineofflineexitedslashedexitingdegraded"
 
-var _NodeStatusIndex = [...]uint8{0, 10, 16, 23, 29, 36, 43}
+var _NodeStatusIndex = [...]uint8{0, 10, 16, 23, 29, 36, 43, 51}
 
-const _NodeStatusLowerName = "registeredonlineofflineexitedslashedexiting"
+const _NodeStatusLowerName = "registeredonlineofflineexitedslashedexitingdegraded"
 
 func (i NodeStatus) String() string {
 	if i < 0 || i >= NodeStatus(len(_NodeStatusIndex)-1) {
@@ -36,9 +36,10 @@ func _NodeStatusNoOp() {
 	_ = x[NodeStatusExited-(3)]
 	_ = x[NodeStatusSlashed-(4)]
 	_ = x[NodeStatusExiting-(5)]
+	_ = x[NodeStatusDegraded-(6)]
 }
 
-var _NodeStatusValues = []NodeStatus{NodeStatusRegistered, NodeStatusOnline, NodeStatusOffline, NodeStatusExited, NodeStatusSlashed, NodeStatusExiting}
+var _NodeStatusValues = []NodeStatus{NodeStatusRegistered, NodeStatusOnline, NodeStatusOffline, NodeStatusExited, NodeStatusSlashed, NodeStatusExiting, NodeStatusDegraded}
 
 var _NodeStatusNameToValueMap = map[string]NodeStatus{
 	_NodeStatusName[0:10]:       NodeStatusRegistered,
@@ -53,6 +54,8 @@ var _NodeStatusNameToValueMap = map[string]NodeStatus{
 	_NodeStatusLowerName[29:36]: NodeStatusSlashed,
 	_NodeStatusName[36:43]:      NodeStatusExiting,
 	_NodeStatusLowerName[36:43]: NodeStatusExiting,
+	_NodeStatusName[43:51]:      NodeStatusDegraded,
+	_NodeStatusLowerName[43:51]: NodeStatusDegraded,
 }
 
 var _NodeStatusNames = []string{
@@ -62,6 +65,7 @@ var _NodeStatusNames = []string{
 	_NodeStatusName[23:29],
 	_NodeStatusName[29:36],
 	_NodeStatusName[36:43],
+	_NodeStatusName[43:51],
 }
 
 // NodeStatusString retrieves an enum value from the enum constants string name.
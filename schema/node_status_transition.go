@@ -0,0 +1,46 @@
+package schema
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// NodeStatusTransition is a record of a Node transiting from one status to another.
+type NodeStatusTransition struct {
+	ID          uint64                     `json:"id"`
+	NodeAddress common.Address             `json:"node_address"`
+	From        NodeStatus                 `json:"from"`
+	To          NodeStatus                 `json:"to"`
+	Reason      NodeStatusTransitionReason `json:"reason"`
+	Timestamp   int64                      `json:"timestamp"`
+}
+
+//go:generate go run --mod=mod github.com/dmarkham/enumer@v1.5.9 --values --type=NodeStatusTransitionReason --linecomment --output node_status_transition_reason_string.go --json --yaml --sql
+type NodeStatusTransitionReason int64
+
+const (
+	// NodeStatusTransitionReasonRegistration the Node registered or re-registered to the Network.
+	NodeStatusTransitionReasonRegistration NodeStatusTransitionReason = iota // registration
+	// NodeStatusTransitionReasonHeartbeat the Node sent a heartbeat.
+	NodeStatusTransitionReasonHeartbeat // heartbeat
+	// NodeStatusTransitionReasonHeartbeatTimeout the Node missed its heartbeats.
+	NodeStatusTransitionReasonHeartbeatTimeout // heartbeat_timeout
+	// NodeStatusTransitionReasonProbe the health probes of the Node changed its status.
+	NodeStatusTransitionReasonProbe // probe
+	// NodeStatusTransitionReasonSlash the Node was slashed on the VSL.
+	NodeStatusTransitionReasonSlash // slash
+	// NodeStatusTransitionReasonExitAnnouncement the Node announced its intention to leave the Network.
+	NodeStatusTransitionReasonExitAnnouncement // exit_announcement
+	// NodeStatusTransitionReasonExitCompletion the Node finished the mandatory waiting period after its exit announcement.
+	NodeStatusTransitionReasonExitCompletion // exit_completion
+	// NodeStatusTransitionReasonLongOffline the Node has been offline for a long time.
+	NodeStatusTransitionReasonLongOffline // long_offline
+)
+
+type NodeStatusTransitionsQuery struct {
+	NodeAddresses []common.Address
+	To            *NodeStatus
+	// Until only matches the transitions at or before the unix timestamp.
+	Until  *int64
+	Cursor *string
+	Limit  *int
+}
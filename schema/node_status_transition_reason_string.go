@@ -0,0 +1,172 @@
+// Code generated by "enumer --values --type=NodeStatusTransitionReason --linecomment --output node_status_transition_reason_string.go --json --yaml --sql"; DO NOT EDIT.
+
+package schema
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _NodeStatusTransitionReasonName = "registrationheartbeatheartbeat_timeoutprobeslashexit_announcementexit_completionlong_offline"
+
+var _NodeStatusTransitionReasonIndex = [...]uint8{0, 12, 21, 38, 43, 48, 65, 80, 92}
+
+const _NodeStatusTransitionReasonLowerName = "registrationheartbeatheartbeat_timeoutprobeslashexit_announcementexit_completionlong_offline"
+
+func (i NodeStatusTransitionReason) String() string {
+	if i < 0 || i >= NodeStatusTransitionReason(len(_NodeStatusTransitionReasonIndex)-1) {
+		return fmt.Sprintf("NodeStatusTransitionReason(%d)", i)
+	}
+	return _NodeStatusTransitionReasonName[_NodeStatusTransitionReasonIndex[i]:_NodeStatusTransitionReasonIndex[i+1]]
+}
+
+func (NodeStatusTransitionReason) Values() []string {
+	return NodeStatusTransitionReasonStrings()
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _NodeStatusTransitionReasonNoOp() {
+	var x [1]struct{}
+	_ = x[NodeStatusTransitionReasonRegistration-(0)]
+	_ = x[NodeStatusTransitionReasonHeartbeat-(1)]
+	_ = x[NodeStatusTransitionReasonHeartbeatTimeout-(2)]
+	_ = x[NodeStatusTransitionReasonProbe-(3)]
+	_ = x[NodeStatusTransitionReasonSlash-(4)]
+	_ = x[NodeStatusTransitionReasonExitAnnouncement-(5)]
+	_ = x[NodeStatusTransitionReasonExitCompletion-(6)]
+	_ = x[NodeStatusTransitionReasonLongOffline-(7)]
+}
+
+var _NodeStatusTransitionReasonValues = []NodeStatusTransitionReason{NodeStatusTransitionReasonRegistration, NodeStatusTransitionReasonHeartbeat, NodeStatusTransitionReasonHeartbeatTimeout, NodeStatusTransitionReasonProbe, NodeStatusTransitionReasonSlash, NodeStatusTransitionReasonExitAnnouncement, NodeStatusTransitionReasonExitCompletion, NodeStatusTransitionReasonLongOffline}
+
+var _NodeStatusTransitionReasonNameToValueMap = map[string]NodeStatusTransitionReason{
+	_NodeStatusTransitionReasonName[0:12]:       NodeStatusTransitionReasonRegistration,
+	_NodeStatusTransitionReasonLowerName[0:12]:  NodeStatusTransitionReasonRegistration,
+	_NodeStatusTransitionReasonName[12:21]:      NodeStatusTransitionReasonHeartbeat,
+	_NodeStatusTransitionReasonLowerName[12:21]: NodeStatusTransitionReasonHeartbeat,
+	_NodeStatusTransitionReasonName[21:38]:      NodeStatusTransitionReasonHeartbeatTimeout,
+	_NodeStatusTransitionReasonLowerName[21:38]: NodeStatusTransitionReasonHeartbeatTimeout,
+	_NodeStatusTransitionReasonName[38:43]:      NodeStatusTransitionReasonProbe,
+	_NodeStatusTransitionReasonLowerName[38:43]: NodeStatusTransitionReasonProbe,
+	_NodeStatusTransitionReasonName[43:48]:      NodeStatusTransitionReasonSlash,
+	_NodeStatusTransitionReasonLowerName[43:48]: NodeStatusTransitionReasonSlash,
+	_NodeStatusTransitionReasonName[48:65]:      NodeStatusTransitionReasonExitAnnouncement,
+	_NodeStatusTransitionReasonLowerName[48:65]: NodeStatusTransitionReasonExitAnnouncement,
+	_NodeStatusTransitionReasonName[65:80]:      NodeStatusTransitionReasonExitCompletion,
+	_NodeStatusTransitionReasonLowerName[65:80]: NodeStatusTransitionReasonExitCompletion,
+	_NodeStatusTransitionReasonName[80:92]:      NodeStatusTransitionReasonLongOffline,
+	_NodeStatusTransitionReasonLowerName[80:92]: NodeStatusTransitionReasonLongOffline,
+}
+
+var _NodeStatusTransitionReasonNames = []string{
+	_NodeStatusTransitionReasonName[0:12],
+	_NodeStatusTransitionReasonName[12:21],
+	_NodeStatusTransitionReasonName[21:38],
+	_NodeStatusTransitionReasonName[38:43],
+	_NodeStatusTransitionReasonName[43:48],
+	_NodeStatusTransitionReasonName[48:65],
+	_NodeStatusTransitionReasonName[65:80],
+	_NodeStatusTransitionReasonName[80:92],
+}
+
+// NodeStatusTransitionReasonString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func NodeStatusTransitionReasonString(s string) (NodeStatusTransitionReason, error) {
+	if val, ok := _NodeStatusTransitionReasonNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _NodeStatusTransitionReasonNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to NodeStatusTransitionReason values", s)
+}
+
+// NodeStatusTransitionReasonValues returns all values of the enum
+func NodeStatusTransitionReasonValues() []NodeStatusTransitionReason {
+	return _NodeStatusTransitionReasonValues
+}
+
+// NodeStatusTransitionReasonStrings returns a slice of all String values of the enum
+func NodeStatusTransitionReasonStrings() []string {
+	strs := make([]string, len(_NodeStatusTransitionReasonNames))
+	copy(strs, _NodeStatusTransitionReasonNames)
+	return strs
+}
+
+// IsANodeStatusTransitionReason returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i NodeStatusTransitionReason) IsANodeStatusTransitionReason() bool {
+	for _, v := range _NodeStatusTransitionReasonValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for NodeStatusTransitionReason
+func (i NodeStatusTransitionReason) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for NodeStatusTransitionReason
+func (i *NodeStatusTransitionReason) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("NodeStatusTransitionReason should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = NodeStatusTransitionReasonString(s)
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for NodeStatusTransitionReason
+func (i NodeStatusTransitionReason) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for NodeStatusTransitionReason
+func (i *NodeStatusTransitionReason) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = NodeStatusTransitionReasonString(s)
+	return err
+}
+
+func (i NodeStatusTransitionReason) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *NodeStatusTransitionReason) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of NodeStatusTransitionReason: %[1]T(%[1]v)", value)
+	}
+
+	val, err := NodeStatusTransitionReasonString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
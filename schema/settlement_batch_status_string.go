@@ -0,0 +1,152 @@
+// Code generated by "enumer --values --type=SettlementBatchStatus --linecomment --output settlement_batch_status_string.go --json --yaml --sql"; DO NOT EDIT.
+
+package schema
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _SettlementBatchStatusName = "pendingsubmittingconfirmed"
+
+var _SettlementBatchStatusIndex = [...]uint8{0, 7, 17, 26}
+
+const _SettlementBatchStatusLowerName = "pendingsubmittingconfirmed"
+
+func (i SettlementBatchStatus) String() string {
+	if i < 0 || i >= SettlementBatchStatus(len(_SettlementBatchStatusIndex)-1) {
+		return fmt.Sprintf("SettlementBatchStatus(%d)", i)
+	}
+	return _SettlementBatchStatusName[_SettlementBatchStatusIndex[i]:_SettlementBatchStatusIndex[i+1]]
+}
+
+func (SettlementBatchStatus) Values() []string {
+	return SettlementBatchStatusStrings()
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _SettlementBatchStatusNoOp() {
+	var x [1]struct{}
+	_ = x[SettlementBatchStatusPending-(0)]
+	_ = x[SettlementBatchStatusSubmitting-(1)]
+	_ = x[SettlementBatchStatusConfirmed-(2)]
+}
+
+var _SettlementBatchStatusValues = []SettlementBatchStatus{SettlementBatchStatusPending, SettlementBatchStatusSubmitting, SettlementBatchStatusConfirmed}
+
+var _SettlementBatchStatusNameToValueMap = map[string]SettlementBatchStatus{
+	_SettlementBatchStatusName[0:7]:        SettlementBatchStatusPending,
+	_SettlementBatchStatusLowerName[0:7]:   SettlementBatchStatusPending,
+	_SettlementBatchStatusName[7:17]:       SettlementBatchStatusSubmitting,
+	_SettlementBatchStatusLowerName[7:17]:  SettlementBatchStatusSubmitting,
+	_SettlementBatchStatusName[17:26]:      SettlementBatchStatusConfirmed,
+	_SettlementBatchStatusLowerName[17:26]: SettlementBatchStatusConfirmed,
+}
+
+var _SettlementBatchStatusNames = []string{
+	_SettlementBatchStatusName[0:7],
+	_SettlementBatchStatusName[7:17],
+	_SettlementBatchStatusName[17:26],
+}
+
+// SettlementBatchStatusString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func SettlementBatchStatusString(s string) (SettlementBatchStatus, error) {
+	if val, ok := _SettlementBatchStatusNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _SettlementBatchStatusNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to SettlementBatchStatus values", s)
+}
+
+// SettlementBatchStatusValues returns all values of the enum
+func SettlementBatchStatusValues() []SettlementBatchStatus {
+	return _SettlementBatchStatusValues
+}
+
+// SettlementBatchStatusStrings returns a slice of all String values of the enum
+func SettlementBatchStatusStrings() []string {
+	strs := make([]string, len(_SettlementBatchStatusNames))
+	copy(strs, _SettlementBatchStatusNames)
+	return strs
+}
+
+// IsASettlementBatchStatus returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i SettlementBatchStatus) IsASettlementBatchStatus() bool {
+	for _, v := range _SettlementBatchStatusValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for SettlementBatchStatus
+func (i SettlementBatchStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for SettlementBatchStatus
+func (i *SettlementBatchStatus) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("SettlementBatchStatus should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = SettlementBatchStatusString(s)
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for SettlementBatchStatus
+func (i SettlementBatchStatus) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for SettlementBatchStatus
+func (i *SettlementBatchStatus) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = SettlementBatchStatusString(s)
+	return err
+}
+
+func (i SettlementBatchStatus) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *SettlementBatchStatus) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of SettlementBatchStatus: %[1]T(%[1]v)", value)
+	}
+
+	val, err := SettlementBatchStatusString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
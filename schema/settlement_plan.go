@@ -0,0 +1,99 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SettlementPlan is the persisted plan of an Epoch settlement.
+// The Node set and the membership of every batch are frozen when the plan is created,
+// so that a restarted settler resumes from the first unconfirmed batch with exactly the same inputs.
+type SettlementPlan struct {
+	EpochID uint64             `json:"epoch_id"`
+	Batches []*SettlementBatch `json:"batches"`
+}
+
+// SettlementBatch is a single Settlement contract invocation of a SettlementPlan.
+type SettlementBatch struct {
+	EpochID       uint64           `json:"epoch_id"`
+	Index         int              `json:"index"`
+	NodeAddresses []common.Address `json:"node_addresses"`
+	IsFinal       bool             `json:"is_final"`
+	// Data is the exact payload sent to the Settlement contract.
+	// It is frozen right before the first submission of the batch, so retries send identical data.
+	Data            *SettlementData       `json:"data,omitempty"`
+	TransactionHash *common.Hash          `json:"transaction_hash,omitempty"`
+	ReceiptStatus   *uint64               `json:"receipt_status,omitempty"`
+	Status          SettlementBatchStatus `json:"status"`
+	// Error is the latest condition preventing the batch from being submitted, e.g. an exceeded fee budget.
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+//go:generate go run --mod=mod github.com/dmarkham/enumer@v1.5.9 --values --type=SettlementBatchStatus --linecomment --output settlement_batch_status_string.go --json --yaml --sql
+type SettlementBatchStatus int64
+
+const (
+	// SettlementBatchStatusPending the batch has not been submitted yet.
+	SettlementBatchStatusPending SettlementBatchStatus = iota // pending
+	// SettlementBatchStatusSubmitting the batch data is frozen and the transaction may have been broadcast,
+	// but no receipt has been recorded yet.
+	SettlementBatchStatusSubmitting // submitting
+	// SettlementBatchStatusConfirmed the transaction of the batch has been confirmed on chain.
+	SettlementBatchStatusConfirmed // confirmed
+)
+
+// NodeAddresses returns the frozen Node set of the plan, in batch order.
+func (p *SettlementPlan) NodeAddresses() []common.Address {
+	addresses := make([]common.Address, 0)
+
+	for _, batch := range p.Batches {
+		addresses = append(addresses, batch.NodeAddresses...)
+	}
+
+	return addresses
+}
+
+// NextBatch returns the first batch that has not been confirmed, or nil if the plan is complete.
+func (p *SettlementPlan) NextBatch() *SettlementBatch {
+	for _, batch := range p.Batches {
+		if batch.Status != SettlementBatchStatusConfirmed {
+			return batch
+		}
+	}
+
+	return nil
+}
+
+// Completed returns true if every batch of the plan has been confirmed.
+func (p *SettlementPlan) Completed() bool {
+	return p.NextBatch() == nil
+}
+
+// NewSettlementPlan splits the Node set into batches of at most batchSize Nodes.
+// An empty Node set still produces a single final batch, as the Epoch must be settled regardless.
+func NewSettlementPlan(epochID uint64, nodeAddresses []common.Address, batchSize int) *SettlementPlan {
+	plan := SettlementPlan{
+		EpochID: epochID,
+	}
+
+	if batchSize <= 0 {
+		batchSize = len(nodeAddresses)
+	}
+
+	for index, start := 0, 0; start < len(nodeAddresses) || index == 0; index, start = index+1, start+batchSize {
+		end := min(start+batchSize, len(nodeAddresses))
+
+		plan.Batches = append(plan.Batches, &SettlementBatch{
+			EpochID:       epochID,
+			Index:         index,
+			NodeAddresses: append([]common.Address{}, nodeAddresses[start:end]...),
+			IsFinal:       end == len(nodeAddresses),
+			Status:        SettlementBatchStatusPending,
+		})
+	}
+
+	return &plan
+}
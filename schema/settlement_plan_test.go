@@ -0,0 +1,75 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSettlementPlan(t *testing.T) {
+	t.Parallel()
+
+	addresses := []common.Address{{1}, {2}, {3}, {4}, {5}}
+
+	tests := []struct {
+		name          string
+		nodeAddresses []common.Address
+		batchSize     int
+		expected      [][]common.Address
+	}{
+		{
+			name:          "no nodes",
+			nodeAddresses: nil,
+			batchSize:     2,
+			expected:      [][]common.Address{{}},
+		},
+		{
+			name:          "single batch",
+			nodeAddresses: addresses,
+			batchSize:     10,
+			expected:      [][]common.Address{addresses},
+		},
+		{
+			name:          "exact batches",
+			nodeAddresses: addresses[:4],
+			batchSize:     2,
+			expected:      [][]common.Address{addresses[:2], addresses[2:4]},
+		},
+		{
+			name:          "remainder batch",
+			nodeAddresses: addresses,
+			batchSize:     2,
+			expected:      [][]common.Address{addresses[:2], addresses[2:4], addresses[4:]},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan := schema.NewSettlementPlan(1, tt.nodeAddresses, tt.batchSize)
+
+			require.Len(t, plan.Batches, len(tt.expected))
+
+			for i, batch := range plan.Batches {
+				require.Equal(t, i, batch.Index)
+				require.Equal(t, uint64(1), batch.EpochID)
+				require.Equal(t, tt.expected[i], batch.NodeAddresses)
+				require.Equal(t, i == len(plan.Batches)-1, batch.IsFinal)
+				require.Equal(t, schema.SettlementBatchStatusPending, batch.Status)
+			}
+
+			require.Equal(t, plan.Batches[0], plan.NextBatch())
+
+			for _, batch := range plan.Batches {
+				batch.Status = schema.SettlementBatchStatusConfirmed
+			}
+
+			require.True(t, plan.Completed())
+		})
+	}
+}
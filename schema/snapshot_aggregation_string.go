@@ -0,0 +1,156 @@
+// Code generated by "enumer --values --type=SnapshotAggregation --linecomment --output snapshot_aggregation_string.go --json --yaml --sql"; DO NOT EDIT.
+
+package schema
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _SnapshotAggregationName = "lastavgminmax"
+
+var _SnapshotAggregationIndex = [...]uint8{0, 4, 7, 10, 13}
+
+const _SnapshotAggregationLowerName = "lastavgminmax"
+
+func (i SnapshotAggregation) String() string {
+	if i < 0 || i >= SnapshotAggregation(len(_SnapshotAggregationIndex)-1) {
+		return fmt.Sprintf("SnapshotAggregation(%d)", i)
+	}
+	return _SnapshotAggregationName[_SnapshotAggregationIndex[i]:_SnapshotAggregationIndex[i+1]]
+}
+
+func (SnapshotAggregation) Values() []string {
+	return SnapshotAggregationStrings()
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _SnapshotAggregationNoOp() {
+	var x [1]struct{}
+	_ = x[SnapshotAggregationLast-(0)]
+	_ = x[SnapshotAggregationAvg-(1)]
+	_ = x[SnapshotAggregationMin-(2)]
+	_ = x[SnapshotAggregationMax-(3)]
+}
+
+var _SnapshotAggregationValues = []SnapshotAggregation{SnapshotAggregationLast, SnapshotAggregationAvg, SnapshotAggregationMin, SnapshotAggregationMax}
+
+var _SnapshotAggregationNameToValueMap = map[string]SnapshotAggregation{
+	_SnapshotAggregationName[0:4]:        SnapshotAggregationLast,
+	_SnapshotAggregationLowerName[0:4]:   SnapshotAggregationLast,
+	_SnapshotAggregationName[4:7]:        SnapshotAggregationAvg,
+	_SnapshotAggregationLowerName[4:7]:   SnapshotAggregationAvg,
+	_SnapshotAggregationName[7:10]:       SnapshotAggregationMin,
+	_SnapshotAggregationLowerName[7:10]:  SnapshotAggregationMin,
+	_SnapshotAggregationName[10:13]:      SnapshotAggregationMax,
+	_SnapshotAggregationLowerName[10:13]: SnapshotAggregationMax,
+}
+
+var _SnapshotAggregationNames = []string{
+	_SnapshotAggregationName[0:4],
+	_SnapshotAggregationName[4:7],
+	_SnapshotAggregationName[7:10],
+	_SnapshotAggregationName[10:13],
+}
+
+// SnapshotAggregationString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func SnapshotAggregationString(s string) (SnapshotAggregation, error) {
+	if val, ok := _SnapshotAggregationNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _SnapshotAggregationNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to SnapshotAggregation values", s)
+}
+
+// SnapshotAggregationValues returns all values of the enum
+func SnapshotAggregationValues() []SnapshotAggregation {
+	return _SnapshotAggregationValues
+}
+
+// SnapshotAggregationStrings returns a slice of all String values of the enum
+func SnapshotAggregationStrings() []string {
+	strs := make([]string, len(_SnapshotAggregationNames))
+	copy(strs, _SnapshotAggregationNames)
+	return strs
+}
+
+// IsASnapshotAggregation returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i SnapshotAggregation) IsASnapshotAggregation() bool {
+	for _, v := range _SnapshotAggregationValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for SnapshotAggregation
+func (i SnapshotAggregation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for SnapshotAggregation
+func (i *SnapshotAggregation) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("SnapshotAggregation should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = SnapshotAggregationString(s)
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for SnapshotAggregation
+func (i SnapshotAggregation) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for SnapshotAggregation
+func (i *SnapshotAggregation) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = SnapshotAggregationString(s)
+	return err
+}
+
+func (i SnapshotAggregation) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *SnapshotAggregation) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of SnapshotAggregation: %[1]T(%[1]v)", value)
+	}
+
+	val, err := SnapshotAggregationString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
@@ -0,0 +1,156 @@
+// Code generated by "enumer --values --type=SnapshotGranularity --linecomment --output snapshot_granularity_string.go --json --yaml --sql"; DO NOT EDIT.
+
+package schema
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _SnapshotGranularityName = "epochdayweekmonth"
+
+var _SnapshotGranularityIndex = [...]uint8{0, 5, 8, 12, 17}
+
+const _SnapshotGranularityLowerName = "epochdayweekmonth"
+
+func (i SnapshotGranularity) String() string {
+	if i < 0 || i >= SnapshotGranularity(len(_SnapshotGranularityIndex)-1) {
+		return fmt.Sprintf("SnapshotGranularity(%d)", i)
+	}
+	return _SnapshotGranularityName[_SnapshotGranularityIndex[i]:_SnapshotGranularityIndex[i+1]]
+}
+
+func (SnapshotGranularity) Values() []string {
+	return SnapshotGranularityStrings()
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _SnapshotGranularityNoOp() {
+	var x [1]struct{}
+	_ = x[SnapshotGranularityEpoch-(0)]
+	_ = x[SnapshotGranularityDay-(1)]
+	_ = x[SnapshotGranularityWeek-(2)]
+	_ = x[SnapshotGranularityMonth-(3)]
+}
+
+var _SnapshotGranularityValues = []SnapshotGranularity{SnapshotGranularityEpoch, SnapshotGranularityDay, SnapshotGranularityWeek, SnapshotGranularityMonth}
+
+var _SnapshotGranularityNameToValueMap = map[string]SnapshotGranularity{
+	_SnapshotGranularityName[0:5]:        SnapshotGranularityEpoch,
+	_SnapshotGranularityLowerName[0:5]:   SnapshotGranularityEpoch,
+	_SnapshotGranularityName[5:8]:        SnapshotGranularityDay,
+	_SnapshotGranularityLowerName[5:8]:   SnapshotGranularityDay,
+	_SnapshotGranularityName[8:12]:       SnapshotGranularityWeek,
+	_SnapshotGranularityLowerName[8:12]:  SnapshotGranularityWeek,
+	_SnapshotGranularityName[12:17]:      SnapshotGranularityMonth,
+	_SnapshotGranularityLowerName[12:17]: SnapshotGranularityMonth,
+}
+
+var _SnapshotGranularityNames = []string{
+	_SnapshotGranularityName[0:5],
+	_SnapshotGranularityName[5:8],
+	_SnapshotGranularityName[8:12],
+	_SnapshotGranularityName[12:17],
+}
+
+// SnapshotGranularityString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func SnapshotGranularityString(s string) (SnapshotGranularity, error) {
+	if val, ok := _SnapshotGranularityNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _SnapshotGranularityNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to SnapshotGranularity values", s)
+}
+
+// SnapshotGranularityValues returns all values of the enum
+func SnapshotGranularityValues() []SnapshotGranularity {
+	return _SnapshotGranularityValues
+}
+
+// SnapshotGranularityStrings returns a slice of all String values of the enum
+func SnapshotGranularityStrings() []string {
+	strs := make([]string, len(_SnapshotGranularityNames))
+	copy(strs, _SnapshotGranularityNames)
+	return strs
+}
+
+// IsASnapshotGranularity returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i SnapshotGranularity) IsASnapshotGranularity() bool {
+	for _, v := range _SnapshotGranularityValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for SnapshotGranularity
+func (i SnapshotGranularity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for SnapshotGranularity
+func (i *SnapshotGranularity) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("SnapshotGranularity should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = SnapshotGranularityString(s)
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for SnapshotGranularity
+func (i SnapshotGranularity) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for SnapshotGranularity
+func (i *SnapshotGranularity) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = SnapshotGranularityString(s)
+	return err
+}
+
+func (i SnapshotGranularity) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *SnapshotGranularity) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of SnapshotGranularity: %[1]T(%[1]v)", value)
+	}
+
+	val, err := SnapshotGranularityString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
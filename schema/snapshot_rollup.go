@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+)
+
+// SnapshotPoint is the aggregation of a series of snapshots over a bucket of time.
+type SnapshotPoint struct {
+	// Date is the start of the bucket.
+	Date  time.Time       `json:"date"`
+	Value decimal.Decimal `json:"value"`
+	// Samples is the number of the snapshots in the bucket.
+	Samples int64 `json:"samples"`
+}
+
+type SnapshotPointsQuery struct {
+	Series SnapshotSeries
+	// Address is the Node operator or the staker of a series per address.
+	Address     *common.Address
+	Granularity SnapshotGranularity
+	Aggregation SnapshotAggregation
+	// Since and Until are inclusive, a bucket is matched if it contains Since.
+	Since *time.Time
+	Until *time.Time
+	Limit *int
+}
+
+//go:generate go run --mod=mod github.com/dmarkham/enumer@v1.5.9 --values --type=SnapshotSeries --linecomment --output snapshot_series_string.go --json --yaml --sql
+type SnapshotSeries int64
+
+const (
+	// SnapshotSeriesNodeCount the daily count of the Nodes.
+	SnapshotSeriesNodeCount SnapshotSeries = iota // node_count
+	// SnapshotSeriesStakerCount the daily count of the stakers.
+	SnapshotSeriesStakerCount // staker_count
+	// SnapshotSeriesOperationPool the operation pool of a Node operator by Epoch.
+	SnapshotSeriesOperationPool // operation_pool
+	// SnapshotSeriesTotalChipAmounts the number of the Chips of a staker by Epoch.
+	SnapshotSeriesTotalChipAmounts // total_chip_amounts
+	// SnapshotSeriesTotalChipValues the value of the Chips of a staker by Epoch.
+	SnapshotSeriesTotalChipValues // total_chip_values
+	// SnapshotSeriesEpochAPY the average APY of the Nodes by Epoch.
+	SnapshotSeriesEpochAPY // epoch_apy
+)
+
+//go:generate go run --mod=mod github.com/dmarkham/enumer@v1.5.9 --values --type=SnapshotGranularity --linecomment --output snapshot_granularity_string.go --json --yaml --sql
+type SnapshotGranularity int64
+
+const (
+	// SnapshotGranularityEpoch the snapshots are not aggregated, the series by Epoch have a snapshot per Epoch.
+	SnapshotGranularityEpoch SnapshotGranularity = iota // epoch
+	SnapshotGranularityDay                              // day
+	SnapshotGranularityWeek                             // week
+	SnapshotGranularityMonth                            // month
+)
+
+// SnapshotRollupGranularities are the granularities the snapshots are continuously rolled up by.
+var SnapshotRollupGranularities = []SnapshotGranularity{
+	SnapshotGranularityDay,
+	SnapshotGranularityWeek,
+	SnapshotGranularityMonth,
+}
+
+//go:generate go run --mod=mod github.com/dmarkham/enumer@v1.5.9 --values --type=SnapshotAggregation --linecomment --output snapshot_aggregation_string.go --json --yaml --sql
+type SnapshotAggregation int64
+
+const (
+	// SnapshotAggregationLast the latest snapshot of the bucket.
+	SnapshotAggregationLast SnapshotAggregation = iota // last
+	SnapshotAggregationAvg                             // avg
+	SnapshotAggregationMin                             // min
+	SnapshotAggregationMax                             // max
+)
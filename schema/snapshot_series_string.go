@@ -0,0 +1,164 @@
+// Code generated by "enumer --values --type=SnapshotSeries --linecomment --output snapshot_series_string.go --json --yaml --sql"; DO NOT EDIT.
+
+package schema
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _SnapshotSeriesName = "node_countstaker_countoperation_pooltotal_chip_amountstotal_chip_valuesepoch_apy"
+
+var _SnapshotSeriesIndex = [...]uint8{0, 10, 22, 36, 54, 71, 80}
+
+const _SnapshotSeriesLowerName = "node_countstaker_countoperation_pooltotal_chip_amountstotal_chip_valuesepoch_apy"
+
+func (i SnapshotSeries) String() string {
+	if i < 0 || i >= SnapshotSeries(len(_SnapshotSeriesIndex)-1) {
+		return fmt.Sprintf("SnapshotSeries(%d)", i)
+	}
+	return _SnapshotSeriesName[_SnapshotSeriesIndex[i]:_SnapshotSeriesIndex[i+1]]
+}
+
+func (SnapshotSeries) Values() []string {
+	return SnapshotSeriesStrings()
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _SnapshotSeriesNoOp() {
+	var x [1]struct{}
+	_ = x[SnapshotSeriesNodeCount-(0)]
+	_ = x[SnapshotSeriesStakerCount-(1)]
+	_ = x[SnapshotSeriesOperationPool-(2)]
+	_ = x[SnapshotSeriesTotalChipAmounts-(3)]
+	_ = x[SnapshotSeriesTotalChipValues-(4)]
+	_ = x[SnapshotSeriesEpochAPY-(5)]
+}
+
+var _SnapshotSeriesValues = []SnapshotSeries{SnapshotSeriesNodeCount, SnapshotSeriesStakerCount, SnapshotSeriesOperationPool, SnapshotSeriesTotalChipAmounts, SnapshotSeriesTotalChipValues, SnapshotSeriesEpochAPY}
+
+var _SnapshotSeriesNameToValueMap = map[string]SnapshotSeries{
+	_SnapshotSeriesName[0:10]:       SnapshotSeriesNodeCount,
+	_SnapshotSeriesLowerName[0:10]:  SnapshotSeriesNodeCount,
+	_SnapshotSeriesName[10:22]:      SnapshotSeriesStakerCount,
+	_SnapshotSeriesLowerName[10:22]: SnapshotSeriesStakerCount,
+	_SnapshotSeriesName[22:36]:      SnapshotSeriesOperationPool,
+	_SnapshotSeriesLowerName[22:36]: SnapshotSeriesOperationPool,
+	_SnapshotSeriesName[36:54]:      SnapshotSeriesTotalChipAmounts,
+	_SnapshotSeriesLowerName[36:54]: SnapshotSeriesTotalChipAmounts,
+	_SnapshotSeriesName[54:71]:      SnapshotSeriesTotalChipValues,
+	_SnapshotSeriesLowerName[54:71]: SnapshotSeriesTotalChipValues,
+	_SnapshotSeriesName[71:80]:      SnapshotSeriesEpochAPY,
+	_SnapshotSeriesLowerName[71:80]: SnapshotSeriesEpochAPY,
+}
+
+var _SnapshotSeriesNames = []string{
+	_SnapshotSeriesName[0:10],
+	_SnapshotSeriesName[10:22],
+	_SnapshotSeriesName[22:36],
+	_SnapshotSeriesName[36:54],
+	_SnapshotSeriesName[54:71],
+	_SnapshotSeriesName[71:80],
+}
+
+// SnapshotSeriesString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func SnapshotSeriesString(s string) (SnapshotSeries, error) {
+	if val, ok := _SnapshotSeriesNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _SnapshotSeriesNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to SnapshotSeries values", s)
+}
+
+// SnapshotSeriesValues returns all values of the enum
+func SnapshotSeriesValues() []SnapshotSeries {
+	return _SnapshotSeriesValues
+}
+
+// SnapshotSeriesStrings returns a slice of all String values of the enum
+func SnapshotSeriesStrings() []string {
+	strs := make([]string, len(_SnapshotSeriesNames))
+	copy(strs, _SnapshotSeriesNames)
+	return strs
+}
+
+// IsASnapshotSeries returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i SnapshotSeries) IsASnapshotSeries() bool {
+	for _, v := range _SnapshotSeriesValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for SnapshotSeries
+func (i SnapshotSeries) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for SnapshotSeries
+func (i *SnapshotSeries) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("SnapshotSeries should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = SnapshotSeriesString(s)
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for SnapshotSeries
+func (i SnapshotSeries) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for SnapshotSeries
+func (i *SnapshotSeries) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = SnapshotSeriesString(s)
+	return err
+}
+
+func (i SnapshotSeries) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *SnapshotSeries) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of SnapshotSeries: %[1]T(%[1]v)", value)
+	}
+
+	val, err := SnapshotSeriesString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
@@ -0,0 +1,108 @@
+package schema
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// WebhookSubscription is a registration of an operator or a staker to receive the events of an address by webhook.
+type WebhookSubscription struct {
+	ID uint64 `json:"id"`
+	// Address is the Node or the staker address whose events are delivered.
+	Address    common.Address     `json:"address"`
+	URL        string             `json:"url"`
+	EventTypes []WebhookEventType `json:"event_types"`
+	// Secret signs the deliveries, it is only returned when the subscription is created.
+	Secret    string `json:"-"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// WebhookEvent is a fact produced by the indexer and the enforcer, it is delivered to the subscriptions of its addresses.
+type WebhookEvent struct {
+	ID uint64 `json:"id"`
+	// Key deduplicates the events produced more than once, such as by reindexing a block.
+	Key  string           `json:"key"`
+	Type WebhookEventType `json:"type"`
+	// Addresses are the Nodes and the stakers concerned by the event.
+	Addresses []common.Address `json:"addresses"`
+	Data      json.RawMessage  `json:"data"`
+	Timestamp int64            `json:"timestamp"`
+	// ScheduledAt delays the delivery of the event, such as until an unstake becomes claimable.
+	ScheduledAt int64 `json:"scheduled_at"`
+	Dispatched  bool  `json:"dispatched"`
+}
+
+//go:generate go run --mod=mod github.com/dmarkham/enumer@v1.5.9 --values --type=WebhookEventType --linecomment --output webhook_event_type_string.go --json --yaml --sql
+type WebhookEventType int64
+
+const (
+	// WebhookEventTypeNodeStatusChanged the Node transited to another status.
+	WebhookEventTypeNodeStatusChanged WebhookEventType = iota // node_status_changed
+	// WebhookEventTypeNodeScoreDropped the reliability score of the Node dropped.
+	WebhookEventTypeNodeScoreDropped // node_score_dropped
+	// WebhookEventTypeNodeInvalidResponse an invalid response of the Node was recorded.
+	WebhookEventTypeNodeInvalidResponse // node_invalid_response
+	// WebhookEventTypeEpochRewardsDistributed the rewards of an Epoch were distributed to the Node.
+	WebhookEventTypeEpochRewardsDistributed // epoch_rewards_distributed
+	// WebhookEventTypeChipTransferred a Chip was transferred from or to the address.
+	WebhookEventTypeChipTransferred // chip_transferred
+	// WebhookEventTypeUnstakeClaimable an unstake request of the staker became claimable.
+	WebhookEventTypeUnstakeClaimable // unstake_claimable
+)
+
+// WebhookDelivery is an attempt, and its retries, to deliver an event to a subscription.
+type WebhookDelivery struct {
+	ID             uint64                `json:"id"`
+	SubscriptionID uint64                `json:"subscription_id"`
+	EventID        uint64                `json:"event_id"`
+	EventType      WebhookEventType      `json:"event_type"`
+	Payload        json.RawMessage       `json:"payload"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  int64                 `json:"next_attempt_at"`
+	// ResponseStatus is the HTTP status code of the last attempt, 0 if no response was received.
+	ResponseStatus int    `json:"response_status"`
+	Error          string `json:"error,omitempty"`
+	CreatedAt      int64  `json:"created_at"`
+	UpdatedAt      int64  `json:"updated_at"`
+}
+
+//go:generate go run --mod=mod github.com/dmarkham/enumer@v1.5.9 --values --type=WebhookDeliveryStatus --linecomment --output webhook_delivery_status_string.go --json --yaml --sql
+type WebhookDeliveryStatus int64
+
+const (
+	// WebhookDeliveryStatusPending the delivery is waiting for its next attempt.
+	WebhookDeliveryStatusPending WebhookDeliveryStatus = iota // pending
+	// WebhookDeliveryStatusSucceeded the subscriber acknowledged the delivery with a 2xx response.
+	WebhookDeliveryStatusSucceeded // succeeded
+	// WebhookDeliveryStatusFailed the delivery ran out of attempts.
+	WebhookDeliveryStatusFailed // failed
+)
+
+type WebhookSubscriptionsQuery struct {
+	IDs     []uint64
+	Address *common.Address
+	// Addresses and EventType match the subscriptions an event is delivered to.
+	Addresses []common.Address
+	EventType *WebhookEventType
+	Cursor    *uint64
+	Limit     *int
+}
+
+type WebhookEventsQuery struct {
+	Dispatched *bool
+	// ScheduledBefore only matches the events scheduled at or before the time.
+	ScheduledBefore *time.Time
+	Limit           *int
+}
+
+type WebhookDeliveriesQuery struct {
+	SubscriptionID *uint64
+	Status         *WebhookDeliveryStatus
+	// NextAttemptBefore only matches the deliveries due at or before the time, ordered by the due time.
+	NextAttemptBefore *time.Time
+	Cursor            *uint64
+	Limit             *int
+}
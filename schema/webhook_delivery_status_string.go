@@ -0,0 +1,152 @@
+// Code generated by "enumer --values --type=WebhookDeliveryStatus --linecomment --output webhook_delivery_status_string.go --json --yaml --sql"; DO NOT EDIT.
+
+package schema
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _WebhookDeliveryStatusName = "pendingsucceededfailed"
+
+var _WebhookDeliveryStatusIndex = [...]uint8{0, 7, 16, 22}
+
+const _WebhookDeliveryStatusLowerName = "pendingsucceededfailed"
+
+func (i WebhookDeliveryStatus) String() string {
+	if i < 0 || i >= WebhookDeliveryStatus(len(_WebhookDeliveryStatusIndex)-1) {
+		return fmt.Sprintf("WebhookDeliveryStatus(%d)", i)
+	}
+	return _WebhookDeliveryStatusName[_WebhookDeliveryStatusIndex[i]:_WebhookDeliveryStatusIndex[i+1]]
+}
+
+func (WebhookDeliveryStatus) Values() []string {
+	return WebhookDeliveryStatusStrings()
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _WebhookDeliveryStatusNoOp() {
+	var x [1]struct{}
+	_ = x[WebhookDeliveryStatusPending-(0)]
+	_ = x[WebhookDeliveryStatusSucceeded-(1)]
+	_ = x[WebhookDeliveryStatusFailed-(2)]
+}
+
+var _WebhookDeliveryStatusValues = []WebhookDeliveryStatus{WebhookDeliveryStatusPending, WebhookDeliveryStatusSucceeded, WebhookDeliveryStatusFailed}
+
+var _WebhookDeliveryStatusNameToValueMap = map[string]WebhookDeliveryStatus{
+	_WebhookDeliveryStatusName[0:7]:        WebhookDeliveryStatusPending,
+	_WebhookDeliveryStatusLowerName[0:7]:   WebhookDeliveryStatusPending,
+	_WebhookDeliveryStatusName[7:16]:       WebhookDeliveryStatusSucceeded,
+	_WebhookDeliveryStatusLowerName[7:16]:  WebhookDeliveryStatusSucceeded,
+	_WebhookDeliveryStatusName[16:22]:      WebhookDeliveryStatusFailed,
+	_WebhookDeliveryStatusLowerName[16:22]: WebhookDeliveryStatusFailed,
+}
+
+var _WebhookDeliveryStatusNames = []string{
+	_WebhookDeliveryStatusName[0:7],
+	_WebhookDeliveryStatusName[7:16],
+	_WebhookDeliveryStatusName[16:22],
+}
+
+// WebhookDeliveryStatusString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func WebhookDeliveryStatusString(s string) (WebhookDeliveryStatus, error) {
+	if val, ok := _WebhookDeliveryStatusNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _WebhookDeliveryStatusNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to WebhookDeliveryStatus values", s)
+}
+
+// WebhookDeliveryStatusValues returns all values of the enum
+func WebhookDeliveryStatusValues() []WebhookDeliveryStatus {
+	return _WebhookDeliveryStatusValues
+}
+
+// WebhookDeliveryStatusStrings returns a slice of all String values of the enum
+func WebhookDeliveryStatusStrings() []string {
+	strs := make([]string, len(_WebhookDeliveryStatusNames))
+	copy(strs, _WebhookDeliveryStatusNames)
+	return strs
+}
+
+// IsAWebhookDeliveryStatus returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i WebhookDeliveryStatus) IsAWebhookDeliveryStatus() bool {
+	for _, v := range _WebhookDeliveryStatusValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for WebhookDeliveryStatus
+func (i WebhookDeliveryStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for WebhookDeliveryStatus
+func (i *WebhookDeliveryStatus) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("WebhookDeliveryStatus should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = WebhookDeliveryStatusString(s)
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for WebhookDeliveryStatus
+func (i WebhookDeliveryStatus) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for WebhookDeliveryStatus
+func (i *WebhookDeliveryStatus) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = WebhookDeliveryStatusString(s)
+	return err
+}
+
+func (i WebhookDeliveryStatus) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *WebhookDeliveryStatus) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of WebhookDeliveryStatus: %[1]T(%[1]v)", value)
+	}
+
+	val, err := WebhookDeliveryStatusString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
@@ -0,0 +1,164 @@
+// Code generated by "enumer --values --type=WebhookEventType --linecomment --output webhook_event_type_string.go --json --yaml --sql"; DO NOT EDIT.
+
+package schema
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _WebhookEventTypeName = "node_status_changednode_score_droppednode_invalid_responseepoch_rewards_distributedchip_transferredunstake_claimable"
+
+var _WebhookEventTypeIndex = [...]uint8{0, 19, 37, 58, 83, 99, 116}
+
+const _WebhookEventTypeLowerName = "node_status_changednode_score_droppednode_invalid_responseepoch_rewards_distributedchip_transferredunstake_claimable"
+
+func (i WebhookEventType) String() string {
+	if i < 0 || i >= WebhookEventType(len(_WebhookEventTypeIndex)-1) {
+		return fmt.Sprintf("WebhookEventType(%d)", i)
+	}
+	return _WebhookEventTypeName[_WebhookEventTypeIndex[i]:_WebhookEventTypeIndex[i+1]]
+}
+
+func (WebhookEventType) Values() []string {
+	return WebhookEventTypeStrings()
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _WebhookEventTypeNoOp() {
+	var x [1]struct{}
+	_ = x[WebhookEventTypeNodeStatusChanged-(0)]
+	_ = x[WebhookEventTypeNodeScoreDropped-(1)]
+	_ = x[WebhookEventTypeNodeInvalidResponse-(2)]
+	_ = x[WebhookEventTypeEpochRewardsDistributed-(3)]
+	_ = x[WebhookEventTypeChipTransferred-(4)]
+	_ = x[WebhookEventTypeUnstakeClaimable-(5)]
+}
+
+var _WebhookEventTypeValues = []WebhookEventType{WebhookEventTypeNodeStatusChanged, WebhookEventTypeNodeScoreDropped, WebhookEventTypeNodeInvalidResponse, WebhookEventTypeEpochRewardsDistributed, WebhookEventTypeChipTransferred, WebhookEventTypeUnstakeClaimable}
+
+var _WebhookEventTypeNameToValueMap = map[string]WebhookEventType{
+	_WebhookEventTypeName[0:19]:        WebhookEventTypeNodeStatusChanged,
+	_WebhookEventTypeLowerName[0:19]:   WebhookEventTypeNodeStatusChanged,
+	_WebhookEventTypeName[19:37]:       WebhookEventTypeNodeScoreDropped,
+	_WebhookEventTypeLowerName[19:37]:  WebhookEventTypeNodeScoreDropped,
+	_WebhookEventTypeName[37:58]:       WebhookEventTypeNodeInvalidResponse,
+	_WebhookEventTypeLowerName[37:58]:  WebhookEventTypeNodeInvalidResponse,
+	_WebhookEventTypeName[58:83]:       WebhookEventTypeEpochRewardsDistributed,
+	_WebhookEventTypeLowerName[58:83]:  WebhookEventTypeEpochRewardsDistributed,
+	_WebhookEventTypeName[83:99]:       WebhookEventTypeChipTransferred,
+	_WebhookEventTypeLowerName[83:99]:  WebhookEventTypeChipTransferred,
+	_WebhookEventTypeName[99:116]:      WebhookEventTypeUnstakeClaimable,
+	_WebhookEventTypeLowerName[99:116]: WebhookEventTypeUnstakeClaimable,
+}
+
+var _WebhookEventTypeNames = []string{
+	_WebhookEventTypeName[0:19],
+	_WebhookEventTypeName[19:37],
+	_WebhookEventTypeName[37:58],
+	_WebhookEventTypeName[58:83],
+	_WebhookEventTypeName[83:99],
+	_WebhookEventTypeName[99:116],
+}
+
+// WebhookEventTypeString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func WebhookEventTypeString(s string) (WebhookEventType, error) {
+	if val, ok := _WebhookEventTypeNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _WebhookEventTypeNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to WebhookEventType values", s)
+}
+
+// WebhookEventTypeValues returns all values of the enum
+func WebhookEventTypeValues() []WebhookEventType {
+	return _WebhookEventTypeValues
+}
+
+// WebhookEventTypeStrings returns a slice of all String values of the enum
+func WebhookEventTypeStrings() []string {
+	strs := make([]string, len(_WebhookEventTypeNames))
+	copy(strs, _WebhookEventTypeNames)
+	return strs
+}
+
+// IsAWebhookEventType returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i WebhookEventType) IsAWebhookEventType() bool {
+	for _, v := range _WebhookEventTypeValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for WebhookEventType
+func (i WebhookEventType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for WebhookEventType
+func (i *WebhookEventType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("WebhookEventType should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = WebhookEventTypeString(s)
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for WebhookEventType
+func (i WebhookEventType) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for WebhookEventType
+func (i *WebhookEventType) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = WebhookEventTypeString(s)
+	return err
+}
+
+func (i WebhookEventType) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *WebhookEventType) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of WebhookEventType: %[1]T(%[1]v)", value)
+	}
+
+	val, err := WebhookEventTypeString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}